@@ -2,6 +2,13 @@
 
 package smartsheet
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
 // Hyperlink is used in Row Cells to store hyperlink information.
 // The link can be to a URL, Sheet, or Report.
 type Hyperlink struct {
@@ -11,11 +18,12 @@ type Hyperlink struct {
 }
 
 // CellLink identifies location of linked value.
+// Status is set by the API (ex. "OK", "BROKEN"), it is not used in requests.
 type CellLink struct {
-	ColumnId int64  `json:"reportId"`
+	ColumnId int64  `json:"columnId"`
 	RowId    int64  `json:"rowId"`
 	SheetId  int64  `json:"sheetId"`
-	Status   string `json:"status"`
+	Status   string `json:"status,omitempty"`
 }
 
 // Column contains values from API Get Sheet.
@@ -26,6 +34,13 @@ type Column struct {
 	Type    string   `json:"type"`
 	Primary bool     `json:"primary"`
 	Options []string `json:"options"`
+	Width   int      `json:"width,omitempty"`
+	Hidden  bool     `json:"hidden,omitempty"`
+	Locked  bool     `json:"locked,omitempty"`
+	Symbol  string   `json:"symbol,omitempty"` // symbol set of PICKLIST & CHECKBOX columns, ex. "RYG", "FLAG", see symbols.go
+
+	SystemColumnType string `json:"systemColumnType,omitempty"` // values set by Smartsheet, ex. "AUTO_NUMBER", see systemcolumns.go
+	Validation       bool   `json:"validation,omitempty"`       // values must be in Options (PICKLIST), a contact, or a date
 }
 
 // Cell contains cell values.
@@ -34,11 +49,16 @@ type Column struct {
 type Cell struct {
 	ColName         string      `json:"-"` // not used by API
 	ColumnId        int64       `json:"columnId"`
+	Format          string      `json:"format,omitempty"` // format descriptor, see FormatTables.ParseFormat
 	Formula         string      `json:"formula,omitempty"`
 	Hyperlink       *Hyperlink  `json:"hyperlink,omitempty"`
 	LinkInFromCell  *CellLink   `json:"linkInFromCell,omitempty"`
 	LinksOutToCells []CellLink  `json:"linksOutToCells,omitempty"`
 	Value           interface{} `json:"value,omitempty"`
+	ObjectValue     ObjectValue `json:"objectValue,omitempty"`  // project sheet predecessors & durations, see projects.go
+	DisplayValue    string      `json:"displayValue,omitempty"` // returned by api, value as shown in Smartsheet UI (with formatting)
+
+	OverwriteFormula bool `json:"-"` // not used by API, allows replacing a formula when SheetInfo.ProtectFormulas set
 }
 
 // Row is used in api responses but not directly in api requests.
@@ -47,6 +67,25 @@ type Row struct {
 	Id     int64  `json:"id"`
 	Cells  []Cell `json:"cells"`
 	Locked *bool  `json:"locked"` // when updating rows: nil-nochange, false-unlock, true-lock
+
+	ParentId    int64  `json:"parentId,omitempty"`    // returned by api, 0 for top level rows, use RowLocation to set
+	RowNumber   int    `json:"rowNumber,omitempty"`   // returned by api, position of row in sheet starting with 1
+	ModifiedAt  string `json:"modifiedAt,omitempty"`  // returned by api, ex. "2020-10-10T14:30:00Z"
+	FilteredOut bool   `json:"filteredOut,omitempty"` // row hidden by GetSheetOptions.FilterId, only returned by api when filter used
+
+	Attachments []Attachment `json:"attachments,omitempty"` // only returned when GetSheetOptions.IncludeAttachments set
+	Discussions []Discussion `json:"discussions,omitempty"` // only returned when GetSheetOptions.IncludeDiscussions set
+	Permalink   string       `json:"permalink,omitempty"`   // only returned when GetSheetOptions.IncludeRowPermalink set
+
+	CreatedAt  string      `json:"createdAt,omitempty"`  // returned by api, ex. "2020-10-10T14:30:00Z"
+	CreatedBy  *WriterInfo `json:"createdBy,omitempty"`  // only returned when GetSheetOptions or GetRowOptions IncludeWriterInfo set
+	ModifiedBy *WriterInfo `json:"modifiedBy,omitempty"` // only returned when GetSheetOptions or GetRowOptions IncludeWriterInfo set
+}
+
+// WriterInfo is the user who created or last modified a row, see RowAudit.
+type WriterInfo struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
 }
 
 // Sheet is the api response for GetSheet.
@@ -63,10 +102,176 @@ type Sheet struct {
 	Permalink  string   `json:"permalink"`
 	CreatedAt  string   `json:"createdAt"`
 	ModifiedAt string   `json:"modifiedAt"`
+	Owner      string   `json:"owner"`   // only returned when GetSheetOptions.IncludeOwnerInfo set
+	OwnerId    int64    `json:"ownerId"` // only returned when GetSheetOptions.IncludeOwnerInfo set
 	Columns    []Column `json:"columns"`
 	Rows       []Row    `json:"rows"`
+
+	Attachments          []Attachment              `json:"attachments"`          // sheet level attachments, only returned when GetSheetOptions.IncludeAttachments set
+	Discussions          []Discussion              `json:"discussions"`          // sheet level discussions, only returned when GetSheetOptions.IncludeDiscussions set
+	CrossSheetReferences []CrossSheetReferenceInfo `json:"crossSheetReferences"` // only returned when GetSheetOptions.IncludeCrossSheetReferences set
+	Source               *SheetSource              `json:"source"`               // only returned when GetSheetOptions.IncludeSource set
+
+	DependenciesEnabled bool               `json:"dependenciesEnabled"` // project sheet, predecessor & duration columns enabled
+	ProjectSettings     *ProjectSettings   `json:"projectSettings"`     // only returned when DependenciesEnabled
+	UserSettings        *SheetUserSettings `json:"userSettings"`
+}
+
+// ProjectSettings contains the working schedule used to calculate project sheet dates.
+// See GetSheetProjectSettings, UpdateSheetProjectSettings.
+type ProjectSettings struct {
+	DependenciesEnabled bool     `json:"-"`              // sheet attribute dependenciesEnabled, not in the api projectSettings object
+	WorkingDays         []string `json:"workingDays"`    // ex. "MONDAY"
+	NonWorkingDays      []string `json:"nonWorkingDays"` // dates, ex. "2020-12-25"
+	LengthOfDay         float64  `json:"lengthOfDay"`    // hours
+}
+
+// SheetUserSettings contains the current user's display settings for a sheet.
+type SheetUserSettings struct {
+	CriticalPathEnabled bool `json:"criticalPathEnabled"`
+	DisplaySummaryTasks bool `json:"displaySummaryTasks"`
+}
+
+// SheetSource identifies the object a sheet was created from (ex. saved as new or created from template).
+type SheetSource struct {
+	Id   int64  `json:"id"`
+	Type string `json:"type"` // ex. "sheet", "template", "report"
+}
+
+// Attachment is a file or link attached to a sheet, row or comment, returned by the attachment funcs (see attachments.go)
+// and in Row.Attachments when GetSheetOptions or GetRowOptions IncludeAttachments set.
+type Attachment struct {
+	Id                 int64     `json:"id"`
+	Name               string    `json:"name"`
+	AttachmentType     string    `json:"attachmentType"` // ex. "FILE", "LINK", "GOOGLE_DRIVE"
+	Url                string    `json:"url"`            // link url, for FILE a temporary download url only returned by GetAttachment
+	UrlExpiresInMillis int64     `json:"urlExpiresInMillis,omitempty"`
+	MimeType           string    `json:"mimeType"`
+	SizeInKb           int64     `json:"sizeInKb"`   // 0 for links
+	ParentType         string    `json:"parentType"` // use Attachment Parent Type constants, ex. AttachedToRow
+	ParentId           int64     `json:"parentId"`
+	CreatedAt          time.Time `json:"createdAt"`
+	CreatedBy          User      `json:"createdBy"` // only Email and Name returned
+}
+
+// Discussion is a discussion of a sheet or row. Comments are returned by ListRowDiscussions, and by GetSheet or
+// GetRowWith when discussions are included (only comment counts for GetSheet).
+type Discussion struct {
+	Id                int64     `json:"id"`
+	Title             string    `json:"title"`
+	ParentType        string    `json:"parentType"` // "SHEET" or "ROW"
+	ParentId          int64     `json:"parentId"`
+	CommentCount      int       `json:"commentCount"`
+	AccessLevel       string    `json:"accessLevel"` // user's access to the discussion, ex. "OWNER"
+	ReadOnly          bool      `json:"readOnly"`
+	CreatedBy         User      `json:"createdBy"` // only Email and Name returned
+	LastCommentedAt   time.Time `json:"lastCommentedAt"`
+	LastCommentedUser User      `json:"lastCommentedUser"`
+
+	Comments           []Comment    `json:"comments,omitempty"`
+	CommentAttachments []Attachment `json:"commentAttachments,omitempty"`
+}
+
+// Comment is a comment in a discussion, see Discussion.Comments.
+type Comment struct {
+	Id           int64     `json:"id"`
+	DiscussionId int64     `json:"discussionId,omitempty"`
+	Text         string    `json:"text"`
+	CreatedAt    time.Time `json:"createdAt"`
+	ModifiedAt   time.Time `json:"modifiedAt"`
+	CreatedBy    User      `json:"createdBy"` // only Email and Name returned
+
+	Attachments []Attachment `json:"attachments,omitempty"`
 }
 
+// UnmarshalJSON decodes the timestamps of an attachment, see parseTimestamp.
+func (attachment *Attachment) UnmarshalJSON(data []byte) error {
+	type attachmentJSON Attachment // without UnmarshalJSON method
+	aux := struct {
+		*attachmentJSON
+		CreatedAt json.RawMessage `json:"createdAt"`
+	}{attachmentJSON: (*attachmentJSON)(attachment)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	return setTimestamp(&attachment.CreatedAt, "createdAt", aux.CreatedAt)
+}
+
+// UnmarshalJSON decodes the timestamps of a discussion, see parseTimestamp.
+func (discussion *Discussion) UnmarshalJSON(data []byte) error {
+	type discussionJSON Discussion // without UnmarshalJSON method
+	aux := struct {
+		*discussionJSON
+		LastCommentedAt json.RawMessage `json:"lastCommentedAt"`
+	}{discussionJSON: (*discussionJSON)(discussion)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	return setTimestamp(&discussion.LastCommentedAt, "lastCommentedAt", aux.LastCommentedAt)
+}
+
+// UnmarshalJSON decodes the timestamps of a comment, see parseTimestamp.
+func (comment *Comment) UnmarshalJSON(data []byte) error {
+	type commentJSON Comment // without UnmarshalJSON method
+	aux := struct {
+		*commentJSON
+		CreatedAt  json.RawMessage `json:"createdAt"`
+		ModifiedAt json.RawMessage `json:"modifiedAt"`
+	}{commentJSON: (*commentJSON)(comment)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if err := setTimestamp(&comment.CreatedAt, "createdAt", aux.CreatedAt); err != nil {
+		return err
+	}
+	return setTimestamp(&comment.ModifiedAt, "modifiedAt", aux.ModifiedAt)
+}
+
+// setTimestamp sets *t to the timestamp raw, name is the json field name used in the error.
+func setTimestamp(t *time.Time, name string, raw json.RawMessage) error {
+	parsed, err := parseTimestamp(raw)
+	if err != nil {
+		return fmt.Errorf("Invalid Timestamp %s - %w", name, err)
+	}
+	*t = parsed
+	return nil
+}
+
+// parseTimestamp decodes a Smartsheet timestamp, a RFC3339 string (ex. "2020-10-10T14:30:00Z") or, when requested
+// using the numericDates parameter, milliseconds since 1970. Missing, null and "" return the zero time.
+func parseTimestamp(raw json.RawMessage) (time.Time, error) {
+	value := bytes.TrimSpace(raw)
+	if len(value) == 0 || bytes.Equal(value, []byte("null")) || bytes.Equal(value, []byte(`""`)) {
+		return time.Time{}, nil
+	}
+	if value[0] != '"' {
+		var millis int64
+		if err := json.Unmarshal(value, &millis); err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(0, millis*int64(time.Millisecond)).UTC(), nil
+	}
+	var text string
+	if err := json.Unmarshal(value, &text); err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, text)
+}
+
+// SheetMeta is the api response for GetSheetMeta, sheet attributes without rows or columns.
+type SheetMeta struct {
+	Id            int64     `json:"id"`
+	Name          string    `json:"name"`
+	Permalink     string    `json:"permalink"`
+	TotalRowCount int       `json:"totalRowCount"`
+	Version       int       `json:"version"` // incremented each time sheet is modified
+	CreatedAt     time.Time `json:"createdAt"`
+	ModifiedAt    time.Time `json:"modifiedAt"`
+	Owner         string    `json:"owner"` // email address
+	OwnerId       int64     `json:"ownerId"`
+}
+
+// CrossSheetReference is used to create a cross sheet reference. See SheetInfo.CreateCrossSheetReference.
 type CrossSheetReference struct {
 	Name          string `json:"name"`
 	SourceSheetId int64  `json:"sourceSheetId"`
@@ -76,11 +281,63 @@ type CrossSheetReference struct {
 	EndColumnId   int64  `json:"endColumnId"`
 }
 
+// CrossSheetReferenceInfo is returned by GetCrossSheetRefs and SheetInfo.CreateCrossSheetReference.
+// Reference a cross sheet reference in a formula using its Name, ex. =SUM({Name}).
+type CrossSheetReferenceInfo struct {
+	Id            int64  `json:"id"`
+	Name          string `json:"name"`
+	Status        string `json:"status"` // ex. "OK", "BLOCKED", "BROKEN", "DISABLED"
+	SourceSheetId int64  `json:"sourceSheetId"`
+	StartRowId    int64  `json:"startRowId"` // 0 if all rows
+	EndRowId      int64  `json:"endRowId"`   // 0 if all rows
+	StartColumnId int64  `json:"startColumnId"`
+	EndColumnId   int64  `json:"endColumnId"`
+}
+
 // AddUpdtRowsResponse is api response object when adding mutiple rows or updating 1 or more rows.
 type AddUpdtRowsResponse struct {
 	Message    string `json:"message"`    // ex. "SUCCESS"
 	ResultCode int    `json:"resultCode"` // ex. 0
 	Result     []Row  `json:"result"`
+
+	FailedItems []FailedItem `json:"failedItems"` // only when partial success allowed, ResultCode is 3
+}
+
+// UnmarshalJSON accepts result as an array of rows or a single row. The api returns a single row object (not an array)
+// when 1 row is added, and may do so for other 1 row requests, so the response shape is not assumed from the request.
+func (resp *AddUpdtRowsResponse) UnmarshalJSON(data []byte) error {
+	type response AddUpdtRowsResponse // without UnmarshalJSON method
+	var raw struct {
+		response
+		Result json.RawMessage `json:"result"` // replaces response.Result
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*resp = AddUpdtRowsResponse(raw.response)
+	result := bytes.TrimSpace(raw.Result)
+	switch {
+	case len(result) == 0 || bytes.Equal(result, []byte("null")):
+		return nil
+	case result[0] == '{':
+		var row Row
+		if err := json.Unmarshal(result, &row); err != nil {
+			return err
+		}
+		resp.Result = []Row{row}
+		return nil
+	}
+	return json.Unmarshal(result, &resp.Result)
+}
+
+// FailedItem is a row not added or updated by a request allowing partial success.
+type FailedItem struct {
+	Index int   `json:"index"` // index of the row in the request
+	RowId int64 `json:"rowId"` // 0 for new rows
+	Error struct {
+		ErrorCode int    `json:"errorCode"`
+		Message   string `json:"message"`
+	} `json:"error"`
 }
 
 // Add1RowResponse is api response object when adding 1 row.
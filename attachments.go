@@ -0,0 +1,140 @@
+// attachments.go contains funcs for listing attachments and reporting the attachments of a whole sheet.
+// See AttachFileToRow, AttachUrlToRow, AttachUrlToSheet in smartsheet.go for adding attachments.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sort"
+	"time"
+)
+
+// Attachment Parent Types
+const (
+	AttachedToSheet   = "SHEET"
+	AttachedToRow     = "ROW"
+	AttachedToComment = "COMMENT"
+)
+
+// ListSheetAttachments returns all attachments in a sheet, including those attached to rows and comments (see ParentType).
+func ListSheetAttachments(sheetId int64) ([]Attachment, error) {
+	trace("ListSheetAttachments")
+	endPoint := fmt.Sprintf("/sheets/%d/attachments", sheetId)
+	return listAttachments(endPoint)
+}
+
+// ListRowAttachments returns the attachments of 1 row, including those attached to the row's comments.
+func ListRowAttachments(sheetId, rowId int64) ([]Attachment, error) {
+	trace("ListRowAttachments")
+	endPoint := fmt.Sprintf("/sheets/%d/rows/%d/attachments", sheetId, rowId)
+	return listAttachments(endPoint)
+}
+
+// GetAttachment returns 1 attachment, for FILE attachments Url is a temporary url for downloading the file.
+func GetAttachment(sheetId, attachmentId int64) (*Attachment, error) {
+	trace("GetAttachment")
+	endPoint := fmt.Sprintf("/sheets/%d/attachments/%d", sheetId, attachmentId)
+	req := Get(endPoint, nil)
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	attachment := new(Attachment)
+	if err = json.Unmarshal(respJSON, attachment); err != nil {
+		log.Println("ERROR GetAttachment Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func listAttachments(endPoint string) ([]Attachment, error) {
+	attachments := make([]Attachment, 0, 10)
+	err := getAllPages(endPoint, nil, func(data json.RawMessage) error {
+		var page []Attachment
+		err := json.Unmarshal(data, &page)
+		attachments = append(attachments, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// AttachmentReport is returned by SheetAttachmentReport.
+type AttachmentReport struct {
+	SheetId     int64
+	Entries     []AttachmentEntry
+	TotalSizeKb int64 // sum of Entries SizeInKb
+}
+
+// AttachmentEntry is an attachment and the row it belongs to.
+type AttachmentEntry struct {
+	RowId        int64  // 0 for sheet level attachments
+	PrimaryValue string // value of row's primary column, for context
+	Attachment
+}
+
+// SheetAttachmentReport returns every attachment of a sheet (sheet and row level) and their total size.
+// The sheet is requested once, including attachments, rather than requesting the attachments of each row.
+func SheetAttachmentReport(sheetId int64) (*AttachmentReport, error) {
+	trace("SheetAttachmentReport")
+	sheet, err := GetSheet(sheetId, &GetSheetOptions{IncludeAttachments: true})
+	if err != nil {
+		return nil, err
+	}
+	var primary Column
+	for _, column := range sheet.Columns {
+		if column.Primary {
+			primary = column
+		}
+	}
+	entries := make([]AttachmentEntry, 0, len(sheet.Attachments))
+	for _, attachment := range sheet.Attachments {
+		entries = append(entries, AttachmentEntry{Attachment: attachment})
+	}
+	for _, row := range sheet.Rows {
+		if len(row.Attachments) == 0 {
+			continue
+		}
+		primaryValue := ""
+		for _, cell := range row.Cells {
+			if cell.ColumnId == primary.Id && cell.Value != nil {
+				primaryValue = fmt.Sprintf("%v", cell.Value)
+			}
+		}
+		for _, attachment := range row.Attachments {
+			entries = append(entries, AttachmentEntry{RowId: row.Id, PrimaryValue: primaryValue, Attachment: attachment})
+		}
+	}
+	return newAttachmentReport(sheetId, entries), nil
+}
+
+// OlderThan returns a report containing only the attachments created before t.
+func (report *AttachmentReport) OlderThan(t time.Time) *AttachmentReport {
+	entries := make([]AttachmentEntry, 0, len(report.Entries))
+	for _, entry := range report.Entries {
+		if entry.CreatedAt.Before(t) {
+			entries = append(entries, entry)
+		}
+	}
+	return newAttachmentReport(report.SheetId, entries)
+}
+
+// newAttachmentReport returns a report of entries, largest attachments first.
+func newAttachmentReport(sheetId int64, entries []AttachmentEntry) *AttachmentReport {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].SizeInKb > entries[j].SizeInKb })
+	report := &AttachmentReport{SheetId: sheetId, Entries: entries}
+	for _, entry := range entries {
+		report.TotalSizeKb += entry.SizeInKb
+	}
+	return report
+}
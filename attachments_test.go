@@ -0,0 +1,121 @@
+package smartsheet
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Attachments(t *testing.T) {
+	var requests []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+"?"+r.URL.Query().Get("include"))
+		switch r.URL.Path {
+		case "/sheets/1849449510135684":
+			w.Write([]byte(`{"id":1849449510135684,"name":"Test1",
+				"columns":[{"id":101,"index":0,"title":"Address","type":"TEXT_NUMBER","primary":true},{"id":105,"index":1,"title":"Amt","type":"TEXT_NUMBER"}],
+				"attachments":[{"id":1,"name":"plan.pdf","attachmentType":"FILE","sizeInKb":300,"parentType":"SHEET","parentId":1849449510135684,"createdAt":"2022-01-05T10:00:00Z"}],
+				"rows":[
+					{"id":11,"cells":[{"columnId":101,"value":"1 Main"},{"columnId":105,"value":74.2}],
+					 "attachments":[{"id":2,"name":"photo.jpg","attachmentType":"FILE","mimeType":"image/jpeg","sizeInKb":1200,"parentType":"ROW","parentId":11,"createdAt":"2024-02-01T10:00:00Z"},
+					                {"id":3,"name":"site","attachmentType":"LINK","parentType":"ROW","parentId":11,"createdAt":"2021-06-01T10:00:00Z"}]},
+					{"id":12,"cells":[{"columnId":101,"value":"2 Elm"}]},
+					{"id":13,"cells":[{"columnId":105,"value":5}],
+					 "attachments":[{"id":4,"name":"invoice.xlsx","attachmentType":"FILE","sizeInKb":45,"parentType":"ROW","parentId":13,"createdAt":"2023-03-01T10:00:00Z"}]}]}`))
+		case "/sheets/1849449510135684/attachments", "/sheets/1849449510135684/rows/11/attachments":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"id":2,"name":"photo.jpg","attachmentType":"FILE","sizeInKb":1200,"parentType":"ROW","parentId":11,
+				"createdBy":{"email":"jay@test.com","name":"Jay"}}]}`))
+		}
+	})
+	var sheetId int64 = 1849449510135684
+
+	report, err := SheetAttachmentReport(sheetId)
+	if err != nil {
+		t.Fatal("SheetAttachmentReport Failed", err)
+	}
+	if len(requests) != 1 || requests[0] != "/sheets/1849449510135684?attachments" {
+		t.Error("SheetAttachmentReport expected 1 sheet request including attachments, got", requests)
+	}
+	if len(report.Entries) != 4 || report.TotalSizeKb != 1545 {
+		t.Fatalf("SheetAttachmentReport wrong result %+v", report)
+	}
+	largest := report.Entries[0]
+	if largest.Name != "photo.jpg" || largest.RowId != 11 || largest.PrimaryValue != "1 Main" || largest.MimeType != "image/jpeg" {
+		t.Errorf("SheetAttachmentReport wrong 1st entry %+v", largest)
+	}
+	if sheetLevel := report.Entries[1]; sheetLevel.Name != "plan.pdf" || sheetLevel.RowId != 0 || sheetLevel.ParentType != AttachedToSheet {
+		t.Errorf("SheetAttachmentReport wrong sheet level entry %+v", sheetLevel)
+	}
+	if noPrimary := report.Entries[2]; noPrimary.RowId != 13 || noPrimary.PrimaryValue != "" {
+		t.Errorf("SheetAttachmentReport wrong entry for row without primary value %+v", noPrimary)
+	}
+
+	old := report.OlderThan(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(old.Entries) != 2 || old.TotalSizeKb != 300 || old.Entries[1].Name != "site" {
+		t.Errorf("OlderThan wrong result %+v", old)
+	}
+
+	attachments, err := ListSheetAttachments(sheetId)
+	if err != nil || len(attachments) != 1 || attachments[0].CreatedBy.Name != "Jay" {
+		t.Errorf("ListSheetAttachments wrong result %+v %v", attachments, err)
+	}
+	attachments, err = ListRowAttachments(sheetId, 11)
+	if err != nil || len(attachments) != 1 || attachments[0].ParentId != 11 {
+		t.Errorf("ListRowAttachments wrong result %+v %v", attachments, err)
+	}
+}
+
+func Test_AttachUrl(t *testing.T) {
+	var body, lastPath string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		lastPath = r.Method + " " + r.URL.Path
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		body = compactJSON(reqBytes)
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":4583173393803140,"name":"Spec","attachmentType":"GOOGLE_DRIVE",` +
+			`"parentType":"ROW","parentId":6840477608372100,"createdAt":"2020-11-20T18:30:00Z","createdBy":{"email":"a@test.com","name":"Ann"}}}`))
+	})
+
+	attachment, err := AttachUrlToRow(1849449510135684, 6840477608372100, "Spec", GOOGLEDRIVE, "https://drive.google.com/file/d/1")
+	if err != nil || attachment.Id != 4583173393803140 || attachment.ParentType != AttachedToRow || attachment.CreatedBy.Name != "Ann" {
+		t.Fatalf("AttachUrlToRow wrong result %+v %v", attachment, err)
+	}
+	expect := `{"name":"Spec","attachmentType":"GOOGLE_DRIVE","url":"https://drive.google.com/file/d/1"}`
+	if lastPath != "POST /sheets/1849449510135684/rows/6840477608372100/attachments" || body != expect {
+		t.Errorf("AttachUrlToRow wrong request %s %s", lastPath, body)
+	}
+
+	if _, err = AttachUrlToSheet(1849449510135684, "Spec", EGNYTE, "http://corp.egnyte.com/spec"); err != nil {
+		t.Error("AttachUrlToSheet Failed", err)
+	}
+	if lastPath != "POST /sheets/1849449510135684/attachments" {
+		t.Error("AttachUrlToSheet wrong request", lastPath)
+	}
+
+	invalid := []struct {
+		name, attachmentType, linkUrl, problem string
+	}{
+		{"type typo", "GOOGLE-DRIVE", "https://drive.google.com/file/d/1", "Invalid Attachment Type - GOOGLE-DRIVE"},
+		{"empty type", "", "https://drive.google.com/file/d/1", "Invalid Attachment Type"},
+		{"file type", "FILE", "https://drive.google.com/file/d/1", "Invalid Attachment Type - FILE"},
+		{"unparsable url", LINK, "https://bad host/%zz", "Invalid Attachment Url"},
+		{"ftp scheme", LINK, "ftp://files.test.com/spec", "Invalid Attachment Url"},
+		{"relative url", LINK, "/file/d/1", "Invalid Attachment Url"},
+		{"no host", LINK, "https://", "Invalid Attachment Url"},
+	}
+	lastPath = ""
+	for _, test := range invalid {
+		_, err = AttachUrlToRow(1849449510135684, 6840477608372100, "Spec", test.attachmentType, test.linkUrl)
+		if err == nil || !strings.Contains(err.Error(), test.problem) {
+			t.Error(test.name, "AttachUrlToRow expected error", test.problem, "- got", err)
+		}
+		_, err = AttachUrlToSheet(1849449510135684, "Spec", test.attachmentType, test.linkUrl)
+		if err == nil || !strings.Contains(err.Error(), test.problem) {
+			t.Error(test.name, "AttachUrlToSheet expected error", test.problem, "- got", err)
+		}
+	}
+	if lastPath != "" {
+		t.Error("invalid parms should not send a request", lastPath)
+	}
+}
@@ -0,0 +1,141 @@
+// automationrules.go contains funcs for listing, enabling/disabling and deleting a sheet's automation rules.
+// Rules are created in the Smartsheet UI, the api cannot create them.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// Automation Action Types
+const (
+	NotificationAction    = "NOTIFICATION_ACTION"
+	ApprovalRequestAction = "APPROVAL_REQUEST_ACTION"
+	UpdateRequestAction   = "UPDATE_REQUEST_ACTION"
+)
+
+type AutomationRule struct {
+	Id             int64            `json:"id"`
+	Name           string           `json:"name"`
+	Enabled        bool             `json:"enabled"`
+	DisabledReason string           `json:"disabledReason"` // set by api when rule is disabled, ex. "APPROVAL_COLUMN_MISSING"
+	Action         AutomationAction `json:"action"`
+	UserCanModify  bool             `json:"userCanModify"`
+	CreatedAt      string           `json:"createdAt"`
+	ModifiedAt     string           `json:"modifiedAt"`
+}
+
+type AutomationAction struct {
+	Type                 string                `json:"type"`      // use Automation Action Type constants, ex. NotificationAction
+	Frequency            string                `json:"frequency"` // ex. "IMMEDIATELY", "DAILY"
+	Recipients           []AutomationRecipient `json:"recipients"`
+	NotifyAllSharedUsers bool                  `json:"notifyAllSharedUsers"`
+	IncludeAllColumns    bool                  `json:"includeAllColumns"`
+	Message              string                `json:"message"`
+}
+
+// AutomationRecipient contains Email or GroupId.
+type AutomationRecipient struct {
+	Email   string `json:"email,omitempty"`
+	GroupId int64  `json:"groupId,omitempty"`
+}
+
+// RecipientSummary returns the rule's recipients as a comma separated list, groups shown as "group:<groupId>".
+func (rule *AutomationRule) RecipientSummary() string {
+	names := make([]string, 0, len(rule.Action.Recipients)+1)
+	if rule.Action.NotifyAllSharedUsers {
+		names = append(names, "all shared users")
+	}
+	for _, recipient := range rule.Action.Recipients {
+		if recipient.Email != "" {
+			names = append(names, recipient.Email)
+		} else {
+			names = append(names, "group:"+strconv.FormatInt(recipient.GroupId, 10))
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// ListAutomationRules returns all automation rules of a sheet.
+func ListAutomationRules(sheetId int64) ([]AutomationRule, error) {
+	trace("ListAutomationRules")
+	endPoint := fmt.Sprintf("/sheets/%d/automationrules", sheetId)
+	rules := make([]AutomationRule, 0, 10)
+	err := getAllPages(endPoint, nil, func(data json.RawMessage) error {
+		var page []AutomationRule
+		err := json.Unmarshal(data, &page)
+		rules = append(rules, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func GetAutomationRule(sheetId, ruleId int64) (*AutomationRule, error) {
+	trace("GetAutomationRule")
+	endPoint := fmt.Sprintf("/sheets/%d/automationrules/%d", sheetId, ruleId)
+	req := Get(endPoint, nil)
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+
+	rule := new(AutomationRule)
+	if err = json.Unmarshal(respJSON, rule); err != nil {
+		log.Println("ERROR GetAutomationRule Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return rule, nil
+}
+
+// UpdateAutomationRule enables or disables a rule and returns the updated rule.
+// The api requires the rule's action type on update, so the rule is retrieved first (2 requests).
+func UpdateAutomationRule(sheetId, ruleId int64, enabled bool) (*AutomationRule, error) {
+	trace("UpdateAutomationRule")
+	rule, err := GetAutomationRule(sheetId, ruleId)
+	if err != nil {
+		return nil, err
+	}
+	reqBody := map[string]interface{}{
+		"enabled": enabled,
+		"action":  map[string]string{"type": rule.Action.Type},
+	}
+	endPoint := fmt.Sprintf("/sheets/%d/automationrules/%d", sheetId, ruleId)
+	req := Put(endPoint, reqBody, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	var apiResp struct {
+		Message    string         `json:"message"`
+		ResultCode int            `json:"resultCode"`
+		Result     AutomationRule `json:"result"`
+	}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR UpdateAutomationRule Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return &apiResp.Result, nil
+}
+
+func DeleteAutomationRule(sheetId, ruleId int64) error {
+	trace("DeleteAutomationRule")
+	endPoint := fmt.Sprintf("/sheets/%d/automationrules/%d", sheetId, ruleId)
+	return deleteObject(endPoint)
+}
@@ -0,0 +1,66 @@
+package smartsheet
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+// automationRulesPage is a captured GET /sheets/{id}/automationrules response, split into 2 pages.
+var automationRulesPage = map[string]string{
+	"1": `{"pageNumber":1,"pageSize":1,"totalPages":2,"totalCount":2,"data":[
+		{"id":789994550205316,"name":"Notify on Complete","enabled":true,"userCanModify":true,
+		 "action":{"type":"NOTIFICATION_ACTION","frequency":"IMMEDIATELY","includeAllColumns":true,"notifyAllSharedUsers":false,
+		   "recipients":[{"email":"jay@test.com"},{"groupId":4583173393803140}]},
+		 "createdAt":"2024-01-10T15:00:00Z","modifiedAt":"2024-02-01T09:30:00Z"}]}`,
+	"2": `{"pageNumber":2,"pageSize":1,"totalPages":2,"totalCount":2,"data":[
+		{"id":3377704015275908,"name":"Approve Amt","enabled":false,"disabledReason":"APPROVAL_COLUMN_MISSING",
+		 "action":{"type":"APPROVAL_REQUEST_ACTION","frequency":"IMMEDIATELY","notifyAllSharedUsers":true,"recipients":[]}}]}`,
+}
+
+func Test_AutomationRules(t *testing.T) {
+	var body, lastPath string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		lastPath = r.Method + " " + r.URL.Path
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		body = compactJSON(reqBytes)
+		switch lastPath {
+		case "GET /sheets/1849449510135684/automationrules":
+			w.Write([]byte(automationRulesPage[r.URL.Query().Get("page")]))
+		case "GET /sheets/1849449510135684/automationrules/789994550205316":
+			w.Write([]byte(`{"id":789994550205316,"name":"Notify on Complete","enabled":true,"action":{"type":"NOTIFICATION_ACTION"}}`))
+		case "PUT /sheets/1849449510135684/automationrules/789994550205316":
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":789994550205316,"name":"Notify on Complete","enabled":false,"action":{"type":"NOTIFICATION_ACTION"}}}`))
+		default:
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+		}
+	})
+	var sheetId int64 = 1849449510135684
+
+	rules, err := ListAutomationRules(sheetId)
+	if err != nil || len(rules) != 2 {
+		t.Fatalf("ListAutomationRules wrong result %+v %v", rules, err)
+	}
+	if rules[0].Action.Type != NotificationAction || !rules[0].Enabled || rules[0].Action.Recipients[1].GroupId != 4583173393803140 {
+		t.Errorf("ListAutomationRules wrong decode %+v", rules[0])
+	}
+	if summary := rules[0].RecipientSummary(); summary != "jay@test.com, group:4583173393803140" {
+		t.Error("RecipientSummary wrong result", summary)
+	}
+	if rules[1].Enabled || rules[1].DisabledReason != "APPROVAL_COLUMN_MISSING" || rules[1].RecipientSummary() != "all shared users" {
+		t.Errorf("ListAutomationRules wrong decode %+v", rules[1])
+	}
+
+	rule, err := UpdateAutomationRule(sheetId, 789994550205316, false)
+	if err != nil || rule.Enabled {
+		t.Errorf("UpdateAutomationRule wrong result %+v %v", rule, err)
+	}
+	expect := `{"action":{"type":"NOTIFICATION_ACTION"},"enabled":false}`
+	if body != expect {
+		t.Errorf("UpdateAutomationRule Expecting %s, Got %s", expect, body)
+	}
+
+	if err = DeleteAutomationRule(sheetId, 3377704015275908); err != nil || lastPath != "DELETE /sheets/1849449510135684/automationrules/3377704015275908" {
+		t.Error("DeleteAutomationRule wrong result", lastPath, err)
+	}
+}
@@ -0,0 +1,182 @@
+// cellhistory.go contains GetCellHistory and ColumnHistoryReport, which collects the changes to 1 column across
+// all rows modified since a time. Each cell history request counts as 10 requests against the rate limit.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CellHistoryEntry is 1 value of a cell, returned by GetCellHistory (newest first).
+type CellHistoryEntry struct {
+	ColumnId     int64       `json:"columnId"`
+	Value        interface{} `json:"value"`
+	DisplayValue string      `json:"displayValue"`
+	ModifiedAt   time.Time   `json:"modifiedAt"`
+	ModifiedBy   struct {
+		Email string `json:"email"`
+		Name  string `json:"name"`
+	} `json:"modifiedBy"`
+}
+
+// GetCellHistory returns the history of 1 cell, newest value first. Each page requested costs CostHeavy (see WithCost).
+func GetCellHistory(sheetId, rowId, columnId int64) ([]CellHistoryEntry, error) {
+	trace("GetCellHistory")
+	endPoint := fmt.Sprintf("/sheets/%d/rows/%d/columns/%d/history", sheetId, rowId, columnId)
+	history := make([]CellHistoryEntry, 0, 10)
+	err := getAllPagesCost(endPoint, nil, CostHeavy, func(data json.RawMessage) error {
+		var page []CellHistoryEntry
+		err := json.Unmarshal(data, &page)
+		history = append(history, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// HistoryReport is returned by ColumnHistoryReport.
+type HistoryReport struct {
+	SheetId    int64
+	ColumnName string
+	Since      time.Time
+	Entries    []HistoryEntry // oldest change first
+	RowsDone   []int64        // rows whose history is in Entries, skipped when the report is resumed
+	Truncated  bool           // more than maxRows rows were modified, resume the report to collect the rest
+}
+
+// HistoryEntry is a change to a cell of the report column.
+type HistoryEntry struct {
+	RowId int64
+	CellHistoryEntry
+}
+
+// HistoryOptions is used by ColumnHistoryReportWith.
+type HistoryOptions struct {
+	Parallelism int                   // number of rows requested concurrently, default 1, requests still share the RequestDelay throttle
+	Progress    func(done, total int) // called after each row's history is received, total is the number of rows to request
+	Resume      *HistoryReport        // a previous (truncated or failed) report, its rows are not requested again
+}
+
+// HistoryError is returned by ColumnHistoryReport when the history of 1 or more rows could not be requested.
+// The report contains the other rows, resume it to retry the failed rows.
+type HistoryError struct {
+	RowErrs map[int64]error // key is rowId
+}
+
+func (e *HistoryError) Error() string {
+	rowIds := make([]int64, 0, len(e.RowErrs))
+	for rowId := range e.RowErrs {
+		rowIds = append(rowIds, rowId)
+	}
+	sort.Slice(rowIds, func(i, j int) bool { return rowIds[i] < rowIds[j] })
+	return fmt.Sprintf("Cell History Failed For %d Rows - rowId %d %v", len(rowIds), rowIds[0], e.RowErrs[rowIds[0]])
+}
+
+// ColumnHistoryReport returns every change to columnName made after since, in all rows.
+// Rows not modified since are skipped (GetSheet with RowsModifiedSince), so only the history of modified rows is requested.
+// If more than maxRows (0 for no limit) rows were modified, the first maxRows in sheet order are reported and
+// the report is Truncated. See ColumnHistoryReportWith for concurrency, progress and resuming a report.
+func ColumnHistoryReport(sheet *SheetInfo, columnName string, since time.Time, maxRows int) (*HistoryReport, error) {
+	return ColumnHistoryReportWith(sheet, columnName, since, maxRows, nil)
+}
+
+// ColumnHistoryReportWith is ColumnHistoryReport using HistoryOptions, nil options uses the defaults.
+// When options.Resume is set, its entries are kept and its RowsDone are not requested again (parm since should be the same).
+// If some rows fail, the report of the other rows and a *HistoryError are returned.
+func ColumnHistoryReportWith(sheet *SheetInfo, columnName string, since time.Time, maxRows int, options *HistoryOptions) (*HistoryReport, error) {
+	trace("ColumnHistoryReportWith")
+	column, found := sheet.ColumnsByName[columnName]
+	if !found {
+		return nil, fmt.Errorf("%w - %s", ErrInvalidColumnName, columnName)
+	}
+	if options == nil {
+		options = new(HistoryOptions)
+	}
+	report := &HistoryReport{SheetId: sheet.SheetId, ColumnName: columnName, Since: since}
+	done := make(map[int64]bool)
+	if options.Resume != nil {
+		report.Entries = append(report.Entries, options.Resume.Entries...)
+		report.RowsDone = append(report.RowsDone, options.Resume.RowsDone...)
+		for _, rowId := range options.Resume.RowsDone {
+			done[rowId] = true
+		}
+	}
+
+	// -- Narrow To Rows Modified Since ----------------
+	modified, err := GetSheet(sheet.SheetId, &GetSheetOptions{RowsModifiedSince: since, ColumnIds: []int64{column.Id}})
+	if err != nil {
+		return nil, err
+	}
+	rowIds := make([]int64, 0, len(modified.Rows))
+	for _, row := range modified.Rows {
+		if done[row.Id] {
+			continue
+		}
+		if maxRows > 0 && len(rowIds) == maxRows {
+			report.Truncated = true
+			break
+		}
+		rowIds = append(rowIds, row.Id)
+	}
+
+	// -- Request History, Up To options.Parallelism Rows At A Time ----------------
+	parallelism := options.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	var mu sync.Mutex
+	completed := 0
+	historyErr := &HistoryError{RowErrs: make(map[int64]error)}
+	jobs := make(chan int64)
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rowId := range jobs {
+				history, err := GetCellHistory(sheet.SheetId, rowId, column.Id)
+				mu.Lock()
+				if err != nil {
+					historyErr.RowErrs[rowId] = err
+				} else {
+					for _, entry := range history {
+						if entry.ModifiedAt.After(since) {
+							report.Entries = append(report.Entries, HistoryEntry{RowId: rowId, CellHistoryEntry: entry})
+						}
+					}
+					report.RowsDone = append(report.RowsDone, rowId)
+				}
+				completed++
+				if options.Progress != nil {
+					options.Progress(completed, len(rowIds))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, rowId := range rowIds {
+		jobs <- rowId
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		a, b := report.Entries[i], report.Entries[j]
+		if !a.ModifiedAt.Equal(b.ModifiedAt) {
+			return a.ModifiedAt.Before(b.ModifiedAt)
+		}
+		return a.RowId < b.RowId
+	})
+	if len(historyErr.RowErrs) > 0 {
+		log.Println("ERROR ColumnHistoryReport", historyErr)
+		return report, historyErr
+	}
+	return report, nil
+}
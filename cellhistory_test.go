@@ -0,0 +1,95 @@
+package smartsheet
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Test_ColumnHistoryReport narrows to rows 11, 12, 13 (modified since) of a sheet, row 13 fails the first time.
+func Test_ColumnHistoryReport(t *testing.T) {
+	since := time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+	history := map[string]string{ // row id: history page, newest first
+		"11": `[{"columnId":105,"value":30,"modifiedAt":"2020-11-20T10:00:00Z","modifiedBy":{"email":"a@test.com"}},
+			{"columnId":105,"value":20,"modifiedAt":"2020-11-05T10:00:00Z","modifiedBy":{"email":"b@test.com"}},
+			{"columnId":105,"value":10,"modifiedAt":"2020-10-01T10:00:00Z"}]`,
+		"12": `[{"columnId":105,"value":5,"modifiedAt":"2020-11-10T10:00:00Z","modifiedBy":{"email":"a@test.com"}}]`,
+		"13": `[{"columnId":105,"value":7,"modifiedAt":"2020-11-02T10:00:00Z","modifiedBy":{"email":"c@test.com"}}]`,
+	}
+	var mu sync.Mutex
+	var sheetQuery string
+	requested := make(map[string]int) // row id: history requests
+	failRow := "13"
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/") // /sheets/{id}/rows/{rowId}/columns/{columnId}/history
+		if len(parts) == 3 {
+			sheetQuery = r.URL.Query().Get("rowsModifiedSince") + " " + r.URL.Query().Get("columnIds")
+			w.Write([]byte(`{"id":1849449510135684,"rows":[{"id":11},{"id":12},{"id":13}]}`))
+			return
+		}
+		rowId := parts[4]
+		mu.Lock()
+		requested[rowId]++
+		mu.Unlock()
+		if parts[6] != "105" {
+			t.Error("ColumnHistoryReport wrong column", r.URL.Path)
+		}
+		if rowId == failRow {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"errorCode":4000,"message":"An unexpected error has occurred."}`))
+			return
+		}
+		fmt.Fprintf(w, `{"pageNumber":1,"totalPages":1,"data":%s}`, history[rowId])
+	})
+	sheet := testSheet()
+
+	var progress []string
+	options := HistoryOptions{Parallelism: 2, Progress: func(done, total int) {
+		progress = append(progress, fmt.Sprintf("%d/%d", done, total))
+	}}
+	report, err := ColumnHistoryReportWith(sheet, "Amt", since, 0, &options)
+	historyErr, ok := err.(*HistoryError)
+	if !ok || len(historyErr.RowErrs) != 1 || historyErr.RowErrs[13] == nil {
+		t.Fatal("ColumnHistoryReport expected HistoryError for row 13, got", err)
+	}
+	if sheetQuery != "2020-11-01T00:00:00Z 105" {
+		t.Error("ColumnHistoryReport wrong narrowing query", sheetQuery)
+	}
+	if len(report.Entries) != 3 || len(report.RowsDone) != 2 || report.Truncated {
+		t.Fatalf("ColumnHistoryReport wrong report %+v", report)
+	}
+	if fmt.Sprint(progress) != "[1/3 2/3 3/3]" {
+		t.Error("ColumnHistoryReport wrong progress", progress)
+	}
+
+	// resume, only row 13 is requested again
+	failRow = ""
+	report, err = ColumnHistoryReportWith(sheet, "Amt", since, 0, &HistoryOptions{Resume: report})
+	if err != nil {
+		t.Fatal("ColumnHistoryReport resume Failed", err)
+	}
+	if requested["11"] != 1 || requested["12"] != 1 || requested["13"] != 2 {
+		t.Error("ColumnHistoryReport resume expected only row 13 requested again", requested)
+	}
+	expect := []string{"13 7 c@test.com", "11 20 b@test.com", "12 5 a@test.com", "11 30 a@test.com"} // oldest first, before since excluded
+	if len(report.Entries) != len(expect) {
+		t.Fatalf("ColumnHistoryReport Expecting %d entries, Got %+v", len(expect), report.Entries)
+	}
+	for i, entry := range report.Entries {
+		got := fmt.Sprintf("%d %v %s", entry.RowId, entry.Value, entry.ModifiedBy.Email)
+		if got != expect[i] {
+			t.Errorf("ColumnHistoryReport entry %d Expecting %s, Got %s", i, expect[i], got)
+		}
+	}
+
+	report, err = ColumnHistoryReport(sheet, "Amt", since, 2)
+	if err != nil || !report.Truncated || len(report.RowsDone) != 2 || requested["13"] != 2 {
+		t.Errorf("ColumnHistoryReport maxRows 2 expected truncated report without row 13, %+v %v", report, err)
+	}
+	if _, err = ColumnHistoryReport(sheet, "Bogus", since, 0); err == nil {
+		t.Error("ColumnHistoryReport expected invalid column error")
+	}
+}
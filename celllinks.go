@@ -0,0 +1,129 @@
+// celllinks.go contains funcs for creating and removing cell links.
+// A linked cell gets its value from a cell in another sheet (the source).
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strconv"
+)
+
+// CellLinkSource identifies the cell a linked cell gets its value from.
+type CellLinkSource struct {
+	SheetId, RowId, ColumnId int64
+}
+
+// CellLinkSpec identifies a target cell (by row id and column name) and its source cell.
+// Used by LinkCellsBatch.
+type CellLinkSpec struct {
+	RowId   int64
+	ColName string
+	Source  CellLinkSource
+}
+
+// linkCell is the cell object sent when creating or removing a cell link.
+// Value is not omitted when nil, the API requires value to be null when creating a link.
+type linkCell struct {
+	ColumnId       int64       `json:"columnId"`
+	Value          interface{} `json:"value"`
+	LinkInFromCell *CellLink   `json:"linkInFromCell,omitempty"`
+}
+
+// LinkCells links 1 cell in targetSheet to a source cell.
+// Parm targetSheet is used to convert targetColumn to a columnId and must contain SheetId.
+func LinkCells(targetSheet *SheetInfo, targetRowId int64, targetColumn string, source CellLinkSource) (*AddUpdtRowsResponse, error) {
+	trace("LinkCells")
+	links := []CellLinkSpec{{RowId: targetRowId, ColName: targetColumn, Source: source}}
+	return LinkCellsBatch(targetSheet, links)
+}
+
+// LinkCellsBatch links multiple cells in targetSheet to their source cells using 1 request.
+// Links targeting the same row are combined into 1 update row.
+// The API does not allow link updates and value updates in the same request.
+func LinkCellsBatch(targetSheet *SheetInfo, links []CellLinkSpec) (*AddUpdtRowsResponse, error) {
+	trace("LinkCellsBatch")
+	cells := make([]linkCell, len(links))
+	rowIds := make([]int64, len(links))
+	colNames := make([]string, len(links))
+	for i, link := range links {
+		cells[i] = linkCell{
+			LinkInFromCell: &CellLink{
+				SheetId:  link.Source.SheetId,
+				RowId:    link.Source.RowId,
+				ColumnId: link.Source.ColumnId,
+			},
+		}
+		rowIds[i] = link.RowId
+		colNames[i] = link.ColName
+	}
+	return putLinkCells(targetSheet, rowIds, colNames, cells)
+}
+
+// UnlinkCell removes the cell link from a cell by replacing it with value.
+// The API only breaks a link when a non-null value is sent, so a nil value is sent as "".
+func UnlinkCell(targetSheet *SheetInfo, targetRowId int64, targetColumn string, value interface{}) (*AddUpdtRowsResponse, error) {
+	trace("UnlinkCell")
+	if value == nil {
+		value = ""
+	}
+	cells := []linkCell{{Value: value}}
+	return putLinkCells(targetSheet, []int64{targetRowId}, []string{targetColumn}, cells)
+}
+
+// putLinkCells loads columnIds using colNames, groups cells by rowId and sends the update rows request.
+// Parms rowIds, colNames and cells are parallel slices.
+func putLinkCells(sheet *SheetInfo, rowIds []int64, colNames []string, cells []linkCell) (*AddUpdtRowsResponse, error) {
+	if sheet.SheetId == 0 {
+		log.Println("ERROR putLinkCells - sheet.SheetId not set")
+		return nil, errors.New("sheet.SheetId empty")
+	}
+	if len(cells) == 0 {
+		log.Println("putLinkCells - No Cells Specified")
+		return nil, nil
+	}
+	// -- Create Request Body, 1 item per row, rows kept in order first seen ----------------
+	type reqItem struct {
+		Id    string     `json:"id"` // api expects row id to be a string
+		Cells []linkCell `json:"cells"`
+	}
+	reqData := make([]reqItem, 0, len(cells))
+	itemIndex := make(map[int64]int) // key-rowId, val-index in reqData
+	for i, cell := range cells {
+		column, found := sheet.ColumnsByName[colNames[i]]
+		if !found {
+			log.Println("ERROR - putLinkCells column not found", sheet.SheetName, colNames[i])
+			return nil, fmt.Errorf("%w - %s", ErrInvalidColumnName, colNames[i])
+		}
+		cell.ColumnId = column.Id
+		ix, found := itemIndex[rowIds[i]]
+		if !found {
+			ix = len(reqData)
+			itemIndex[rowIds[i]] = ix
+			reqData = append(reqData, reqItem{Id: strconv.FormatInt(rowIds[i], 10)})
+		}
+		reqData[ix].Cells = append(reqData[ix].Cells, cell)
+	}
+	endPoint := fmt.Sprintf("/sheets/%d/rows", sheet.SheetId)
+	req := Put(endPoint, reqData, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+	apiResp := new(AddUpdtRowsResponse)
+	err = json.Unmarshal(respJSON, apiResp)
+	if err != nil {
+		log.Println("ERROR - putLinkCells Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return apiResp, nil
+}
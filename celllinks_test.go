@@ -0,0 +1,65 @@
+package smartsheet
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// linkStub returns a stub server handler that saves the request body in *body.
+func linkStub(t *testing.T, body *string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/sheets/1849449510135684/rows" {
+			t.Error("unexpected request", r.Method, r.URL.Path)
+		}
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		*body = compactJSON(reqBytes)
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[]}`))
+	}
+}
+
+func Test_LinkCells(t *testing.T) {
+	var body string
+	stubServer(t, linkStub(t, &body))
+	sheet := testSheet()
+
+	source := CellLinkSource{SheetId: 8094487248430980, RowId: 555, ColumnId: 777}
+	if _, err := LinkCells(sheet, 12, "Amt", source); err != nil {
+		t.Fatal("Test_LinkCells LinkCells Failed", err)
+	}
+	expect := `[{"id":"12","cells":[{"columnId":105,"value":null,"linkInFromCell":{"columnId":777,"rowId":555,"sheetId":8094487248430980}}]}]`
+	if body != expect {
+		t.Errorf("Test_LinkCells Expecting %s, Got %s", expect, body)
+	}
+
+	links := []CellLinkSpec{
+		{RowId: 12, ColName: "Amt", Source: source},
+		{RowId: 13, ColName: "Amt", Source: CellLinkSource{SheetId: 8094487248430980, RowId: 556, ColumnId: 777}},
+		{RowId: 12, ColName: "OrderNo", Source: CellLinkSource{SheetId: 8094487248430980, RowId: 555, ColumnId: 778}},
+	}
+	if _, err := LinkCellsBatch(sheet, links); err != nil {
+		t.Fatal("Test_LinkCells LinkCellsBatch Failed", err)
+	}
+	expect = `[{"id":"12","cells":[` +
+		`{"columnId":105,"value":null,"linkInFromCell":{"columnId":777,"rowId":555,"sheetId":8094487248430980}},` +
+		`{"columnId":102,"value":null,"linkInFromCell":{"columnId":778,"rowId":555,"sheetId":8094487248430980}}]},` +
+		`{"id":"13","cells":[{"columnId":105,"value":null,"linkInFromCell":{"columnId":777,"rowId":556,"sheetId":8094487248430980}}]}]`
+	if body != expect {
+		t.Errorf("Test_LinkCells Batch Expecting %s, Got %s", expect, body)
+	}
+
+	if _, err := UnlinkCell(sheet, 12, "Amt", nil); err != nil {
+		t.Fatal("Test_LinkCells UnlinkCell Failed", err)
+	}
+	expect = `[{"id":"12","cells":[{"columnId":105,"value":""}]}]`
+	if body != expect {
+		t.Errorf("Test_LinkCells Unlink Expecting %s, Got %s", expect, body)
+	}
+
+	_, err := LinkCells(sheet, 12, "Bogus", source)
+	if !errors.Is(err, ErrInvalidColumnName) || !strings.Contains(err.Error(), "Bogus") {
+		t.Error("Test_LinkCells expected invalid column error, got", err)
+	}
+}
@@ -0,0 +1,181 @@
+// changejournal.go contains ChangeJournal, which turns the rows loaded by SyncCursor.LoadDelta into an ordered stream
+// of row change records (added, updated, deleted), ex. to replicate a sheet into a database.
+
+package smartsheet
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Change Record Types
+const (
+	ChangeAdded   = "added"
+	ChangeUpdated = "updated"
+	ChangeDeleted = "deleted"
+)
+
+// ValueChange is the value of a cell before and after a change, as returned by RowValues.
+type ValueChange struct {
+	Old, New string
+}
+
+// ChangeRecord is 1 row change emitted by ChangeJournal.
+// ChangedCells is keyed by column title. Added rows contain every cell with a value (Old is ""), deleted rows every
+// cell that had a value (New is ""). At is the row's modified time, for deleted rows the sheet's modified time.
+type ChangeRecord struct {
+	RowId        int64
+	Type         string // use Change Record Type constants
+	ChangedCells map[string]ValueChange
+	At           time.Time
+}
+
+// ChangeJournal emits a ChangeRecord for each row added, updated or deleted in a sheet, in the order of the changes.
+// Each Sync loads the rows modified since the cursor's last commit (SyncCursor.LoadDelta), compares them with Mirror
+// (the rows as of the last Sync) and calls OnChange for each changed row. Rows reloaded without changes (ex. in the
+// cursor overlap window) are not emitted, so each change is emitted once while the journal is in use.
+//
+// If OnChange returns an error, Sync stops, records not yet emitted are emitted by the next Sync and the cursor is not
+// committed. The cursor is committed when all records of a Sync are emitted.
+// Mirror holds every row of the sheet, store it (SheetInfo.Store) after Sync and restore it when the program restarts,
+// otherwise the 1st Sync reports every row loaded as added. Safe for use by multiple goroutines, Syncs run 1 at a time.
+type ChangeJournal struct {
+	SheetId       int64
+	Cursor        *SyncCursor
+	OnChange      func(record ChangeRecord) error
+	DetectDeletes bool       // each Sync requests the sheet's row ids (see FindDeletedRows) to emit deleted rows, 1 extra request
+	Mirror        *SheetInfo // rows as of the last Sync
+
+	mu sync.Mutex
+}
+
+// NewChangeJournal returns a journal of sheetId using cursor. Mirror is the sheet as of the cursor's last commit,
+// nil to start with no rows (the 1st Sync emits every row loaded as added, all rows if the cursor was never committed).
+func NewChangeJournal(sheetId int64, cursor *SyncCursor, mirror *SheetInfo, onChange func(record ChangeRecord) error) *ChangeJournal {
+	trace("NewChangeJournal")
+	if mirror == nil {
+		mirror = &SheetInfo{SheetId: sheetId}
+	}
+	return &ChangeJournal{SheetId: sheetId, Cursor: cursor, OnChange: onChange, DetectDeletes: true, Mirror: mirror}
+}
+
+// Sync loads the rows modified since the last commit, emits their changes and commits the cursor.
+// Returns the number of records emitted.
+func (j *ChangeJournal) Sync() (int, error) {
+	trace("ChangeJournal.Sync")
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.OnChange == nil {
+		log.Println("ERROR - ChangeJournal.Sync OnChange not set")
+		return 0, errors.New("Invalid ChangeJournal - OnChange not set")
+	}
+	delta := new(SheetInfo)
+	if err := j.Cursor.LoadDelta(delta, j.SheetId); err != nil {
+		log.Println("ERROR - ChangeJournal.Sync LoadDelta Failed", j.SheetId, err)
+		return 0, err
+	}
+	j.Mirror.SheetId, j.Mirror.SheetName = delta.SheetId, delta.SheetName
+	j.Mirror.Columns, j.Mirror.ColumnsById, j.Mirror.ColumnsByName, j.Mirror.ColumnsByIndex = delta.Columns, delta.ColumnsById, delta.ColumnsByName, delta.ColumnsByIndex
+
+	var deleted []int64
+	if j.DetectDeletes {
+		var err error
+		if deleted, err = j.Mirror.FindDeletedRows(); err != nil {
+			return 0, err
+		}
+	}
+
+	previous := make(map[int64]int, len(j.Mirror.Rows)) // row id to index in Mirror.Rows
+	for i, row := range j.Mirror.Rows {
+		previous[row.Id] = i
+	}
+	rows := append([]Row(nil), delta.Rows...)
+	sort.SliceStable(rows, func(a, b int) bool { return rows[a].ModifiedAt < rows[b].ModifiedAt }) // RFC3339 UTC sorts as text
+	emitted := 0
+	for _, row := range rows {
+		record := ChangeRecord{RowId: row.Id, Type: ChangeAdded}
+		var before map[string]string
+		if i, found := previous[row.Id]; found {
+			record.Type = ChangeUpdated
+			before = RowValues(j.Mirror, j.Mirror.Rows[i])
+		}
+		record.ChangedCells = diffValues(before, RowValues(j.Mirror, row))
+		record.At, _ = time.Parse(time.RFC3339, row.ModifiedAt)
+		if record.Type == ChangeAdded || len(record.ChangedCells) > 0 {
+			if err := j.OnChange(record); err != nil {
+				log.Println("ERROR - ChangeJournal.Sync OnChange Failed, row", row.Id, err)
+				return emitted, err
+			}
+			emitted++
+		}
+		if i, found := previous[row.Id]; found {
+			j.Mirror.Rows[i] = row
+		} else {
+			previous[row.Id] = len(j.Mirror.Rows)
+			j.Mirror.Rows = append(j.Mirror.Rows, row)
+		}
+	}
+	removed := make([]int64, 0, len(deleted))
+	defer func() { j.Mirror.removeRows(removed) }() // only rows whose record was emitted
+	for _, rowId := range deleted {
+		before := RowValues(j.Mirror, j.Mirror.Rows[previous[rowId]])
+		record := ChangeRecord{RowId: rowId, Type: ChangeDeleted, ChangedCells: diffValues(before, nil), At: delta.ModifiedAt}
+		if err := j.OnChange(record); err != nil {
+			log.Println("ERROR - ChangeJournal.Sync OnChange Failed, deleted row", rowId, err)
+			return emitted, err
+		}
+		emitted++
+		removed = append(removed, rowId)
+	}
+	j.Mirror.ModifiedAt = delta.ModifiedAt
+	if delta.ModifiedAt.IsZero() {
+		return emitted, nil
+	}
+	return emitted, j.Cursor.Commit(delta.ModifiedAt)
+}
+
+// diffValues returns the cells whose value differs between before and after, cells empty in both are not included.
+func diffValues(before, after map[string]string) map[string]ValueChange {
+	changes := make(map[string]ValueChange)
+	for title, value := range after {
+		if before[title] != value {
+			changes[title] = ValueChange{Old: before[title], New: value}
+		}
+	}
+	for title, value := range before {
+		if _, found := after[title]; !found && value != "" {
+			changes[title] = ValueChange{Old: value}
+		}
+	}
+	return changes
+}
+
+// Run calls Sync every interval until ctx is cancelled, returning ctx.Err(). A failed Sync is logged and retried
+// at the next interval.
+func (j *ChangeJournal) Run(ctx context.Context, interval time.Duration) error {
+	trace("ChangeJournal.Run")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		j.Sync() // errors are logged by Sync
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// HandleWebhookEvent calls Sync for a webhook callback of the journal's sheet, other callbacks are ignored.
+func (j *ChangeJournal) HandleWebhookEvent(cb WebhookCallback) error {
+	trace("ChangeJournal.HandleWebhookEvent")
+	if cb.Scope != SheetScope || cb.ScopeObjectId != j.SheetId {
+		return nil
+	}
+	_, err := j.Sync()
+	return err
+}
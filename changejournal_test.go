@@ -0,0 +1,93 @@
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func Test_ChangeJournal(t *testing.T) {
+	// cycle 0 is the 1st load (all rows), later cycles return the delta; live is the sheet's current row ids
+	var rows, live string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body := rows
+		if r.URL.Query().Get("columnIds") != "" { // FindDeletedRows
+			body = live
+		}
+		fmt.Fprintf(w, `{"id":1849449510135684,"name":"Orders","modifiedAt":"2024-03-02T10:00:00Z",
+			"columns":[{"id":101,"index":0,"title":"Name","type":"TEXT_NUMBER","primary":true},{"id":102,"index":1,"title":"Status","type":"TEXT_NUMBER"}],
+			"rows":%s}`, body)
+	})
+	records := make([]string, 0)
+	failRow := int64(0)
+	onChange := func(record ChangeRecord) error {
+		if record.RowId == failRow {
+			return errors.New("database unavailable")
+		}
+		cells := make([]string, 0, len(record.ChangedCells))
+		for title, change := range record.ChangedCells {
+			cells = append(cells, fmt.Sprintf("%s:%s>%s", title, change.Old, change.New))
+		}
+		sort.Strings(cells)
+		records = append(records, fmt.Sprintf("%d %s %s %s", record.RowId, record.Type, record.At.Format("15:04"), strings.Join(cells, ",")))
+		return nil
+	}
+	cursor := &SyncCursor{Store: new(memoryCursorStore)}
+	journal := NewChangeJournal(1849449510135684, cursor, nil, onChange)
+	row := func(id int64, modifiedAt, name, status string) string {
+		return fmt.Sprintf(`{"id":%d,"modifiedAt":"2024-03-02T%s:00Z","cells":[{"columnId":101,"value":"%s"},{"columnId":102,"value":"%s"}]}`,
+			id, modifiedAt, name, status)
+	}
+
+	// 1st cycle, all rows added in modified order
+	rows = "[" + row(2, "09:10", "B", "Open") + "," + row(1, "09:05", "A", "Open") + "]"
+	live = `[{"id":1},{"id":2}]`
+	count, err := journal.Sync()
+	expect := "1 added 09:05 Name:>A,Status:>Open | 2 added 09:10 Name:>B,Status:>Open"
+	if err != nil || count != 2 || strings.Join(records, " | ") != expect {
+		t.Errorf("ChangeJournal 1st Sync, Expecting %s, Got %d %v %v", expect, count, records, err)
+	}
+	if cursor.LastSync().IsZero() {
+		t.Error("ChangeJournal expected cursor committed")
+	}
+
+	// 2nd cycle, row 1 reloaded unchanged (overlap), row 2 updated, row 3 added, row 1 then deleted
+	records = records[:0]
+	rows = "[" + row(1, "09:05", "A", "Open") + "," + row(3, "09:50", "C", "New") + "," + row(2, "09:40", "B", "Closed") + "]"
+	live = `[{"id":2},{"id":3}]`
+	count, err = journal.Sync()
+	expect = "2 updated 09:40 Status:Open>Closed | 3 added 09:50 Name:>C,Status:>New | 1 deleted 10:00 Name:A>,Status:Open>"
+	if err != nil || count != 3 || strings.Join(records, " | ") != expect {
+		t.Errorf("ChangeJournal 2nd Sync, Expecting %s, Got %d %v %v", expect, count, records, err)
+	}
+
+	// same delta again, nothing emitted
+	records = records[:0]
+	rows = "[" + row(3, "09:50", "C", "New") + "," + row(2, "09:40", "B", "Closed") + "]"
+	if count, err = journal.Sync(); err != nil || count != 0 || len(records) != 0 {
+		t.Error("ChangeJournal expected no records for unchanged rows, got", count, records, err)
+	}
+
+	// OnChange fails, records not emitted are emitted by the next Sync
+	rows = "[" + row(2, "11:00", "B", "Late") + "," + row(3, "11:05", "C", "Late") + "]"
+	failRow = 3
+	if count, err = journal.Sync(); err == nil || count != 1 {
+		t.Error("ChangeJournal expected OnChange error after 1 record, got", count, err)
+	}
+	records = records[:0]
+	failRow = 0
+	if count, err = journal.Sync(); err != nil || count != 1 || strings.Join(records, " | ") != "3 updated 11:05 Status:New>Late" {
+		t.Error("ChangeJournal expected failed record emitted once, got", count, records, err)
+	}
+	if len(journal.Mirror.Rows) != 2 || RowValues(journal.Mirror, journal.Mirror.Rows[0])["Status"] != "Late" {
+		t.Error("ChangeJournal wrong Mirror rows", journal.Mirror.Rows)
+	}
+
+	cb := WebhookCallback{Scope: "sheet", ScopeObjectId: 99}
+	if err = journal.HandleWebhookEvent(cb); err != nil {
+		t.Error("ChangeJournal.HandleWebhookEvent expected other sheet ignored, got", err)
+	}
+}
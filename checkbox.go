@@ -0,0 +1,100 @@
+// checkbox.go contains the text of CHECKBOX values returned by RowValues and RowValuesDetailed (SheetInfo.CheckboxText),
+// configured by Column.Symbol so plain, FLAG and STAR checkboxes can render differently (ex. "Yes"/"No", "★"/"☆"),
+// and CellBool, which returns the value of a checkbox cell as a bool.
+
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// BoolText is the text of the true and false values of a CHECKBOX column, see SheetInfo.CheckboxText.
+type BoolText struct {
+	True  string
+	False string
+}
+
+// Checkbox Texts, for SheetInfo.CheckboxText
+var (
+	BoolTextTrueFalse = BoolText{"true", "false"}
+	BoolTextYesNo     = BoolText{"Yes", "No"}
+	BoolTextStars     = BoolText{"★", "☆"}
+)
+
+// ErrNotBool is wrapped by the error returned by CellBool when the cell value is not a bool.
+var ErrNotBool = errors.New("Cell Value Not Bool")
+
+// checkboxText returns the text of a CHECKBOX cell from SheetInfo.CheckboxText, found is false if the column is not a
+// checkbox, no text is set for its Column.Symbol, or the value is not a bool. An empty cell is false.
+func (she *SheetInfo) checkboxText(cell Cell, column Column) (text string, found bool) {
+	if column.Type != CHECKBOX {
+		return "", false
+	}
+	boolText, found := she.CheckboxText[column.Symbol]
+	if !found {
+		return "", false
+	}
+	switch cell.Value {
+	case true:
+		return boolText.True, true
+	case false, nil:
+		return boolText.False, true
+	}
+	return "", false
+}
+
+// valueText returns the text of cell, as returned by RowValues for cells without a hyperlink.
+func (she *SheetInfo) valueText(cell Cell, column Column) string {
+	if text, found := she.checkboxText(cell, column); found {
+		return text
+	}
+	return cellText(cell, column)
+}
+
+// checkboxValue returns the bool of a string value equal to the SheetInfo.CheckboxText of a CHECKBOX column,
+// so values read using RowValues can be written back (used by coerceCells). Found is false for other values.
+func (she *SheetInfo) checkboxValue(value interface{}, column Column) (boolValue bool, found bool) {
+	text, isText := value.(string)
+	boolText, configured := she.CheckboxText[column.Symbol]
+	if !isText || !configured || column.Type != CHECKBOX {
+		return false, false
+	}
+	switch text {
+	case boolText.True:
+		return true, true
+	case boolText.False:
+		return false, true
+	}
+	return false, false
+}
+
+// CellBool returns the value of a cell in row as a bool. Parm columnName is matched using sheet.NameMatcher.
+// In CHECKBOX columns (including FLAG and STAR), a cell missing from row or without value is false.
+// In other columns, the error wraps ErrNotBool unless the cell value is a bool.
+func CellBool(sheet *SheetInfo, row Row, columnName string) (bool, error) {
+	column, found, err := sheet.columnNamed(columnName)
+	if err != nil {
+		log.Println("ERROR - CellBool", err)
+		return false, err
+	}
+	if !found {
+		log.Println("ERROR - CellBool, columnName not found in sheet.ColumnsByName: ", columnName)
+		return false, fmt.Errorf("%w - %s", ErrInvalidColumnName, columnName)
+	}
+	var value interface{}
+	for _, cell := range row.Cells {
+		if cell.ColumnId == column.Id {
+			value = cell.Value
+			break
+		}
+	}
+	if boolValue, isBool := value.(bool); isBool {
+		return boolValue, nil
+	}
+	if value == nil && column.Type == CHECKBOX {
+		return false, nil
+	}
+	return false, fmt.Errorf("%w - row %d column %s (%s) value %v", ErrNotBool, row.Id, column.Title, column.Type, value)
+}
@@ -0,0 +1,91 @@
+package smartsheet
+
+import (
+	"errors"
+	"testing"
+)
+
+// checkboxSheet returns testSheet with column 106 Complete (plain checkbox), 110 Flagged (FLAG) and 111 Starred (STAR).
+func checkboxSheet() *SheetInfo {
+	sheet := testSheet()
+	for _, column := range []Column{
+		{Id: 110, Index: 9, Title: "Flagged", Type: CHECKBOX, Symbol: SymbolFlag},
+		{Id: 111, Index: 10, Title: "Starred", Type: CHECKBOX, Symbol: SymbolStar},
+	} {
+		sheet.ColumnsById[column.Id], sheet.ColumnsByName[column.Title], sheet.ColumnsByIndex[column.Index] = column, column, column
+	}
+	return sheet
+}
+
+func Test_CheckboxText(t *testing.T) {
+	row := Row{Id: 11, Cells: []Cell{{ColumnId: 106, Value: true}, {ColumnId: 110, Value: true}, {ColumnId: 101, Value: "100 Main"}}}
+	unchecked := Row{Id: 12, Cells: []Cell{{ColumnId: 106, Value: false}}} // 110, 111 missing
+
+	tests := []struct {
+		name         string
+		checkboxText map[string]BoolText
+		expect       [6]string // Complete, Flagged, Starred of row, then of unchecked
+	}{
+		{"default", nil, [6]string{"true", "true", "false", "false", "false", "false"}},
+		{"plain", map[string]BoolText{"": BoolTextYesNo}, [6]string{"Yes", "true", "false", "No", "false", "false"}},
+		{"flag", map[string]BoolText{SymbolFlag: {"Flagged", ""}}, [6]string{"true", "Flagged", "false", "false", "", "false"}},
+		{"star", map[string]BoolText{SymbolStar: BoolTextStars}, [6]string{"true", "true", "☆", "false", "false", "☆"}},
+	}
+	for _, test := range tests {
+		sheet := checkboxSheet()
+		sheet.CheckboxText = test.checkboxText
+		values, unValues := RowValues(sheet, row), RowValues(sheet, unchecked)
+		got := [6]string{values["Complete"], values["Flagged"], values["Starred"], unValues["Complete"], unValues["Flagged"], unValues["Starred"]}
+		if got != test.expect || values["Address"] != "100 Main" {
+			t.Errorf("RowValues %s, Expecting %q, Got %q", test.name, test.expect, got)
+		}
+		details := RowValuesDetailed(sheet, row)
+		if details["Complete"].Value != test.expect[0] || details["Complete"].DisplayValue != test.expect[0] || details["Starred"].DisplayValue != test.expect[2] {
+			t.Errorf("RowValuesDetailed %s, Expecting %s %s, Got %+v %+v", test.name, test.expect[0], test.expect[2], details["Complete"], details["Starred"])
+		}
+	}
+}
+
+func Test_CheckboxTextCoerce(t *testing.T) {
+	sheet := checkboxSheet()
+	sheet.CheckboxText = map[string]BoolText{SymbolStar: BoolTextStars, "": BoolTextYesNo}
+	sheet.CoerceValues = true
+	err := sheet.UpdateRow(Row{Id: 11, Cells: []Cell{{ColName: "Starred", Value: "★"}, {ColName: "Complete", Value: "No"}, {ColName: "Flagged", Value: "★"}}})
+	if err != nil {
+		t.Fatal("UpdateRow Failed", err)
+	}
+	cells := sheet.UpdateRows[0].Cells
+	if cells[0].Value != true || cells[1].Value != false || cells[2].Value != "★" {
+		t.Error("UpdateRow expected CheckboxText values converted to bool, got", cells)
+	}
+	if len(sheet.Warnings) != 1 || sheet.Warnings[0].Code != WarnNotCoerced || sheet.Warnings[0].ColumnId != 110 {
+		t.Error("UpdateRow expected WarnNotCoerced for FLAG column without CheckboxText, got", sheet.Warnings)
+	}
+}
+
+func Test_CellBool(t *testing.T) {
+	sheet := checkboxSheet()
+	row := Row{Id: 11, Cells: []Cell{{ColumnId: 106, Value: true}, {ColumnId: 111}, {ColumnId: 101, Value: "yes"}, {ColumnId: 105, Value: true}}}
+	tests := []struct {
+		column string
+		expect bool
+	}{
+		{"Complete", true},
+		{"Flagged", false}, // missing
+		{"Starred", false}, // no value
+		{"Amt", true},      // bool value in TEXT_NUMBER column
+	}
+	for _, test := range tests {
+		if got, err := CellBool(sheet, row, test.column); err != nil || got != test.expect {
+			t.Errorf("CellBool %s, Expecting %v, Got %v %v", test.column, test.expect, got, err)
+		}
+	}
+	for _, column := range []string{"Address", "OrderNo"} { // text value, missing
+		if _, err := CellBool(sheet, row, column); !errors.Is(err, ErrNotBool) {
+			t.Error("CellBool", column, "expected ErrNotBool, got", err)
+		}
+	}
+	if _, err := CellBool(sheet, row, "Missing"); !errors.Is(err, ErrInvalidColumnName) {
+		t.Error("CellBool expected ErrInvalidColumnName, got", err)
+	}
+}
@@ -0,0 +1,110 @@
+// coerce.go contains the optional conversion of string cell values to the type of their column, used when rows are
+// staged with SheetInfo.CoerceValues set. Upstream data often arrives as strings (ex. csv files), and the api handles
+// "true" in a CHECKBOX column or "42" in a TEXT_NUMBER column differently depending on strict mode.
+
+package smartsheet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TEXTNUMBER is the Column Type of text and number columns.
+const TEXTNUMBER = "TEXT_NUMBER"
+
+// CoerceDateLayouts are the layouts of string values converted in date columns, tried in order.
+// Slash dates are month first, replace this var for day first data.
+var CoerceDateLayouts = []string{
+	DateFormat, DateTimeFormat, AbstractDateTimeFormat, "2006-01-02 15:04:05", "01/02/2006", "1/2/2006", "2006/01/02",
+	"Jan 2, 2006", "January 2, 2006", "2 Jan 2006",
+}
+
+// checkbox string values, compared in lower case
+var (
+	checkboxTrue  = map[string]bool{"true": true, "1": true, "yes": true, "y": true}
+	checkboxFalse = map[string]bool{"false": true, "0": true, "no": true, "n": true, "": true}
+)
+
+// CoerceValue returns value converted to the type of column, if value is a string:
+//   - CHECKBOX: "true", "1", "yes", "y" to true, "false", "0", "no", "n" and "" to false (case ignored)
+//   - TEXT_NUMBER: numeric strings to float64, except numbers with leading zeros (ex. "00123"), they are kept as text
+//   - DATE, DATETIME, ABSTRACT_DATETIME: strings matching CoerceDateLayouts to the column's format, see FormatForColumn
+//
+// Other values and column types are returned unchanged. An error is returned with the unchanged value if a CHECKBOX or
+// date string cannot be converted, text in a TEXT_NUMBER column is not an error.
+func CoerceValue(value interface{}, column Column) (interface{}, error) {
+	text, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	trimmed := strings.TrimSpace(text)
+	switch column.Type {
+	case CHECKBOX:
+		lower := strings.ToLower(trimmed)
+		if checkboxTrue[lower] {
+			return true, nil
+		}
+		if checkboxFalse[lower] {
+			return false, nil
+		}
+		return value, fmt.Errorf("Invalid Checkbox Value in Column %s - %s", column.Title, text)
+	case TEXTNUMBER:
+		if number, ok := parseNumber(trimmed); ok {
+			return number, nil
+		}
+		return value, nil
+	case DATE, DATETIME, ABSTRACTDATETIME:
+		if trimmed == "" {
+			return value, nil
+		}
+		for _, layout := range CoerceDateLayouts {
+			if t, err := time.Parse(layout, trimmed); err == nil {
+				return FormatForColumn(t, column), nil
+			}
+		}
+		return value, fmt.Errorf("Invalid Date Value in Column %s - %s", column.Title, text)
+	}
+	return value, nil
+}
+
+// parseNumber returns text as float64 if it is a plain decimal number, ex. "42", "-3.5", "1e6".
+// Leading zeros, hex, Inf and NaN are not numbers.
+func parseNumber(text string) (float64, bool) {
+	digits := strings.TrimLeft(text, "+-")
+	if digits == "" || strings.Trim(text, "0123456789.eE+-") != "" {
+		return 0, false
+	}
+	if len(digits) > 1 && digits[0] == '0' && digits[1] != '.' {
+		return 0, false
+	}
+	number, err := strconv.ParseFloat(text, 64)
+	return number, err == nil
+}
+
+// coerceCells converts the values of cells using CoerceValue if SheetInfo.CoerceValues is set.
+// CHECKBOX values equal to the column's SheetInfo.CheckboxText are converted to bool.
+// ColumnIds must be loaded. Cells that cannot be converted are left unchanged and reported in Warnings (WarnNotCoerced).
+// Formula cells are not changed.
+func (she *SheetInfo) coerceCells(rowId int64, cells []Cell) {
+	if !she.CoerceValues {
+		return
+	}
+	for i, cell := range cells {
+		if cell.Formula != "" {
+			continue
+		}
+		column := she.ColumnsById[cell.ColumnId]
+		if boolValue, found := she.checkboxValue(cell.Value, column); found { // text returned by RowValues
+			cells[i].Value = boolValue
+			continue
+		}
+		value, err := CoerceValue(cell.Value, column)
+		if err != nil {
+			she.warn(WarnNotCoerced, err.Error(), rowId, cell.ColumnId)
+			continue
+		}
+		cells[i].Value = value
+	}
+}
@@ -0,0 +1,104 @@
+package smartsheet
+
+import (
+	"testing"
+)
+
+func Test_CoerceValue(t *testing.T) {
+	checkbox := Column{Title: "Complete", Type: CHECKBOX}
+	number := Column{Title: "Amt", Type: TEXTNUMBER}
+	date := Column{Title: "DueDate", Type: DATE}
+	dateTime := Column{Title: "Sent", Type: DATETIME}
+	abstract := Column{Title: "Start", Type: ABSTRACTDATETIME}
+	picklist := Column{Title: "Status", Type: PICKLIST}
+
+	tests := []struct {
+		column   Column
+		value    interface{}
+		expected interface{}
+		fails    bool
+	}{
+		{checkbox, "true", true, false},
+		{checkbox, " Yes ", true, false},
+		{checkbox, "1", true, false},
+		{checkbox, "FALSE", false, false},
+		{checkbox, "no", false, false},
+		{checkbox, "", false, false},
+		{checkbox, true, true, false},
+		{checkbox, "maybe", "maybe", true},
+		{checkbox, "2", "2", true},
+
+		{number, "42", 42.0, false},
+		{number, "-3.5", -3.5, false},
+		{number, "0", 0.0, false},
+		{number, "0.25", 0.25, false},
+		{number, "1e3", 1000.0, false},
+		{number, " 7 ", 7.0, false},
+		{number, "00123", "00123", false}, // zip code, kept as text
+		{number, "0x1F", "0x1F", false},
+		{number, "NaN", "NaN", false},
+		{number, "12 Main St", "12 Main St", false},
+		{number, "1-2", "1-2", false},
+		{number, "", "", false},
+		{number, 5, 5, false},
+
+		{date, "2020-10-10", "2020-10-10", false},
+		{date, "10/31/2020", "2020-10-31", false},
+		{date, "2020/10/31", "2020-10-31", false},
+		{date, "Oct 31, 2020", "2020-10-31", false},
+		{date, "2020-10-31T14:30:00Z", "2020-10-31", false},
+		{date, "", "", false},
+		{date, "31/10/2020", "31/10/2020", true},
+		{date, "tomorrow", "tomorrow", true},
+		{dateTime, "2020-10-31 14:30:00", "2020-10-31T14:30:00Z", false},
+		{dateTime, "2020-10-31T14:30:00-05:00", "2020-10-31T19:30:00Z", false},
+		{abstract, "10/31/2020", "2020-10-31T00:00:00", false},
+
+		{picklist, "1", "1", false},
+		{picklist, "true", "true", false},
+	}
+	for _, test := range tests {
+		value, err := CoerceValue(test.value, test.column)
+		if (err != nil) != test.fails || value != test.expected {
+			t.Errorf("%s %#v, Expecting %#v fails %v, Got %#v %v", test.column.Type, test.value, test.expected, test.fails, value, err)
+		}
+	}
+}
+
+func Test_CoerceValues(t *testing.T) {
+	sheet := testSheet()
+	row := func() Row {
+		return Row{Cells: []Cell{{ColName: "Address", Value: "12 Main"}, {ColName: "Amt", Value: "10.5"},
+			{ColName: "Complete", Value: "yes"}, {ColName: "DueDate", Value: "soon"}}}
+	}
+
+	// off by default
+	sheet.AddRow(row())
+	if cells := sheet.NewRows[0].Cells; cells[1].Value != "10.5" || cells[2].Value != "yes" || len(sheet.Warnings) != 0 {
+		t.Error("AddRow expected values unchanged without CoerceValues, got", cells)
+	}
+
+	sheet.CoerceValues = true
+	sheet.AddRow(row())
+	cells := sheet.NewRows[1].Cells
+	if cells[0].Value != "12 Main" || cells[1].Value != 10.5 || cells[2].Value != true || cells[3].Value != "soon" {
+		t.Error("AddRow wrong coerced values", cells)
+	}
+	if len(sheet.Warnings) != 1 || sheet.Warnings[0].Code != WarnNotCoerced || sheet.Warnings[0].ColumnId != 103 {
+		t.Error("AddRow expected NOT_COERCED warning for DueDate, got", sheet.Warnings)
+	}
+
+	sheet.Warnings = nil
+	sheet.UpdateRow(Row{Id: 5, Cells: []Cell{{ColName: "Complete", Value: "0"}, {ColName: "Amt", Formula: "=1+1"}}})
+	if cells = sheet.UpdateRows[0].Cells; cells[0].Value != false || cells[1].Value != nil {
+		t.Error("UpdateRow wrong coerced values", cells)
+	}
+	sheet.StageCellUpdate(5, "Amt", "20")
+	sheet.StageCellUpdate(6, "Complete", "x")
+	if cells = sheet.UpdateRows[0].Cells; cells[1].Value != 20.0 || sheet.UpdateRows[1].Cells[0].Value != "x" {
+		t.Error("StageCellUpdate wrong coerced values", sheet.UpdateRows)
+	}
+	if len(sheet.Warnings) != 1 || sheet.Warnings[0].RowId != 6 {
+		t.Error("StageCellUpdate expected NOT_COERCED warning for row 6, got", sheet.Warnings)
+	}
+}
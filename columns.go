@@ -0,0 +1,359 @@
+// columns.go contains funcs for adding, deleting, moving and changing the attributes (width, hidden, locked, title, validation) of columns.
+// SheetInfo helpers resolve column names and update the SheetInfo column maps after each change, no reload is needed.
+// AddColumn, DeleteColumn and MoveColumn find columns by id and shift the indexes of the other loaded columns, so a sheet
+// loaded with a subset of columns (Load option ColumnIds) can be used.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strings"
+)
+
+// ColumnUpdate contains the column attributes to change, zero values are not changed.
+// Hidden and Locked are pointers so false can be sent: nil-nochange, false-show/unlock, true-hide/lock.
+// Index is a pointer so 0 can be sent: nil-nochange, use MoveColumn to keep SheetInfo column indexes current.
+type ColumnUpdate struct {
+	Title  string `json:"title,omitempty"`
+	Width  int    `json:"width,omitempty"`
+	Hidden *bool  `json:"hidden,omitempty"`
+	Locked *bool  `json:"locked,omitempty"`
+	Index  *int   `json:"index,omitempty"`
+
+	// column type, set with Options, Symbol and SystemColumnType when the type changes (ex. to PICKLIST), see ApplySchema
+	Type             string   `json:"type,omitempty"`
+	Options          []string `json:"options,omitempty"`
+	Symbol           string   `json:"symbol,omitempty"`
+	SystemColumnType string   `json:"systemColumnType,omitempty"`
+	Validation       *bool    `json:"validation,omitempty"`
+}
+
+// UpdateColumn changes 1 column and returns the updated column.
+func UpdateColumn(sheetId, columnId int64, update ColumnUpdate) (*Column, error) {
+	trace("UpdateColumn")
+	endPoint := fmt.Sprintf("/sheets/%d/columns/%d", sheetId, columnId)
+
+	req := Put(endPoint, update, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	apiResp := struct {
+		Message    string `json:"message"`
+		ResultCode int    `json:"resultCode"`
+		Result     Column `json:"result"`
+	}{}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR - UpdateColumn Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return &apiResp.Result, nil
+}
+
+// SetColumnWidth changes the width (pixels) of 1 column.
+func SetColumnWidth(sheet *SheetInfo, columnName string, width int) error {
+	trace("SetColumnWidth")
+	return updateColumns(sheet, []string{columnName}, ColumnUpdate{Width: width})
+}
+
+// HideColumns hides columns in the Smartsheet UI, column values are still returned by the api.
+func HideColumns(sheet *SheetInfo, names ...string) error {
+	trace("HideColumns")
+	hidden := true
+	return updateColumns(sheet, names, ColumnUpdate{Hidden: &hidden})
+}
+
+// LockColumns locks columns, only sheet owners and admins can change their values.
+func LockColumns(sheet *SheetInfo, names ...string) error {
+	trace("LockColumns")
+	locked := true
+	return updateColumns(sheet, names, ColumnUpdate{Locked: &locked})
+}
+
+// SetColumnValidation restricts (on true) or unrestricts the values of columns: PICKLIST columns to their options,
+// DATE columns to dates and CONTACT_LIST columns to contacts. See SheetInfo.ValidateRows.
+func SetColumnValidation(sheet *SheetInfo, on bool, names ...string) error {
+	trace("SetColumnValidation")
+	return updateColumns(sheet, names, ColumnUpdate{Validation: &on})
+}
+
+// updateColumns sends update for each named column, 1 request per column (api has no bulk column update).
+// All names are checked before any request is sent, the error lists every unknown name.
+// Sheet column maps are updated with each returned column, if a request fails the remaining columns are not sent.
+func updateColumns(sheet *SheetInfo, names []string, update ColumnUpdate) error {
+	columns := make([]Column, 0, len(names))
+	unknown := make([]string, 0)
+	for _, name := range names {
+		column, found := sheet.ColumnsByName[name]
+		if !found {
+			unknown = append(unknown, name)
+			continue
+		}
+		columns = append(columns, column)
+	}
+	if len(unknown) > 0 {
+		err := fmt.Errorf("%w - %s", ErrInvalidColumnName, strings.Join(unknown, ", "))
+		log.Println("ERROR - updateColumns", sheet.SheetName, err)
+		return err
+	}
+	for _, column := range columns {
+		updated, err := UpdateColumn(sheet.SheetId, column.Id, update)
+		if err != nil {
+			return err
+		}
+		delete(sheet.ColumnsByName, column.Title) // title may have changed
+		sheet.ColumnsById[updated.Id] = *updated
+		sheet.ColumnsByName[updated.Title] = *updated
+		sheet.ColumnsByIndex[updated.Index] = *updated
+		for i := range sheet.Columns {
+			if sheet.Columns[i].Id == updated.Id {
+				sheet.Columns[i] = *updated
+			}
+		}
+	}
+	return nil
+}
+
+// AddColumn adds a column at index (0 is first, the number of sheet columns is last) and returns the new column.
+// Columns at and after index are shifted right in the sheet column maps. If the sheet was loaded with a subset of
+// columns (SheetInfo.ColumnsSelected), the columns are requested to check index.
+func AddColumn(sheet *SheetInfo, spec ColumnSpec, index int) (*Column, error) {
+	trace("AddColumn")
+	columns := sheet.columnList()
+	count, err := sheet.columnCount(columns)
+	if err != nil {
+		log.Println("ERROR - AddColumn", sheet.SheetName, err)
+		return nil, err
+	}
+	if index < 0 || index > count {
+		err := fmt.Errorf("Invalid Column Index - %d, sheet has %d columns", index, count)
+		log.Println("ERROR - AddColumn", sheet.SheetName, err)
+		return nil, err
+	}
+	reqData := []struct {
+		ColumnSpec
+		Index int `json:"index"`
+	}{{spec, index}}
+	endPoint := fmt.Sprintf("/sheets/%d/columns", sheet.SheetId)
+	req := Post(endPoint, reqData, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	apiResp := struct {
+		Message    string   `json:"message"`
+		ResultCode int      `json:"resultCode"`
+		Result     []Column `json:"result"`
+	}{}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR - AddColumn Unmarshal Response Failed", err)
+		return nil, err
+	}
+	if len(apiResp.Result) != 1 {
+		log.Println("ERROR - AddColumn expected 1 column in response, got", len(apiResp.Result))
+		return nil, errors.New("AddColumn Failed - column not returned")
+	}
+	added := apiResp.Result[0]
+	added.Index = index
+	shiftColumns(columns, index, -1, 1)
+	sheet.setColumns(append(columns, added))
+	added = sheet.ColumnsById[added.Id]
+	return &added, nil
+}
+
+// DeleteColumn deletes a column. Columns after it are shifted left in the sheet column maps,
+// cells of the column are removed from sheet.Rows.
+func DeleteColumn(sheet *SheetInfo, columnName string) error {
+	trace("DeleteColumn")
+	column, found := sheet.ColumnsByName[columnName]
+	if !found {
+		err := fmt.Errorf("%w - %s", ErrInvalidColumnName, columnName)
+		log.Println("ERROR - DeleteColumn", sheet.SheetName, err)
+		return err
+	}
+	endPoint := fmt.Sprintf("/sheets/%d/columns/%d", sheet.SheetId, column.Id)
+	if err := deleteObject(endPoint); err != nil {
+		return err
+	}
+	columns := removeColumn(sheet.columnList(), column.Id)
+	shiftColumns(columns, column.Index+1, -1, -1)
+	sheet.setColumns(columns)
+	for i, row := range sheet.Rows {
+		cells := row.Cells[:0]
+		for _, cell := range row.Cells {
+			if cell.ColumnId != column.Id {
+				cells = append(cells, cell)
+			}
+		}
+		sheet.Rows[i].Cells = cells
+	}
+	return nil
+}
+
+// MoveColumn moves a column to toIndex (0 is first). Columns between its old and new position are shifted in the sheet column maps.
+// If the sheet was loaded with a subset of columns (SheetInfo.ColumnsSelected), the columns are requested to check toIndex.
+func MoveColumn(sheet *SheetInfo, columnName string, toIndex int) error {
+	trace("MoveColumn")
+	column, found := sheet.ColumnsByName[columnName]
+	if !found {
+		err := fmt.Errorf("%w - %s", ErrInvalidColumnName, columnName)
+		log.Println("ERROR - MoveColumn", sheet.SheetName, err)
+		return err
+	}
+	columns := sheet.columnList()
+	count, err := sheet.columnCount(columns)
+	if err != nil {
+		log.Println("ERROR - MoveColumn", sheet.SheetName, err)
+		return err
+	}
+	if toIndex < 0 || toIndex >= count {
+		err := fmt.Errorf("Invalid Column Index - %d, sheet has %d columns", toIndex, count)
+		log.Println("ERROR - MoveColumn", sheet.SheetName, err)
+		return err
+	}
+	if toIndex == column.Index {
+		return nil
+	}
+	updated, err := UpdateColumn(sheet.SheetId, column.Id, ColumnUpdate{Index: &toIndex})
+	if err != nil {
+		return err
+	}
+	columns = removeColumn(columns, column.Id)
+	if toIndex > column.Index {
+		shiftColumns(columns, column.Index+1, toIndex, -1)
+	} else {
+		shiftColumns(columns, toIndex, column.Index-1, 1)
+	}
+	updated.Index = toIndex
+	sheet.setColumns(append(columns, *updated))
+	return nil
+}
+
+// ListColumns returns the columns of a sheet in index order.
+func ListColumns(sheetId int64) ([]Column, error) {
+	trace("ListColumns")
+	endPoint := fmt.Sprintf("/sheets/%d/columns", sheetId)
+	columns := make([]Column, 0, 20)
+	err := getAllPages(endPoint, nil, func(data json.RawMessage) error {
+		var page []Column
+		err := json.Unmarshal(data, &page)
+		columns = append(columns, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// RefreshColumns replaces the sheet column maps with the current columns of the sheet, without reloading rows.
+// Only needed if columns are changed by other users or other means than the funcs in this file.
+func (she *SheetInfo) RefreshColumns() error {
+	trace("SheetInfo.RefreshColumns")
+	columns, err := ListColumns(she.SheetId)
+	if err != nil {
+		log.Println("ERROR SheetInfo.RefreshColumns failed", she.SheetName, she.SheetId, err)
+		return err
+	}
+	she.setColumns(columns)
+	she.ColumnsSelected = false
+	return nil
+}
+
+// columnList returns a copy of the sheet columns in index order, SheetInfo.Columns if it contains the columns of
+// ColumnsById, otherwise (ex. maps set by the caller) the columns of ColumnsById sorted by Index.
+func (she *SheetInfo) columnList() []Column {
+	inSync := len(she.Columns) == len(she.ColumnsById)
+	for i := 0; inSync && i < len(she.Columns); i++ {
+		_, inSync = she.ColumnsById[she.Columns[i].Id]
+	}
+	if inSync {
+		return append([]Column(nil), she.Columns...)
+	}
+	columns := make([]Column, 0, len(she.ColumnsById))
+	for _, column := range she.ColumnsById {
+		columns = append(columns, column)
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		if columns[i].Index == columns[j].Index {
+			return columns[i].Id < columns[j].Id // duplicate index, same order each call
+		}
+		return columns[i].Index < columns[j].Index
+	})
+	return columns
+}
+
+// columnCount returns the number of sheet columns. If Load selected a subset of columns (ColumnsSelected), the columns
+// are requested using ListColumns, otherwise it is the number of columns, at least the highest Index + 1.
+func (she *SheetInfo) columnCount(columns []Column) (int, error) {
+	if she.ColumnsSelected {
+		all, err := ListColumns(she.SheetId)
+		if err != nil {
+			return 0, err
+		}
+		return len(all), nil
+	}
+	count := len(columns)
+	for _, column := range columns {
+		if column.Index >= count {
+			count = column.Index + 1
+		}
+	}
+	return count, nil
+}
+
+// removeColumn returns columns without the column with columnId.
+func removeColumn(columns []Column, columnId int64) []Column {
+	kept := columns[:0]
+	for _, column := range columns {
+		if column.Id != columnId {
+			kept = append(kept, column)
+		}
+	}
+	return kept
+}
+
+// shiftColumns adds delta to the Index of the columns with an Index from first to last (-1 for no limit), after a
+// column is added, deleted or moved. Indexes are shifted rather than set from positions in columns, which may be
+// a subset of the sheet columns or have index gaps.
+func shiftColumns(columns []Column, first, last, delta int) {
+	for i := range columns {
+		if columns[i].Index >= first && (last < 0 || columns[i].Index <= last) {
+			columns[i].Index += delta
+		}
+	}
+}
+
+// setColumns rebuilds SheetInfo.Columns, sorted by Index (columns with the same Index keep their order), and the
+// column maps from columns.
+func (she *SheetInfo) setColumns(columns []Column) {
+	she.Columns = append(make([]Column, 0, len(columns)), columns...)
+	sort.SliceStable(she.Columns, func(i, j int) bool { return she.Columns[i].Index < she.Columns[j].Index })
+	she.ColumnsById = make(map[int64]Column, len(columns))
+	she.ColumnsByName = make(map[string]Column, len(columns))
+	she.ColumnsByIndex = make(map[int]Column, len(columns))
+	for _, column := range columns {
+		she.ColumnsById[column.Id] = column
+		she.ColumnsByName[column.Title] = column
+		she.ColumnsByIndex[column.Index] = column
+	}
+}
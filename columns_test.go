@@ -0,0 +1,314 @@
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_Columns(t *testing.T) {
+	columnTitles := map[string]string{"101": "Address", "104": "Util", "107": "Level", "108": "Status"}
+	columnIndexes := map[string]int{"101": 0, "104": 3, "107": 6, "108": 7}
+	var requests []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Error("Test_Columns expected PUT, got", r.Method)
+		}
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		reqJSON := compactJSON(reqBytes)
+		requests = append(requests, r.URL.Path+" "+reqJSON)
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		attrs := strings.Trim(reqJSON, "{}")
+		fmt.Fprintf(w, `{"message":"SUCCESS","resultCode":0,"result":{"id":%s,"index":%d,"title":"%s","type":"TEXT_NUMBER",%s}}`,
+			id, columnIndexes[id], columnTitles[id], attrs)
+	})
+	sheet := testSheet()
+
+	if err := SetColumnWidth(sheet, "Address", 250); err != nil {
+		t.Fatal("SetColumnWidth Failed", err)
+	}
+	if err := HideColumns(sheet, "Util", "Level"); err != nil {
+		t.Fatal("HideColumns Failed", err)
+	}
+	if err := LockColumns(sheet, "Level"); err != nil {
+		t.Fatal("LockColumns Failed", err)
+	}
+	if err := SetColumnValidation(sheet, true, "Status"); err != nil {
+		t.Fatal("SetColumnValidation Failed", err)
+	}
+	expect := []string{
+		`/sheets/1849449510135684/columns/101 {"width":250}`,
+		`/sheets/1849449510135684/columns/104 {"hidden":true}`,
+		`/sheets/1849449510135684/columns/107 {"hidden":true}`,
+		`/sheets/1849449510135684/columns/107 {"locked":true}`,
+		`/sheets/1849449510135684/columns/108 {"validation":true}`,
+	}
+	if strings.Join(requests, "\n") != strings.Join(expect, "\n") {
+		t.Errorf("Column update requests, Expecting\n%s\nGot\n%s", strings.Join(expect, "\n"), strings.Join(requests, "\n"))
+	}
+	if sheet.ColumnsByName["Address"].Width != 250 || sheet.ColumnsById[101].Width != 250 || sheet.ColumnsByIndex[0].Width != 250 {
+		t.Error("SetColumnWidth expected column maps refreshed", sheet.ColumnsByName["Address"])
+	}
+	if !sheet.ColumnsByName["Util"].Hidden || !sheet.ColumnsById[107].Locked || !sheet.ColumnsById[108].Validation {
+		t.Error("HideColumns, LockColumns, SetColumnValidation expected column maps refreshed", sheet.ColumnsByName["Util"], sheet.ColumnsById[107])
+	}
+
+	requests = nil
+	err := HideColumns(sheet, "Amt", "Nope", "Status", "Missing")
+	if !errors.Is(err, ErrInvalidColumnName) || !strings.Contains(err.Error(), "Nope, Missing") {
+		t.Error("HideColumns expected error listing unknown names, got", err)
+	}
+	if len(requests) != 0 {
+		t.Error("HideColumns expected no requests when a name is unknown, got", requests)
+	}
+}
+
+// reindexColumns sets the Index of each column to its position, as the api does after a column change.
+func reindexColumns(columns []Column) []Column {
+	for i := range columns {
+		columns[i].Index = i
+	}
+	return columns
+}
+
+func Test_ColumnOrder(t *testing.T) {
+	sheet := testSheet()
+	server := sheet.columnList() // columns of the stub server, in sheet order
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		id, _ := strconv.ParseInt(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], 10, 64)
+		position := -1
+		for i, column := range server {
+			if column.Id == id {
+				position = i
+			}
+		}
+		var result interface{}
+		switch r.Method {
+		case "GET":
+			data, _ := json.Marshal(reindexColumns(server))
+			fmt.Fprintf(w, `{"pageNumber":1,"totalPages":1,"data":%s}`, data)
+			return
+		case "POST":
+			var added []Column
+			json.Unmarshal(reqBytes, &added)
+			added[0].Id = 110
+			server = append(server[:added[0].Index], append(added, server[added[0].Index:]...)...)
+			result = added
+		case "PUT":
+			var update struct{ Index int }
+			json.Unmarshal(reqBytes, &update)
+			column := server[position]
+			server = append(server[:position], server[position+1:]...)
+			server = append(server[:update.Index], append([]Column{column}, server[update.Index:]...)...)
+			column.Index = update.Index
+			result = column
+		case "DELETE":
+			server = append(server[:position], server[position+1:]...)
+		}
+		data, _ := json.Marshal(result)
+		fmt.Fprintf(w, `{"message":"SUCCESS","resultCode":0,"result":%s}`, data)
+	})
+	sheet.Rows = []Row{{Id: 1, Cells: []Cell{{ColumnId: 101, Value: "1 Main"}, {ColumnId: 104, Value: "Gas"}, {ColumnId: 105, Value: 10}}}}
+
+	// maps must match the server's column list after each change
+	checkColumns := func(step string) {
+		t.Helper()
+		server = reindexColumns(server)
+		if len(sheet.ColumnsById) != len(server) || len(sheet.ColumnsByName) != len(server) || len(sheet.ColumnsByIndex) != len(server) {
+			t.Fatalf("%s column map sizes %d %d %d, server %d", step, len(sheet.ColumnsById), len(sheet.ColumnsByName), len(sheet.ColumnsByIndex), len(server))
+		}
+		for index, column := range server {
+			if sheet.ColumnsByIndex[index].Id != column.Id || sheet.ColumnsById[column.Id].Index != index || sheet.ColumnsByName[column.Title].Index != index {
+				t.Errorf("%s column %s, Expecting index %d, Got ByIndex %d, ById %d, ByName %d", step, column.Title, index,
+					sheet.ColumnsByIndex[index].Id, sheet.ColumnsById[column.Id].Index, sheet.ColumnsByName[column.Title].Index)
+			}
+		}
+	}
+
+	added, err := AddColumn(sheet, ColumnSpec{Title: "Region", Type: "TEXT_NUMBER"}, 2)
+	if err != nil {
+		t.Fatal("AddColumn Failed", err)
+	}
+	if added.Id != 110 || added.Index != 2 || sheet.ColumnsByIndex[3].Title != "DueDate" {
+		t.Error("AddColumn expected Region at index 2 and DueDate shifted to 3", added, sheet.ColumnsByIndex[3])
+	}
+	checkColumns("AddColumn")
+
+	if err = MoveColumn(sheet, "Status", 1); err != nil {
+		t.Fatal("MoveColumn Failed", err)
+	}
+	checkColumns("MoveColumn left")
+	if err = MoveColumn(sheet, "OrderNo", 9); err != nil {
+		t.Fatal("MoveColumn Failed", err)
+	}
+	checkColumns("MoveColumn right")
+	if sheet.ColumnsByIndex[9].Title != "OrderNo" || sheet.ColumnsByIndex[1].Title != "Status" {
+		t.Error("MoveColumn expected OrderNo last and Status at 1", sheet.ColumnsByIndex[9].Title, sheet.ColumnsByIndex[1].Title)
+	}
+
+	if err = DeleteColumn(sheet, "Util"); err != nil {
+		t.Fatal("DeleteColumn Failed", err)
+	}
+	checkColumns("DeleteColumn")
+	if _, found := sheet.ColumnsByName["Util"]; found || len(sheet.Rows[0].Cells) != 2 {
+		t.Error("DeleteColumn expected Util removed from maps and row cells", sheet.Rows[0].Cells)
+	}
+
+	if err = MoveColumn(sheet, "Amt", 9); err == nil {
+		t.Error("MoveColumn expected error for index past last column")
+	}
+	if _, err = AddColumn(sheet, ColumnSpec{Title: "X", Type: "TEXT_NUMBER"}, -1); err == nil {
+		t.Error("AddColumn expected error for negative index")
+	}
+	if err = DeleteColumn(sheet, "Nope"); !errors.Is(err, ErrInvalidColumnName) {
+		t.Error("DeleteColumn expected ErrInvalidColumnName, got", err)
+	}
+
+	// RefreshColumns replaces the maps with the server's list
+	sheet.ColumnsByIndex[0] = Column{Title: "stale"}
+	if err = sheet.RefreshColumns(); err != nil {
+		t.Fatal("RefreshColumns Failed", err)
+	}
+	checkColumns("RefreshColumns")
+}
+
+func Test_ColumnIndexGaps(t *testing.T) {
+	columnsJSON := map[string]string{
+		"gap": `[{"id":101,"index":0,"title":"Address","primary":true},{"id":102,"index":1,"title":"OrderNo"},{"id":104,"index":3,"title":"Util"}]`,
+		"duplicate": `[{"id":101,"index":0,"title":"Address","primary":true},{"id":103,"index":1,"title":"DueDate"},` +
+			`{"id":102,"index":1,"title":"OrderNo"},{"id":104,"index":2,"title":"Util"}]`,
+	}
+	fixture := ""
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id":1849449510135684,"name":"Test1","columns":%s,"rows":[{"id":11,"cells":[{"columnId":101,"value":"1 Main"},{"columnId":104,"value":"Gas"}]}]}`,
+			columnsJSON[fixture])
+	})
+	tests := []struct {
+		fixture  string
+		titles   string
+		warnings []Warning
+	}{
+		{"gap", "Address,OrderNo,Util", []Warning{{Code: WarnColumnIndex, Message: "column Util has index 3, expected 2", ColumnId: 104}}},
+		{"duplicate", "Address,DueDate,OrderNo,Util", []Warning{ // Util follows the duplicate, warned once
+			{Code: WarnColumnIndex, Message: "column OrderNo has the index 1 of column DueDate", ColumnId: 102},
+		}},
+	}
+	for _, test := range tests {
+		fixture = test.fixture
+		sheet := new(SheetInfo)
+		if err := sheet.Load(Test1Id, nil); err != nil {
+			t.Fatal("Load Failed", test.fixture, err)
+		}
+		titles := make([]string, 0, len(sheet.Columns))
+		for _, column := range sheet.Columns {
+			titles = append(titles, column.Title)
+		}
+		if strings.Join(titles, ",") != test.titles {
+			t.Errorf("Load %s Columns, Expecting %s, Got %v", test.fixture, test.titles, titles)
+		}
+		if fmt.Sprint(sheet.Warnings) != fmt.Sprint(test.warnings) {
+			t.Errorf("Load %s Warnings, Expecting %v, Got %v", test.fixture, test.warnings, sheet.Warnings)
+		}
+
+		// every column rendered once, no zero value columns
+		out := new(strings.Builder)
+		sheet.Render(out, nil)
+		section := strings.SplitN(strings.SplitN(out.String(), "--- COLUMNS ---\n", 2)[1], "--- ROWS ---", 2)[0]
+		lines := strings.Split(strings.TrimSuffix(section, "\n"), "\n")
+		if len(lines) != len(titles) {
+			t.Errorf("Render %s expected %d column lines, got\n%s", test.fixture, len(titles), section)
+		}
+		for i, line := range lines {
+			if i < len(sheet.Columns) && !strings.Contains(line, strconv.FormatInt(sheet.Columns[i].Id, 10)) {
+				t.Errorf("Render %s column line %d, Expecting id %d, Got %q", test.fixture, i, sheet.Columns[i].Id, line)
+			}
+		}
+
+		csv := new(strings.Builder)
+		if err := sheet.WriteCSV(csv, CSVOptions{}); err != nil {
+			t.Fatal("WriteCSV Failed", err)
+		}
+		if header := strings.SplitN(csv.String(), "\n", 2)[0]; strings.TrimSpace(header) != test.titles {
+			t.Errorf("WriteCSV %s header, Expecting %s, Got %s", test.fixture, test.titles, header)
+		}
+		spec := sheet.sheetSpec("Copy")
+		if len(spec.Columns) != len(titles) || spec.Columns[len(titles)-1].Title != "Util" {
+			t.Errorf("sheetSpec %s expected %d columns ending with Util, got %+v", test.fixture, len(titles), spec.Columns)
+		}
+	}
+
+	// subset of columns, index gaps expected
+	fixture = "gap"
+	sheet := new(SheetInfo)
+	if err := sheet.Load(Test1Id, &GetSheetOptions{ColumnIds: []int64{101, 104}}); err != nil {
+		t.Fatal("Load Failed", err)
+	}
+	if len(sheet.Warnings) != 0 || !sheet.ColumnsSelected {
+		t.Error("Load ColumnIds subset expected ColumnsSelected and no warnings, got", sheet.Warnings)
+	}
+}
+
+func Test_ColumnSubset(t *testing.T) {
+	serverCount, listRequests := 8, 0 // columns of the sheet on the server
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		result := "null"
+		switch r.Method {
+		case "GET":
+			listRequests++
+			columns := make([]Column, serverCount)
+			for i := range columns {
+				columns[i] = Column{Id: int64(500 + i), Index: i, Title: strconv.Itoa(i)}
+			}
+			data, _ := json.Marshal(columns)
+			fmt.Fprintf(w, `{"pageNumber":1,"totalPages":1,"data":%s}`, data)
+			return
+		case "POST":
+			result = `[{"id":110,"index":2,"title":"Region"}]`
+		case "PUT":
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			var update struct{ Index int }
+			json.Unmarshal(reqBytes, &update)
+			result = fmt.Sprintf(`{"id":%s,"index":%d,"title":"Address"}`, id, update.Index)
+		}
+		fmt.Fprintf(w, `{"message":"SUCCESS","resultCode":0,"result":%s}`, result)
+	})
+	// loaded using ColumnIds, sheet columns 102, 103, 105 ... not included
+	sheet := testSheet()
+	sheet.setColumns([]Column{sheet.ColumnsById[101], sheet.ColumnsById[104], sheet.ColumnsById[108]})
+	sheet.ColumnsSelected = true
+	checkIndexes := func(step string, expect map[int64]int) {
+		t.Helper()
+		for id, index := range expect {
+			if sheet.ColumnsById[id].Index != index || sheet.ColumnsByIndex[index].Id != id {
+				t.Errorf("%s column %d, Expecting index %d, Got %d", step, id, index, sheet.ColumnsById[id].Index)
+			}
+		}
+		if len(sheet.ColumnsById) != len(expect) || len(sheet.ColumnsByIndex) != len(expect) {
+			t.Errorf("%s expected %d columns, got %v", step, len(expect), sheet.Columns)
+		}
+	}
+
+	if err := DeleteColumn(sheet, "Util"); err != nil {
+		t.Fatal("DeleteColumn Failed", err)
+	}
+	checkIndexes("DeleteColumn", map[int64]int{101: 0, 108: 6})
+	if _, err := AddColumn(sheet, ColumnSpec{Title: "Region", Type: "TEXT_NUMBER"}, 2); err != nil {
+		t.Fatal("AddColumn Failed", err)
+	}
+	checkIndexes("AddColumn", map[int64]int{101: 0, 110: 2, 108: 7})
+	serverCount = 9
+	if err := MoveColumn(sheet, "Address", 8); err != nil { // past the loaded columns
+		t.Fatal("MoveColumn Failed", err)
+	}
+	checkIndexes("MoveColumn", map[int64]int{110: 1, 108: 6, 101: 8})
+	if err := MoveColumn(sheet, "Address", 9); err == nil || listRequests != 3 {
+		t.Error("MoveColumn expected error for index past last sheet column, got", err, listRequests)
+	}
+}
@@ -0,0 +1,166 @@
+// compare.go contains CompareSheets func for finding data differences between 2 loaded sheets, ex. mirrored prod and staging sheets.
+// Cell values are compared as returned by RowValues. FindDeletedRows and DeletedRows find rows no longer in a sheet.
+
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+)
+
+// SheetComparison is returned by CompareSheets.
+// Rows are identified by their key column value. Slices are sorted by key.
+type SheetComparison struct {
+	KeyColumn     string
+	OnlyInA       []string  // keys of rows only in sheet A
+	OnlyInB       []string  // keys of rows only in sheet B
+	Differences   []RowDiff // rows in both sheets with different values in common columns
+	ColumnsOnlyA  []string  // columns only in sheet A, not compared
+	ColumnsOnlyB  []string  // columns only in sheet B, not compared
+	DuplicateKeys []string  // keys used by more than 1 row in a sheet, only the 1st row with the key is compared
+}
+
+// RowDiff contains the differing cells of a row found in both sheets.
+type RowDiff struct {
+	Key    string
+	RowIdA int64
+	RowIdB int64
+	Cells  []CellDiff // in sheet A column order
+}
+
+// CellDiff is a column whose values differ between the matched rows of sheet A and sheet B.
+type CellDiff struct {
+	Column         string
+	ValueA, ValueB string
+}
+
+// Match returns true if no differences were found.
+func (comp *SheetComparison) Match() bool {
+	return len(comp.OnlyInA) == 0 && len(comp.OnlyInB) == 0 && len(comp.Differences) == 0 &&
+		len(comp.ColumnsOnlyA) == 0 && len(comp.ColumnsOnlyB) == 0
+}
+
+// CompareSheets matches the rows of 2 loaded sheets using the value of keyColumn and reports their differences.
+// Differing column sets are reported in the comparison, only columns in both sheets are compared.
+// An error is returned if keyColumn is not in both sheets.
+func CompareSheets(a, b *SheetInfo, keyColumn string) (*SheetComparison, error) {
+	trace("CompareSheets")
+	if _, found := a.ColumnsByName[keyColumn]; !found {
+		return nil, fmt.Errorf("%w - %s not in sheet A %s", ErrInvalidColumnName, keyColumn, a.SheetName)
+	}
+	if _, found := b.ColumnsByName[keyColumn]; !found {
+		return nil, fmt.Errorf("%w - %s not in sheet B %s", ErrInvalidColumnName, keyColumn, b.SheetName)
+	}
+	comp := &SheetComparison{KeyColumn: keyColumn}
+
+	// -- columns compared are those in both sheets, in sheet A order ----------
+	common := make([]string, 0, len(a.ColumnsByName))
+	for _, column := range a.columnList() {
+		title := column.Title
+		if _, found := b.ColumnsByName[title]; found {
+			common = append(common, title)
+		} else {
+			comp.ColumnsOnlyA = append(comp.ColumnsOnlyA, title)
+		}
+	}
+	for _, column := range b.columnList() {
+		title := column.Title
+		if _, found := a.ColumnsByName[title]; !found {
+			comp.ColumnsOnlyB = append(comp.ColumnsOnlyB, title)
+		}
+	}
+
+	rowsA, dupsA := rowsByKey(a, keyColumn)
+	rowsB, dupsB := rowsByKey(b, keyColumn)
+	comp.DuplicateKeys = append(dupsA, dupsB...)
+	sort.Strings(comp.DuplicateKeys)
+
+	for key, rowA := range rowsA {
+		rowB, found := rowsB[key]
+		if !found {
+			comp.OnlyInA = append(comp.OnlyInA, key)
+			continue
+		}
+		valuesA, valuesB := RowValues(a, rowA), RowValues(b, rowB)
+		diff := RowDiff{Key: key, RowIdA: rowA.Id, RowIdB: rowB.Id}
+		for _, title := range common {
+			if valuesA[title] != valuesB[title] {
+				diff.Cells = append(diff.Cells, CellDiff{Column: title, ValueA: valuesA[title], ValueB: valuesB[title]})
+			}
+		}
+		if len(diff.Cells) > 0 {
+			comp.Differences = append(comp.Differences, diff)
+		}
+	}
+	for key := range rowsB {
+		if _, found := rowsA[key]; !found {
+			comp.OnlyInB = append(comp.OnlyInB, key)
+		}
+	}
+	sort.Strings(comp.OnlyInA)
+	sort.Strings(comp.OnlyInB)
+	sort.Slice(comp.Differences, func(i, j int) bool { return comp.Differences[i].Key < comp.Differences[j].Key })
+	return comp, nil
+}
+
+// rowsByKey returns sheet rows indexed by the value of keyColumn, and keys used by more than 1 row.
+func rowsByKey(sheet *SheetInfo, keyColumn string) (map[string]Row, []string) {
+	rows := make(map[string]Row, len(sheet.Rows))
+	duplicates := make([]string, 0)
+	for _, row := range sheet.Rows {
+		key := RowValues(sheet, row)[keyColumn]
+		if _, found := rows[key]; found {
+			duplicates = append(duplicates, key)
+			continue
+		}
+		rows[key] = row
+	}
+	return rows, duplicates
+}
+
+// FindDeletedRows returns the ids of rows in she.Rows (loaded or restored) that are no longer in the sheet, in she.Rows order.
+// The current row ids are requested with 1 GetSheet call returning only the primary column, she.Rows is not changed.
+// Useful when webhook callbacks may have been missed, ex. to remove ghost rows from a mirror database.
+func (she *SheetInfo) FindDeletedRows() ([]int64, error) {
+	trace("SheetInfo.FindDeletedRows")
+	options := new(GetSheetOptions)
+	if primary, err := she.PrimaryColumn(); err == nil {
+		options.ColumnIds = []int64{primary.Id} // minimize response, only row ids are used
+	}
+	live, err := GetSheet(she.SheetId, options)
+	if err != nil {
+		log.Println("ERROR - SheetInfo.FindDeletedRows GetSheet Failed", she.SheetName, she.SheetId, err)
+		return nil, err
+	}
+	return deletedRowIds(she.Rows, live.Rows), nil
+}
+
+// DeletedRows returns the ids of rows in baseline that are not in live, in baseline row order.
+// Ex. baseline is restored (see SheetInfo.Restore) from the previous run and live is loaded with all rows.
+// If live was loaded with options selecting rows (live.RowsSelected), rows not selected would be reported
+// as deleted, nil and an error are returned.
+func DeletedRows(baseline, live *SheetInfo) ([]int64, error) {
+	trace("DeletedRows")
+	if live.RowsSelected {
+		log.Println("ERROR - DeletedRows live sheet loaded with a subset of rows", live.SheetName)
+		return nil, errors.New("Invalid Live Sheet - loaded with options selecting rows")
+	}
+	return deletedRowIds(baseline.Rows, live.Rows), nil
+}
+
+// deletedRowIds returns the ids of rows not in current, in rows order.
+func deletedRowIds(rows, current []Row) []int64 {
+	currentIds := make(map[int64]bool, len(current))
+	for _, row := range current {
+		currentIds[row.Id] = true
+	}
+	deleted := make([]int64, 0)
+	for _, row := range rows {
+		if !currentIds[row.Id] {
+			deleted = append(deleted, row.Id)
+		}
+	}
+	return deleted
+}
@@ -0,0 +1,120 @@
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func Test_CompareSheets(t *testing.T) {
+	prod := testSheet()
+	prod.Rows = []Row{
+		{Id: 11, Cells: []Cell{{ColumnId: 101, Value: "1 Main"}, {ColumnId: 102, Value: "A-1"}, {ColumnId: 105, Value: 74.2}}},
+		{Id: 12, Cells: []Cell{{ColumnId: 101, Value: "2 Elm"}, {ColumnId: 102, Value: "A-2"}, {ColumnId: 108, Value: "Red"}}},
+		{Id: 13, Cells: []Cell{{ColumnId: 101, Value: "3 Oak"}, {ColumnId: 102, Value: "A-3"}}},
+		{Id: 14, Cells: []Cell{{ColumnId: 101, Value: "9 Pine"}, {ColumnId: 102, Value: "A-3"}}}, // duplicate key
+	}
+
+	// staging has different column ids, no Hyperlink column and an extra Notes column
+	staging := &SheetInfo{
+		SheetName:      "Staging",
+		ColumnsById:    make(map[int64]Column),
+		ColumnsByName:  make(map[string]Column),
+		ColumnsByIndex: make(map[int]Column),
+	}
+	for i := 0; i < 8; i++ {
+		column := prod.ColumnsByIndex[i]
+		column.Id += 100
+		staging.ColumnsById[column.Id] = column
+		staging.ColumnsByName[column.Title] = column
+		staging.ColumnsByIndex[column.Index] = column
+	}
+	notes := Column{Id: 301, Index: 8, Title: "Notes", Type: "TEXT_NUMBER"}
+	staging.ColumnsById[notes.Id], staging.ColumnsByName[notes.Title], staging.ColumnsByIndex[notes.Index] = notes, notes, notes
+	staging.Rows = []Row{
+		{Id: 21, Cells: []Cell{{ColumnId: 201, Value: "1 Main"}, {ColumnId: 202, Value: "A-1"}, {ColumnId: 205, Value: 74.2}, {ColumnId: 301, Value: "new"}}},
+		{Id: 22, Cells: []Cell{{ColumnId: 201, Value: "2 Elm St"}, {ColumnId: 202, Value: "A-2"}, {ColumnId: 208, Value: "Green"}}},
+		{Id: 24, Cells: []Cell{{ColumnId: 201, Value: "4 Ash"}, {ColumnId: 202, Value: "A-4"}}},
+	}
+
+	comp, err := CompareSheets(prod, staging, "OrderNo")
+	if err != nil {
+		t.Fatal("CompareSheets Failed", err)
+	}
+	if comp.Match() {
+		t.Error("CompareSheets expected differences")
+	}
+	if !reflect.DeepEqual(comp.OnlyInA, []string{"A-3"}) || !reflect.DeepEqual(comp.OnlyInB, []string{"A-4"}) {
+		t.Error("CompareSheets wrong OnlyInA/B", comp.OnlyInA, comp.OnlyInB)
+	}
+	if !reflect.DeepEqual(comp.ColumnsOnlyA, []string{"Hyperlink"}) || !reflect.DeepEqual(comp.ColumnsOnlyB, []string{"Notes"}) {
+		t.Error("CompareSheets wrong ColumnsOnlyA/B", comp.ColumnsOnlyA, comp.ColumnsOnlyB)
+	}
+	if !reflect.DeepEqual(comp.DuplicateKeys, []string{"A-3"}) {
+		t.Error("CompareSheets wrong DuplicateKeys", comp.DuplicateKeys)
+	}
+	expect := []RowDiff{{Key: "A-2", RowIdA: 12, RowIdB: 22, Cells: []CellDiff{
+		{Column: "Address", ValueA: "2 Elm", ValueB: "2 Elm St"},
+		{Column: "Status", ValueA: "Red", ValueB: "Green"},
+	}}}
+	if !reflect.DeepEqual(comp.Differences, expect) {
+		t.Errorf("CompareSheets wrong Differences %+v", comp.Differences)
+	}
+
+	comp, _ = CompareSheets(prod, prod, "Address")
+	if !comp.Match() {
+		t.Errorf("CompareSheets expected sheet to match itself %+v", comp)
+	}
+	if _, err = CompareSheets(prod, staging, "Hyperlink"); !errors.Is(err, ErrInvalidColumnName) {
+		t.Error("CompareSheets expected ErrInvalidColumnName, got", err)
+	}
+}
+
+func Test_DeletedRows(t *testing.T) {
+	liveJSON, err := ioutil.ReadFile("testdata/deleted_live.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var queries []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Path+" "+r.URL.Query().Get("columnIds"))
+		w.Write(liveJSON)
+	})
+
+	// rows 12 and 14 removed, 15 added, 11 and 13 unchanged
+	baseline := new(SheetInfo)
+	if err = baseline.Restore("testdata/deleted_baseline.json"); err != nil {
+		t.Fatal("Restore Failed", err)
+	}
+	deleted, err := baseline.FindDeletedRows()
+	if err != nil {
+		t.Fatal("FindDeletedRows Failed", err)
+	}
+	if fmt.Sprint(deleted) != "[12 14]" {
+		t.Error("FindDeletedRows, Expecting [12 14], Got", deleted)
+	}
+	if fmt.Sprint(queries) != "[/sheets/1849449510135684 101]" {
+		t.Error("FindDeletedRows expected 1 request for the primary column only, got", queries)
+	}
+	if len(baseline.Rows) != 4 {
+		t.Error("FindDeletedRows expected loaded rows unchanged, got", len(baseline.Rows))
+	}
+
+	live := new(SheetInfo)
+	if err = live.Load(1849449510135684, nil); err != nil {
+		t.Fatal("Load Failed", err)
+	}
+	if deleted, err = DeletedRows(baseline, live); err != nil || fmt.Sprint(deleted) != "[12 14]" {
+		t.Error("DeletedRows, Expecting [12 14], Got", deleted, err)
+	}
+	if deleted, _ = DeletedRows(live, live); len(deleted) != 0 {
+		t.Error("DeletedRows expected none for unchanged sheet, got", deleted)
+	}
+	live.RowsSelected = true
+	if _, err = DeletedRows(baseline, live); err == nil {
+		t.Error("DeletedRows expected error when live sheet has a subset of rows")
+	}
+}
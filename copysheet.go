@@ -0,0 +1,252 @@
+// copysheet.go contains CopySheet, CopySheetWith and CopyWorkspace funcs, and WaitForAsyncResult, used when the api
+// accepts a copy request (status 202) and completes it in the background.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Copy Include values, elements copied with the sheet or workspace (default is columns only)
+const (
+	CopyData           = "data"
+	CopyAttachments    = "attachments"
+	CopyDiscussions    = "discussions"
+	CopyCellLinks      = "cellLinks"
+	CopyFilters        = "filters"
+	CopyForms          = "forms"
+	CopyRules          = "rules"
+	CopyRuleRecipients = "ruleRecipients" // requires CopyRules
+	CopyShares         = "shares"
+	CopyBrand          = "brand" // workspace copies only
+	CopyAll            = "all"
+)
+
+// CopyExcludeSheetHyperlinks is the Copy Exclude value keeping hyperlinks to other sheets pointing at the original
+// sheets, instead of the copies, see CopySheetOptions.
+const CopyExcludeSheetHyperlinks = "sheetHyperlinks"
+
+// copyIncludes are the Copy Include values accepted by each copy operation.
+var copyIncludes = map[string][]string{
+	"sheet": {CopyData, CopyAttachments, CopyDiscussions, CopyCellLinks, CopyFilters, CopyForms, CopyRules,
+		CopyRuleRecipients, CopyShares, CopyAll},
+	"workspace": {CopyData, CopyAttachments, CopyDiscussions, CopyCellLinks, CopyFilters, CopyForms, CopyRules,
+		CopyRuleRecipients, CopyShares, CopyBrand, CopyAll},
+}
+
+// Async Status values, returned by the result url of an accepted (202) request
+const (
+	AsyncInProgress = "IN_PROGRESS"
+	AsyncComplete   = "COMPLETE"
+	AsyncFailed     = "FAILED"
+)
+
+// AsyncPollInterval and AsyncTimeout are used by CopySheet and CopyWorkspace when the copy is completed asynchronously.
+var (
+	AsyncPollInterval = 5 * time.Second
+	AsyncTimeout      = 10 * time.Minute
+)
+
+// ErrAsyncFailed is wrapped by the error returned by WaitForAsyncResult when the result status is FAILED.
+var ErrAsyncFailed = errors.New("Asynchronous Request Failed")
+
+// AsyncTimeoutError is returned by WaitForAsyncResult when the request is not complete before the timeout.
+type AsyncTimeoutError struct {
+	ResultURL  string
+	LastStatus string // status of the last poll, ex. IN_PROGRESS
+	Timeout    time.Duration
+}
+
+func (e *AsyncTimeoutError) Error() string {
+	return fmt.Sprintf("Asynchronous Request Timeout after %v - last status %s, %s", e.Timeout, e.LastStatus, e.ResultURL)
+}
+
+// CopySheet copies a sheet to dest (nil for Sheets home) and returns the id of the new sheet.
+// Parm include contains Copy Include values, ex. CopyData, an invalid value returns an error before the request is sent.
+// If the api completes the copy asynchronously (status 202), the result is polled every AsyncPollInterval until
+// complete, see WaitForAsyncResult. See CopySheetWith to set the elements copied using CopySheetOptions.
+func CopySheet(sheetId int64, newName string, dest *SheetDestination, include ...string) (int64, error) {
+	trace("CopySheet")
+	if err := validateCopyIncludes("sheet", include); err != nil {
+		log.Println("ERROR CopySheet", err)
+		return 0, err
+	}
+	return copySheet(sheetId, newName, dest, include, nil)
+}
+
+// CopySheetWith is CopySheet using CopySheetOptions (see options.go), nil options copies the columns only.
+// Ex. copy the sheet without its shares: &CopySheetOptions{Data: true, Attachments: true, Discussions: true,
+// CellLinks: true, Filters: true, Forms: true, Rules: true, RuleRecipients: true}.
+func CopySheetWith(sheetId int64, newName string, dest *SheetDestination, options *CopySheetOptions) (int64, error) {
+	trace("CopySheetWith")
+	if options == nil {
+		options = new(CopySheetOptions)
+	}
+	if err := options.Validate(); err != nil {
+		log.Println("ERROR CopySheetWith", err)
+		return 0, err
+	}
+	var exclude []string
+	if options.ExcludeSheetHyperlinks {
+		exclude = []string{CopyExcludeSheetHyperlinks}
+	}
+	return copySheet(sheetId, newName, dest, options.includes(), exclude)
+}
+
+// copySheet sends the copy sheet request with validated include and exclude values.
+func copySheet(sheetId int64, newName string, dest *SheetDestination, include, exclude []string) (int64, error) {
+	if _, err := dest.endPoint(); err != nil { // validates destination
+		log.Println("ERROR CopySheet", err)
+		return 0, err
+	}
+	reqData := map[string]interface{}{"newName": newName, "destinationType": "home"}
+	if dest != nil && dest.FolderId != 0 {
+		reqData["destinationType"], reqData["destinationId"] = "folder", dest.FolderId
+	}
+	if dest != nil && dest.WorkspaceId != 0 {
+		reqData["destinationType"], reqData["destinationId"] = "workspace", dest.WorkspaceId
+	}
+	endPoint := fmt.Sprintf("/sheets/%d/copy", sheetId)
+	return sendCopy(endPoint, reqData, include, exclude)
+}
+
+// CopyWorkspace copies a workspace, including its sheets, and returns the id of the new workspace.
+// Parm include (CopyBrand is also valid) and asynchronous completion are described in CopySheet.
+func CopyWorkspace(workspaceId int64, newName string, include ...string) (int64, error) {
+	trace("CopyWorkspace")
+	if err := validateCopyIncludes("workspace", include); err != nil {
+		log.Println("ERROR CopyWorkspace", err)
+		return 0, err
+	}
+	reqData := map[string]interface{}{"newName": newName}
+	endPoint := fmt.Sprintf("/workspaces/%d/copy", workspaceId)
+	return sendCopy(endPoint, reqData, include, nil)
+}
+
+// validateCopyIncludes returns an error listing the include values not accepted by the copy operation ("sheet" or
+// "workspace"), and CopyRuleRecipients without CopyRules.
+func validateCopyIncludes(operation string, include []string) error {
+	problems := make([]string, 0)
+	given := make(map[string]bool, len(include))
+	for _, value := range include {
+		given[value] = true
+		valid := false
+		for _, accepted := range copyIncludes[operation] {
+			valid = valid || value == accepted
+		}
+		if !valid {
+			problems = append(problems, fmt.Sprintf("%q not valid for %s copy", value, operation))
+		}
+	}
+	if given[CopyRuleRecipients] && !given[CopyRules] && !given[CopyAll] {
+		problems = append(problems, "ruleRecipients requires rules")
+	}
+	if len(problems) > 0 {
+		return errors.New("Invalid Copy Include - " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// sendCopy posts a copy request and returns the new object id, waiting for the result if the request is accepted (202).
+// The result url of an accepted request is the Location header.
+func sendCopy(endPoint string, reqData interface{}, include, exclude []string) (int64, error) {
+	query := newQuery()
+	for _, value := range include {
+		query.include(value, true)
+	}
+	for _, value := range exclude {
+		query.exclude(value, true)
+	}
+	req := Post(endPoint, reqData, query.parms())
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	if resp.StatusCode == http.StatusAccepted {
+		resultURL := resp.Header.Get("Location")
+		if resultURL == "" {
+			log.Println("ERROR - Copy accepted without result url", endPoint)
+			return 0, errors.New("Copy Accepted - no Location header, result url unknown")
+		}
+		return WaitForAsyncResult(resultURL, AsyncPollInterval, AsyncTimeout)
+	}
+	apiResp := struct {
+		Message    string `json:"message"`
+		ResultCode int    `json:"resultCode"`
+		Result     struct {
+			Id int64 `json:"id"`
+		} `json:"result"`
+	}{}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR - Copy Unmarshal Response Failed", err)
+		return 0, err
+	}
+	return apiResp.Result.Id, nil
+}
+
+// WaitForAsyncResult polls resultURL every pollInterval until the status is COMPLETE or FAILED, and returns the id
+// of the created object (result.id). Parm resultURL is an absolute url or an endpoint, ex. "/asyncresults/123".
+// Returns *AsyncTimeoutError if not complete within timeout, an error wrapping ErrAsyncFailed if the status is FAILED.
+func WaitForAsyncResult(resultURL string, pollInterval, timeout time.Duration) (int64, error) {
+	trace("WaitForAsyncResult")
+	deadline := time.Now().Add(timeout)
+	for {
+		var req *http.Request
+		if strings.HasPrefix(resultURL, "http://") || strings.HasPrefix(resultURL, "https://") {
+			var err error
+			if req, err = http.NewRequest("GET", resultURL, nil); err != nil {
+				log.Println("ERROR - WaitForAsyncResult invalid result url", resultURL, err)
+				return 0, err
+			}
+		} else {
+			req = Get(resultURL, nil)
+		}
+		resp, err := DoRequest(req)
+		if err != nil {
+			return 0, err
+		}
+		respJSON, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		debugLn(string(respJSON))
+
+		var result struct {
+			Status  string `json:"status"`
+			Message string `json:"message"`
+			Result  struct {
+				Id int64 `json:"id"`
+			} `json:"result"`
+		}
+		if err = json.Unmarshal(respJSON, &result); err != nil {
+			log.Println("ERROR - WaitForAsyncResult Unmarshal Response Failed", err)
+			return 0, err
+		}
+		switch result.Status {
+		case AsyncComplete:
+			return result.Result.Id, nil
+		case AsyncFailed:
+			err = fmt.Errorf("%w - %s", ErrAsyncFailed, result.Message)
+			log.Println("ERROR - WaitForAsyncResult", err)
+			return 0, err
+		}
+		if time.Now().Add(pollInterval).After(deadline) {
+			err = &AsyncTimeoutError{ResultURL: resultURL, LastStatus: result.Status, Timeout: timeout}
+			log.Println("ERROR - WaitForAsyncResult", err)
+			return 0, err
+		}
+		time.Sleep(pollInterval)
+	}
+}
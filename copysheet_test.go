@@ -0,0 +1,133 @@
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_CopySheet(t *testing.T) {
+	var reqBody, query string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		reqBody, query = compactJSON(reqBytes), r.URL.RawQuery
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":6660001,"name":"Copy"}}`))
+	})
+	sheetId, err := CopySheet(1849449510135684, "Copy", &SheetDestination{FolderId: 777}, CopyData, CopyAttachments)
+	if err != nil || sheetId != 6660001 {
+		t.Fatal("CopySheet Failed", sheetId, err)
+	}
+	expect := `{"destinationId":777,"destinationType":"folder","newName":"Copy"}`
+	if reqBody != expect || query != "include=data%2Cattachments" {
+		t.Errorf("CopySheet, Expecting %s include=data,attachments, Got %s %s", expect, reqBody, query)
+	}
+	CopySheet(1849449510135684, "Copy", nil)
+	if reqBody != `{"destinationType":"home","newName":"Copy"}` || query != "" {
+		t.Error("CopySheet home wrong request", reqBody, query)
+	}
+	if _, err = CopySheet(1849449510135684, "Copy", &SheetDestination{FolderId: 1, WorkspaceId: 2}); err == nil {
+		t.Error("CopySheet expected error for FolderId and WorkspaceId both set")
+	}
+}
+
+func Test_WaitForAsyncResult(t *testing.T) {
+	polls := 0
+	status := []string{AsyncInProgress, AsyncInProgress, AsyncComplete}
+	server := stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/42/copy":
+			w.Header().Set("Location", "/asyncresults/9")
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"message":"ACCEPTED","resultCode":0}`))
+		case "/asyncresults/9":
+			fmt.Fprintf(w, `{"status":"%s","result":{"id":8880001}}`, status[polls])
+			polls++
+		case "/asyncresults/10":
+			w.Write([]byte(`{"status":"FAILED","message":"Workspace too large"}`))
+		case "/asyncresults/11":
+			w.Write([]byte(`{"status":"IN_PROGRESS"}`))
+		}
+	})
+	savePoll := AsyncPollInterval
+	AsyncPollInterval = time.Millisecond
+	defer func() { AsyncPollInterval = savePoll }()
+
+	workspaceId, err := CopyWorkspace(42, "Copy", CopyAll)
+	if err != nil || workspaceId != 8880001 || polls != 3 {
+		t.Error("CopyWorkspace expected id after IN_PROGRESS, IN_PROGRESS, COMPLETE", workspaceId, polls, err)
+	}
+
+	polls = 0
+	if id, err := WaitForAsyncResult(server.URL+"/asyncresults/9", time.Millisecond, time.Second); err != nil || id != 8880001 {
+		t.Error("WaitForAsyncResult absolute url Failed", id, err)
+	}
+	if _, err = WaitForAsyncResult("/asyncresults/10", time.Millisecond, time.Second); !errors.Is(err, ErrAsyncFailed) {
+		t.Error("WaitForAsyncResult expected ErrAsyncFailed, got", err)
+	}
+	_, err = WaitForAsyncResult("/asyncresults/11", 10*time.Millisecond, 25*time.Millisecond)
+	var timeoutErr *AsyncTimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.LastStatus != AsyncInProgress {
+		t.Error("WaitForAsyncResult expected AsyncTimeoutError with last status IN_PROGRESS, got", err)
+	}
+	if _, err = WaitForAsyncResult("http://bad host/asyncresults/9", time.Millisecond, time.Second); err == nil {
+		t.Error("WaitForAsyncResult expected error for invalid result url")
+	}
+}
+
+func Test_CopySheetOptions(t *testing.T) {
+	requests := 0
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":6660001,"name":"Copy"}}`))
+	})
+	tests := []struct {
+		options CopySheetOptions
+		expect  []string
+	}{
+		{CopySheetOptions{}, nil},
+		{CopySheetOptions{Data: true, Shares: true}, []string{CopyData, CopyShares}},
+		{CopySheetOptions{Data: true, Filters: true, Forms: true, Rules: true, RuleRecipients: true},
+			[]string{CopyData, CopyFilters, CopyForms, CopyRules, CopyRuleRecipients}},
+		{CopySheetOptions{All: true, Data: true, RuleRecipients: true}, []string{CopyAll}},
+	}
+	for _, test := range tests {
+		if err := test.options.Validate(); err != nil {
+			t.Errorf("CopySheetOptions %+v Validate Failed %v", test.options, err)
+		}
+		if got := test.options.includes(); fmt.Sprint(got) != fmt.Sprint(test.expect) {
+			t.Errorf("CopySheetOptions %+v includes, Expecting %v, Got %v", test.options, test.expect, got)
+		}
+	}
+
+	// rejected before the request is sent
+	if _, err := CopySheetWith(1849449510135684, "Copy", nil, &CopySheetOptions{Data: true, RuleRecipients: true}); err == nil {
+		t.Error("CopySheetWith expected error for RuleRecipients without Rules")
+	}
+	invalid := []struct {
+		workspace bool
+		include   []string
+	}{
+		{false, []string{CopyData, "children"}},
+		{false, []string{CopyBrand}}, // workspace copies only
+		{false, []string{CopyRuleRecipients}},
+		{true, []string{"Data"}},
+	}
+	for _, test := range invalid {
+		var err error
+		if test.workspace {
+			_, err = CopyWorkspace(42, "Copy", test.include...)
+		} else {
+			_, err = CopySheet(1849449510135684, "Copy", nil, test.include...)
+		}
+		if err == nil || !strings.HasPrefix(err.Error(), "Invalid Copy Include") {
+			t.Error("Copy expected Invalid Copy Include error for", test.include, "got", err)
+		}
+	}
+	if requests != 0 {
+		t.Error("invalid copy options expected no requests, got", requests)
+	}
+}
@@ -0,0 +1,163 @@
+// createsheet.go contains CreateSheet, creating an empty sheet from a column spec,
+// CloneSheetStructure, creating an empty sheet with the columns of a loaded sheet, and DeleteSheet.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+)
+
+// SheetDestination is the location of a new sheet, set FolderId or WorkspaceId (not both).
+// A nil destination is the user's Sheets home.
+type SheetDestination struct {
+	FolderId    int64
+	WorkspaceId int64
+}
+
+// endPoint returns the create sheet endpoint for the destination.
+func (dest *SheetDestination) endPoint() (string, error) {
+	switch {
+	case dest == nil || dest.FolderId == 0 && dest.WorkspaceId == 0:
+		return "/sheets", nil
+	case dest.FolderId != 0 && dest.WorkspaceId != 0:
+		return "", errors.New("Invalid SheetDestination - FolderId and WorkspaceId both set")
+	case dest.FolderId != 0:
+		return fmt.Sprintf("/folders/%d/sheets", dest.FolderId), nil
+	}
+	return fmt.Sprintf("/workspaces/%d/sheets", dest.WorkspaceId), nil
+}
+
+// SheetSpec is the request body of CreateSheet. Exactly 1 column must be Primary (type TEXT_NUMBER), column titles
+// must be set and unique.
+type SheetSpec struct {
+	Name    string       `json:"name"`
+	Columns []ColumnSpec `json:"columns"`
+}
+
+// ColumnSpec is a column of a new sheet, in sheet order.
+type ColumnSpec struct {
+	Title            string   `json:"title"`
+	Type             string   `json:"type"`
+	Primary          bool     `json:"primary,omitempty"`
+	Options          []string `json:"options,omitempty"`
+	Symbol           string   `json:"symbol,omitempty"`
+	SystemColumnType string   `json:"systemColumnType,omitempty"` // values set by Smartsheet, see systemcolumns.go
+	Width            int      `json:"width,omitempty"`
+	Hidden           bool     `json:"hidden,omitempty"`
+	Locked           bool     `json:"locked,omitempty"`
+	Validation       bool     `json:"validation,omitempty"`
+}
+
+// validate returns an error if the spec would be rejected by the api: not exactly 1 Primary column, a Primary column
+// not TEXT_NUMBER, or an empty or duplicate column title.
+func (spec *SheetSpec) validate() error {
+	primary := 0
+	titles := make(map[string]bool, len(spec.Columns))
+	for i, column := range spec.Columns {
+		if column.Title == "" {
+			return fmt.Errorf("Invalid SheetSpec - column %d has no title", i)
+		}
+		if titles[column.Title] {
+			return errors.New("Invalid SheetSpec - duplicate column title " + column.Title)
+		}
+		titles[column.Title] = true
+		if column.Primary {
+			primary++
+			if column.Type != TEXTNUMBER {
+				return fmt.Errorf("Invalid SheetSpec - primary column %s type %s, must be %s", column.Title, column.Type, TEXTNUMBER)
+			}
+		}
+	}
+	if primary != 1 {
+		return fmt.Errorf("Invalid SheetSpec - %d primary columns, must be 1", primary)
+	}
+	return nil
+}
+
+// CreateSheet creates an empty sheet and returns its id. The spec is validated before the request is sent, see SheetSpec.
+func CreateSheet(spec SheetSpec, dest *SheetDestination) (int64, error) {
+	trace("CreateSheet")
+	endPoint, err := dest.endPoint()
+	if err == nil {
+		err = spec.validate()
+	}
+	if err != nil {
+		log.Println("ERROR CreateSheet", err)
+		return 0, err
+	}
+	req := Post(endPoint, spec, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	apiResp := struct {
+		Message    string `json:"message"`
+		ResultCode int    `json:"resultCode"`
+		Result     struct {
+			Id int64 `json:"id"`
+		} `json:"result"`
+	}{}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR - CreateSheet Unmarshal Response Failed", err)
+		return 0, err
+	}
+	return apiResp.Result.Id, nil
+}
+
+// DeleteSheet deletes a sheet, including its rows, attachments and discussions.
+func DeleteSheet(sheetId int64) error {
+	trace("DeleteSheet")
+	if err := deleteObject(fmt.Sprintf("/sheets/%d", sheetId)); err != nil {
+		log.Println("ERROR DeleteSheet", sheetId, err)
+		return err
+	}
+	return nil
+}
+
+// CloneSheetStructure creates an empty sheet named newName with the columns of source (loaded), in the same order.
+// Column titles, types, options, symbols, widths and the primary column are copied. Rows, formulas and column
+// formatting are not. System columns (ex. AUTO_NUMBER, CREATED_DATE) are created as system columns, Smartsheet sets
+// their values in the new sheet. The AUTO_NUMBER prefix, suffix and starting number are not copied (not returned by GetSheet).
+// Returns the new sheet, loaded with no rows.
+func CloneSheetStructure(source *SheetInfo, newName string, dest *SheetDestination) (*SheetInfo, error) {
+	trace("CloneSheetStructure")
+	spec := source.sheetSpec(newName)
+	sheetId, err := CreateSheet(spec, dest)
+	if err != nil {
+		log.Println("ERROR CloneSheetStructure", source.SheetName, err)
+		return nil, err
+	}
+	clone := new(SheetInfo)
+	if err = clone.Load(sheetId, NoRows); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// sheetSpec returns the spec of an empty sheet with the columns of the loaded sheet.
+func (she *SheetInfo) sheetSpec(name string) SheetSpec {
+	spec := SheetSpec{Name: name, Columns: make([]ColumnSpec, 0, len(she.ColumnsById))}
+	for _, column := range she.columnList() {
+		spec.Columns = append(spec.Columns, ColumnSpec{
+			Title:            column.Title,
+			Type:             column.Type,
+			Primary:          column.Primary,
+			Options:          column.Options,
+			Symbol:           column.Symbol,
+			SystemColumnType: column.SystemColumnType,
+			Width:            column.Width,
+		})
+	}
+	return spec
+}
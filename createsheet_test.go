@@ -0,0 +1,124 @@
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_CloneSheetStructure(t *testing.T) {
+	var reqBody []byte
+	var postPath string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			postPath = r.URL.Path
+			reqBody, _ = ioutil.ReadAll(r.Body)
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":5550001,"name":"Test1 Copy"}}`))
+		case "GET":
+			if r.URL.Path != "/sheets/5550001" || r.URL.Query().Get("rowIds") != "0" {
+				t.Error("CloneSheetStructure unexpected load", r.URL)
+			}
+			w.Write([]byte(`{"id":5550001,"name":"Test1 Copy","columns":[{"id":1,"index":0,"title":"Address","type":"TEXT_NUMBER","primary":true}],"rows":[]}`))
+		}
+	})
+	source := testSheet()
+	for _, column := range []Column{
+		{Id: 110, Index: 9, Title: "Health", Type: PICKLIST, Symbol: SymbolRYG, Options: []string{Red, Yellow, Green}, Width: 60},
+		{Id: 111, Index: 10, Title: "Ticket", Type: "TEXT_NUMBER", SystemColumnType: AutoNumber},
+	} {
+		source.ColumnsById[column.Id], source.ColumnsByName[column.Title], source.ColumnsByIndex[column.Index] = column, column, column
+	}
+	clone, err := CloneSheetStructure(source, "Test1 Copy", &SheetDestination{FolderId: 777})
+	if err != nil {
+		t.Fatal("CloneSheetStructure Failed", err)
+	}
+	if postPath != "/folders/777/sheets" || clone.SheetId != 5550001 || clone.SheetName != "Test1 Copy" {
+		t.Error("CloneSheetStructure wrong destination or result", postPath, clone.SheetId, clone.SheetName)
+	}
+
+	var spec SheetSpec
+	if err = json.Unmarshal(reqBody, &spec); err != nil {
+		t.Fatal(err)
+	}
+	if spec.Name != "Test1 Copy" || len(spec.Columns) != len(source.ColumnsByIndex) {
+		t.Fatalf("CloneSheetStructure wrong spec %s", reqBody)
+	}
+	expectColumns := map[int]string{
+		3:  `{"title":"Util","type":"PICKLIST","options":["Elec","Water","Gas"]}`,
+		9:  `{"title":"Health","type":"PICKLIST","options":["Red","Yellow","Green"],"symbol":"RYG","width":60}`,
+		10: `{"title":"Ticket","type":"TEXT_NUMBER","systemColumnType":"AUTO_NUMBER"}`,
+	}
+	for index, expect := range expectColumns {
+		if specJSON, _ := json.Marshal(spec.Columns[index]); string(specJSON) != expect {
+			t.Errorf("CloneSheetStructure column %d, Expecting %s, Got %s", index, expect, specJSON)
+		}
+	}
+	if primary, _ := json.Marshal(spec.Columns[0]); string(primary) != `{"title":"Address","type":"TEXT_NUMBER","primary":true}` {
+		t.Error("CloneSheetStructure primary column spec wrong", string(primary))
+	}
+
+	if _, err = CreateSheet(spec, &SheetDestination{FolderId: 1, WorkspaceId: 2}); err == nil {
+		t.Error("CreateSheet expected error for FolderId and WorkspaceId both set")
+	}
+	CreateSheet(spec, &SheetDestination{WorkspaceId: 2})
+	if postPath != "/workspaces/2/sheets" {
+		t.Error("CreateSheet wrong workspace endpoint", postPath)
+	}
+	CreateSheet(spec, nil)
+	if postPath != "/sheets" {
+		t.Error("CreateSheet wrong home endpoint", postPath)
+	}
+}
+
+func Test_CreateSheetValidation(t *testing.T) {
+	requests := 0
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":5550001}}`))
+	})
+	tests := []struct {
+		columns []ColumnSpec
+		expect  string
+	}{
+		{[]ColumnSpec{{Title: "Address", Type: TEXTNUMBER}}, "0 primary columns"},
+		{[]ColumnSpec{{Title: "Address", Type: TEXTNUMBER, Primary: true}, {Title: "OrderNo", Type: TEXTNUMBER, Primary: true}}, "2 primary columns"},
+		{[]ColumnSpec{{Title: "DueDate", Type: DATE, Primary: true}}, "primary column DueDate type DATE"},
+		{[]ColumnSpec{{Title: "Address", Type: TEXTNUMBER, Primary: true}, {Type: TEXTNUMBER}}, "column 1 has no title"},
+		{[]ColumnSpec{{Title: "Address", Type: TEXTNUMBER, Primary: true}, {Title: "Address", Type: TEXTNUMBER}}, "duplicate column title Address"},
+	}
+	for _, test := range tests {
+		_, err := CreateSheet(SheetSpec{Name: "Test", Columns: test.columns}, nil)
+		if err == nil || !strings.Contains(err.Error(), test.expect) {
+			t.Errorf("CreateSheet %+v, Expecting error %q, Got %v", test.columns, test.expect, err)
+		}
+	}
+	if requests != 0 {
+		t.Error("CreateSheet expected no requests for invalid specs, got", requests)
+	}
+	if sheetId, err := CreateSheet(SheetSpec{Name: "Test", Columns: []ColumnSpec{{Title: "Address", Type: TEXTNUMBER, Primary: true}}}, nil); err != nil || sheetId != 5550001 || requests != 1 {
+		t.Error("CreateSheet valid spec expected request, got", sheetId, err, requests)
+	}
+}
+
+func Test_DeleteSheet(t *testing.T) {
+	var request string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		request = r.Method + " " + r.URL.Path
+		if r.URL.Path != "/sheets/42" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorCode":1006,"message":"Not Found"}`))
+			return
+		}
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+	})
+	if err := DeleteSheet(42); err != nil || request != "DELETE /sheets/42" {
+		t.Error("DeleteSheet expected DELETE /sheets/42, got", request, err)
+	}
+	if err := DeleteSheet(43); !errors.Is(err, ErrSheetNotFound) {
+		t.Error("DeleteSheet expected ErrSheetNotFound, got", err)
+	}
+}
@@ -0,0 +1,85 @@
+// dates.go contains funcs for converting date cell values to and from time.Time.
+// The API stores DATETIME values in UTC, convert to a local zone with time.In when displaying them.
+// DATE and ABSTRACT_DATETIME values have no zone, they are parsed as UTC so the date portion is not shifted.
+
+package smartsheet
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	DateTimeFormat         = time.RFC3339          // DATETIME columns, ex. "2020-10-10T14:30:00Z"
+	AbstractDateTimeFormat = "2006-01-02T15:04:05" // ABSTRACT_DATETIME columns, ex. "2020-10-10T14:30:00"
+)
+
+// Column Types containing dates
+const (
+	DATE             = "DATE"
+	DATETIME         = "DATETIME"
+	ABSTRACTDATETIME = "ABSTRACT_DATETIME"
+)
+
+// ParseCellTime converts a date cell value to time.Time using the layout matching column.Type.
+// An empty cell returns the zero time (check with IsZero) and no error.
+// Cells in non-date columns are parsed if their value matches one of the date layouts.
+func ParseCellTime(cell Cell, column Column) (time.Time, error) {
+	if cell.Value == nil {
+		return time.Time{}, nil
+	}
+	value, ok := cell.Value.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("Invalid Date Value in Column %s - %v", column.Title, cell.Value)
+	}
+	if value == "" {
+		return time.Time{}, nil
+	}
+	var layouts []string
+	switch column.Type {
+	case DATE:
+		layouts = []string{DateFormat, DateTimeFormat, AbstractDateTimeFormat} // project sheet dates can include time
+	case DATETIME:
+		layouts = []string{DateTimeFormat}
+	case ABSTRACTDATETIME:
+		layouts = []string{AbstractDateTimeFormat, DateTimeFormat}
+	default:
+		layouts = []string{DateFormat, DateTimeFormat, AbstractDateTimeFormat}
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Invalid Date Value in Column %s - %s", column.Title, value)
+}
+
+// FormatForColumn returns t formatted for use as a cell value in column.
+// DATETIME values are converted to UTC. Non-date columns use DateFormat.
+func FormatForColumn(t time.Time, column Column) string {
+	switch column.Type {
+	case DATETIME:
+		return t.UTC().Format(DateTimeFormat)
+	case ABSTRACTDATETIME:
+		return t.Format(AbstractDateTimeFormat)
+	default:
+		return t.Format(DateFormat)
+	}
+}
+
+// CellTime returns the value of a date cell in row as time.Time.
+// Parm columnName must be in sheet.ColumnsByName. If row has no value in the column, the zero time is returned.
+func CellTime(sheet *SheetInfo, row Row, columnName string) (time.Time, error) {
+	column, found := sheet.ColumnsByName[columnName]
+	if !found {
+		log.Println("ERROR - CellTime, columnName not found in sheet.ColumnsByName: ", columnName)
+		return time.Time{}, fmt.Errorf("%w - %s", ErrInvalidColumnName, columnName)
+	}
+	for _, cell := range row.Cells {
+		if cell.ColumnId == column.Id {
+			return ParseCellTime(cell, column)
+		}
+	}
+	return time.Time{}, nil
+}
@@ -0,0 +1,69 @@
+package smartsheet
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_ParseCellTime(t *testing.T) {
+	tests := []struct {
+		colType string
+		value   interface{}
+		expect  time.Time
+		isErr   bool
+	}{
+		{DATE, "2020-10-10", time.Date(2020, 10, 10, 0, 0, 0, 0, time.UTC), false},
+		{DATE, "2020-10-10T08:00:00", time.Date(2020, 10, 10, 8, 0, 0, 0, time.UTC), false},
+		{DATETIME, "2020-10-10T14:30:00Z", time.Date(2020, 10, 10, 14, 30, 0, 0, time.UTC), false},
+		{ABSTRACTDATETIME, "2020-10-10T14:30:00", time.Date(2020, 10, 10, 14, 30, 0, 0, time.UTC), false},
+		{"TEXT_NUMBER", "2020-10-10", time.Date(2020, 10, 10, 0, 0, 0, 0, time.UTC), false},
+		{DATE, nil, time.Time{}, false},
+		{DATE, "10/10/2020", time.Time{}, true},
+		{DATETIME, "2020-10-10", time.Time{}, true},
+		{DATE, 44114.0, time.Time{}, true},
+	}
+	for _, test := range tests {
+		column := Column{Title: "When", Type: test.colType}
+		got, err := ParseCellTime(Cell{Value: test.value}, column)
+		if test.isErr != (err != nil) {
+			t.Error("ParseCellTime", test.colType, test.value, "unexpected error result", err)
+			continue
+		}
+		if !got.Equal(test.expect) {
+			t.Error("ParseCellTime", test.colType, test.value, "expecting", test.expect, "got", got)
+		}
+	}
+}
+
+func Test_FormatForColumn(t *testing.T) {
+	central := time.FixedZone("CST", -6*3600)
+	when := time.Date(2020, 10, 10, 20, 30, 0, 0, central)
+	tests := map[string]string{
+		DATE:             "2020-10-10",
+		DATETIME:         "2020-10-11T02:30:00Z",
+		ABSTRACTDATETIME: "2020-10-10T20:30:00",
+		"TEXT_NUMBER":    "2020-10-10",
+	}
+	for colType, expect := range tests {
+		got := FormatForColumn(when, Column{Type: colType})
+		if got != expect {
+			t.Error("FormatForColumn", colType, "expecting", expect, "got", got)
+		}
+	}
+}
+
+func Test_CellTime(t *testing.T) {
+	sheet := testSheet()
+	row := Row{Cells: []Cell{{ColumnId: 103, Value: "2020-11-22"}}}
+	got, err := CellTime(sheet, row, "DueDate")
+	if err != nil || !got.Equal(time.Date(2020, 11, 22, 0, 0, 0, 0, time.UTC)) {
+		t.Error("CellTime wrong result", got, err)
+	}
+	if got, err = CellTime(sheet, Row{}, "DueDate"); err != nil || !got.IsZero() {
+		t.Error("CellTime empty cell wrong result", got, err)
+	}
+	if _, err = CellTime(sheet, row, "Bogus"); !errors.Is(err, ErrInvalidColumnName) {
+		t.Error("CellTime expected ErrInvalidColumnName, got", err)
+	}
+}
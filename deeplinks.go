@@ -0,0 +1,97 @@
+// deeplinks.go contains BuildSheetURL and BuildRowURL, which build links to a sheet and its rows from the sheet permalink
+// returned by Load, without api requests. A row url is the sheet permalink with a rowId parameter
+// (ex. https://app.smartsheet.com/sheets/abc?rowId=11). The api is only requested when the permalink is not known,
+// the requested permalink is saved in SheetInfo.Permalink.
+
+package smartsheet
+
+import (
+	"log"
+	"net/url"
+	"strconv"
+)
+
+// sheetPermalink returns the sheet permalink set by Load, RefreshMeta or an earlier request, "" if not known.
+func sheetPermalink(sheet *SheetInfo) string {
+	if sheet.Permalink != "" {
+		return sheet.Permalink
+	}
+	if sheet.Meta != nil {
+		return sheet.Meta.Permalink
+	}
+	return ""
+}
+
+// requestPermalink requests the sheet permalink using GetSheetMeta and saves it in sheet.Permalink, so it is
+// requested once per sheet.
+func requestPermalink(sheet *SheetInfo) (string, error) {
+	meta, err := GetSheetMeta(sheet.SheetId)
+	if err != nil {
+		return "", err
+	}
+	sheet.Permalink = meta.Permalink
+	return sheet.Permalink, nil
+}
+
+// BuildSheetURL returns the url of sheet. If the permalink was not loaded (see SheetInfo.Permalink), it is requested
+// using GetSheetMeta and saved in sheet.Permalink for later calls. If the request fails, the error is logged and ""
+// is returned. Like other funcs changing a SheetInfo, it is not safe for concurrent use with the same sheet.
+func BuildSheetURL(sheet *SheetInfo) string {
+	trace("BuildSheetURL")
+	if permalink := sheetPermalink(sheet); permalink != "" {
+		return permalink
+	}
+	permalink, err := requestPermalink(sheet)
+	if err != nil {
+		log.Println("ERROR - BuildSheetURL", sheet.SheetId, err)
+		return ""
+	}
+	return permalink
+}
+
+// rowURL returns permalink with a rowId parameter, "" if permalink is not an absolute url.
+func rowURL(permalink string, rowId int64) string {
+	link, err := url.Parse(permalink)
+	if err != nil || !link.IsAbs() || link.Host == "" {
+		return ""
+	}
+	query := link.Query()
+	query.Set("rowId", strconv.FormatInt(rowId, 10))
+	link.RawQuery = query.Encode()
+	return link.String()
+}
+
+// BuildRowURL returns the url of a row of sheet, built from the sheet permalink. If the permalink was not loaded,
+// Row.Permalink is used when the row is in sheet.Rows (see GetSheetOptions.IncludeRowPermalink), otherwise the sheet
+// permalink is requested as by BuildSheetURL, once per sheet. If the sheet permalink is not an absolute url, the row
+// permalink is requested using GetRowWith. If a request fails, the error is logged and "" is returned.
+// BuildRowURL may set sheet.Permalink, it is not safe for concurrent use with the same sheet, call BuildSheetURL first
+// to request the permalink once before building row urls from multiple goroutines.
+func BuildRowURL(sheet *SheetInfo, rowId int64) string {
+	trace("BuildRowURL")
+	permalink := sheetPermalink(sheet)
+	if link := rowURL(permalink, rowId); link != "" {
+		return link
+	}
+	for _, row := range sheet.Rows {
+		if row.Id == rowId && row.Permalink != "" {
+			return row.Permalink
+		}
+	}
+	if permalink == "" {
+		var err error
+		if permalink, err = requestPermalink(sheet); err != nil {
+			log.Println("ERROR - BuildRowURL", sheet.SheetId, rowId, err)
+			return ""
+		}
+		if link := rowURL(permalink, rowId); link != "" {
+			return link
+		}
+	}
+	row, err := GetRowWith(sheet.SheetId, rowId, &GetRowOptions{IncludePermalink: true})
+	if err != nil {
+		log.Println("ERROR - BuildRowURL", sheet.SheetId, rowId, err)
+		return ""
+	}
+	return row.Permalink
+}
@@ -0,0 +1,70 @@
+package smartsheet
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_BuildURLs(t *testing.T) {
+	var requests []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+"?"+r.URL.RawQuery)
+		switch r.URL.Path {
+		case "/sheets/1849449510135684":
+			w.Write([]byte(`{"id":1849449510135684,"name":"Test1","permalink":"https://app.smartsheet.com/sheets/xyz"}`))
+		case "/sheets/1849449510135684/rows/13":
+			w.Write([]byte(`{"id":13,"permalink":"https://app.smartsheet.com/sheets/xyz?rowId=13"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorCode":1006,"message":"Not Found"}`))
+		}
+	})
+
+	// derived from the loaded permalink, no requests
+	sheet := testSheet()
+	sheet.Permalink = "https://app.smartsheet.com/sheets/abc"
+	if got := BuildSheetURL(sheet); got != "https://app.smartsheet.com/sheets/abc" {
+		t.Error("BuildSheetURL loaded, Got", got)
+	}
+	if got := BuildRowURL(sheet, 11); got != "https://app.smartsheet.com/sheets/abc?rowId=11" {
+		t.Error("BuildRowURL loaded, Got", got)
+	}
+	sheet.Permalink = ""
+	sheet.Meta = &SheetMeta{Permalink: "https://app.smartsheet.com/sheets/def"}
+	if got := BuildRowURL(sheet, 12); got != "https://app.smartsheet.com/sheets/def?rowId=12" {
+		t.Error("BuildRowURL meta, Got", got)
+	}
+	if len(requests) != 0 {
+		t.Fatal("BuildURLs with loaded permalink expected no requests, got", requests)
+	}
+
+	// fallback, permalink not loaded
+	sheet.Meta = nil
+	sheet.Rows = []Row{{Id: 12, Permalink: "https://app.smartsheet.com/sheets/xyz?rowId=12"}}
+	if got := BuildRowURL(sheet, 12); got != "https://app.smartsheet.com/sheets/xyz?rowId=12" || len(requests) != 0 {
+		t.Error("BuildRowURL loaded row permalink, Got", got, requests)
+	}
+	if got := BuildRowURL(sheet, 13); got != "https://app.smartsheet.com/sheets/xyz?rowId=13" {
+		t.Error("BuildRowURL requested, Got", got)
+	}
+	// sheet permalink requested once, saved for later calls
+	if got := BuildSheetURL(sheet); got != "https://app.smartsheet.com/sheets/xyz" || sheet.Permalink != got {
+		t.Error("BuildSheetURL requested, Got", got, sheet.Permalink)
+	}
+	if got := BuildRowURL(sheet, 14); got != "https://app.smartsheet.com/sheets/xyz?rowId=14" {
+		t.Error("BuildRowURL saved permalink, Got", got)
+	}
+	if len(requests) != 1 || !strings.HasPrefix(requests[0], "/sheets/1849449510135684?") {
+		t.Error("BuildURLs fallback expected 1 sheet request, got", requests)
+	}
+	other := testSheet()
+	other.SheetId = 99
+	if got := BuildRowURL(other, 14); got != "" || other.Permalink != "" {
+		t.Error("BuildRowURL failed request expected empty url, Got", got)
+	}
+	sheet.Permalink = "sheets/abc" // not absolute
+	if got := BuildRowURL(sheet, 13); got != "https://app.smartsheet.com/sheets/xyz?rowId=13" {
+		t.Error("BuildRowURL relative permalink expected fallback, Got", got)
+	}
+}
@@ -0,0 +1,77 @@
+// discussions.go contains funcs for listing the discussions (with comments) of a row, creating row discussions
+// and adding comments to a discussion.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+)
+
+// ListRowDiscussions returns the discussions of a row, including their comments (oldest first).
+func ListRowDiscussions(sheetId, rowId int64) ([]Discussion, error) {
+	trace("ListRowDiscussions")
+	endPoint := fmt.Sprintf("/sheets/%d/rows/%d/discussions", sheetId, rowId)
+	discussions := make([]Discussion, 0, 5)
+	err := getAllPages(endPoint, map[string]string{"include": "comments"}, func(data json.RawMessage) error {
+		var page []Discussion
+		err := json.Unmarshal(data, &page)
+		discussions = append(discussions, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return discussions, nil
+}
+
+// CreateRowDiscussion creates a discussion on a row, text is the first comment. Returns the new discussion.
+func CreateRowDiscussion(sheetId, rowId int64, text string) (*Discussion, error) {
+	trace("CreateRowDiscussion")
+	reqData := map[string]interface{}{"comment": map[string]string{"text": text}}
+	endPoint := fmt.Sprintf("/sheets/%d/rows/%d/discussions", sheetId, rowId)
+	discussion := new(Discussion)
+	if err := postComment("CreateRowDiscussion", endPoint, reqData, discussion); err != nil {
+		return nil, err
+	}
+	return discussion, nil
+}
+
+// AddComment adds a comment to a discussion. Returns the new comment.
+func AddComment(sheetId, discussionId int64, text string) (*Comment, error) {
+	trace("AddComment")
+	reqData := map[string]string{"text": text}
+	endPoint := fmt.Sprintf("/sheets/%d/discussions/%d/comments", sheetId, discussionId)
+	comment := new(Comment)
+	if err := postComment("AddComment", endPoint, reqData, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// postComment posts reqData to endPoint and unmarshals the response result into result.
+func postComment(funcName, endPoint string, reqData interface{}, result interface{}) error {
+	req := Post(endPoint, reqData, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+	apiResp := struct {
+		Message    string      `json:"message"`
+		ResultCode int         `json:"resultCode"`
+		Result     interface{} `json:"result"`
+	}{Result: result}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR", funcName, "Unmarshal Response Failed", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// duplicateupdates.go contains the handling of a row id staged more than once for update (SheetInfo.DuplicateUpdates).
+// The api rejects a batch with 2 entries for 1 row, so the staged rows are merged into 1, or an error names the row.
+
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// Duplicate Update Actions, used by SheetInfo.DuplicateUpdates
+const (
+	DuplicateUpdatesMerge  = iota // merge into the staged row, later cells replace earlier cells of the same column (default)
+	DuplicateUpdatesReject        // return error wrapping ErrDuplicateRow, the row is not staged again
+)
+
+// ErrDuplicateRow is wrapped by the error returned by UpdateRow and UploadUpdateRows when SheetInfo.DuplicateUpdates
+// is DuplicateUpdatesReject and a row id is staged more than once.
+var ErrDuplicateRow = errors.New("Row Staged More Than Once")
+
+// mergeUpdateRow merges later into staged: cells of later replace the cells of staged for the same column, other cells
+// are added, and the Locked value of later (if set) replaces the staged value. Returns a description of a Locked
+// conflict (1 row locks and the other unlocks), empty if none.
+func mergeUpdateRow(staged *Row, later Row) (conflict string) {
+	staged.Cells = append(make([]Cell, 0, len(staged.Cells)+len(later.Cells)), staged.Cells...) // not the caller's array
+	for _, cell := range later.Cells {
+		replaced := false
+		for j := range staged.Cells {
+			if staged.Cells[j].ColumnId == cell.ColumnId {
+				staged.Cells[j], replaced = cell, true
+				break
+			}
+		}
+		if !replaced {
+			staged.Cells = append(staged.Cells, cell)
+		}
+	}
+	if later.Locked != nil {
+		if staged.Locked != nil && *staged.Locked != *later.Locked {
+			conflict = fmt.Sprintf("row staged with locked %v then %v, %v kept", *staged.Locked, *later.Locked, *later.Locked)
+		}
+		staged.Locked = later.Locked
+	}
+	return conflict
+}
+
+// stageUpdateRow appends row to UpdateRows, or if its id is already staged, merges or rejects it (SheetInfo.DuplicateUpdates).
+// A merged row is logged and held in stagedWarnings, UploadUpdateRows reports it in Warnings (WarnDuplicateRow) as it
+// does for rows merged at upload.
+func (she *SheetInfo) stageUpdateRow(row Row) error {
+	for i := range she.UpdateRows {
+		if she.UpdateRows[i].Id != row.Id {
+			continue
+		}
+		if she.DuplicateUpdates == DuplicateUpdatesReject {
+			return fmt.Errorf("%w - row %d", ErrDuplicateRow, row.Id)
+		}
+		warning := Warning{Code: WarnDuplicateRow, Message: mergeMessage(mergeUpdateRow(&she.UpdateRows[i], row)), RowId: row.Id}
+		log.Println("WARNING - SheetInfo.UpdateRow", she.SheetName, warning)
+		she.stagedWarnings = append(she.stagedWarnings, warning)
+		return nil
+	}
+	if she.UpdateRows == nil { // set to nil by UploadUpdateRows
+		she.UpdateRows = make([]Row, 0, 100)
+	}
+	she.UpdateRows = append(she.UpdateRows, row)
+	return nil
+}
+
+// mergeDuplicateUpdates handles rows with the same id appended to UpdateRows directly (not using UpdateRow), called by
+// UploadUpdateRows before any row is sent. Each merged row is reported in Warnings (WarnDuplicateRow).
+func (she *SheetInfo) mergeDuplicateUpdates() error {
+	index := make(map[int64]int, len(she.UpdateRows)) // key: row id, value: index in merged
+	merged := make([]Row, 0, len(she.UpdateRows))
+	for _, row := range she.UpdateRows {
+		i, found := index[row.Id]
+		if !found {
+			index[row.Id] = len(merged)
+			merged = append(merged, row)
+			continue
+		}
+		if she.DuplicateUpdates == DuplicateUpdatesReject {
+			return fmt.Errorf("%w - row %d", ErrDuplicateRow, row.Id)
+		}
+		she.warn(WarnDuplicateRow, mergeMessage(mergeUpdateRow(&merged[i], row)), row.Id, 0)
+	}
+	she.UpdateRows = merged
+	return nil
+}
+
+// mergeMessage returns the WarnDuplicateRow message of a merged row, the Locked conflict returned by mergeUpdateRow if any.
+func mergeMessage(conflict string) string {
+	if conflict != "" {
+		return conflict
+	}
+	return "row staged more than once, cells merged"
+}
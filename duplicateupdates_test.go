@@ -0,0 +1,89 @@
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_DuplicateUpdates(t *testing.T) {
+	var requests []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, compactJSON(reqBytes))
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[{"id":11},{"id":12}]}`))
+	})
+	locked, unlocked := true, false
+
+	// merge, later cells win per column
+	sheet := testSheet()
+	for _, row := range []Row{
+		{Id: 11, Cells: []Cell{{ColName: "Amt", Value: 1}, {ColName: "Level", Value: 2}}},
+		{Id: 12, Cells: []Cell{{ColName: "Amt", Value: 5}}},
+		{Id: 11, Locked: &locked, Cells: []Cell{{ColName: "Level", Value: 3}, {ColName: "OrderNo", Value: "A1"}}},
+	} {
+		if err := sheet.UpdateRow(row); err != nil {
+			t.Fatal("UpdateRow Failed", err)
+		}
+	}
+	if len(sheet.UpdateRows) != 2 {
+		t.Fatal("UpdateRow expected rows merged, got", sheet.UpdateRows)
+	}
+	if _, err := sheet.UploadUpdateRows(nil); err != nil {
+		t.Fatal("UploadUpdateRows Failed", err)
+	}
+	expect := `[{"cells":[{"columnId":105,"value":1},{"columnId":107,"value":3},{"columnId":102,"value":"A1"}],"id":"11","locked":true},` +
+		`{"cells":[{"columnId":105,"value":5}],"id":"12"}]`
+	if len(requests) != 1 || requests[0] != expect {
+		t.Errorf("UploadUpdateRows merged, Expecting\n%s\nGot\n%v", expect, requests)
+	}
+	expectWarnings := []Warning{{Code: WarnDuplicateRow, Message: "row staged more than once, cells merged", RowId: 11}}
+	if fmt.Sprint(sheet.Warnings) != fmt.Sprint(expectWarnings) || sheet.stagedWarnings != nil {
+		t.Errorf("UploadUpdateRows merged Warnings, Expecting %v, Got %v", expectWarnings, sheet.Warnings)
+	}
+
+	// rows appended to UpdateRows directly, conflicting Locked values, the later is kept
+	requests = nil
+	first := Row{Id: 11, Locked: &locked, Cells: []Cell{{ColumnId: 105, Value: 1}}}
+	sheet.UpdateRows = []Row{first, {Id: 12, Cells: []Cell{{ColumnId: 105, Value: 3}}}, {Id: 11, Locked: &unlocked, Cells: []Cell{{ColumnId: 107, Value: 2}}}}
+	if _, err := sheet.UploadUpdateRows(nil); err != nil {
+		t.Fatal("UploadUpdateRows Failed", err)
+	}
+	expect = `[{"cells":[{"columnId":105,"value":1},{"columnId":107,"value":2}],"id":"11","locked":false},{"cells":[{"columnId":105,"value":3}],"id":"12"}]`
+	if len(requests) != 1 || requests[0] != expect {
+		t.Errorf("UploadUpdateRows appended, Expecting\n%s\nGot\n%v", expect, requests)
+	}
+	expectWarnings = []Warning{{Code: WarnDuplicateRow, Message: "row staged with locked true then false, false kept", RowId: 11}}
+	if fmt.Sprint(sheet.Warnings) != fmt.Sprint(expectWarnings) {
+		t.Errorf("UploadUpdateRows Warnings, Expecting %v, Got %v", expectWarnings, sheet.Warnings)
+	}
+	if len(first.Cells) != 1 || *first.Locked != true {
+		t.Error("merge changed the caller's row", first)
+	}
+}
+
+func Test_DuplicateUpdatesReject(t *testing.T) {
+	requests := 0
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[]}`))
+	})
+	sheet := testSheet()
+	sheet.DuplicateUpdates = DuplicateUpdatesReject
+	if err := sheet.UpdateRow(Row{Id: 11, Cells: []Cell{{ColName: "Amt", Value: 1}}}); err != nil {
+		t.Fatal("UpdateRow Failed", err)
+	}
+	err := sheet.UpdateRow(Row{Id: 11, Cells: []Cell{{ColName: "Level", Value: 2}}})
+	if !errors.Is(err, ErrDuplicateRow) || !strings.Contains(err.Error(), "row 11") || len(sheet.UpdateRows) != 1 {
+		t.Error("UpdateRow expected ErrDuplicateRow naming row 11, got", err, sheet.UpdateRows)
+	}
+
+	sheet.UpdateRows = append(sheet.UpdateRows, Row{Id: 12}, Row{Id: 11, Cells: []Cell{{ColumnId: 107, Value: 2}}})
+	_, err = sheet.UploadUpdateRows(nil)
+	if !errors.Is(err, ErrDuplicateRow) || !strings.Contains(err.Error(), "row 11") || requests != 0 || len(sheet.UpdateRows) != 3 {
+		t.Error("UploadUpdateRows expected ErrDuplicateRow and no request, got", err, requests, len(sheet.UpdateRows))
+	}
+}
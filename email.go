@@ -6,25 +6,161 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"strings"
+)
+
+const (
+	EmailMaxRows    = 100 // maximum number of rows api will include in 1 email
+	EmailMaxColumns = 400 // maximum number of columns in a sheet
 )
 
 type EmailRecipient map[string]interface{} // key: "email" or "groupId", val: address or groupId number
 
+// NewEmailRecipient returns an EmailRecipient for an email address.
+func NewEmailRecipient(email string) EmailRecipient {
+	return EmailRecipient{"email": email}
+}
+
+// NewGroupRecipient returns an EmailRecipient for a Smartsheet group.
+func NewGroupRecipient(groupId int64) EmailRecipient {
+	return EmailRecipient{"groupId": groupId}
+}
+
+// Recipients returns the recipients as a slice, ready for use as EmailRowsObj.SendTo.
+// Example: Recipients(NewEmailRecipient("x@y.com"), NewGroupRecipient(groupId))
+func Recipients(recipients ...EmailRecipient) []EmailRecipient {
+	sendTo := make([]EmailRecipient, len(recipients))
+	copy(sendTo, recipients)
+	return sendTo
+}
+
+// MarshalJSON returns an error if the recipient does not contain exactly 1 key of "email" or "groupId".
+func (recipient EmailRecipient) MarshalJSON() ([]byte, error) {
+	if msg := checkRecipient(recipient); msg != "" {
+		return nil, errors.New("Invalid EmailRecipient - " + msg)
+	}
+	return json.Marshal(map[string]interface{}(recipient)) // convert to map type to avoid calling this method again
+}
+
 // EmailRowsObj is the object sent by EmailRows func via API.
+// ColumnNames is used by EmailRowsByName to load ColumnIds, it is not sent to the API.
 type EmailRowsObj struct {
 	SendTo             []EmailRecipient `json:"sendTo"`
 	Subject            string           `json:"subject"`
 	Message            string           `json:"message"`
 	CCMe               bool             `json:"ccMe"`
 	RowIds             []int64          `json:"rowIds"`
+	ColumnNames        []string         `json:"-"` // not used by API
 	ColumnIds          []int64          `json:"columnIds,omitempty"`
 	IncludeAttachments bool             `json:"includeAttachments"`
 	IncludeDiscussions bool             `json:"includeDiscussions"`
 }
 
+// Validate checks EmailRowsObj values before they are sent to the API.
+// All problems found are included in the returned error.
+func (obj *EmailRowsObj) Validate() error {
+	problems := make([]string, 0, 5)
+	if len(obj.SendTo) == 0 {
+		problems = append(problems, "SendTo is empty")
+	}
+	for i, recipient := range obj.SendTo {
+		if msg := checkRecipient(recipient); msg != "" {
+			problems = append(problems, fmt.Sprintf("SendTo[%d] %s", i, msg))
+		}
+	}
+	if len(obj.RowIds) == 0 {
+		problems = append(problems, "RowIds is empty")
+	}
+	if len(obj.RowIds) > EmailMaxRows {
+		problems = append(problems, fmt.Sprintf("RowIds count %d exceeds limit of %d", len(obj.RowIds), EmailMaxRows))
+	}
+	if len(obj.ColumnIds) > EmailMaxColumns {
+		problems = append(problems, fmt.Sprintf("ColumnIds count %d exceeds limit of %d", len(obj.ColumnIds), EmailMaxColumns))
+	}
+	if len(problems) > 0 {
+		return errors.New("Invalid EmailRowsObj - " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// checkRecipient returns description of problem with recipient or "" if recipient is valid.
+// A recipient must contain exactly 1 key, either "email" or "groupId".
+func checkRecipient(recipient EmailRecipient) string {
+	if len(recipient) != 1 {
+		return fmt.Sprintf("must contain exactly 1 of email or groupId, has %d keys", len(recipient))
+	}
+	for key := range recipient {
+		if key != "email" && key != "groupId" {
+			return "invalid key " + key
+		}
+	}
+	return ""
+}
+
+// ValidateRecipients checks that each email recipient is one of users, matching the user's Email or a confirmed
+// alternate email (User.AlternateEmails, see ListAlternateEmails). Case is ignored. Group recipients are not checked.
+// All recipients not matched are included in the returned error.
+func ValidateRecipients(recipients []EmailRecipient, users []User) error {
+	known := make(map[string]bool, len(users))
+	for _, user := range users {
+		known[strings.ToLower(user.Email)] = true
+		for _, alternate := range user.AlternateEmails {
+			if alternate.Confirmed {
+				known[strings.ToLower(alternate.Email)] = true
+			}
+		}
+	}
+	problems := make([]string, 0, 5)
+	for i, recipient := range recipients {
+		if msg := checkRecipient(recipient); msg != "" {
+			problems = append(problems, fmt.Sprintf("SendTo[%d] %s", i, msg))
+			continue
+		}
+		if _, isGroup := recipient["groupId"]; isGroup {
+			continue
+		}
+		email, _ := recipient["email"].(string)
+		if email == "" || !known[strings.ToLower(email)] {
+			problems = append(problems, fmt.Sprintf("SendTo[%d] %v is not a user", i, recipient["email"]))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("Invalid Recipients - " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// EmailRowsByName emails sheet rows using values in EmailRowsObj parm.
+// Parm sheet is used to convert reqData.ColumnNames to ColumnIds and must contain SheetId.
+func EmailRowsByName(sheet *SheetInfo, reqData EmailRowsObj) error {
+	trace("EmailRowsByName")
+
+	if sheet.SheetId == 0 {
+		log.Println("ERROR EmailRowsByName - sheet.SheetId not set")
+		return errors.New("sheet.SheetId empty")
+	}
+	if len(reqData.ColumnNames) > 0 {
+		reqData.ColumnIds = make([]int64, len(reqData.ColumnNames))
+		for i, colName := range reqData.ColumnNames {
+			column, found := sheet.ColumnsByName[colName]
+			if !found {
+				log.Println("ERROR - EmailRowsByName column not found", sheet.SheetName, colName)
+				return fmt.Errorf("%w - %s", ErrInvalidColumnName, colName)
+			}
+			reqData.ColumnIds[i] = column.Id
+		}
+	}
+	return EmailRows(sheet.SheetId, reqData)
+}
+
 // EmailRows emails sheet rows using values in EmailRowsObj parm.
+// ReqData is validated before the request is sent, see EmailRowsObj.Validate.
 func EmailRows(sheetId int64, reqData EmailRowsObj) error {
 
+	if err := reqData.Validate(); err != nil {
+		log.Println("ERROR EmailRows", err)
+		return err
+	}
 	endPoint := fmt.Sprintf("/sheets/%d/rows/emails", sheetId)
 	req := Post(endPoint, reqData, nil)
 	req.Header.Set("Content-Type", "application/json")
@@ -53,3 +189,129 @@ func EmailRows(sheetId int64, reqData EmailRowsObj) error {
 	}
 	return nil
 }
+
+// EmailRowsBuilder assembles the EmailRowsObj of loaded rows by column name and row predicate, see SheetInfo.EmailBuilder.
+// Send splits the rows into several emails when there are more than the rows per email limit.
+type EmailRowsBuilder struct {
+	sheet        *SheetInfo
+	where        func(row Row) bool
+	columnNames  []string
+	rowsPerEmail int
+	obj          EmailRowsObj
+}
+
+// EmailBuilder returns an EmailRowsBuilder for the loaded rows of the sheet. Example:
+//
+//	sent, err := sheet.EmailBuilder().WithRowsWhere(isLate).WithColumns("Address", "DueDate").
+//		To(NewEmailRecipient("x@y.com")).Subject("Late Orders").Send()
+func (she *SheetInfo) EmailBuilder() *EmailRowsBuilder {
+	return &EmailRowsBuilder{sheet: she}
+}
+
+// WithRowsWhere selects the rows of SheetInfo.Rows to email, default is all rows.
+func (b *EmailRowsBuilder) WithRowsWhere(where func(row Row) bool) *EmailRowsBuilder {
+	b.where = where
+	return b
+}
+
+// WithColumns selects the columns included in the email by name, default is all columns.
+// Names are resolved by Send.
+func (b *EmailRowsBuilder) WithColumns(names ...string) *EmailRowsBuilder {
+	b.columnNames = append(b.columnNames, names...)
+	return b
+}
+
+// To adds recipients, see NewEmailRecipient and NewGroupRecipient.
+func (b *EmailRowsBuilder) To(recipients ...EmailRecipient) *EmailRowsBuilder {
+	b.obj.SendTo = append(b.obj.SendTo, recipients...)
+	return b
+}
+
+// Subject sets the email subject. When the rows are sent in several emails, " (n of count)" is added to the subject.
+func (b *EmailRowsBuilder) Subject(subject string) *EmailRowsBuilder {
+	b.obj.Subject = subject
+	return b
+}
+
+// Message sets the email message, sent with each email.
+func (b *EmailRowsBuilder) Message(message string) *EmailRowsBuilder {
+	b.obj.Message = message
+	return b
+}
+
+// CCMe sends a copy of each email to the token's user.
+func (b *EmailRowsBuilder) CCMe() *EmailRowsBuilder {
+	b.obj.CCMe = true
+	return b
+}
+
+// IncludeAttachments includes the row attachments in the email.
+func (b *EmailRowsBuilder) IncludeAttachments() *EmailRowsBuilder {
+	b.obj.IncludeAttachments = true
+	return b
+}
+
+// IncludeDiscussions includes the row discussions in the email.
+func (b *EmailRowsBuilder) IncludeDiscussions() *EmailRowsBuilder {
+	b.obj.IncludeDiscussions = true
+	return b
+}
+
+// RowsPerEmail sets the maximum rows in 1 email, default and maximum is EmailMaxRows.
+// Lower it when including attachments or discussions produces emails the mail server rejects as too large.
+func (b *EmailRowsBuilder) RowsPerEmail(count int) *EmailRowsBuilder {
+	b.rowsPerEmail = count
+	return b
+}
+
+// Send emails the selected rows and returns the number of emails sent. If no rows are selected, nothing is sent.
+// Recipients and column names are checked before any email is sent. If an email fails, the number sent before it
+// is returned with the error.
+func (b *EmailRowsBuilder) Send() (int, error) {
+	trace("EmailRowsBuilder.Send")
+	obj := b.obj
+	if len(obj.SendTo) == 0 {
+		log.Println("ERROR EmailRowsBuilder.Send - no recipients")
+		return 0, errors.New("Invalid EmailRowsBuilder - no recipients, see To")
+	}
+	if len(b.columnNames) > 0 {
+		obj.ColumnIds = make([]int64, len(b.columnNames))
+		for i, colName := range b.columnNames {
+			column, found := b.sheet.ColumnsByName[colName]
+			if !found {
+				log.Println("ERROR - EmailRowsBuilder.Send column not found", b.sheet.SheetName, colName)
+				return 0, fmt.Errorf("%w - %s", ErrInvalidColumnName, colName)
+			}
+			obj.ColumnIds[i] = column.Id
+		}
+	}
+	rowIds := make([]int64, 0, len(b.sheet.Rows))
+	for _, row := range b.sheet.Rows {
+		if b.where == nil || b.where(row) {
+			rowIds = append(rowIds, row.Id)
+		}
+	}
+	if len(rowIds) == 0 {
+		return 0, nil
+	}
+	chunkSize := b.rowsPerEmail
+	if chunkSize < 1 || chunkSize > EmailMaxRows {
+		chunkSize = EmailMaxRows
+	}
+	emailCount := (len(rowIds) + chunkSize - 1) / chunkSize
+	for i := 0; i < emailCount; i++ {
+		last := (i + 1) * chunkSize
+		if last > len(rowIds) {
+			last = len(rowIds)
+		}
+		obj.RowIds = rowIds[i*chunkSize : last]
+		if emailCount > 1 {
+			obj.Subject = fmt.Sprintf("%s (%d of %d)", b.obj.Subject, i+1, emailCount)
+		}
+		if err := EmailRows(b.sheet.SheetId, obj); err != nil {
+			log.Println("ERROR EmailRowsBuilder.Send email", i+1, "of", emailCount, "failed", err)
+			return i, err
+		}
+	}
+	return emailCount, nil
+}
@@ -1,7 +1,10 @@
 package smartsheet
 
 import (
+	"encoding/json"
+	"errors"
 	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 )
@@ -11,8 +14,7 @@ func Test_Email(t *testing.T) {
 
 	var err error
 
-	tkn, _ := ioutil.ReadFile("token.txt")
-	Token = strings.TrimSpace(string(tkn))
+	liveToken(t)
 
 	TraceOn = true
 	DebugOn = true
@@ -38,3 +40,192 @@ func Test_Email(t *testing.T) {
 		t.Fatal("Test_Email EmailRows Failed", err)
 	}
 }
+
+func Test_EmailRowsByName(t *testing.T) {
+	var received EmailRowsObj
+	var receivedJSON map[string]interface{}
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sheets/1849449510135684/rows/emails" {
+			t.Error("Test_EmailRowsByName wrong path", r.URL.Path)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &received)
+		json.Unmarshal(body, &receivedJSON)
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+	})
+	sheet := testSheet()
+	emailParms := EmailRowsObj{
+		SendTo:      []EmailRecipient{{"email": "txjmp19@gmail.com"}},
+		Subject:     "Test Email Rows",
+		RowIds:      []int64{1, 2},
+		ColumnNames: []string{"Address", "OrderNo"},
+	}
+	if err := EmailRowsByName(sheet, emailParms); err != nil {
+		t.Fatal("Test_EmailRowsByName Failed", err)
+	}
+	if len(received.ColumnIds) != 2 || received.ColumnIds[0] != 101 || received.ColumnIds[1] != 102 {
+		t.Error("Test_EmailRowsByName wrong ColumnIds", received.ColumnIds)
+	}
+	if _, found := receivedJSON["ColumnNames"]; found {
+		t.Error("Test_EmailRowsByName ColumnNames sent to API")
+	}
+
+	emailParms.ColumnNames = []string{"Address", "Bogus"}
+	err := EmailRowsByName(sheet, emailParms)
+	if !errors.Is(err, ErrInvalidColumnName) || !strings.Contains(err.Error(), "Bogus") {
+		t.Error("Test_EmailRowsByName expected invalid column error, got", err)
+	}
+}
+
+func Test_EmailRowsValidate(t *testing.T) {
+	tooManyRows := make([]int64, EmailMaxRows+1)
+	tooManyCols := make([]int64, EmailMaxColumns+1)
+	tests := []struct {
+		name     string
+		obj      EmailRowsObj
+		problems []string
+	}{
+		{"valid", EmailRowsObj{SendTo: []EmailRecipient{{"email": "a@b.com"}, {"groupId": 44}}, RowIds: []int64{1}}, nil},
+		{"no sendTo", EmailRowsObj{RowIds: []int64{1}}, []string{"SendTo is empty"}},
+		{"no rows", EmailRowsObj{SendTo: []EmailRecipient{{"email": "a@b.com"}}}, []string{"RowIds is empty"}},
+		{"too many rows", EmailRowsObj{SendTo: []EmailRecipient{{"email": "a@b.com"}}, RowIds: tooManyRows}, []string{"RowIds count"}},
+		{"too many columns", EmailRowsObj{SendTo: []EmailRecipient{{"email": "a@b.com"}}, RowIds: []int64{1}, ColumnIds: tooManyCols}, []string{"ColumnIds count"}},
+		{"both keys", EmailRowsObj{SendTo: []EmailRecipient{{"email": "a@b.com", "groupId": 44}}, RowIds: []int64{1}}, []string{"SendTo[0] must contain exactly 1"}},
+		{"empty recipient", EmailRowsObj{SendTo: []EmailRecipient{{}}, RowIds: []int64{1}}, []string{"SendTo[0] must contain exactly 1"}},
+		{"bad key", EmailRowsObj{SendTo: []EmailRecipient{{"email": "a@b.com"}, {"emial": "c@d.com"}}, RowIds: []int64{1}}, []string{"SendTo[1] invalid key emial"}},
+		{"all problems", EmailRowsObj{SendTo: []EmailRecipient{{"emial": "a@b.com"}}, ColumnIds: tooManyCols}, []string{"SendTo[0] invalid key", "RowIds is empty", "ColumnIds count"}},
+	}
+	for _, test := range tests {
+		err := test.obj.Validate()
+		if len(test.problems) == 0 {
+			if err != nil {
+				t.Error(test.name, "unexpected error", err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Error(test.name, "expected error")
+			continue
+		}
+		for _, problem := range test.problems {
+			if !strings.Contains(err.Error(), problem) {
+				t.Error(test.name, "error missing", problem, "-", err)
+			}
+		}
+	}
+}
+
+func Test_EmailRecipient(t *testing.T) {
+	sendTo := Recipients(NewEmailRecipient("x@y.com"), NewGroupRecipient(4583173393803140))
+	jsonData, err := json.Marshal(sendTo)
+	if err != nil {
+		t.Fatal("Test_EmailRecipient Marshal Failed", err)
+	}
+	expect := `[{"email":"x@y.com"},{"groupId":4583173393803140}]`
+	if string(jsonData) != expect {
+		t.Errorf("Test_EmailRecipient Expecting %s, Got %s", expect, jsonData)
+	}
+
+	invalid := []EmailRecipient{
+		{"emial": "x@y.com"},
+		{"email": "x@y.com", "groupId": 4583173393803140},
+		{},
+	}
+	for _, recipient := range invalid {
+		if _, err = json.Marshal(recipient); err == nil {
+			t.Error("Test_EmailRecipient Marshal expected error", recipient)
+		}
+	}
+}
+
+func Test_ValidateRecipients(t *testing.T) {
+	users := []User{
+		{Id: 1, Email: "ann.lee@corp.com"},
+		{Id: 2, Email: "bob@login.corp.com", AlternateEmails: []AlternateEmail{
+			{Id: 21, Email: "Bob.Smith@corp.com", Confirmed: true},
+			{Id: 22, Email: "bsmith@old.corp.com", Confirmed: false},
+		}},
+	}
+	valid := Recipients(
+		NewEmailRecipient("ann.lee@corp.com"),
+		NewEmailRecipient("bob.smith@corp.com"), // confirmed alternate email, case ignored
+		NewGroupRecipient(4583173393803140),
+	)
+	if err := ValidateRecipients(valid, users); err != nil {
+		t.Error("ValidateRecipients unexpected error", err)
+	}
+
+	invalid := Recipients(
+		NewEmailRecipient("bsmith@old.corp.com"), // unconfirmed alternate email
+		NewEmailRecipient("ann.lee@corp.com"),
+		NewEmailRecipient("nobody@corp.com"),
+		EmailRecipient{"emial": "ann.lee@corp.com"},
+	)
+	err := ValidateRecipients(invalid, users)
+	if err == nil {
+		t.Fatal("ValidateRecipients expected error")
+	}
+	for _, problem := range []string{"SendTo[0] bsmith@old.corp.com", "SendTo[2] nobody@corp.com", "SendTo[3] invalid key emial"} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Error("ValidateRecipients error missing", problem, "-", err)
+		}
+	}
+	if strings.Contains(err.Error(), "SendTo[1]") {
+		t.Error("ValidateRecipients SendTo[1] is a user -", err)
+	}
+}
+
+func Test_EmailRowsBuilder(t *testing.T) {
+	var sent []EmailRowsObj
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var obj EmailRowsObj
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &obj)
+		sent = append(sent, obj)
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+	})
+	sheet := testSheet()
+	for id := int64(1); id <= 250; id++ {
+		sheet.Rows = append(sheet.Rows, Row{Id: id})
+	}
+	even := func(row Row) bool { return row.Id%2 == 0 }
+
+	count, err := sheet.EmailBuilder().WithRowsWhere(even).WithColumns("Address", "DueDate").
+		To(NewEmailRecipient("x@y.com")).Subject("Orders").Message("See rows").Send()
+	if err != nil || count != 2 || len(sent) != 2 {
+		t.Fatal("EmailRowsBuilder.Send expected 2 emails, got", count, len(sent), err)
+	}
+	if len(sent[0].RowIds) != 100 || len(sent[1].RowIds) != 25 || sent[1].RowIds[24] != 250 || sent[0].RowIds[0] != 2 {
+		t.Error("EmailRowsBuilder.Send wrong chunks", len(sent[0].RowIds), len(sent[1].RowIds))
+	}
+	if sent[1].Subject != "Orders (2 of 2)" || sent[1].Message != "See rows" || len(sent[1].ColumnIds) != 2 || sent[1].ColumnIds[1] != 103 {
+		t.Error("EmailRowsBuilder.Send wrong layout", sent[1].Subject, sent[1].Message, sent[1].ColumnIds)
+	}
+
+	sent = nil
+	count, err = sheet.EmailBuilder().To(NewGroupRecipient(5)).IncludeAttachments().RowsPerEmail(200).Send()
+	if err != nil || count != 3 || len(sent[0].RowIds) != 100 || !sent[0].IncludeAttachments {
+		t.Error("EmailRowsBuilder.Send expected RowsPerEmail limited to EmailMaxRows, got", count, err)
+	}
+	sent = nil
+	count, err = sheet.EmailBuilder().To(NewGroupRecipient(5)).RowsPerEmail(50).Subject("All").Send()
+	if err != nil || count != 5 || sent[4].Subject != "All (5 of 5)" {
+		t.Error("EmailRowsBuilder.Send expected 5 emails of 50 rows, got", count, err)
+	}
+
+	// nothing sent
+	sent = nil
+	none := func(row Row) bool { return false }
+	if count, err = sheet.EmailBuilder().WithRowsWhere(none).To(NewEmailRecipient("x@y.com")).Send(); err != nil || count != 0 {
+		t.Error("EmailRowsBuilder.Send expected no email for 0 rows, got", count, err)
+	}
+	if _, err = sheet.EmailBuilder().WithRowsWhere(even).Send(); err == nil || !strings.Contains(err.Error(), "no recipients") {
+		t.Error("EmailRowsBuilder.Send expected no recipients error, got", err)
+	}
+	if _, err = sheet.EmailBuilder().WithColumns("Bogus").To(NewEmailRecipient("x@y.com")).Send(); !errors.Is(err, ErrInvalidColumnName) || !strings.Contains(err.Error(), "Bogus") {
+		t.Error("EmailRowsBuilder.Send expected ErrInvalidColumnName, got", err)
+	}
+	if len(sent) != 0 {
+		t.Error("EmailRowsBuilder.Send expected no requests, got", len(sent))
+	}
+}
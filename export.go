@@ -0,0 +1,380 @@
+// export.go contains SheetInfo methods writing loaded rows in other formats, without requesting the sheet again.
+// Rows are written in RowNumber order.
+
+package smartsheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CSVOptions is used by SheetInfo.WriteCSV.
+type CSVOptions struct {
+	Columns               []string      // column titles in output order, default is all columns in sheet order
+	DateFormat            string        // time layout for date column values, ex. "01/02/2006", default is value unchanged
+	TrueValue, FalseValue string        // used for bool values (ex. CHECKBOX), default "true" and "false"
+	DisplayValues         bool          // use Cell.DisplayValue (as shown in Smartsheet UI) when returned by api
+	HyperlinkURLs         bool          // write the url of hyperlink cells (see Hyperlink.Target), default is the cell text
+	LevelColumn           string        // if set, a 1st column with this title contains each row's hierarchy level (0 is top level)
+	Format                ExportOptions // delimiter, line ending and byte order mark
+}
+
+// ExportOptions changes the csv format written by SheetInfo.WriteCSV, GetSheetAsCSV and ConvertCSV.
+// Ex. Excel in European locales expects ';' delimiters, some tools require a byte order mark to detect utf-8.
+type ExportOptions struct {
+	AddBOM     bool   // begin output with the utf-8 byte order mark
+	Delimiter  rune   // field separator, default ','
+	LineEnding string // "\n" (default) or "\r\n"
+}
+
+const utf8BOM = "\xEF\xBB\xBF"
+
+// newWriter writes the byte order mark if requested and returns a csv.Writer using the options.
+func (opts ExportOptions) newWriter(w io.Writer) (*csv.Writer, error) {
+	if opts.LineEnding != "" && opts.LineEnding != "\n" && opts.LineEnding != "\r\n" {
+		return nil, fmt.Errorf("Invalid ExportOptions - LineEnding %q, use \"\\n\" or \"\\r\\n\"", opts.LineEnding)
+	}
+	if opts.Delimiter == '"' || opts.Delimiter == '\r' || opts.Delimiter == '\n' || opts.Delimiter == utf8.RuneError {
+		return nil, fmt.Errorf("Invalid ExportOptions - Delimiter %q", opts.Delimiter)
+	}
+	csvWriter := csv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		csvWriter.Comma = opts.Delimiter
+	}
+	csvWriter.UseCRLF = opts.LineEnding == "\r\n"
+	if opts.AddBOM {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return nil, err
+		}
+	}
+	return csvWriter, nil
+}
+
+// ConvertCSV copies csv data from src to dst using opts, 1 record at a time (src is not fully read into memory).
+// Fields are quoted as needed for the new delimiter. A byte order mark at the start of src is removed.
+func ConvertCSV(dst io.Writer, src io.Reader, opts ExportOptions) error {
+	trace("ConvertCSV")
+	buffered := bufio.NewReader(src)
+	if start, err := buffered.Peek(len(utf8BOM)); err == nil && string(start) == utf8BOM {
+		buffered.Discard(len(utf8BOM))
+	}
+	csvReader := csv.NewReader(buffered)
+	csvReader.FieldsPerRecord = -1 // rows may have different field counts
+	csvWriter, err := opts.newWriter(dst)
+	if err != nil {
+		log.Println("ERROR - ConvertCSV", err)
+		return err
+	}
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Println("ERROR - ConvertCSV Read Failed", err)
+			return err
+		}
+		if err = csvWriter.Write(record); err != nil {
+			log.Println("ERROR - ConvertCSV Write Failed", err)
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// WriteCSV writes SheetInfo.Rows to w in csv format, 1st line is column titles.
+func (she *SheetInfo) WriteCSV(w io.Writer, opts CSVOptions) error {
+	trace("SheetInfo.WriteCSV")
+	columns, err := she.exportColumns(opts.Columns)
+	if err != nil {
+		log.Println("ERROR - SheetInfo.WriteCSV", err)
+		return err
+	}
+	if opts.TrueValue == "" {
+		opts.TrueValue = "true"
+	}
+	if opts.FalseValue == "" {
+		opts.FalseValue = "false"
+	}
+	csvWriter, err := opts.Format.newWriter(w)
+	if err != nil {
+		log.Println("ERROR - SheetInfo.WriteCSV", err)
+		return err
+	}
+
+	record := make([]string, 0, len(columns)+1)
+	if opts.LevelColumn != "" {
+		record = append(record, opts.LevelColumn)
+	}
+	for _, column := range columns {
+		record = append(record, column.Title)
+	}
+	csvWriter.Write(record)
+
+	rows := sortedRows(she.Rows)
+	levels := rowLevels(rows)
+	for _, row := range rows {
+		cells := make(map[int64]Cell, len(row.Cells))
+		for _, cell := range row.Cells {
+			cells[cell.ColumnId] = cell
+		}
+		record = record[:0]
+		if opts.LevelColumn != "" {
+			record = append(record, strconv.Itoa(levels[row.Id]))
+		}
+		for _, column := range columns {
+			record = append(record, csvValue(cells[column.Id], column, &opts))
+		}
+		csvWriter.Write(record)
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// JSONLOptions is used by SheetInfo.WriteJSONL.
+type JSONLOptions struct {
+	Columns        []string // column titles in output order, default is all columns in sheet order
+	NormalizeDates bool     // write date column values in RFC3339 format, ex. DATE "2020-10-10" is "2020-10-10T00:00:00Z"
+	Metadata       bool     // include _rowId, _rowNumber and _modifiedAt (null if not returned by api) before the column values
+	OmitEmpty      bool     // omit columns with no value, default writes null
+	HyperlinkURLs  bool     // write the url of hyperlink cells (see Hyperlink.Target), default is the cell text
+}
+
+// WriteJSONL writes SheetInfo.Rows to w in JSON Lines format, 1 object per row keyed by column title.
+// Values keep their json type (number, bool, string). Each row is written as it is encoded.
+func (she *SheetInfo) WriteJSONL(w io.Writer, opts JSONLOptions) error {
+	trace("SheetInfo.WriteJSONL")
+	columns, err := she.exportColumns(opts.Columns)
+	if err != nil {
+		log.Println("ERROR - SheetInfo.WriteJSONL", err)
+		return err
+	}
+	var buf bytes.Buffer // reused for each row
+	for _, row := range sortedRows(she.Rows) {
+		buf.Reset()
+		buf.WriteByte('{')
+		writeJSONRowFields(&buf, row, columns, &opts)
+		buf.WriteString("}\n")
+		if _, err = w.Write(buf.Bytes()); err != nil {
+			log.Println("ERROR - SheetInfo.WriteJSONL", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJSONRowFields appends the metadata (if requested) and column values of row to buf, see JSONLOptions.
+func writeJSONRowFields(buf *bytes.Buffer, row Row, columns []Column, opts *JSONLOptions) {
+	cells := make(map[int64]Cell, len(row.Cells))
+	for _, cell := range row.Cells {
+		cells[cell.ColumnId] = cell
+	}
+	if opts.Metadata {
+		var modifiedAt interface{}
+		if row.ModifiedAt != "" {
+			modifiedAt = row.ModifiedAt
+		}
+		writeJSONField(buf, "_rowId", row.Id)
+		writeJSONField(buf, "_rowNumber", row.RowNumber)
+		writeJSONField(buf, "_modifiedAt", modifiedAt)
+	}
+	for _, column := range columns {
+		value := jsonlValue(cells[column.Id], column, opts)
+		if value == nil && opts.OmitEmpty {
+			continue
+		}
+		writeJSONField(buf, column.Title, value)
+	}
+}
+
+// NestedJSONOptions is used by SheetInfo.WriteNestedJSON.
+type NestedJSONOptions struct {
+	JSONLOptions        // columns and value typing, same as WriteJSONL
+	ChildrenKey  string // key of each row's array of child rows, default "children"
+	Indent       string // if set, output is indented using this string, ex. "  ", default is compact
+}
+
+// ErrRowCycle is wrapped by the error returned by WriteNestedJSON when row parent ids form a cycle.
+var ErrRowCycle = errors.New("Row Parent Cycle")
+
+// WriteNestedJSON writes SheetInfo.Rows to w as a json array of the top level rows, each row an object keyed by
+// column title (as WriteJSONL) followed by an array of its child rows (empty if none), recursively.
+// A row whose parent is not in Rows (ex. excluded by GetSheetOptions) is written at top level, as Render does.
+// If parent ids form a cycle (corrupt data), nothing is written and the error wraps ErrRowCycle, listing the rows.
+func (she *SheetInfo) WriteNestedJSON(w io.Writer, opts NestedJSONOptions) error {
+	trace("SheetInfo.WriteNestedJSON")
+	columns, err := she.exportColumns(opts.Columns)
+	if err != nil {
+		log.Println("ERROR - SheetInfo.WriteNestedJSON", err)
+		return err
+	}
+	top, children, err := rowTree(sortedRows(she.Rows))
+	if err != nil {
+		log.Println("ERROR - SheetInfo.WriteNestedJSON", she.SheetName, err)
+		return err
+	}
+	if opts.ChildrenKey == "" {
+		opts.ChildrenKey = "children"
+	}
+	var rowObjects func(rows []Row) json.RawMessage
+	rowObjects = func(rows []Row) json.RawMessage {
+		var list bytes.Buffer
+		list.WriteByte('[')
+		for i, row := range rows {
+			if i > 0 {
+				list.WriteByte(',')
+			}
+			var buf bytes.Buffer
+			buf.WriteByte('{')
+			writeJSONRowFields(&buf, row, columns, &opts.JSONLOptions)
+			writeJSONField(&buf, opts.ChildrenKey, rowObjects(children[row.Id]))
+			buf.WriteByte('}')
+			list.Write(buf.Bytes())
+		}
+		list.WriteByte(']')
+		return list.Bytes()
+	}
+	output := []byte(rowObjects(top))
+	if opts.Indent != "" {
+		var indented bytes.Buffer
+		if err = json.Indent(&indented, output, "", opts.Indent); err != nil {
+			return err
+		}
+		output = indented.Bytes()
+	}
+	if _, err = w.Write(append(output, '\n')); err != nil {
+		log.Println("ERROR - SheetInfo.WriteNestedJSON", err)
+		return err
+	}
+	return nil
+}
+
+// rowTree returns the top level rows and the child rows of each row (key is parent id), both in rows order.
+// A row whose parent is not in rows is top level. Rows not reachable from a top level row have parent ids forming
+// a cycle, an error wrapping ErrRowCycle listing them is returned.
+func rowTree(rows []Row) ([]Row, map[int64][]Row, error) {
+	loaded := make(map[int64]bool, len(rows))
+	for _, row := range rows {
+		loaded[row.Id] = true
+	}
+	top := make([]Row, 0)
+	children := make(map[int64][]Row)
+	for _, row := range rows {
+		if row.ParentId == 0 || !loaded[row.ParentId] {
+			top = append(top, row)
+		} else {
+			children[row.ParentId] = append(children[row.ParentId], row)
+		}
+	}
+	// each row has 1 parent, so walking down from the top level rows cannot loop
+	reached := make(map[int64]bool, len(rows))
+	pending := make([]Row, len(top))
+	copy(pending, top)
+	for len(pending) > 0 {
+		row := pending[len(pending)-1]
+		pending = append(pending[:len(pending)-1], children[row.Id]...)
+		reached[row.Id] = true
+	}
+	if len(reached) < len(rows) {
+		cycle := make([]string, 0)
+		for _, row := range rows {
+			if !reached[row.Id] {
+				cycle = append(cycle, strconv.FormatInt(row.Id, 10))
+			}
+		}
+		return nil, nil, fmt.Errorf("%w - rowIds %s", ErrRowCycle, strings.Join(cycle, ", "))
+	}
+	return top, children, nil
+}
+
+// writeJSONField appends "key":value to buf, preceded by a comma if buf contains other fields.
+// Fields are written in order, unlike marshaling a map (keys sorted).
+func writeJSONField(buf *bytes.Buffer, key string, value interface{}) {
+	if buf.Len() > 1 {
+		buf.WriteByte(',')
+	}
+	keyJSON, _ := json.Marshal(key)
+	valueJSON, err := json.Marshal(value)
+	if err != nil { // not expected, cell values are decoded from json
+		valueJSON, _ = json.Marshal(fmt.Sprintf("%v", value))
+	}
+	buf.Write(keyJSON)
+	buf.WriteByte(':')
+	buf.Write(valueJSON)
+}
+
+// jsonlValue returns a cell value based on JSONLOptions, nil if cell has no value.
+func jsonlValue(cell Cell, column Column, opts *JSONLOptions) interface{} {
+	if opts.HyperlinkURLs && cell.Hyperlink != nil && cell.Hyperlink.Target() != "" {
+		return cell.Hyperlink.Target()
+	}
+	value, ok := cell.Value.(string)
+	if !ok || !opts.NormalizeDates || !isDateColumn(column) {
+		return cell.Value
+	}
+	if t, err := ParseCellTime(cell, column); err == nil && !t.IsZero() {
+		return t.Format(time.RFC3339)
+	}
+	return value
+}
+
+// csvValue returns a cell value formatted based on its type and CSVOptions.
+func csvValue(cell Cell, column Column, opts *CSVOptions) string {
+	if opts.HyperlinkURLs && cell.Hyperlink != nil && cell.Hyperlink.Target() != "" {
+		return cell.Hyperlink.Target()
+	}
+	if opts.DisplayValues && cell.DisplayValue != "" {
+		return cell.DisplayValue
+	}
+	switch value := cell.Value.(type) {
+	case nil:
+		return ""
+	case bool:
+		if value {
+			return opts.TrueValue
+		}
+		return opts.FalseValue
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64) // %v would use exponent for large numbers
+	case string:
+		if opts.DateFormat != "" && isDateColumn(column) {
+			if t, err := ParseCellTime(cell, column); err == nil {
+				return t.Format(opts.DateFormat)
+			}
+		}
+		return value
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// exportColumns returns the columns matching titles, or all columns in sheet order if titles is empty.
+func (she *SheetInfo) exportColumns(titles []string) ([]Column, error) {
+	if len(titles) == 0 {
+		return she.columnList(), nil
+	}
+	columns := make([]Column, len(titles))
+	for i, title := range titles {
+		column, found := she.ColumnsByName[title]
+		if !found {
+			return nil, fmt.Errorf("%w - %s", ErrInvalidColumnName, title)
+		}
+		columns[i] = column
+	}
+	return columns, nil
+}
+
+func isDateColumn(column Column) bool {
+	return column.Type == DATE || column.Type == DATETIME || column.Type == ABSTRACTDATETIME
+}
@@ -0,0 +1,203 @@
+package smartsheet
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// exportSheet returns testSheet with rows covering the value types and a 2 level hierarchy, rows out of order.
+func exportSheet() *SheetInfo {
+	sheet := testSheet()
+	sheet.Rows = []Row{
+		{Id: 12, ParentId: 11, RowNumber: 2, Cells: []Cell{
+			{ColumnId: 101, Value: `12 "B" St, Apt 4`},
+			{ColumnId: 102, Value: 1234567.0, DisplayValue: "1,234,567"},
+			{ColumnId: 106, Value: false},
+			{ColumnId: 109, Value: "cheepcode", Hyperlink: &Hyperlink{Url: "https://cheepcode.com"}},
+		}},
+		{Id: 11, RowNumber: 1, Cells: []Cell{
+			{ColumnId: 101, Value: "1200 Canton Road"},
+			{ColumnId: 102, Value: "A-100"},
+			{ColumnId: 103, Value: "2024-03-01"},
+			{ColumnId: 104, Value: "Gas"},
+			{ColumnId: 105, Value: 74.2, DisplayValue: "$74.20"},
+			{ColumnId: 106, Value: true},
+			{ColumnId: 108, Value: "Green"},
+		}},
+		{Id: 13, RowNumber: 3, Cells: []Cell{
+			{ColumnId: 101, Value: "Line 1\nLine 2"},
+			{ColumnId: 103, Value: "not a date"},
+		}},
+	}
+	return sheet
+}
+
+func Test_WriteCSV(t *testing.T) {
+	tests := []struct {
+		golden string
+		opts   CSVOptions
+	}{
+		{"testdata/export.csv", CSVOptions{}},
+		{"testdata/export_options.csv", CSVOptions{
+			Columns:       []string{"Complete", "Address", "Amt", "DueDate", "OrderNo"},
+			DateFormat:    "01/02/2006",
+			TrueValue:     "Y",
+			FalseValue:    "N",
+			DisplayValues: true,
+			LevelColumn:   "RowLevel",
+		}},
+		{"testdata/export_bom_semicolon.csv", CSVOptions{Format: ExportOptions{AddBOM: true, Delimiter: ';'}}},
+	}
+	for _, test := range tests {
+		var buf strings.Builder
+		if err := exportSheet().WriteCSV(&buf, test.opts); err != nil {
+			t.Fatal("WriteCSV Failed", err)
+		}
+		golden, _ := ioutil.ReadFile(test.golden)
+		if buf.String() != string(golden) {
+			t.Errorf("WriteCSV %s Expecting:\n%s\nGot:\n%s", test.golden, golden, buf.String())
+		}
+	}
+
+	err := exportSheet().WriteCSV(ioutil.Discard, CSVOptions{Columns: []string{"Address", "Bogus"}})
+	if !errors.Is(err, ErrInvalidColumnName) {
+		t.Error("WriteCSV expected ErrInvalidColumnName, got", err)
+	}
+}
+
+// Test_ConvertCSV converts testdata/convert.csv (api csv with byte order mark and CRLF line endings).
+func Test_ConvertCSV(t *testing.T) {
+	tests := []struct {
+		golden string
+		opts   ExportOptions
+	}{
+		{"testdata/convert_bom_semicolon.csv", ExportOptions{AddBOM: true, Delimiter: ';'}},
+		{"testdata/convert_semicolon_crlf.csv", ExportOptions{Delimiter: ';', LineEnding: "\r\n"}},
+		{"testdata/convert_bom_crlf.csv", ExportOptions{AddBOM: true, LineEnding: "\r\n"}},
+	}
+	for _, test := range tests {
+		src, _ := os.Open("testdata/convert.csv")
+		var buf strings.Builder
+		err := ConvertCSV(&buf, src, test.opts)
+		src.Close()
+		if err != nil {
+			t.Fatal("ConvertCSV Failed", err)
+		}
+		golden, _ := ioutil.ReadFile(test.golden)
+		if buf.String() != string(golden) {
+			t.Errorf("ConvertCSV %s Expecting:\n%q\nGot:\n%q", test.golden, golden, buf.String())
+		}
+	}
+
+	invalid := []ExportOptions{{LineEnding: "\r"}, {Delimiter: '"'}, {Delimiter: '\n'}}
+	for _, opts := range invalid {
+		if err := ConvertCSV(ioutil.Discard, strings.NewReader("a,b\n"), opts); err == nil {
+			t.Errorf("ConvertCSV expected error for %+v", opts)
+		}
+	}
+
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/csv" {
+			t.Error("GetSheetAsCSV wrong Accept header", r.Header.Get("Accept"))
+		}
+		http.ServeFile(w, r, "testdata/convert.csv")
+	})
+	filePath := filepath.Join(t.TempDir(), "sheet.csv")
+	if err := GetSheetAsCSV(1849449510135684, filePath, ExportOptions{AddBOM: true, Delimiter: ';'}); err != nil {
+		t.Fatal("GetSheetAsCSV Failed", err)
+	}
+	got, _ := ioutil.ReadFile(filePath)
+	golden, _ := ioutil.ReadFile("testdata/convert_bom_semicolon.csv")
+	if string(got) != string(golden) {
+		t.Errorf("GetSheetAsCSV Expecting:\n%q\nGot:\n%q", golden, got)
+	}
+}
+
+func Test_WriteJSONL(t *testing.T) {
+	tests := []struct {
+		golden string
+		opts   JSONLOptions
+	}{
+		{"testdata/export.jsonl", JSONLOptions{Columns: []string{"Address", "OrderNo", "DueDate", "Amt", "Complete"}}},
+		{"testdata/export_options.jsonl", JSONLOptions{
+			Columns:        []string{"Address", "OrderNo", "DueDate", "Amt", "Complete"},
+			NormalizeDates: true,
+			Metadata:       true,
+			OmitEmpty:      true,
+		}},
+	}
+	for _, test := range tests {
+		sheet := exportSheet()
+		sheet.Rows[1].ModifiedAt = "2024-03-02T09:15:30Z"
+		var buf strings.Builder
+		if err := sheet.WriteJSONL(&buf, test.opts); err != nil {
+			t.Fatal("WriteJSONL Failed", err)
+		}
+		golden, _ := ioutil.ReadFile(test.golden)
+		if buf.String() != string(golden) {
+			t.Errorf("WriteJSONL %s Expecting:\n%s\nGot:\n%s", test.golden, golden, buf.String())
+		}
+	}
+}
+
+func Test_ExportHyperlinks(t *testing.T) {
+	var csvText, jsonl strings.Builder
+	exportSheet().WriteCSV(&csvText, CSVOptions{Columns: []string{"Hyperlink"}})
+	exportSheet().WriteJSONL(&jsonl, JSONLOptions{Columns: []string{"Hyperlink"}, OmitEmpty: true})
+	if csvText.String() != "Hyperlink\n\ncheepcode\n\n" || !strings.Contains(jsonl.String(), `{"Hyperlink":"cheepcode"}`) {
+		t.Errorf("Export expected hyperlink cell text, got %q %q", csvText.String(), jsonl.String())
+	}
+
+	var csvURL, jsonlURL strings.Builder
+	exportSheet().WriteCSV(&csvURL, CSVOptions{Columns: []string{"Hyperlink"}, HyperlinkURLs: true})
+	exportSheet().WriteJSONL(&jsonlURL, JSONLOptions{Columns: []string{"Hyperlink"}, OmitEmpty: true, HyperlinkURLs: true})
+	if csvURL.String() != "Hyperlink\n\nhttps://cheepcode.com\n\n" || !strings.Contains(jsonlURL.String(), `{"Hyperlink":"https://cheepcode.com"}`) {
+		t.Errorf("Export HyperlinkURLs expected url, got %q %q", csvURL.String(), jsonlURL.String())
+	}
+}
+
+func Test_WriteNestedJSON(t *testing.T) {
+	sheet := testSheet()
+	row := func(id, parentId int64, rowNumber int, address string, amt interface{}) Row {
+		return Row{Id: id, ParentId: parentId, RowNumber: rowNumber, Cells: []Cell{{ColumnId: 101, Value: address}, {ColumnId: 105, Value: amt}}}
+	}
+	sheet.Rows = []Row{ // not in RowNumber order
+		row(5, 0, 5, "Region B", nil),
+		row(1, 0, 1, "Region A", 300.0),
+		row(2, 1, 2, "Store 1", 200.0),
+		row(3, 2, 3, "Dept 10", 125.5),
+		row(4, 1, 4, "Store 2", 100.0),
+		row(6, 99, 6, "Orphan", 1.0), // parent not loaded, written at top level
+	}
+	opts := NestedJSONOptions{JSONLOptions: JSONLOptions{Columns: []string{"Address", "Amt"}, Metadata: true, OmitEmpty: true}, Indent: "  "}
+	var buf strings.Builder
+	if err := sheet.WriteNestedJSON(&buf, opts); err != nil {
+		t.Fatal("WriteNestedJSON Failed", err)
+	}
+	golden, _ := ioutil.ReadFile("testdata/export_nested.json")
+	if buf.String() != string(golden) {
+		t.Errorf("WriteNestedJSON Expecting:\n%s\nGot:\n%s", golden, buf.String())
+	}
+
+	buf.Reset()
+	opts = NestedJSONOptions{JSONLOptions: JSONLOptions{Columns: []string{"Address"}}, ChildrenKey: "items"}
+	sheet.Rows = sheet.Rows[1:4]
+	sheet.WriteNestedJSON(&buf, opts)
+	expect := `[{"Address":"Region A","items":[{"Address":"Store 1","items":[{"Address":"Dept 10","items":[]}]}]}]` + "\n"
+	if buf.String() != expect {
+		t.Errorf("WriteNestedJSON compact, Expecting %s, Got %s", expect, buf.String())
+	}
+
+	// 7 and 8 are each other's parent
+	buf.Reset()
+	sheet.Rows = append(sheet.Rows, row(7, 8, 5, "Loop A", nil), row(8, 7, 6, "Loop B", nil))
+	err := sheet.WriteNestedJSON(&buf, opts)
+	if !errors.Is(err, ErrRowCycle) || !strings.Contains(err.Error(), "rowIds 7, 8") || buf.Len() != 0 {
+		t.Error("WriteNestedJSON expected ErrRowCycle listing rows 7 and 8 and nothing written, got", err, buf.String())
+	}
+}
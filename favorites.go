@@ -0,0 +1,105 @@
+// favorites.go contains funcs for listing, adding and removing the user's favorites.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+)
+
+// Favorite Types
+const (
+	FavoriteSheet     = "sheet"
+	FavoriteReport    = "report"
+	FavoriteWorkspace = "workspace"
+	FavoriteFolder    = "folder"
+	FavoriteSight     = "sight"
+	FavoriteTemplate  = "template"
+)
+
+// FavoriteItem identifies an object marked as a favorite.
+type FavoriteItem struct {
+	Type     string `json:"type"` // use Favorite Type constants, ex. FavoriteSheet
+	ObjectId int64  `json:"objectId"`
+}
+
+// ListFavorites returns all items marked as favorites by the user.
+func ListFavorites() ([]FavoriteItem, error) {
+	trace("ListFavorites")
+	favorites := make([]FavoriteItem, 0, 20)
+	err := getAllPages("/favorites", nil, func(data json.RawMessage) error {
+		var page []FavoriteItem
+		err := json.Unmarshal(data, &page)
+		favorites = append(favorites, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return favorites, nil
+}
+
+// AddFavorites marks items as favorites.
+// Items already marked as favorites are ignored (no error), they are not included in the returned items.
+func AddFavorites(items []FavoriteItem) ([]FavoriteItem, error) {
+	trace("AddFavorites")
+	if len(items) == 0 {
+		log.Println("AddFavorites - No Items Specified")
+		return nil, nil
+	}
+	for _, item := range items {
+		if !validFavoriteType(item.Type) {
+			log.Println("ERROR AddFavorites invalid type", item.Type)
+			return nil, errors.New("Invalid Favorite Type - " + item.Type)
+		}
+	}
+	req := Post("/favorites", items, nil) // always send array, api ignores existing favorites in an array
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		var apiErr *ApiError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode == errCodeAlreadyExists {
+			return []FavoriteItem{}, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+	var apiResp struct {
+		Message    string         `json:"message"`
+		ResultCode int            `json:"resultCode"`
+		Result     []FavoriteItem `json:"result"`
+	}
+	err = json.Unmarshal(respJSON, &apiResp)
+	if err != nil {
+		log.Println("ERROR AddFavorites Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return apiResp.Result, nil
+}
+
+// RemoveFavorite removes the favorite mark from 1 object.
+// Parm objectType uses Favorite Type constants, ex. FavoriteSheet.
+func RemoveFavorite(objectType string, objectId int64) error {
+	trace("RemoveFavorite")
+	if !validFavoriteType(objectType) {
+		log.Println("ERROR RemoveFavorite invalid type", objectType)
+		return errors.New("Invalid Favorite Type - " + objectType)
+	}
+	endPoint := fmt.Sprintf("/favorites/%s/%d", objectType, objectId)
+	return deleteObject(endPoint)
+}
+
+func validFavoriteType(objectType string) bool {
+	switch objectType {
+	case FavoriteSheet, FavoriteReport, FavoriteWorkspace, FavoriteFolder, FavoriteSight, FavoriteTemplate:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package smartsheet
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func Test_Favorites(t *testing.T) {
+	var body, lastPath string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		lastPath = r.Method + " " + r.URL.Path
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"type":"sheet","objectId":1849449510135684},{"type":"workspace","objectId":77}]}`))
+		case "POST":
+			reqBytes, _ := ioutil.ReadAll(r.Body)
+			body = compactJSON(reqBytes)
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[{"type":"sheet","objectId":8094487248430980}]}`))
+		case "DELETE":
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+		}
+	})
+
+	favorites, err := ListFavorites()
+	if err != nil || len(favorites) != 2 || favorites[1].Type != FavoriteWorkspace || favorites[1].ObjectId != 77 {
+		t.Errorf("ListFavorites wrong result %+v %v", favorites, err)
+	}
+
+	items := []FavoriteItem{
+		{Type: FavoriteSheet, ObjectId: 1849449510135684}, // already a favorite, api omits from result
+		{Type: FavoriteSheet, ObjectId: 8094487248430980},
+	}
+	added, err := AddFavorites(items)
+	if err != nil || len(added) != 1 {
+		t.Errorf("AddFavorites wrong result %+v %v", added, err)
+	}
+	expect := `[{"type":"sheet","objectId":1849449510135684},{"type":"sheet","objectId":8094487248430980}]`
+	if body != expect {
+		t.Errorf("AddFavorites Expecting %s, Got %s", expect, body)
+	}
+	if _, err = AddFavorites([]FavoriteItem{{Type: "sheets", ObjectId: 1}}); err == nil {
+		t.Error("AddFavorites expected invalid type error")
+	}
+
+	if err = RemoveFavorite(FavoriteSheet, 8094487248430980); err != nil || lastPath != "DELETE /favorites/sheet/8094487248430980" {
+		t.Error("RemoveFavorite wrong result", lastPath, err)
+	}
+}
@@ -0,0 +1,46 @@
+// filters.go contains ListSheetFilters func.
+// Filters are created in the Smartsheet UI, use GetSheetOptions.FilterId to load the rows a filter shows.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type SheetFilter struct {
+	Id         int64            `json:"id"`
+	Name       string           `json:"name"`
+	FilterType string           `json:"filterType"` // "PERSONAL" or "SHARED"
+	Query      SheetFilterQuery `json:"query"`
+	Version    int              `json:"version"`
+}
+
+type SheetFilterQuery struct {
+	Operator      string                `json:"operator"` // how criteria are combined, "AND" or "OR"
+	IncludeParent bool                  `json:"includeParent"`
+	Criteria      []SheetFilterCriteria `json:"criteria"`
+}
+
+type SheetFilterCriteria struct {
+	ColumnId int64         `json:"columnId"`
+	Operator string        `json:"operator"` // ex. "EQUAL", "CONTAINS", "IS_BLANK"
+	Values   []interface{} `json:"values"`
+}
+
+// ListSheetFilters returns the filters defined for a sheet.
+func ListSheetFilters(sheetId int64) ([]SheetFilter, error) {
+	trace("ListSheetFilters")
+	endPoint := fmt.Sprintf("/sheets/%d/filters", sheetId)
+	filters := make([]SheetFilter, 0, 10)
+	err := getAllPages(endPoint, nil, func(data json.RawMessage) error {
+		var page []SheetFilter
+		err := json.Unmarshal(data, &page)
+		filters = append(filters, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
@@ -0,0 +1,55 @@
+package smartsheet
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func Test_Filters(t *testing.T) {
+	var query url.Values
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		switch r.URL.Path {
+		case "/sheets/1849449510135684/filters":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"id":6130,"name":"Open Gas","filterType":"SHARED","version":2,
+				"query":{"operator":"AND","includeParent":false,"criteria":[{"columnId":104,"operator":"EQUAL","values":["Gas"]},{"columnId":106,"operator":"IS_UNCHECKED"}]}}]}`))
+		case "/sheets/1849449510135684":
+			w.Write([]byte(`{"id":1849449510135684,"name":"Test1","columns":[{"id":101,"index":0,"title":"Address","type":"TEXT_NUMBER","primary":true}],
+				"rows":[{"id":11,"cells":[{"columnId":101,"value":"1 Main"}]},{"id":12,"filteredOut":true,"cells":[{"columnId":101,"value":"2 Elm"}]}]}`))
+		}
+	})
+	var sheetId int64 = 1849449510135684
+
+	filters, err := ListSheetFilters(sheetId)
+	if err != nil || len(filters) != 1 {
+		t.Fatalf("ListSheetFilters wrong result %+v %v", filters, err)
+	}
+	filter := filters[0]
+	if filter.Name != "Open Gas" || filter.FilterType != "SHARED" || filter.Query.Operator != "AND" || len(filter.Query.Criteria) != 2 || filter.Query.Criteria[0].Values[0] != "Gas" {
+		t.Errorf("ListSheetFilters wrong decode %+v", filter)
+	}
+
+	sheet := new(SheetInfo)
+	if err = sheet.Load(sheetId, &GetSheetOptions{FilterId: filter.Id}); err != nil {
+		t.Fatal("SheetInfo.Load Failed", err)
+	}
+	if query.Get("filterId") != "6130" || query.Get("exclude") != "nonexistentCells" {
+		t.Error("GetSheet wrong query", query)
+	}
+	if len(sheet.Rows) != 2 || sheet.Rows[0].FilteredOut || !sheet.Rows[1].FilteredOut {
+		t.Errorf("GetSheet wrong filteredOut decode %+v", sheet.Rows)
+	}
+	if values := RowValues(sheet, sheet.Rows[1]); values["Address"] != "2 Elm" {
+		t.Error("RowValues wrong result for filtered out row", values)
+	}
+
+	sheet.Load(sheetId, &GetSheetOptions{FilterId: filter.Id, ExcludeFilteredOutRows: true})
+	if query.Get("filterId") != "6130" || query.Get("exclude") != "nonexistentCells,filteredOutRows" {
+		t.Error("GetSheet wrong query", query)
+	}
+	sheet.Load(sheetId, &GetSheetOptions{ExcludeFilteredOutRows: true}) // ignored without FilterId
+	if query.Get("filterId") != "" || query.Get("exclude") != "nonexistentCells" {
+		t.Error("GetSheet wrong query", query)
+	}
+}
@@ -0,0 +1,68 @@
+// formulas.go contains funcs for reading the formulas of loaded rows, and the check used by SheetInfo.ProtectFormulas
+// to keep staged updates from replacing a formula with a value.
+
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// ErrFormulaCell is wrapped by the error returned when SheetInfo.ProtectFormulas is set
+// and a staged value would replace a formula.
+var ErrFormulaCell = errors.New("Cell Contains Formula")
+
+// RowFormulas returns the formula of each formula cell in a row, keyed by column name.
+// Cells without a formula are not included, see RowValues for their values (and the computed value of formula cells).
+func RowFormulas(sheet *SheetInfo, row Row) map[string]string {
+	formulas := make(map[string]string)
+	for _, cell := range row.Cells {
+		if cell.Formula != "" {
+			formulas[sheet.ColumnsById[cell.ColumnId].Title] = cell.Formula
+		}
+	}
+	return formulas
+}
+
+// HasFormula returns true if the row's cell for columnName contains a formula.
+// False is returned if columnName is not in the sheet.
+func HasFormula(sheet *SheetInfo, row Row, columnName string) bool {
+	column, found := sheet.ColumnsByName[columnName]
+	if !found {
+		log.Println("ERROR - HasFormula, columnName not found in sheet.ColumnsByName: ", columnName)
+		return false
+	}
+	for _, cell := range row.Cells {
+		if cell.ColumnId == column.Id {
+			return cell.Formula != ""
+		}
+	}
+	return false
+}
+
+// checkFormulaCells returns an error if SheetInfo.ProtectFormulas is set and a cell would replace a formula
+// of the loaded row rowId with a value. Cells with a Formula or OverwriteFormula set are allowed.
+// Rows not in SheetInfo.Rows are not checked.
+func (she *SheetInfo) checkFormulaCells(rowId int64, cells []Cell) error {
+	if !she.ProtectFormulas {
+		return nil
+	}
+	for _, row := range she.Rows {
+		if row.Id != rowId {
+			continue
+		}
+		for _, cell := range cells {
+			if cell.Formula != "" || cell.OverwriteFormula {
+				continue
+			}
+			for _, current := range row.Cells {
+				if current.ColumnId == cell.ColumnId && current.Formula != "" {
+					return fmt.Errorf("%w - RowId %d, ColName %s, Formula %s", ErrFormulaCell, rowId, she.ColumnsById[cell.ColumnId].Title, current.Formula)
+				}
+			}
+		}
+		return nil
+	}
+	return nil
+}
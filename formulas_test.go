@@ -0,0 +1,66 @@
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_Formulas(t *testing.T) {
+	sheet := testSheet()
+	sheet.Rows = []Row{
+		{Id: 11, Cells: []Cell{
+			{ColumnId: 101, Value: "1 Main"},
+			{ColumnId: 105, Value: 150.0, Formula: "=SUM(Amt1:Amt3)"},
+			{ColumnId: 108, Value: "Green", Formula: `=IF(Complete@row, "Green", "Red")`},
+		}},
+		{Id: 12, Cells: []Cell{{ColumnId: 101, Value: "2 Elm"}, {ColumnId: 105, Value: 75.0}}},
+	}
+	formulas := RowFormulas(sheet, sheet.Rows[0])
+	expect := `map[Amt:=SUM(Amt1:Amt3) Status:=IF(Complete@row, "Green", "Red")]`
+	if fmt.Sprint(formulas) != expect {
+		t.Errorf("RowFormulas, Expecting %s, Got %v", expect, formulas)
+	}
+	if len(RowFormulas(sheet, sheet.Rows[1])) != 0 {
+		t.Error("RowFormulas expected no formulas for plain row")
+	}
+	if values := RowValues(sheet, sheet.Rows[0]); values["Amt"] != "150" {
+		t.Error("RowValues expected computed value of formula cell, got", values["Amt"])
+	}
+	if !HasFormula(sheet, sheet.Rows[0], "Amt") || HasFormula(sheet, sheet.Rows[0], "Address") ||
+		HasFormula(sheet, sheet.Rows[1], "Amt") || HasFormula(sheet, sheet.Rows[0], "Bogus") {
+		t.Error("HasFormula wrong result")
+	}
+
+	// not protected, value replaces formula
+	if err := sheet.UpdateRow(Row{Id: 11, Cells: []Cell{{ColName: "Amt", Value: 10}}}); err != nil {
+		t.Error("UpdateRow Failed", err)
+	}
+
+	sheet.ProtectFormulas = true
+	err := sheet.UpdateRow(Row{Id: 11, Cells: []Cell{{ColName: "Address", Value: "1 Main St"}, {ColName: "Amt", Value: 10}}})
+	if !errors.Is(err, ErrFormulaCell) {
+		t.Error("UpdateRow expected ErrFormulaCell, got", err)
+	}
+	if err = sheet.StageCellUpdate(11, "Status", "Red"); !errors.Is(err, ErrFormulaCell) {
+		t.Error("StageCellUpdate expected ErrFormulaCell, got", err)
+	}
+	if _, err = UpdateRow(sheet, Row{Id: 11, Cells: []Cell{{ColName: "Status", Value: "Red"}}}, nil); !errors.Is(err, ErrFormulaCell) {
+		t.Error("UpdateRow func expected ErrFormulaCell, got", err)
+	}
+	allowed := []Row{
+		{Id: 11, Cells: []Cell{{ColName: "Address", Value: "1 Main St"}}},            // plain cell
+		{Id: 11, Cells: []Cell{{ColName: "Amt", Formula: "=SUM(Amt1:Amt2)"}}},        // formula replaced by formula
+		{Id: 11, Cells: []Cell{{ColName: "Amt", Value: 10, OverwriteFormula: true}}}, // explicit override
+		{Id: 12, Cells: []Cell{{ColName: "Amt", Value: 80}}},                         // no formula in row
+		{Id: 99, Cells: []Cell{{ColName: "Amt", Value: 80}}},                         // row not loaded
+	}
+	for _, row := range allowed {
+		if err = sheet.UpdateRow(row); err != nil {
+			t.Errorf("UpdateRow %+v, expected no error, got %v", row.Cells[0], err)
+		}
+	}
+	if len(sheet.UpdateRows) != 3 { // updates of row 11 merged, see DuplicateUpdates
+		t.Error("UpdateRow expected 3 staged rows, got", len(sheet.UpdateRows))
+	}
+}
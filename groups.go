@@ -0,0 +1,158 @@
+// groups.go contains funcs for managing Smartsheet groups and their members.
+// A group's Id can be used as an EmailRecipient, see NewGroupRecipient.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+)
+
+// Group is returned by ListGroups, GetGroup and CreateGroup.
+// Members is only loaded by GetGroup and CreateGroup.
+type Group struct {
+	Id          int64         `json:"id,omitempty"`
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Owner       string        `json:"owner,omitempty"` // email address
+	OwnerId     int64         `json:"ownerId,omitempty"`
+	CreatedAt   string        `json:"createdAt,omitempty"`
+	ModifiedAt  string        `json:"modifiedAt,omitempty"`
+	Members     []GroupMember `json:"members,omitempty"`
+}
+
+// GroupMember is a user in a group. Only Email is used when adding members.
+type GroupMember struct {
+	Id        int64  `json:"id,omitempty"`
+	Email     string `json:"email"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// ListGroups returns all groups in the organization (members not included).
+func ListGroups() ([]Group, error) {
+	trace("ListGroups")
+	groups := make([]Group, 0, 20)
+	err := getAllPages("/groups", nil, func(data json.RawMessage) error {
+		var page []Group
+		err := json.Unmarshal(data, &page)
+		groups = append(groups, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// GetGroup returns a group including its members.
+func GetGroup(groupId int64) (*Group, error) {
+	trace("GetGroup")
+	endPoint := fmt.Sprintf("/groups/%d", groupId)
+	req := Get(endPoint, nil)
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+
+	group := new(Group)
+	if err = json.Unmarshal(respJSON, group); err != nil {
+		log.Println("ERROR GetGroup Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return group, nil
+}
+
+// CreateGroup creates a group with optional members and returns it.
+func CreateGroup(name, description string, memberEmails []string) (*Group, error) {
+	trace("CreateGroup")
+	reqData := Group{
+		Name:        name,
+		Description: description,
+		Members:     emailsToMembers(memberEmails),
+	}
+	req := Post("/groups", reqData, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	var apiResp struct {
+		Message    string `json:"message"`
+		ResultCode int    `json:"resultCode"`
+		Result     Group  `json:"result"`
+	}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR CreateGroup Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return &apiResp.Result, nil
+}
+
+// AddGroupMembers adds users (by email) to a group and returns the members added.
+// Users already in the group are ignored (no error), they are not included in the returned members.
+func AddGroupMembers(groupId int64, emails []string) ([]GroupMember, error) {
+	trace("AddGroupMembers")
+	if len(emails) == 0 {
+		log.Println("AddGroupMembers - No Emails Specified")
+		return nil, nil
+	}
+	endPoint := fmt.Sprintf("/groups/%d/members", groupId)
+	req := Post(endPoint, emailsToMembers(emails), nil) // always send array, api ignores existing members in an array
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		if apiErr, ok := err.(*ApiError); ok && apiErr.ErrorCode == errCodeAlreadyExists {
+			return []GroupMember{}, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	var apiResp struct {
+		Message    string        `json:"message"`
+		ResultCode int           `json:"resultCode"`
+		Result     []GroupMember `json:"result"`
+	}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR AddGroupMembers Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return apiResp.Result, nil
+}
+
+// RemoveGroupMember removes a user from a group.
+func RemoveGroupMember(groupId, userId int64) error {
+	trace("RemoveGroupMember")
+	endPoint := fmt.Sprintf("/groups/%d/members/%d", groupId, userId)
+	return deleteObject(endPoint)
+}
+
+// DeleteGroup deletes a group.
+func DeleteGroup(groupId int64) error {
+	trace("DeleteGroup")
+	endPoint := fmt.Sprintf("/groups/%d", groupId)
+	return deleteObject(endPoint)
+}
+
+func emailsToMembers(emails []string) []GroupMember {
+	members := make([]GroupMember, len(emails))
+	for i, email := range emails {
+		members[i].Email = email
+	}
+	return members
+}
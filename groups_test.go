@@ -0,0 +1,64 @@
+package smartsheet
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func Test_Groups(t *testing.T) {
+	var body, lastPath string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		lastPath = r.Method + " " + r.URL.Path
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		body = compactJSON(reqBytes)
+		switch lastPath {
+		case "POST /groups":
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":4583173393803140,"name":"Team A","description":"Project A","owner":"jay@test.com","ownerId":2331373580117892,
+				"members":[{"id":48569348493401200,"email":"a@test.com","firstName":"Ann","lastName":"Lee","name":"Ann Lee"}]}}`))
+		case "POST /groups/4583173393803140/members":
+			if body == `[{"email":"a@test.com"}]` { // single existing member
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"errorCode":1129,"message":"The specified group member already exists."}`))
+				return
+			}
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[{"id":48569348493401201,"email":"b@test.com"}]}`))
+		case "GET /groups":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"id":4583173393803140,"name":"Team A","owner":"jay@test.com"}]}`))
+		default:
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+		}
+	})
+
+	group, err := CreateGroup("Team A", "Project A", []string{"a@test.com"})
+	if err != nil || group.Id != 4583173393803140 || len(group.Members) != 1 || group.Members[0].LastName != "Lee" {
+		t.Fatalf("CreateGroup wrong result %+v %v", group, err)
+	}
+	expect := `{"name":"Team A","description":"Project A","members":[{"email":"a@test.com"}]}`
+	if body != expect {
+		t.Errorf("CreateGroup Expecting %s, Got %s", expect, body)
+	}
+
+	added, err := AddGroupMembers(group.Id, []string{"a@test.com", "b@test.com"})
+	if err != nil || len(added) != 1 || added[0].Email != "b@test.com" {
+		t.Errorf("AddGroupMembers wrong result %+v %v", added, err)
+	}
+	expect = `[{"email":"a@test.com"},{"email":"b@test.com"}]`
+	if body != expect {
+		t.Errorf("AddGroupMembers Expecting %s, Got %s", expect, body)
+	}
+	if added, err = AddGroupMembers(group.Id, []string{"a@test.com"}); err != nil || len(added) != 0 {
+		t.Error("AddGroupMembers expected duplicate member to be ignored", added, err)
+	}
+
+	groups, err := ListGroups()
+	if err != nil || len(groups) != 1 || groups[0].Owner != "jay@test.com" {
+		t.Errorf("ListGroups wrong result %+v %v", groups, err)
+	}
+	if err = RemoveGroupMember(group.Id, 48569348493401201); err != nil || lastPath != "DELETE /groups/4583173393803140/members/48569348493401201" {
+		t.Error("RemoveGroupMember wrong result", lastPath, err)
+	}
+	if err = DeleteGroup(group.Id); err != nil || lastPath != "DELETE /groups/4583173393803140" {
+		t.Error("DeleteGroup wrong result", lastPath, err)
+	}
+}
@@ -0,0 +1,108 @@
+// healthcheck.go contains Healthcheck, which verifies the token can reach the api and read sheets,
+// for services to call at startup. Common failures are reported with the likely cause and fix.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http/httptrace"
+	"time"
+)
+
+// HealthReport is returned by Healthcheck.
+type HealthReport struct {
+	OK          bool          // token is valid and sheets can be listed
+	UserId      int64         // authenticated user, set if the token is valid
+	Email       string        // authenticated user, set if the token is valid
+	Latency     time.Duration // response time of GET /users/me, excluding the RequestDelay throttle
+	ListLatency time.Duration // response time of the sheets list request
+	StatusCode  int           // of the failed request, 0 if OK or the api was not reached
+	ErrorCode   int           // api errorCode of the failed request, ex. 1002
+	Problem     string        // actionable description of the failure, empty if OK
+}
+
+// healthProblems maps api errorCodes to the likely cause of a failed Healthcheck.
+var healthProblems = map[int]string{
+	1001: "no access token sent - set smartsheet.Token (\"Bearer \" + token) or TokenSource",
+	1002: "invalid access token - check for a typo or missing \"Bearer \" prefix; the token may have been revoked, " +
+		"or belongs to another region (ex. smartsheet.eu) than the api url",
+	1003: "access token expired - refresh the OAuth token or generate a new api token",
+	1004: "token not authorized - it lacks the required scope (ex. READ_SHEETS) or the user's access was removed",
+	4003: "rate limit exceeded - too many requests with this token, increase RequestDelay or wait a minute",
+}
+
+// Healthcheck requests the authenticated user (GET /users/me) and 1 page of the sheets list.
+// The report is always returned. If a request fails, report.Problem describes the likely cause and the returned
+// error wraps the request error (ex. errors.Is(err, ErrNotAuthorized) is true for 1004).
+func Healthcheck() (*HealthReport, error) {
+	trace("Healthcheck")
+	report := new(HealthReport)
+
+	var user User
+	respJSON, latency, err := timedGet("/users/me", nil)
+	report.Latency = latency
+	if err == nil {
+		err = json.Unmarshal(respJSON, &user)
+	}
+	if err != nil {
+		return report, report.fail("GET /users/me", err)
+	}
+	report.UserId, report.Email = user.Id, user.Email
+
+	_, report.ListLatency, err = timedGet("/sheets", map[string]string{"pageSize": "1"})
+	if err != nil {
+		return report, report.fail("list sheets", err)
+	}
+	report.OK = true
+	return report, nil
+}
+
+// fail sets the report Problem for err and returns the Healthcheck error.
+func (report *HealthReport) fail(check string, err error) error {
+	var apiErr *ApiError
+	switch {
+	case errors.As(err, &apiErr):
+		report.StatusCode, report.ErrorCode = apiErr.StatusCode, apiErr.ErrorCode
+		problem, found := healthProblems[apiErr.ErrorCode]
+		if !found {
+			problem = fmt.Sprintf("api error %d, status %d - %s", apiErr.ErrorCode, apiErr.StatusCode, apiErr.Message)
+		}
+		report.Problem = problem
+	case errors.Is(err, ErrNoToken), errors.Is(err, ErrInvalidToken):
+		report.Problem = err.Error()
+	case errors.As(err, new(*json.SyntaxError)):
+		report.Problem = "response is not json - check the api url, a proxy may be answering instead of Smartsheet"
+	default:
+		report.Problem = "api not reachable - check network access, proxy settings and the api url (" + err.Error() + ")"
+	}
+	log.Println("ERROR - Healthcheck", check, report.Problem)
+	return fmt.Errorf("Healthcheck Failed - %s: %s: %w", check, report.Problem, err)
+}
+
+// timedGet sends a GET request and returns the response body and the time from connecting to the first response byte.
+func timedGet(endPoint string, urlParms map[string]string) ([]byte, time.Duration, error) {
+	var start, firstByte time.Time
+	clientTrace := &httptrace.ClientTrace{
+		GetConn:              func(string) { start = time.Now() },
+		GotFirstResponseByte: func() { firstByte = time.Now() },
+	}
+	req := Get(endPoint, urlParms)
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), clientTrace))
+
+	resp, err := DoRequest(req)
+	var latency time.Duration
+	if !firstByte.IsZero() {
+		latency = firstByte.Sub(start)
+	}
+	if err != nil {
+		return nil, latency, err
+	}
+	defer resp.Body.Close()
+	respJSON, err := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+	return respJSON, latency, err
+}
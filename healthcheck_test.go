@@ -0,0 +1,81 @@
+package smartsheet
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_Healthcheck(t *testing.T) {
+	var failPath, failBody string
+	failStatus := 0
+	var paths []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		if r.URL.Path == failPath {
+			w.WriteHeader(failStatus)
+			w.Write([]byte(failBody))
+			return
+		}
+		switch r.URL.Path {
+		case "/users/me":
+			w.Write([]byte(`{"id":48569348493401,"email":"svc@example.com","firstName":"Svc"}`))
+		case "/sheets":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"id":1,"name":"Test1"}]}`))
+		}
+	})
+
+	report, err := Healthcheck()
+	if err != nil {
+		t.Fatal("Healthcheck Failed", err)
+	}
+	if !report.OK || report.Email != "svc@example.com" || report.UserId != 48569348493401 || report.Problem != "" {
+		t.Errorf("Healthcheck wrong report %+v", report)
+	}
+	if report.Latency <= 0 || report.ListLatency <= 0 {
+		t.Error("Healthcheck expected latencies measured", report.Latency, report.ListLatency)
+	}
+	if strings.Join(paths, " ") != "/users/me /sheets?pageSize=1" {
+		t.Error("Healthcheck wrong requests", paths)
+	}
+
+	tests := []struct {
+		path, body string
+		status     int
+		errorCode  int
+		problem    string // expected in report.Problem
+		email      string // expected report.Email
+	}{
+		{"/users/me", `{"errorCode":1002,"message":"Your Access Token is invalid."}`, 401, 1002, "invalid access token", ""},
+		{"/users/me", `{"errorCode":1003,"message":"Your Access Token has expired."}`, 401, 1003, "expired", ""},
+		{"/sheets", `{"errorCode":1004,"message":"You are not authorized to perform this action."}`, 403, 1004, "scope", "svc@example.com"},
+		{"/users/me", `{"errorCode":4003,"message":"Rate limit exceeded."}`, 429, 4003, "rate limit", ""},
+		{"/users/me", `{"errorCode":5999,"message":"Something odd."}`, 500, 5999, "Something odd", ""},
+		{"/users/me", `<html>proxy login</html>`, 200, 0, "not json", ""},
+	}
+	for _, test := range tests {
+		failPath, failBody, failStatus = test.path, test.body, test.status
+		report, err = Healthcheck()
+		if err == nil || report.OK {
+			t.Errorf("Healthcheck %d expected failure, got %+v", test.errorCode, report)
+			continue
+		}
+		if report.ErrorCode != test.errorCode || !strings.Contains(report.Problem, test.problem) || report.Email != test.email {
+			t.Errorf("Healthcheck %d, Expecting problem containing %q, Got %+v", test.errorCode, test.problem, report)
+		}
+		if test.errorCode != 0 && report.StatusCode != test.status {
+			t.Errorf("Healthcheck %d expected StatusCode %d, got %d", test.errorCode, test.status, report.StatusCode)
+		}
+	}
+	failPath, failBody, failStatus = "/sheets", `{"errorCode":1004,"message":"not authorized"}`, 403
+	if _, err = Healthcheck(); !errors.Is(err, ErrNotAuthorized) {
+		t.Error("Healthcheck expected error matching ErrNotAuthorized, got", err)
+	}
+
+	basePath = "http://127.0.0.1:1" // nothing listening
+	report, err = Healthcheck()
+	if err == nil || !strings.Contains(report.Problem, "not reachable") {
+		t.Error("Healthcheck expected api not reachable, got", report.Problem, err)
+	}
+}
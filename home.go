@@ -0,0 +1,122 @@
+// home.go contains GetHome, returning the sheets, folders, reports, templates, workspaces and sights visible to the token,
+// and Home.AllSheets, which flattens the tree into a list of sheets with their containing path.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+)
+
+// Home Exclude values, parm exclude of GetHome
+const (
+	ExcludePermalinks = "permalinks"
+	ExcludeSource     = "source"
+)
+
+// HomeItem is a sheet, report, template or sight in the home tree.
+type HomeItem struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	AccessLevel string `json:"accessLevel"`
+	Permalink   string `json:"permalink"`
+	CreatedAt   string `json:"createdAt"`
+	ModifiedAt  string `json:"modifiedAt"`
+}
+
+// HomeFolder is a folder in Home, a workspace or another folder.
+type HomeFolder struct {
+	Id        int64        `json:"id"`
+	Name      string       `json:"name"`
+	Permalink string       `json:"permalink"`
+	Sheets    []HomeItem   `json:"sheets"`
+	Folders   []HomeFolder `json:"folders"`
+	Reports   []HomeItem   `json:"reports"`
+	Templates []HomeItem   `json:"templates"`
+	Sights    []HomeItem   `json:"sights"`
+}
+
+// HomeWorkspace is a workspace shared to the user.
+type HomeWorkspace struct {
+	Id          int64        `json:"id"`
+	Name        string       `json:"name"`
+	AccessLevel string       `json:"accessLevel"`
+	Permalink   string       `json:"permalink"`
+	Sheets      []HomeItem   `json:"sheets"`
+	Folders     []HomeFolder `json:"folders"`
+	Reports     []HomeItem   `json:"reports"`
+	Templates   []HomeItem   `json:"templates"`
+	Sights      []HomeItem   `json:"sights"`
+}
+
+// Home is returned by GetHome, objects in the user's Sheets home and the workspaces shared to the user.
+type Home struct {
+	Sheets     []HomeItem      `json:"sheets"`
+	Folders    []HomeFolder    `json:"folders"`
+	Reports    []HomeItem      `json:"reports"`
+	Templates  []HomeItem      `json:"templates"`
+	Workspaces []HomeWorkspace `json:"workspaces"`
+	Sights     []HomeItem      `json:"sights"`
+}
+
+// HomeSheet is returned by Home.AllSheets.
+type HomeSheet struct {
+	HomeItem
+	Path string // workspace and folder names followed by sheet name, ex. "Workspace X / Folder Y / Sheet"
+}
+
+// homePathSep separates the names in HomeSheet.Path.
+const homePathSep = " / "
+
+// GetHome returns the full tree of objects visible to the token.
+// Parm exclude contains Home Exclude values, ex. ExcludePermalinks (nil for none).
+// The response is decoded as it is read, large orgs can return a large payload.
+func GetHome(exclude []string) (*Home, error) {
+	trace("GetHome")
+	query := newQuery()
+	for _, value := range exclude {
+		query.exclude(value, true)
+	}
+	req := Get("/home", query.parms())
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body io.Reader = resp.Body
+	if DebugOn {
+		body = io.TeeReader(resp.Body, os.Stdout)
+	}
+	home := new(Home)
+	if err = json.NewDecoder(body).Decode(home); err != nil {
+		log.Println("ERROR GetHome Decode Response Failed", err)
+		return nil, err
+	}
+	return home, nil
+}
+
+// AllSheets returns every sheet in the home tree: home sheets, then sheets in home folders, then workspace sheets.
+// Within a workspace or folder, its sheets are listed before the sheets of its subfolders.
+func (home *Home) AllSheets() []HomeSheet {
+	sheets := make([]HomeSheet, 0, len(home.Sheets))
+	sheets = appendHomeSheets(sheets, "", home.Sheets, home.Folders)
+	for _, workspace := range home.Workspaces {
+		sheets = appendHomeSheets(sheets, workspace.Name+homePathSep, workspace.Sheets, workspace.Folders)
+	}
+	return sheets
+}
+
+// appendHomeSheets appends sheets and the sheets of folders (recursively), path is prefixed to each name.
+func appendHomeSheets(list []HomeSheet, path string, sheets []HomeItem, folders []HomeFolder) []HomeSheet {
+	for _, sheet := range sheets {
+		list = append(list, HomeSheet{HomeItem: sheet, Path: path + sheet.Name})
+	}
+	for _, folder := range folders {
+		list = appendHomeSheets(list, path+folder.Name+homePathSep, folder.Sheets, folder.Folders)
+	}
+	return list
+}
@@ -0,0 +1,74 @@
+package smartsheet
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+// Test_GetHome decodes testdata/home.json (GET /home response with nested folders and workspaces).
+func Test_GetHome(t *testing.T) {
+	homeJSON, err := ioutil.ReadFile("testdata/home.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var query string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write(homeJSON)
+	})
+	home, err := GetHome([]string{ExcludePermalinks, ExcludeSource})
+	if err != nil {
+		t.Fatal("GetHome Failed", err)
+	}
+	if query != "exclude=permalinks%2Csource" {
+		t.Error("GetHome wrong query", query)
+	}
+	if len(home.Sheets) != 1 || home.Sheets[0].Id != 1849449510135684 || home.Sheets[0].AccessLevel != "OWNER" {
+		t.Errorf("GetHome wrong sheets %+v", home.Sheets)
+	}
+	if len(home.Folders) != 1 || len(home.Folders[0].Folders) != 1 || home.Folders[0].Folders[0].Sheets[0].Name != "Returns" {
+		t.Errorf("GetHome wrong folders %+v", home.Folders)
+	}
+	if len(home.Reports) != 1 || len(home.Sights) != 1 || len(home.Templates) != 0 {
+		t.Errorf("GetHome wrong reports/sights/templates %+v %+v %+v", home.Reports, home.Sights, home.Templates)
+	}
+	if len(home.Workspaces) != 2 || home.Workspaces[0].Folders[0].Reports[0].Id != 703 {
+		t.Errorf("GetHome wrong workspaces %+v", home.Workspaces)
+	}
+}
+
+func Test_HomeAllSheets(t *testing.T) {
+	home := &Home{
+		Sheets: []HomeItem{{Id: 1, Name: "Orders"}},
+		Folders: []HomeFolder{{Name: "Archive", Sheets: []HomeItem{{Id: 2, Name: "Orders 2019"}},
+			Folders: []HomeFolder{{Name: "Q4", Sheets: []HomeItem{{Id: 3, Name: "Returns"}}}}}},
+		Workspaces: []HomeWorkspace{
+			{Name: "Operations", Sheets: []HomeItem{{Id: 4, Name: "Inventory"}},
+				Folders: []HomeFolder{{Name: "Shipping", Sheets: []HomeItem{{Id: 5, Name: "Carriers"}}}, {Name: "Empty"}}},
+			{Name: "Finance"},
+		},
+	}
+	expect := []struct {
+		id   int64
+		path string
+	}{
+		{1, "Orders"},
+		{2, "Archive / Orders 2019"},
+		{3, "Archive / Q4 / Returns"},
+		{4, "Operations / Inventory"},
+		{5, "Operations / Shipping / Carriers"},
+	}
+	sheets := home.AllSheets()
+	if len(sheets) != len(expect) {
+		t.Fatalf("AllSheets Expecting %d sheets, Got %+v", len(expect), sheets)
+	}
+	for i, sheet := range sheets {
+		if sheet.Id != expect[i].id || sheet.Path != expect[i].path {
+			t.Errorf("AllSheets %d Expecting %d %q, Got %d %q", i, expect[i].id, expect[i].path, sheet.Id, sheet.Path)
+		}
+	}
+	if sheets := new(Home).AllSheets(); len(sheets) != 0 {
+		t.Error("AllSheets of empty Home expected no sheets", sheets)
+	}
+}
@@ -0,0 +1,82 @@
+// hyperlinks.go contains funcs for creating hyperlink cells and validating hyperlinks.
+// A hyperlink targets exactly 1 of a url, sheet or report. Cell.Value is the text displayed in the cell.
+
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// base of links built by Hyperlink.Target when api did not return a url (permalink)
+const (
+	sheetLinkBase  = "https://app.smartsheet.com/sheets/"
+	reportLinkBase = "https://app.smartsheet.com/reports/"
+)
+
+// NewURLLinkCell returns a cell displaying text display and linking to url.
+func NewURLLinkCell(colName, display, url string) Cell {
+	return Cell{ColName: colName, Value: display, Hyperlink: &Hyperlink{Url: url}}
+}
+
+// NewSheetLinkCell returns a cell displaying text display and linking to another sheet.
+func NewSheetLinkCell(colName, display string, sheetId int64) Cell {
+	return Cell{ColName: colName, Value: display, Hyperlink: &Hyperlink{Sheetid: sheetId}}
+}
+
+// NewReportLinkCell returns a cell displaying text display and linking to a report.
+func NewReportLinkCell(colName, display string, reportId int64) Cell {
+	return Cell{ColName: colName, Value: display, Hyperlink: &Hyperlink{Reportid: reportId}}
+}
+
+// Validate returns an error if the hyperlink does not have exactly 1 target.
+// A sheet or report link must not also contain a url. The api sets Url to the permalink of linked sheets and reports,
+// so hyperlinks returned by the api should not be sent back unchanged.
+func (link *Hyperlink) Validate() error {
+	targets := make([]string, 0, 3)
+	if link.Url != "" {
+		targets = append(targets, "Url")
+	}
+	if link.Sheetid != 0 {
+		targets = append(targets, "Sheetid")
+	}
+	if link.Reportid != 0 {
+		targets = append(targets, "Reportid")
+	}
+	if len(targets) == 0 {
+		return errors.New("Invalid Hyperlink - no target, set 1 of Url, Sheetid, Reportid")
+	}
+	if len(targets) > 1 {
+		return errors.New("Invalid Hyperlink - only 1 target allowed, has " + strings.Join(targets, ", "))
+	}
+	return nil
+}
+
+// Target returns the link's url. For sheet and report links the url is the permalink returned by the api,
+// if not present (ex. link created by NewSheetLinkCell) a url is built using the sheet or report id.
+func (link *Hyperlink) Target() string {
+	switch {
+	case link.Url != "":
+		return link.Url
+	case link.Sheetid != 0:
+		return fmt.Sprintf("%s%d", sheetLinkBase, link.Sheetid)
+	case link.Reportid != 0:
+		return fmt.Sprintf("%s%d", reportLinkBase, link.Reportid)
+	}
+	return ""
+}
+
+// validateHyperlinks returns an error describing the 1st cell with an invalid hyperlink.
+// Called when rows are staged or sent, see Hyperlink.Validate.
+func validateHyperlinks(cells []Cell) error {
+	for _, cell := range cells {
+		if cell.Hyperlink == nil {
+			continue
+		}
+		if err := cell.Hyperlink.Validate(); err != nil {
+			return fmt.Errorf("%v, ColName %s", err, cell.ColName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package smartsheet
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func Test_Hyperlinks(t *testing.T) {
+	sheet := testSheet()
+	cells := []Cell{
+		NewURLLinkCell("Hyperlink", "cheepcode", "https://cheepcode.com"),
+		NewSheetLinkCell("Address", "Sheet 2", 8094487248430980),
+		NewReportLinkCell("OrderNo", "Orders", 5609237329889156),
+	}
+	expect := []string{
+		`{"columnId":0,"hyperlink":{"url":"https://cheepcode.com"},"value":"cheepcode"}`,
+		`{"columnId":0,"hyperlink":{"sheetId":8094487248430980},"value":"Sheet 2"}`,
+		`{"columnId":0,"hyperlink":{"reportId":5609237329889156},"value":"Orders"}`,
+	}
+	for i, cell := range cells {
+		jsonData, _ := json.Marshal(cell)
+		if string(jsonData) != expect[i] {
+			t.Errorf("Link Cell Marshal Expecting %s, Got %s", expect[i], jsonData)
+		}
+	}
+	if err := sheet.AddRow(Row{Cells: cells}); err != nil {
+		t.Error("AddRow Failed", err)
+	}
+
+	invalid := []*Hyperlink{
+		{},
+		{Url: "https://app.smartsheet.com/b/home?lx=abc", Sheetid: 8094487248430980}, // as returned by api
+		{Sheetid: 8094487248430980, Reportid: 5609237329889156},
+	}
+	for _, link := range invalid {
+		if err := link.Validate(); err == nil {
+			t.Errorf("Hyperlink.Validate expected error for %+v", link)
+		}
+		cell := Cell{ColName: "Hyperlink", Value: "x", Hyperlink: link}
+		if err := sheet.UpdateRow(Row{Id: 11, Cells: []Cell{cell}}); err == nil {
+			t.Errorf("UpdateRow expected hyperlink error for %+v", link)
+		}
+	}
+
+	row := Row{Id: 11, Cells: []Cell{
+		{ColumnId: 109, Value: "cheepcode", Hyperlink: &Hyperlink{Url: "https://cheepcode.com"}},
+		{ColumnId: 101, Value: "Sheet 2", Hyperlink: &Hyperlink{Sheetid: 8094487248430980, Url: "https://app.smartsheet.com/b/home?lx=abc"}},
+		{ColumnId: 102, Value: "Orders", Hyperlink: &Hyperlink{Reportid: 5609237329889156}},
+	}}
+	values := RowValues(sheet, row)
+	if values["Hyperlink"] != "https://cheepcode.com" {
+		t.Error("RowValues wrong url link value", values["Hyperlink"])
+	}
+	if values["Address"] != "https://app.smartsheet.com/b/home?lx=abc" {
+		t.Error("RowValues wrong sheet link value", values["Address"])
+	}
+	if values["OrderNo"] != "https://app.smartsheet.com/reports/5609237329889156" {
+		t.Error("RowValues wrong report link value", values["OrderNo"])
+	}
+}
+
+func Test_RowValuesDetailed(t *testing.T) {
+	sheet := testSheet()
+	row := Row{Id: 11, Cells: []Cell{
+		{ColumnId: 109, Value: "cheepcode", Hyperlink: &Hyperlink{Url: "https://cheepcode.com"}},
+		{ColumnId: 105, Value: 74.2, DisplayValue: "$74.20", Formula: "=SUM(Amt1:Amt3)"},
+		{ColumnId: 101, Value: "1200 Canton Road"},
+	}}
+	details := RowValuesDetailed(sheet, row)
+	expect := map[string]CellValueDetail{
+		"Hyperlink": {Value: "cheepcode", DisplayValue: "cheepcode", HyperlinkURL: "https://cheepcode.com"},
+		"Amt":       {Value: "74.2", DisplayValue: "$74.20", IsFormula: true},
+		"Address":   {Value: "1200 Canton Road", DisplayValue: "1200 Canton Road"},
+		"OrderNo":   {},
+	}
+	for colName, want := range expect {
+		if details[colName] != want {
+			t.Errorf("RowValuesDetailed %s, Expecting %+v, Got %+v", colName, want, details[colName])
+		}
+	}
+	if len(details) != len(sheet.ColumnsByName) {
+		t.Error("RowValuesDetailed expected all columns, got", len(details))
+	}
+	if values := RowValues(sheet, row); values["Hyperlink"] != "https://cheepcode.com" {
+		t.Error("RowValues expected hyperlink url, got", values["Hyperlink"])
+	}
+}
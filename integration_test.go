@@ -0,0 +1,140 @@
+package smartsheet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Test_Integration runs against the live api using a temporary sheet, created in a workspace and deleted at the end.
+// It is skipped unless these environment variables are set:
+//
+//	SMARTSHEET_TEST_TOKEN      api access token, with or without the "Bearer " prefix
+//	SMARTSHEET_TEST_WORKSPACE  id of a workspace the token can create sheets in
+//	SMARTSHEET_TEST_EMAIL      optional, recipient of the email scenario, skipped if not set
+func Test_Integration(t *testing.T) {
+	token, workspace := os.Getenv("SMARTSHEET_TEST_TOKEN"), os.Getenv("SMARTSHEET_TEST_WORKSPACE")
+	if token == "" || workspace == "" {
+		t.Skip("SMARTSHEET_TEST_TOKEN and SMARTSHEET_TEST_WORKSPACE not set")
+	}
+	workspaceId, err := strconv.ParseInt(workspace, 10, 64)
+	if err != nil {
+		t.Fatal("Invalid SMARTSHEET_TEST_WORKSPACE", err)
+	}
+	saveToken := Token
+	t.Cleanup(func() { Token = saveToken })
+	Token = strings.TrimSpace(token)
+	if !strings.HasPrefix(Token, "Bearer ") {
+		Token = "Bearer " + Token
+	}
+
+	spec := SheetSpec{Name: "smartsheet integration " + strconv.Itoa(os.Getpid()), Columns: []ColumnSpec{
+		{Title: "Address", Type: TEXTNUMBER, Primary: true},
+		{Title: "OrderNo", Type: TEXTNUMBER},
+		{Title: "Util", Type: PICKLIST, Options: []string{"Gas", "Water", "Electric"}},
+		{Title: "Amt", Type: TEXTNUMBER},
+		{Title: "Complete", Type: CHECKBOX},
+	}}
+	sheetId, err := CreateSheet(spec, &SheetDestination{WorkspaceId: workspaceId})
+	if err != nil {
+		t.Fatal("CreateSheet Failed", err)
+	}
+	// cleanup runs when the test ends, including after t.Fatal or a panic in a scenario
+	t.Cleanup(func() {
+		if err := DeleteSheet(sheetId); err != nil {
+			t.Errorf("DeleteSheet %d Failed, delete it manually: %v", sheetId, err)
+		}
+	})
+
+	sheet := new(SheetInfo)
+	reload := func(t *testing.T) {
+		t.Helper()
+		if err := sheet.Load(sheetId, nil); err != nil {
+			t.Fatal("Load Failed", err)
+		}
+	}
+	reload(t)
+
+	scenarios := []struct {
+		name string
+		run  func(t *testing.T)
+	}{
+		{"add", func(t *testing.T) {
+			for _, values := range [][]interface{}{{"100 Main", "3400", "Gas", 120.40}, {"Unit A", "3401", "Water", 35}, {"Unit B", "3402", "Electric", 80}} {
+				sheet.AddRow(Row{Cells: []Cell{
+					{ColName: "Address", Value: values[0]}, {ColName: "OrderNo", Value: values[1]},
+					{ColName: "Util", Value: values[2]}, {ColName: "Amt", Value: values[3]},
+				}})
+			}
+			if _, err := sheet.UploadNewRows(nil); err != nil {
+				t.Fatal("UploadNewRows Failed", err)
+			}
+			reload(t)
+			if len(sheet.Rows) != 3 || RowValues(sheet, sheet.Rows[0])["Address"] != "100 Main" {
+				t.Fatal("expected 3 rows added, got", len(sheet.Rows))
+			}
+		}},
+		{"update", func(t *testing.T) {
+			sheet.UpdateRow(Row{Id: sheet.Rows[0].Id, Cells: []Cell{{ColName: "Complete", Value: true}, {ColName: "Amt", Value: 125}}})
+			if _, err := sheet.UploadUpdateRows(nil); err != nil {
+				t.Fatal("UploadUpdateRows Failed", err)
+			}
+			reload(t)
+			if complete, err := CellBool(sheet, sheet.Rows[0], "Complete"); err != nil || !complete {
+				t.Error("expected Complete checked, got", complete, err)
+			}
+			if amt := RowValues(sheet, sheet.Rows[0])["Amt"]; amt != "125" {
+				t.Error("expected Amt 125, got", amt)
+			}
+		}},
+		{"indent", func(t *testing.T) {
+			parentId, childIds := sheet.Rows[0].Id, []int64{sheet.Rows[1].Id, sheet.Rows[2].Id}
+			if _, err := SetParentId(sheet, parentId, childIds); err != nil {
+				t.Fatal("SetParentId Failed", err)
+			}
+			reload(t)
+			for _, row := range sheet.Rows[1:] {
+				if row.ParentId != parentId {
+					t.Errorf("expected row %d indented under %d, got parent %d", row.Id, parentId, row.ParentId)
+				}
+			}
+		}},
+		{"attachment", func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "attachment.txt")
+			if err := ioutil.WriteFile(filePath, []byte("integration test attachment\n"), 0600); err != nil {
+				t.Fatal(err)
+			}
+			rowId := sheet.Rows[0].Id
+			if err := AttachFileToRow(sheetId, rowId, filePath); err != nil {
+				t.Fatal("AttachFileToRow Failed", err)
+			}
+			attachments, err := ListRowAttachments(sheetId, rowId)
+			if err != nil || len(attachments) != 1 || attachments[0].Name != "attachment.txt" {
+				t.Error("expected attachment.txt on row, got", attachments, err)
+			}
+		}},
+		{"email", func(t *testing.T) {
+			recipient := os.Getenv("SMARTSHEET_TEST_EMAIL")
+			if recipient == "" {
+				t.Skip("SMARTSHEET_TEST_EMAIL not set")
+			}
+			err := EmailRowsByName(sheet, EmailRowsObj{
+				SendTo:      []EmailRecipient{{"email": recipient}},
+				Subject:     "smartsheet integration test",
+				RowIds:      []int64{sheet.Rows[0].Id},
+				ColumnNames: []string{"Address", "OrderNo"},
+			})
+			if err != nil {
+				t.Error("EmailRowsByName Failed", err)
+			}
+		}},
+	}
+	for _, scenario := range scenarios {
+		if !t.Run(scenario.name, scenario.run) {
+			return // later scenarios use the rows of earlier ones
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// loadsheets.go contains LoadSheets func for loading multiple sheets concurrently.
+// All requests share the DoRequest throttle, so concurrency hides response latency but does not exceed RequestDelay spacing.
+
+package smartsheet
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+// SheetLoadSpec identifies a sheet to be loaded by LoadSheets.
+type SheetLoadSpec struct {
+	SheetId int64
+	Options *GetSheetOptions // optional, see SheetInfo.Load, ColumnNames cannot be used (sheet columns are not yet known)
+}
+
+// LoadSheetsError is returned by LoadSheets when 1 or more sheets fail to load.
+type LoadSheetsError struct {
+	Errors []error // same order as specs, nil if sheet loaded
+}
+
+func (e *LoadSheetsError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for i, err := range e.Errors {
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("specs[%d] %v", i, err))
+		}
+	}
+	return fmt.Sprintf("LoadSheets %d of %d Sheets Failed - %s", len(msgs), len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// LoadSheets loads each sheet in specs using up to concurrency goroutines.
+// The returned slice is in the same order as specs, a failed sheet has a nil entry.
+// A failed sheet does not stop the others from loading, if any fail the error returned is type *LoadSheetsError.
+// If ctx is cancelled, sheets not yet started are not loaded and their error is ctx.Err().
+func LoadSheets(ctx context.Context, specs []SheetLoadSpec, concurrency int) ([]*SheetInfo, error) {
+	trace("LoadSheets")
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sheets := make([]*SheetInfo, len(specs))
+	errs := make([]error, len(specs))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				sheet := new(SheetInfo)
+				if err := sheet.Load(specs[i].SheetId, specs[i].Options); err != nil {
+					errs[i] = err
+					continue
+				}
+				sheets[i] = sheet
+			}
+		}()
+	}
+	for i := range specs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			loadErr := &LoadSheetsError{Errors: errs}
+			log.Println("ERROR", loadErr)
+			return sheets, loadErr
+		}
+	}
+	return sheets, nil
+}
@@ -0,0 +1,85 @@
+package smartsheet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_LoadSheets(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		var sheetId int64
+		fmt.Sscanf(r.URL.Path, "/sheets/%d", &sheetId)
+		time.Sleep(time.Duration(10-sheetId%10) * 3 * time.Millisecond) // earlier sheets respond slower
+		if sheetId == 13 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorCode":1006,"message":"Not Found"}`))
+			return
+		}
+		fmt.Fprintf(w, `{"id":%d,"name":"Sheet%d","columns":[],"rows":[]}`, sheetId, sheetId)
+	})
+
+	specs := make([]SheetLoadSpec, 8)
+	for i := range specs {
+		specs[i] = SheetLoadSpec{SheetId: int64(10 + i)}
+	}
+	sheets, err := LoadSheets(context.Background(), specs, 3)
+
+	var loadErr *LoadSheetsError
+	if !errors.As(err, &loadErr) {
+		t.Fatal("LoadSheets expected LoadSheetsError, got", err)
+	}
+	for i, spec := range specs {
+		if spec.SheetId == 13 {
+			if sheets[i] != nil || loadErr.Errors[i] == nil {
+				t.Error("LoadSheets expected failure for sheet 13")
+			}
+			continue
+		}
+		if sheets[i] == nil || sheets[i].SheetId != spec.SheetId || loadErr.Errors[i] != nil {
+			t.Errorf("LoadSheets wrong result at %d, expecting sheet %d, got %+v %v", i, spec.SheetId, sheets[i], loadErr.Errors[i])
+		}
+	}
+	if !strings.Contains(err.Error(), "1 of 8") {
+		t.Error("LoadSheetsError wrong message", err)
+	}
+	if maxInFlight > 3 || maxInFlight < 2 {
+		t.Error("LoadSheets expected at most 3 concurrent requests, got", maxInFlight)
+	}
+
+	// requests from all workers share RequestDelay spacing
+	RequestDelay = 10 * time.Millisecond
+	start := time.Now()
+	if _, err = LoadSheets(context.Background(), specs[4:], 4); err != nil {
+		t.Fatal("LoadSheets Failed", err)
+	}
+	if elapsed := time.Since(start); elapsed < 3*RequestDelay {
+		t.Error("LoadSheets requests not throttled, elapsed", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sheets, err = LoadSheets(ctx, specs[:2], 2)
+	if !errors.As(err, &loadErr) || !errors.Is(loadErr.Errors[0], context.Canceled) || sheets[1] != nil {
+		t.Error("LoadSheets expected cancelled error", err)
+	}
+}
@@ -0,0 +1,112 @@
+// lockedcells.go contains the check of staged updates against locked rows and columns (SheetInfo.LockedCells), done by
+// UploadUpdateRows before any row is sent, and UnlockRows. The api rejects a change to a locked row or column with a
+// per-row error, unless the user is the sheet owner or an admin; the check does not know the user's sharing level.
+
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// Locked Cell Actions, used by SheetInfo.LockedCells
+const (
+	LockedCellsWarn   = iota // add a warning (WarnLockedCell) for each cell, the update is sent unchanged (default)
+	LockedCellsIgnore        // no check
+	LockedCellsSkip          // remove the cells of locked rows and columns from UpdateRows, with a warning for each
+	LockedCellsReject        // return error wrapping ErrLockedCell, no rows are sent
+)
+
+// ErrLockedCell is wrapped by the error returned by UploadUpdateRows when LockedCellsReject is set and a staged cell
+// is in a locked row or column.
+var ErrLockedCell = errors.New("Cell Locked")
+
+// checkLockedCells applies SheetInfo.LockedCells to UpdateRows. A row is locked if it is locked in Rows (rows not
+// loaded are not checked) and the update does not unlock it, a column is locked if Column.Locked is set.
+// With LockedCellsSkip, rows left with no cells and no other change are removed from UpdateRows.
+func (she *SheetInfo) checkLockedCells() error {
+	if she.LockedCells == LockedCellsIgnore || len(she.UpdateRows) == 0 {
+		return nil
+	}
+	lockedRows := make(map[int64]bool)
+	for _, row := range she.Rows {
+		if row.Locked != nil && *row.Locked {
+			lockedRows[row.Id] = true
+		}
+	}
+	problems := make([]string, 0)
+	updateRows := make([]Row, 0, len(she.UpdateRows))
+	for _, row := range she.UpdateRows {
+		rowLocked := lockedRows[row.Id] && (row.Locked == nil || *row.Locked)
+		cells := make([]Cell, 0, len(row.Cells))
+		for _, cell := range row.Cells {
+			column := she.ColumnsById[cell.ColumnId]
+			var reason string
+			switch {
+			case rowLocked:
+				reason = "row locked"
+			case column.Locked:
+				reason = "column locked"
+			default:
+				cells = append(cells, cell)
+				continue
+			}
+			problem := fmt.Sprintf("row %d column %s %s", row.Id, column.Title, reason)
+			problems = append(problems, problem)
+			if she.LockedCells != LockedCellsReject {
+				she.warn(WarnLockedCell, problem, row.Id, cell.ColumnId)
+			}
+			if she.LockedCells == LockedCellsWarn {
+				cells = append(cells, cell)
+			}
+		}
+		if len(cells) == 0 && len(row.Cells) > 0 && row.Locked == nil {
+			continue // nothing left to update
+		}
+		row.Cells = cells
+		updateRows = append(updateRows, row)
+	}
+	if len(problems) > 0 && she.LockedCells == LockedCellsReject {
+		err := fmt.Errorf("%w - %s", ErrLockedCell, strings.Join(problems, "; "))
+		log.Println("ERROR - SheetInfo.UploadUpdateRows", she.SheetName, err)
+		return err
+	}
+	if she.LockedCells == LockedCellsSkip {
+		she.UpdateRows = updateRows
+	}
+	return nil
+}
+
+// UnlockRows unlocks rows, for flows that need to update locked rows (lock them again by staging Row.Locked true).
+// Rows are sent in requests of UploadChunkSize rows. Rows of sheet.Rows are marked unlocked after each request.
+// If a request fails, rows of earlier requests are unlocked and the error is returned.
+func UnlockRows(sheet *SheetInfo, rowIds ...int64) error {
+	trace("UnlockRows")
+	endPoint := fmt.Sprintf("/sheets/%d/rows", sheet.SheetId)
+	for first := 0; first < len(rowIds); first += UploadChunkSize {
+		last := first + UploadChunkSize
+		if last > len(rowIds) {
+			last = len(rowIds)
+		}
+		reqData := make([]map[string]interface{}, 0, last-first)
+		unlocked := make(map[int64]bool, last-first)
+		for _, rowId := range rowIds[first:last] {
+			reqData = append(reqData, map[string]interface{}{"id": strconv.FormatInt(rowId, 10), "locked": false})
+			unlocked[rowId] = true
+		}
+		if _, err := putUpdateRows(endPoint, reqData); err != nil {
+			log.Println("ERROR - UnlockRows", sheet.SheetName, err)
+			return err
+		}
+		for i, row := range sheet.Rows {
+			if unlocked[row.Id] {
+				locked := false
+				sheet.Rows[i].Locked = &locked
+			}
+		}
+	}
+	return nil
+}
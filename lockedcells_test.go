@@ -0,0 +1,120 @@
+package smartsheet
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_LockedCells(t *testing.T) {
+	var requests []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, compactJSON(reqBytes))
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[]}`))
+	})
+	locked, unlocked := true, false
+	stage := func(mode int) *SheetInfo {
+		sheet := testSheet()
+		sheet.LockedCells = mode
+		column := sheet.ColumnsById[107]
+		column.Locked = true
+		sheet.ColumnsById[107] = column
+		sheet.Rows = []Row{{Id: 11, Locked: &locked}, {Id: 12}, {Id: 13, Locked: &locked}}
+		sheet.UpdateRows = []Row{
+			{Id: 11, Cells: []Cell{{ColumnId: 105, Value: 1}}},                            // locked row
+			{Id: 12, Cells: []Cell{{ColumnId: 105, Value: 2}, {ColumnId: 107, Value: 1}}}, // locked column
+			{Id: 13, Locked: &unlocked, Cells: []Cell{{ColumnId: 105, Value: 3}}},         // unlocked by the update
+			{Id: 14, Cells: []Cell{{ColumnId: 105, Value: 4}}},                            // not loaded
+		}
+		requests = nil
+		return sheet
+	}
+	lockedWarnings := func(sheet *SheetInfo) string {
+		messages := make([]string, 0)
+		for _, warning := range sheet.Warnings {
+			if warning.Code == WarnLockedCell {
+				messages = append(messages, warning.Message)
+			}
+		}
+		return strings.Join(messages, "; ")
+	}
+	expectWarnings := "row 11 column Amt row locked; row 12 column Level column locked"
+
+	sheet := stage(LockedCellsWarn)
+	if _, err := sheet.UploadUpdateRows(nil); err != nil {
+		t.Fatal("UploadUpdateRows LockedCellsWarn Failed", err)
+	}
+	expect := `[{"cells":[{"columnId":105,"value":1}],"id":"11"},{"cells":[{"columnId":105,"value":2},{"columnId":107,"value":1}],"id":"12"},` +
+		`{"cells":[{"columnId":105,"value":3}],"id":"13","locked":false},{"cells":[{"columnId":105,"value":4}],"id":"14"}]`
+	if len(requests) != 1 || requests[0] != expect || lockedWarnings(sheet) != expectWarnings {
+		t.Errorf("LockedCellsWarn, Expecting\n%s\n%s\nGot\n%v\n%s", expect, expectWarnings, requests, lockedWarnings(sheet))
+	}
+
+	sheet = stage(LockedCellsSkip)
+	if _, err := sheet.UploadUpdateRows(nil); err != nil {
+		t.Fatal("UploadUpdateRows LockedCellsSkip Failed", err)
+	}
+	expect = `[{"cells":[{"columnId":105,"value":2}],"id":"12"},{"cells":[{"columnId":105,"value":3}],"id":"13","locked":false},` +
+		`{"cells":[{"columnId":105,"value":4}],"id":"14"}]`
+	if len(requests) != 1 || requests[0] != expect || lockedWarnings(sheet) != expectWarnings {
+		t.Errorf("LockedCellsSkip, Expecting\n%s\n%s\nGot\n%v\n%s", expect, expectWarnings, requests, lockedWarnings(sheet))
+	}
+
+	sheet = stage(LockedCellsReject)
+	_, err := sheet.UploadUpdateRows(nil)
+	if !errors.Is(err, ErrLockedCell) || !strings.HasSuffix(err.Error(), expectWarnings) || len(requests) != 0 {
+		t.Error("LockedCellsReject expected ErrLockedCell and no request, got", err, requests)
+	}
+	if len(sheet.UpdateRows) != 4 || lockedWarnings(sheet) != "" {
+		t.Error("LockedCellsReject expected UpdateRows unchanged and no warnings", sheet.UpdateRows, sheet.Warnings)
+	}
+
+	sheet = stage(LockedCellsIgnore)
+	if _, err = sheet.UploadUpdateRows(nil); err != nil || len(requests) != 1 || lockedWarnings(sheet) != "" {
+		t.Error("LockedCellsIgnore expected rows sent without warnings", err, requests, sheet.Warnings)
+	}
+
+	// all staged cells skipped, nothing sent
+	sheet = stage(LockedCellsSkip)
+	sheet.UpdateRows = sheet.UpdateRows[:1]
+	if resp, err := sheet.UploadUpdateRows(nil); resp != nil || err != nil || len(requests) != 0 {
+		t.Error("LockedCellsSkip expected no request when all cells skipped, got", resp, err, requests)
+	}
+}
+
+func Test_UnlockRows(t *testing.T) {
+	var requests []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/sheets/1849449510135684/rows" {
+			t.Error("UnlockRows wrong request", r.Method, r.URL.Path)
+		}
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, compactJSON(reqBytes))
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[]}`))
+	})
+	locked := true
+	sheet := testSheet()
+	sheet.Rows = []Row{{Id: 11, Locked: &locked}, {Id: 12, Locked: &locked}, {Id: 13, Locked: &locked}}
+	if err := UnlockRows(sheet, 11, 13); err != nil {
+		t.Fatal("UnlockRows Failed", err)
+	}
+	expect := `[{"id":"11","locked":false},{"id":"13","locked":false}]`
+	if len(requests) != 1 || requests[0] != expect {
+		t.Errorf("UnlockRows, Expecting %s, Got %v", expect, requests)
+	}
+	if *sheet.Rows[0].Locked || !*sheet.Rows[1].Locked || *sheet.Rows[2].Locked {
+		t.Error("UnlockRows expected rows 11 and 13 marked unlocked")
+	}
+
+	requests = nil
+	rowIds := make([]int64, UploadChunkSize+1)
+	for i := range rowIds {
+		rowIds[i] = int64(i + 1)
+	}
+	if err := UnlockRows(sheet, rowIds...); err != nil || len(requests) != 2 || requests[1] != `[{"id":"501","locked":false}]` {
+		t.Error("UnlockRows expected 2 requests of UploadChunkSize rows, got", err, len(requests))
+	}
+}
@@ -0,0 +1,65 @@
+// namematch.go contains the column name matching of SheetInfo.NameMatcher, so a column renamed only by case, spacing or
+// punctuation (ex. "Order No" to "Order No.") is still found by AddRow, UpdateRow, CellInfo, GetRowLevel and
+// GetSheetOptions.ColumnNames.
+
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Name Matchers, used by SheetInfo.NameMatcher. An exact title match is always used first.
+const (
+	NameMatchExact = iota // column title must equal the name (default)
+	NameMatchFold         // case-insensitive, "order no" matches "Order No"
+	NameMatchLoose        // case, whitespace and punctuation insensitive, "orderno" matches "Order No."
+)
+
+// ErrAmbiguousColumnName is wrapped by the error returned when, after normalizing by SheetInfo.NameMatcher,
+// a column name matches more than 1 column title.
+var ErrAmbiguousColumnName = errors.New("Ambiguous ColumnName")
+
+// normalizeName returns name normalized by matcher.
+func normalizeName(name string, matcher int) string {
+	switch matcher {
+	case NameMatchFold:
+		return strings.ToLower(name)
+	case NameMatchLoose:
+		return strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, name)
+	}
+	return name
+}
+
+// columnNamed returns the column whose title matches name using SheetInfo.NameMatcher, found is false if none match.
+// The error, wrapping ErrAmbiguousColumnName, is returned when name has no exact match and normalizes to the title
+// of more than 1 column.
+func (she *SheetInfo) columnNamed(name string) (column Column, found bool, err error) {
+	if column, found = she.ColumnsByName[name]; found || she.NameMatcher == NameMatchExact {
+		return column, found, nil
+	}
+	want := normalizeName(name, she.NameMatcher)
+	if want == "" { // ex. only punctuation
+		return Column{}, false, nil
+	}
+	matches := make([]string, 0, 1)
+	for title, candidate := range she.ColumnsByName {
+		if normalizeName(title, she.NameMatcher) == want {
+			column = candidate
+			matches = append(matches, title)
+		}
+	}
+	if len(matches) > 1 {
+		sort.Strings(matches)
+		return Column{}, false, fmt.Errorf("%w - %s matches columns %s", ErrAmbiguousColumnName, name, strings.Join(matches, ", "))
+	}
+	return column, len(matches) == 1, nil
+}
@@ -0,0 +1,100 @@
+package smartsheet
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func Test_NameMatcher(t *testing.T) {
+	tests := []struct {
+		matcher int
+		name    string
+		expect  int64 // column id, 0 if not found
+	}{
+		{NameMatchExact, "OrderNo", 102},
+		{NameMatchExact, "orderno", 0},
+		{NameMatchFold, "orderno", 102},
+		{NameMatchFold, "Order No.", 0},
+		{NameMatchLoose, "Order No.", 102},
+		{NameMatchLoose, " due-date ", 103},
+		{NameMatchLoose, "...", 0},
+		{NameMatchLoose, "Missing", 0},
+	}
+	for _, test := range tests {
+		sheet := testSheet()
+		sheet.NameMatcher = test.matcher
+		column, found, err := sheet.columnNamed(test.name)
+		if err != nil || found != (test.expect != 0) || column.Id != test.expect {
+			t.Errorf("columnNamed %d %q, Expecting %d, Got %d %v %v", test.matcher, test.name, test.expect, column.Id, found, err)
+		}
+	}
+
+	// methods using the matcher
+	sheet := testSheet()
+	sheet.NameMatcher = NameMatchLoose
+	if err := sheet.AddRow(Row{Cells: []Cell{{ColName: "address", Value: "100 Main"}, {ColName: "Order No.", Value: "5001"}}}); err != nil {
+		t.Fatal("AddRow NameMatchLoose Failed", err)
+	}
+	if cells := sheet.NewRows[0].Cells; cells[0].ColumnId != 101 || cells[1].ColumnId != 102 {
+		t.Error("AddRow NameMatchLoose wrong column ids", cells)
+	}
+	if err := sheet.UpdateRow(Row{Id: 11, Cells: []Cell{{ColName: "AMT", Value: 5}}}); err != nil || sheet.UpdateRows[0].Cells[0].ColumnId != 105 {
+		t.Error("UpdateRow NameMatchLoose Failed", err, sheet.UpdateRows)
+	}
+	row := Row{Id: 11, Cells: []Cell{{ColumnId: 107, Value: 2}}}
+	if level, err := sheet.GetRowLevel(row, "level"); err != nil || level != "2" {
+		t.Error("GetRowLevel NameMatchLoose, Expecting 2, Got", level, err)
+	}
+	if cell := CellInfo(sheet, row, "LEVEL"); cell == nil || cell.Value != 2 {
+		t.Error("CellInfo NameMatchLoose, Expecting value 2, Got", cell)
+	}
+
+	// exact default unchanged
+	sheet = testSheet()
+	if err := sheet.AddRow(Row{Cells: []Cell{{ColName: "address", Value: "100 Main"}}}); err == nil {
+		t.Error("AddRow NameMatchExact expected error for address")
+	}
+}
+
+func Test_NameMatcherCollision(t *testing.T) {
+	sheet := testSheet()
+	sheet.NameMatcher = NameMatchLoose
+	column := Column{Id: 110, Index: 9, Title: "Order-No", Type: "TEXT_NUMBER"}
+	sheet.ColumnsById[column.Id], sheet.ColumnsByName[column.Title], sheet.ColumnsByIndex[column.Index] = column, column, column
+
+	expect := "Ambiguous ColumnName - order no matches columns Order-No, OrderNo"
+	if _, _, err := sheet.columnNamed("order no"); !errors.Is(err, ErrAmbiguousColumnName) || err.Error() != expect {
+		t.Errorf("columnNamed collision, Expecting %s, Got %v", expect, err)
+	}
+	if err := sheet.AddRow(Row{Cells: []Cell{{ColName: "order no", Value: "5001"}}}); !errors.Is(err, ErrAmbiguousColumnName) || len(sheet.NewRows) != 0 {
+		t.Error("AddRow collision expected ErrAmbiguousColumnName, got", err)
+	}
+	if err := sheet.UpdateRow(Row{Id: 11, Cells: []Cell{{ColName: "order no", Value: "5001"}}}); !errors.Is(err, ErrAmbiguousColumnName) {
+		t.Error("UpdateRow collision expected ErrAmbiguousColumnName, got", err)
+	}
+	if cell := CellInfo(sheet, Row{}, "order no"); cell != nil {
+		t.Error("CellInfo collision expected nil, got", cell)
+	}
+	// an exact title is not ambiguous
+	if column, found, err := sheet.columnNamed("Order-No"); err != nil || !found || column.Id != 110 {
+		t.Error("columnNamed exact title expected column 110, got", column.Id, found, err)
+	}
+
+	// Load converts options.ColumnNames using the matcher, no request when ambiguous
+	var query url.Values
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"id":1849449510135684,"name":"Test1","columns":[{"id":105,"index":0,"title":"Amt","type":"TEXT_NUMBER"}],"rows":[]}`))
+	})
+	if err := sheet.Load(Test1Id, &GetSheetOptions{ColumnNames: []string{"order no"}}); !errors.Is(err, ErrAmbiguousColumnName) || query != nil {
+		t.Error("Load collision expected ErrAmbiguousColumnName and no request, got", err, query)
+	}
+	if err := sheet.Load(Test1Id, &GetSheetOptions{ColumnNames: []string{"amt"}}); err != nil || query.Get("columnIds") != "105" {
+		t.Error("Load NameMatchLoose expected columnIds=105, got", err, query)
+	}
+	if sheet.NameMatcher != NameMatchLoose {
+		t.Error("Load expected NameMatcher kept, got", sheet.NameMatcher)
+	}
+}
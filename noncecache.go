@@ -0,0 +1,91 @@
+// noncecache.go contains NonceStore, used by WebhookServer to drop callbacks redelivered by the api (same nonce),
+// and NonceCache, the in-memory NonceStore. Use a shared NonceStore (ex. a database table) when several instances
+// of a callback service receive the callbacks of the same webhooks.
+
+package smartsheet
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Defaults used when NonceCache TTL or Capacity is 0.
+const (
+	DefaultNonceTTL      = time.Hour
+	DefaultNonceCapacity = 10000
+)
+
+// NonceStore records the nonces of received webhook callbacks.
+type NonceStore interface {
+	// Seen records nonce and returns true if it was already recorded.
+	Seen(nonce string) (bool, error)
+}
+
+// NonceCache is a NonceStore holding the last Capacity nonces seen, each for TTL after it was first seen.
+// When full, the least recently seen nonce is dropped. Safe for use by multiple goroutines.
+type NonceCache struct {
+	TTL      time.Duration // default DefaultNonceTTL
+	Capacity int           // default DefaultNonceCapacity
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // of *nonceEntry, most recently seen first
+	clock   clock      // replaced in tests
+}
+
+type nonceEntry struct {
+	nonce  string
+	seenAt time.Time
+}
+
+// NewNonceCache returns an empty NonceCache, ttl and capacity 0 use the defaults.
+func NewNonceCache(ttl time.Duration, capacity int) *NonceCache {
+	return &NonceCache{TTL: ttl, Capacity: capacity}
+}
+
+// Seen records nonce and returns true if it was seen less than TTL ago. The error is always nil.
+func (c *NonceCache) Seen(nonce string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]*list.Element)
+		c.order = list.New()
+	}
+	if c.clock == nil {
+		c.clock = realClock{}
+	}
+	ttl, capacity := c.TTL, c.Capacity
+	if ttl <= 0 {
+		ttl = DefaultNonceTTL
+	}
+	if capacity <= 0 {
+		capacity = DefaultNonceCapacity
+	}
+	now := c.clock.Now()
+
+	if element, found := c.entries[nonce]; found {
+		entry := element.Value.(*nonceEntry)
+		if now.Sub(entry.seenAt) < ttl {
+			c.order.MoveToFront(element)
+			return true, nil
+		}
+		entry.seenAt = now // expired, seen again as new
+		c.order.MoveToFront(element)
+		return false, nil
+	}
+	c.entries[nonce] = c.order.PushFront(&nonceEntry{nonce: nonce, seenAt: now})
+	for c.order.Len() > capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*nonceEntry).nonce)
+	}
+	return false, nil
+}
+
+// Len returns the number of nonces held, including expired nonces not yet dropped.
+func (c *NonceCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
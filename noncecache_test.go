@@ -0,0 +1,53 @@
+package smartsheet
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test_NonceCache(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)}
+	cache := NewNonceCache(10*time.Minute, 3)
+	cache.clock = clock
+	seen := func(nonce string) bool {
+		result, err := cache.Seen(nonce)
+		if err != nil {
+			t.Fatal("NonceCache.Seen Failed", err)
+		}
+		return result
+	}
+
+	if seen("a") || !seen("a") || seen("b") {
+		t.Error("NonceCache expected a new, a seen, b new")
+	}
+	clock.Advance(9 * time.Minute)
+	if !seen("a") {
+		t.Error("NonceCache expected a seen before TTL")
+	}
+	clock.Advance(time.Minute)
+	if seen("a") || !seen("a") {
+		t.Error("NonceCache expected a new after TTL, then seen again")
+	}
+
+	// capacity 3, least recently seen dropped
+	seen("c")
+	seen("b") // b most recent, a least recent
+	seen("d")
+	if cache.Len() != 3 || seen("a") {
+		t.Error("NonceCache expected least recently seen a dropped, len", cache.Len())
+	}
+	for _, nonce := range []string{"b", "d"} {
+		if !seen(nonce) {
+			t.Error("NonceCache expected nonce kept", nonce)
+		}
+	}
+
+	defaults := new(NonceCache)
+	for i := 0; i < DefaultNonceCapacity+5; i++ {
+		defaults.Seen(fmt.Sprint(i))
+	}
+	if defaults.Len() != DefaultNonceCapacity {
+		t.Error("NonceCache expected DefaultNonceCapacity nonces, got", defaults.Len())
+	}
+}
@@ -0,0 +1,144 @@
+// oauth.go contains funcs for the OAuth2 flow: exchanging an authorization code for tokens and refreshing tokens.
+// OAuthProvider implements TokenProvider, set TokenSource to use it for all requests.
+
+package smartsheet
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenProvider supplies the access token used by DoRequest.
+// If TokenSource is nil, the Token var is used.
+type TokenProvider interface {
+	Token() (string, error) // returns access token, without "Bearer " prefix
+	Refresh() error         // called by DoRequest when a request is rejected as unauthorized (401)
+}
+
+// TokenSource, when set, is used by DoRequest instead of the Token var.
+var TokenSource TokenProvider
+
+// OAuthToken is the api response for GetAccessToken and RefreshAccessToken.
+type OAuthToken struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"` // "bearer"
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresIn    int       `json:"expires_in"` // seconds
+	Expires      time.Time `json:"expires"`    // not returned by api, computed from ExpiresIn
+}
+
+// OAuthHash returns the SHA-256 hash required by the token endpoint.
+// Parm value is the authorization code or refresh token.
+func OAuthHash(secret, value string) string {
+	sum := sha256.Sum256([]byte(secret + "|" + value))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetAccessToken exchanges an authorization code (from the OAuth redirect) for an access token and refresh token.
+// Parm redirectURI is optional ("") and must match the app's redirect url if used.
+func GetAccessToken(clientId, secret, code, redirectURI string) (*OAuthToken, error) {
+	trace("GetAccessToken")
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("client_id", clientId)
+	form.Set("code", code)
+	form.Set("hash", OAuthHash(secret, code))
+	if redirectURI != "" {
+		form.Set("redirect_uri", redirectURI)
+	}
+	return requestToken(form)
+}
+
+// RefreshAccessToken uses a refresh token to get a new access token and refresh token.
+func RefreshAccessToken(clientId, secret, refreshToken string) (*OAuthToken, error) {
+	trace("RefreshAccessToken")
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("client_id", clientId)
+	form.Set("refresh_token", refreshToken)
+	form.Set("hash", OAuthHash(secret, refreshToken))
+	return requestToken(form)
+}
+
+// requestToken posts form to the token endpoint.
+// DoRequest is not used since the request is not authorized with a token.
+func requestToken(form url.Values) (*OAuthToken, error) {
+	req, _ := http.NewRequest("POST", basePath+"/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := HttpClient.Do(req)
+	if err != nil {
+		log.Println("ERROR requestToken HTTP Request Failed - ", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		log.Println("ERROR requestToken StatusCode", resp.StatusCode, string(respJSON))
+		apiErr := &ApiError{StatusCode: resp.StatusCode}
+		json.Unmarshal(respJSON, apiErr)
+		return nil, apiErr
+	}
+	token := new(OAuthToken)
+	if err = json.Unmarshal(respJSON, token); err != nil {
+		log.Println("ERROR requestToken Unmarshal Response Failed", err)
+		return nil, err
+	}
+	token.Expires = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return token, nil
+}
+
+// OAuthProvider is a TokenProvider that refreshes the access token when it is near expiry.
+type OAuthProvider struct {
+	ClientId, Secret string
+	RefreshMargin    time.Duration          // refresh when token expires within margin, default 5 minutes
+	OnRefresh        func(token OAuthToken) // optional, called after refresh so caller can save new tokens
+
+	mu    sync.Mutex
+	token OAuthToken
+}
+
+// NewOAuthProvider returns an OAuthProvider using token, typically returned by GetAccessToken or restored from storage.
+func NewOAuthProvider(clientId, secret string, token OAuthToken) *OAuthProvider {
+	return &OAuthProvider{ClientId: clientId, Secret: secret, RefreshMargin: 5 * time.Minute, token: token}
+}
+
+// Token returns the access token, refreshing it first if it expires within RefreshMargin.
+func (p *OAuthProvider) Token() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.token.Expires.IsZero() && time.Until(p.token.Expires) < p.RefreshMargin {
+		if err := p.refresh(); err != nil {
+			return "", err
+		}
+	}
+	return p.token.AccessToken, nil
+}
+
+// Refresh gets a new access token using the refresh token.
+func (p *OAuthProvider) Refresh() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.refresh()
+}
+
+func (p *OAuthProvider) refresh() error {
+	token, err := RefreshAccessToken(p.ClientId, p.Secret, p.token.RefreshToken)
+	if err != nil {
+		return err
+	}
+	p.token = *token
+	if p.OnRefresh != nil {
+		p.OnRefresh(*token)
+	}
+	return nil
+}
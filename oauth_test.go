@@ -0,0 +1,78 @@
+package smartsheet
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_OAuthHash(t *testing.T) {
+	expect := "fa063b2c059ed3613747368aff4c0d150087a47e4346f42eb3a3d64dde096415"
+	if hash := OAuthHash("9samplesecret", "sample6p9qisx6a"); hash != expect {
+		t.Error("OAuthHash Expecting", expect, "Got", hash)
+	}
+}
+
+func Test_OAuthRefresh(t *testing.T) {
+	var refreshes int
+	var lastForm map[string]string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			r.ParseForm()
+			lastForm = map[string]string{}
+			for k := range r.PostForm {
+				lastForm[k] = r.PostForm.Get(k)
+			}
+			if lastForm["grant_type"] == "refresh_token" {
+				refreshes++
+			}
+			w.Write([]byte(`{"access_token":"new-token","token_type":"bearer","refresh_token":"refresh-2","expires_in":604799}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errorCode":1003,"message":"Your Access Token has expired."}`))
+			return
+		}
+		w.Write([]byte(`{"id":5}`))
+	})
+
+	token, err := GetAccessToken("client1", "secret1", "code1", "")
+	if err != nil || token.AccessToken != "new-token" || token.RefreshToken != "refresh-2" || token.Expires.IsZero() {
+		t.Fatalf("GetAccessToken wrong result %+v %v", token, err)
+	}
+	if lastForm["grant_type"] != "authorization_code" || lastForm["hash"] != OAuthHash("secret1", "code1") {
+		t.Error("GetAccessToken wrong form", lastForm)
+	}
+
+	// token not near expiry, 401 response causes refresh & retry
+	var saved OAuthToken
+	provider := NewOAuthProvider("client1", "secret1", OAuthToken{AccessToken: "old-token", RefreshToken: "refresh-1", Expires: time.Now().Add(time.Hour)})
+	provider.OnRefresh = func(token OAuthToken) { saved = token }
+	TokenSource = provider
+	defer func() { TokenSource = nil }()
+
+	resp, err := DoRequest(Post("/sheets/1/rows", map[string]int{"x": 1}, nil))
+	if err != nil {
+		t.Fatal("DoRequest expected retry to succeed", err)
+	}
+	resp.Body.Close()
+	if refreshes != 1 || saved.AccessToken != "new-token" {
+		t.Error("DoRequest expected 1 refresh, got", refreshes, saved)
+	}
+	if lastForm["refresh_token"] != "refresh-1" || lastForm["hash"] != OAuthHash("secret1", "refresh-1") {
+		t.Error("RefreshAccessToken wrong form", lastForm)
+	}
+
+	// token near expiry is refreshed before request
+	provider = NewOAuthProvider("client1", "secret1", OAuthToken{AccessToken: "old-token", RefreshToken: "refresh-1", Expires: time.Now().Add(time.Minute)})
+	TokenSource = provider
+	resp, err = DoRequest(Get("/sheets/1", nil))
+	if err != nil {
+		t.Fatal("DoRequest expected success", err)
+	}
+	resp.Body.Close()
+	if refreshes != 2 {
+		t.Error("Token expected refresh near expiry, refreshes:", refreshes)
+	}
+}
@@ -1,17 +1,106 @@
 package smartsheet
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // RowLocation indicates where a row should be added or moved to.
+// Legal combinations (see Validate): ToTop or ToBottom alone (top/bottom of sheet),
+// ParentId alone or with ToTop (1st child of parent) or ToBottom (last child of parent),
+// SiblingId alone or with BelowSibling (below sibling) or AboveSibling (above sibling),
+// Indent or Outdent alone (only when updating rows).
 type RowLocation struct {
-	ParentId, SiblingId           int64 // 0 indicates no parent or sibling, only one can be used
-	ToTop, ToBottom, AboveSibling bool  // only one should be true, ToBottom is default when adding rows to sheet without parent
-	Indent, Outdent               int   // to activate, load either with value of 1
+	ParentId, SiblingId                         int64 // 0 indicates no parent or sibling, only one can be used
+	ToTop, ToBottom, AboveSibling, BelowSibling bool  // only one should be true, ToBottom is default when adding rows to sheet without parent
+	Indent, Outdent                             int   // to activate, load either with value of 1
+}
+
+// Validate returns an error describing all illegal combinations of location values.
+// Called by funcs using RowLocation before the request is sent.
+func (loc *RowLocation) Validate() error {
+	problems := make([]string, 0, 3)
+	if loc.ToTop && loc.ToBottom {
+		problems = append(problems, "ToTop and ToBottom both set")
+	}
+	if loc.ParentId != 0 && loc.SiblingId != 0 {
+		problems = append(problems, "ParentId and SiblingId both set")
+	}
+	if loc.SiblingId != 0 && (loc.ToTop || loc.ToBottom) {
+		problems = append(problems, "SiblingId cannot be used with ToTop or ToBottom")
+	}
+	if loc.AboveSibling && loc.BelowSibling {
+		problems = append(problems, "AboveSibling and BelowSibling both set")
+	}
+	if (loc.AboveSibling || loc.BelowSibling) && loc.SiblingId == 0 {
+		problems = append(problems, "AboveSibling or BelowSibling requires SiblingId")
+	}
+	if loc.Indent != 0 && loc.Indent != 1 || loc.Outdent != 0 && loc.Outdent != 1 {
+		problems = append(problems, "Indent and Outdent must be 0 or 1")
+	}
+	if loc.Indent != 0 && loc.Outdent != 0 {
+		problems = append(problems, "Indent and Outdent both set")
+	}
+	if (loc.Indent != 0 || loc.Outdent != 0) && (loc.ParentId != 0 || loc.SiblingId != 0 || loc.ToTop || loc.ToBottom) {
+		problems = append(problems, "Indent or Outdent cannot be used with other location values")
+	}
+	if len(problems) > 0 {
+		return errors.New("Invalid RowLocation - " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateAdd validates location used when adding rows, Indent & Outdent are only allowed when updating rows.
+func (loc *RowLocation) validateAdd() error {
+	if loc.Indent != 0 || loc.Outdent != 0 {
+		return errors.New("Invalid RowLocation - Indent and Outdent can only be used when updating rows")
+	}
+	return loc.Validate()
 }
 
 // CopyOptions is used by CopyRows to indicate what elements (in addition to cells) are copied to the destination sheet.
 type CopyOptions struct {
 	All, Attachments, Children, Discussions bool // specify All or any mix of other options
+	CellLinks                               bool // cell links into the copied rows
+}
+
+// CopySheetOptions is used by CopySheetWith to indicate what elements (in addition to columns) are copied with the sheet.
+// Shares are only copied if Shares (or All) is set.
+type CopySheetOptions struct {
+	All                               bool // all elements, including shares, the other options are not sent with it
+	Data, Attachments, Discussions    bool
+	CellLinks, Filters, Forms, Shares bool
+	Rules, RuleRecipients             bool // RuleRecipients (notification recipients of the rules) requires Rules
+	ExcludeSheetHyperlinks            bool // hyperlinks to other sheets keep pointing at the original sheets
+}
+
+// Validate returns an error if RuleRecipients is set without Rules.
+func (options *CopySheetOptions) Validate() error {
+	if options.RuleRecipients && !options.Rules && !options.All {
+		return errors.New("Invalid CopySheetOptions - RuleRecipients requires Rules")
+	}
+	return nil
+}
+
+// includes returns the Copy Include values of the options, in CopySheetOptions field order.
+func (options *CopySheetOptions) includes() []string {
+	if options.All {
+		return []string{CopyAll}
+	}
+	return newQuery().
+		include(CopyData, options.Data).
+		include(CopyAttachments, options.Attachments).
+		include(CopyDiscussions, options.Discussions).
+		include(CopyCellLinks, options.CellLinks).
+		include(CopyFilters, options.Filters).
+		include(CopyForms, options.Forms).
+		include(CopyShares, options.Shares).
+		include(CopyRules, options.Rules).
+		include(CopyRuleRecipients, options.RuleRecipients).
+		includes
 }
 
 // MoveOptions is used by MoveRows to indicate what elements (in addition to cells) are copied to the destination sheet.
@@ -19,14 +108,163 @@ type MoveOptions struct {
 	Attachments, Discussions bool // Child rows are always moved
 }
 
+// ApplyOptions is used by ApplySchema.
+type ApplyOptions struct {
+	DryRun             bool // return the plan without changing the sheet
+	DeleteExtraColumns bool // delete sheet columns not in the schema, by default they are kept (after the schema columns)
+}
+
+// ArchiveOptions is used by ArchiveRowsWith to copy the discussions and attachments of archived rows to the dest rows.
+// Each row copied costs several requests (list, create and upload), use Include and MaxAttachmentKb to limit them.
+type ArchiveOptions struct {
+	Discussions     bool               // re-create discussions, each comment is prefixed with its original author and date
+	Attachments     bool               // re-upload file attachments and re-create link attachments (comment attachments are not copied)
+	Include         func(row Row) bool // source rows to copy discussions and attachments for, nil for all
+	MaxAttachmentKb int64              // file attachments larger than this are skipped (ArchiveResult.SkippedAttachments), 0 for no limit
+}
+
+// UploadChunkSize is the default number of rows sent per request by UploadNewRows.
+const UploadChunkSize = 500
+
+// UploadOptions is used by UploadNewRowsWith to control how NewRows are sent.
+// Parallel chunks may complete in any order, so rows added using ToBottom or ToTop may interleave in the sheet.
+// When row order matters and Parallelism > 1, use ParentId or SiblingId locations or sort the sheet afterwards.
+type UploadOptions struct {
+	ChunkSize   int // rows per request, default UploadChunkSize
+	Parallelism int // number of chunks sent concurrently, default 1, requests still share the RequestDelay throttle
+
+	// ImportKeyColumn is a text column set to a generated unique key (uuid) on each new row without a value in it.
+	// Keys are stored in NewRows, so after a failed upload SheetInfo.ReconcileNewRows can remove rows already added.
+	ImportKeyColumn string
+}
+
+func (opt *UploadOptions) chunkSize() int {
+	if opt.ChunkSize < 1 {
+		return UploadChunkSize
+	}
+	return opt.ChunkSize
+}
+
+func (opt *UploadOptions) parallelism() int {
+	if opt.Parallelism < 1 {
+		return 1
+	}
+	return opt.Parallelism
+}
+
+// UpdateQueueOptions is used by NewUpdateQueue, zero values use the defaults in updatequeue.go.
+type UpdateQueueOptions struct {
+	Interval   time.Duration               // staged updates are sent at least this often, default DefaultQueueInterval
+	BatchSize  int                         // rows per request, a batch is sent as soon as this many rows are staged, default DefaultQueueBatchSize
+	MaxRetries int                         // retries of a failed batch, default DefaultQueueRetries, -1 for no retries
+	RetryDelay time.Duration               // wait before the 1st retry, doubled for each retry, default DefaultQueueRetryDelay
+	OnError    func(rows []Row, err error) // called from the queue's goroutine with the rows not updated when a batch fails
+}
+
+// ReportOptions is used by WorkspaceReport, each option adds requests to the report.
+type ReportOptions struct {
+	Concurrency int  // sheets requested concurrently, default DefaultReportConcurrency, requests still share the RequestDelay throttle
+	Webhooks    bool // count the webhooks of each sheet, 1 ListWebHooks request for the report
+	Attachments bool // count the attachments of each sheet and their total size, 1 or more requests per sheet
+}
+
+// ParentOptions is used by SetParentIdWith.
+type ParentOptions struct {
+	ToBottom            bool // with 1 child, make it the last child of parent (default is 1st child)
+	AllowPartialSuccess bool // children that can be set are set, the others are returned in *ParentError
+}
+
 // GetSheetOptions determines what rows and columns are returned by GetSheet func.
 // If no attributes set, all rows and columns returned.
 type GetSheetOptions struct {
 	RowIds            []int64   // include only specific rows, added to url query parameters
+	RowNumbers        []int     // include only rows at these positions (Row.RowNumber, 1st row is 1), cannot be used with RowIds
 	RowsModifiedSince time.Time // include only rows modified since specific time
 	RowsModifiedMins  int       // include only rows where modified-time within x minutes before current time
 	ColumnNames       []string  // used by sheetInfo.Load to get columnIds, not used by GetSheet func
 	ColumnIds         []int64   // include only specified columns
+
+	FilterId                    int64 // apply saved sheet filter (see ListSheetFilters), rows hidden by filter have Row.FilteredOut set
+	ExcludeFilteredOutRows      bool  // used with FilterId, rows hidden by filter are not returned
+	IncludeOwnerInfo            bool  // return sheet owner email and id
+	IncludeAttachments          bool  // return attachment info in Sheet.Attachments and Row.Attachments
+	IncludeDiscussions          bool  // return discussion info (not comments) in Sheet.Discussions and Row.Discussions
+	IncludeRowPermalink         bool  // return Row.Permalink
+	IncludeSource               bool  // return Sheet.Source, the sheet, template or report the sheet was created from
+	IncludeCrossSheetReferences bool  // return Sheet.CrossSheetReferences
+	IncludeObjectValue          bool  // return Cell.ObjectValue, ex. predecessor and duration cells of project sheets
+	IncludeNonexistentCells     bool  // return a Cell for every column, including cells never having a value (excluded by default)
+	IncludeWriterInfo           bool  // return Row.CreatedBy and Row.ModifiedBy, see RowAudit
+
+	// Extra contains url query parameters not supported by other fields.
+	// Values for "include" and "exclude" are added to the values set by other fields (comma separated), other parameters replace them.
+	Extra map[string]string
+}
+
+// Validate returns an error describing all invalid option values, used by GetSheet before sending the request.
+func (options *GetSheetOptions) Validate() error {
+	problems := make([]string, 0, 2)
+	if len(options.RowIds) > 0 && len(options.RowNumbers) > 0 {
+		problems = append(problems, "RowIds and RowNumbers both set")
+	}
+	for _, rowNumber := range options.RowNumbers {
+		if rowNumber < 1 {
+			problems = append(problems, fmt.Sprintf("RowNumbers must be 1 or more, got %d", rowNumber))
+			break
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("Invalid GetSheetOptions - " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// urlParms returns the GetSheet url query parameters, exclude contains nonexistentCells unless IncludeNonexistentCells is set.
+func (options *GetSheetOptions) urlParms() map[string]string {
+	query := newQuery().
+		exclude("nonexistentCells", !options.IncludeNonexistentCells).
+		include("ownerInfo", options.IncludeOwnerInfo).
+		include("attachments", options.IncludeAttachments).
+		include("discussions", options.IncludeDiscussions).
+		include("rowPermalink", options.IncludeRowPermalink).
+		include("source", options.IncludeSource).
+		include("crossSheetReferences", options.IncludeCrossSheetReferences).
+		include("objectValue", options.IncludeObjectValue).
+		include("writerInfo", options.IncludeWriterInfo)
+	if options.FilterId != 0 {
+		query.set("filterId", strconv.FormatInt(options.FilterId, 10))
+		query.exclude("filteredOutRows", options.ExcludeFilteredOutRows)
+	}
+	query.ids("rowIds", options.RowIds).ints("rowNumbers", options.RowNumbers).ids("columnIds", options.ColumnIds)
+	if !options.RowsModifiedSince.IsZero() {
+		query.set("rowsModifiedSince", options.RowsModifiedSince.Format(time.RFC3339))
+	}
+	if options.RowsModifiedMins > 0 {
+		d := time.Duration(options.RowsModifiedMins) * time.Minute // convert mins to duration type & compute duration
+		rowsModifiedSince := time.Now().Add(-d).Format(time.RFC3339)
+		debugLn("rowsModifiedSince: ", rowsModifiedSince)
+		query.set("rowsModifiedSince", rowsModifiedSince)
+	}
+	for key, val := range options.Extra {
+		query.set(key, val)
+	}
+	return query.parms()
+}
+
+// selectsRows returns true if options request a subset of the sheet's rows.
+// A saved filter only reduces the rows returned when ExcludeFilteredOutRows is set.
+func (options *GetSheetOptions) selectsRows() bool {
+	return len(options.RowIds) > 0 || len(options.RowNumbers) > 0 || !options.RowsModifiedSince.IsZero() || options.RowsModifiedMins > 0 ||
+		(options.FilterId != 0 && options.ExcludeFilteredOutRows)
+}
+
+// GetRowOptions is used by GetRowWith.
+type GetRowOptions struct {
+	IncludeNonexistentCells bool // return a Cell for every column, see GetSheetOptions
+	IncludeWriterInfo       bool // return Row.CreatedBy and Row.ModifiedBy
+	IncludeAttachments      bool // return Row.Attachments
+	IncludeDiscussions      bool // return Row.Discussions, including their comments
+	IncludePermalink        bool // return Row.Permalink
 }
 
 // NoRows is a convenience value when requesting no rows be returned by SheetInfo.Load().
@@ -0,0 +1,84 @@
+package smartsheet
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_RowLocationValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		loc     RowLocation
+		problem string // "" indicates location is valid
+	}{
+		{"toTop", RowLocation{ToTop: true}, ""},
+		{"toBottom", RowLocation{ToBottom: true}, ""},
+		{"parent", RowLocation{ParentId: 9}, ""},
+		{"parent toBottom", RowLocation{ParentId: 9, ToBottom: true}, ""},
+		{"parent toTop", RowLocation{ParentId: 9, ToTop: true}, ""},
+		{"sibling", RowLocation{SiblingId: 9}, ""},
+		{"above sibling", RowLocation{SiblingId: 9, AboveSibling: true}, ""},
+		{"below sibling", RowLocation{SiblingId: 9, BelowSibling: true}, ""},
+		{"indent", RowLocation{Indent: 1}, ""},
+		{"outdent", RowLocation{Outdent: 1}, ""},
+		{"toTop toBottom", RowLocation{ToTop: true, ToBottom: true}, "ToTop and ToBottom both set"},
+		{"parent sibling", RowLocation{ParentId: 9, SiblingId: 8}, "ParentId and SiblingId both set"},
+		{"sibling toTop", RowLocation{SiblingId: 8, ToTop: true}, "SiblingId cannot be used with ToTop or ToBottom"},
+		{"sibling toBottom", RowLocation{SiblingId: 8, ToBottom: true}, "SiblingId cannot be used with ToTop or ToBottom"},
+		{"above below", RowLocation{SiblingId: 8, AboveSibling: true, BelowSibling: true}, "AboveSibling and BelowSibling both set"},
+		{"above no sibling", RowLocation{AboveSibling: true}, "AboveSibling or BelowSibling requires SiblingId"},
+		{"below no sibling", RowLocation{ParentId: 9, BelowSibling: true}, "AboveSibling or BelowSibling requires SiblingId"},
+		{"indent 2", RowLocation{Indent: 2}, "Indent and Outdent must be 0 or 1"},
+		{"indent outdent", RowLocation{Indent: 1, Outdent: 1}, "Indent and Outdent both set"},
+		{"indent parent", RowLocation{Indent: 1, ParentId: 9}, "Indent or Outdent cannot be used with other location values"},
+		{"outdent toTop", RowLocation{Outdent: 1, ToTop: true}, "Indent or Outdent cannot be used with other location values"},
+	}
+	for _, test := range tests {
+		err := test.loc.Validate()
+		if test.problem == "" {
+			if err != nil {
+				t.Error(test.name, "unexpected error", err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.problem) {
+			t.Error(test.name, "expected", test.problem, "got", err)
+		}
+	}
+
+	loc := RowLocation{Indent: 1}
+	if err := loc.validateAdd(); err == nil {
+		t.Error("validateAdd expected error for Indent")
+	}
+	loc = RowLocation{SiblingId: 9, BelowSibling: true}
+	locMap := CreateLocationMap(&loc)
+	if above, found := locMap["above"]; !found || above != false {
+		t.Error("CreateLocationMap expected above false for BelowSibling", locMap)
+	}
+}
+
+func Test_GetSheetOptionsValidate(t *testing.T) {
+	valid := []GetSheetOptions{
+		{},
+		{RowIds: []int64{11}, ColumnIds: []int64{101}},
+		{RowNumbers: []int{10, 25}, ColumnIds: []int64{101}},
+		{RowNumbers: []int{1}, RowsModifiedSince: time.Now()},
+	}
+	for _, options := range valid {
+		if err := options.Validate(); err != nil {
+			t.Errorf("GetSheetOptions %+v unexpected error %v", options, err)
+		}
+	}
+	options := GetSheetOptions{RowIds: []int64{11}, RowNumbers: []int{0, 2}}
+	err := options.Validate()
+	if err == nil || !strings.Contains(err.Error(), "RowIds and RowNumbers both set") || !strings.Contains(err.Error(), "got 0") {
+		t.Error("GetSheetOptions expected RowIds and RowNumbers errors, got", err)
+	}
+	if _, err = GetSheet(1, &options); err == nil {
+		t.Error("GetSheet expected invalid options error")
+	}
+	if !(&GetSheetOptions{RowNumbers: []int{3}}).selectsRows() {
+		t.Error("selectsRows expected true for RowNumbers")
+	}
+}
@@ -0,0 +1,355 @@
+// projects.go contains types for the object values of project sheet cells (predecessors and durations),
+// funcs creating predecessor and duration cells, and funcs reading and updating a sheet's project and user settings.
+// Object values are returned when GetSheetOptions.IncludeObjectValue is set, see Cell.ObjectValue.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// Column Types of project sheet columns with object values
+const (
+	PREDECESSOR = "PREDECESSOR"
+	DURATION    = "DURATION"
+)
+
+// Object Value Types
+const (
+	ObjectPredecessorList = "PREDECESSOR_LIST"
+	ObjectDuration        = "DURATION"
+)
+
+// Predecessor Types
+const (
+	FinishToStart  = "FS" // default
+	FinishToFinish = "FF"
+	StartToStart   = "SS"
+	StartToFinish  = "SF"
+)
+
+// ObjectValue is the value of Cell.ObjectValue, *PredecessorList, *Duration or OtherObjectValue.
+type ObjectValue interface {
+	ObjectValueType() string
+}
+
+// PredecessorList is the object value of a PREDECESSOR column cell.
+type PredecessorList struct {
+	ObjectType   string        `json:"objectType"` // ObjectPredecessorList
+	Predecessors []Predecessor `json:"predecessors"`
+}
+
+// Predecessor identifies a row the cell's row depends on.
+// RowId is required by the api when writing, RowNumber is set from RowId by the api.
+// SheetInfo.AddRow and UpdateRow set RowId using RowNumber and SheetInfo.Rows, see NewPredecessorCell.
+type Predecessor struct {
+	RowId          int64     `json:"rowId,omitempty"`
+	RowNumber      int       `json:"rowNumber,omitempty"`
+	Type           string    `json:"type"` // use Predecessor Type constants, ex. FinishToStart
+	Lag            *Duration `json:"lag,omitempty"`
+	InCriticalPath bool      `json:"inCriticalPath,omitempty"` // returned by api
+	Invalid        bool      `json:"invalid,omitempty"`        // returned by api, ex. predecessor row deleted
+}
+
+// Duration is the object value of a DURATION column cell, also used for Predecessor.Lag.
+// Elapsed durations include non-working days.
+type Duration struct {
+	ObjectType   string  `json:"objectType"` // ObjectDuration
+	Negative     bool    `json:"negative,omitempty"`
+	Elapsed      bool    `json:"elapsed,omitempty"`
+	Weeks        float64 `json:"weeks,omitempty"`
+	Days         float64 `json:"days,omitempty"`
+	Hours        float64 `json:"hours,omitempty"`
+	Minutes      float64 `json:"minutes,omitempty"`
+	Seconds      float64 `json:"seconds,omitempty"`
+	Milliseconds float64 `json:"milliseconds,omitempty"`
+}
+
+// OtherObjectValue contains object values of types not supported by this package, ex. MULTI_CONTACT.
+type OtherObjectValue map[string]interface{}
+
+func (list *PredecessorList) ObjectValueType() string { return ObjectPredecessorList }
+func (d *Duration) ObjectValueType() string           { return ObjectDuration }
+func (other OtherObjectValue) ObjectValueType() string {
+	objectType, _ := other["objectType"].(string)
+	return objectType
+}
+
+// String returns predecessors as shown in Smartsheet, ex. "3, 5SS, 7FS +2d".
+func (list *PredecessorList) String() string {
+	values := make([]string, len(list.Predecessors))
+	for i, pred := range list.Predecessors {
+		values[i] = pred.String()
+	}
+	return strings.Join(values, ", ")
+}
+
+// String returns the predecessor as shown in Smartsheet, ex. "3FS +2d". The type is omitted for FS without lag.
+func (pred Predecessor) String() string {
+	value := strconv.Itoa(pred.RowNumber)
+	hasLag := pred.Lag != nil && !pred.Lag.IsZero()
+	if pred.Type != FinishToStart || hasLag {
+		value += pred.Type
+	}
+	if hasLag {
+		if pred.Lag.Negative {
+			value += " " + pred.Lag.String()
+		} else {
+			value += " +" + pred.Lag.String()
+		}
+	}
+	return value
+}
+
+// IsZero returns true if all duration units are 0.
+func (d *Duration) IsZero() bool {
+	return d.Weeks == 0 && d.Days == 0 && d.Hours == 0 && d.Minutes == 0 && d.Seconds == 0 && d.Milliseconds == 0
+}
+
+// String returns the duration as shown in Smartsheet, ex. "2d", "1w 2d", "-4h", elapsed "e3d".
+func (d *Duration) String() string {
+	units := []struct {
+		value  float64
+		suffix string
+	}{{d.Weeks, "w"}, {d.Days, "d"}, {d.Hours, "h"}, {d.Minutes, "m"}, {d.Seconds, "s"}, {d.Milliseconds, "ms"}}
+	prefix := ""
+	if d.Negative {
+		prefix = "-"
+	}
+	if d.Elapsed {
+		prefix += "e"
+	}
+	values := make([]string, 0, 2)
+	for _, unit := range units {
+		if unit.value != 0 {
+			values = append(values, prefix+strconv.FormatFloat(unit.value, 'f', -1, 64)+unit.suffix)
+			prefix = ""
+		}
+	}
+	if len(values) == 0 {
+		return prefix + "0d"
+	}
+	return strings.Join(values, " ")
+}
+
+// NewPredecessorCell returns a cell for a PREDECESSOR column depending on 1 row.
+// Parm predType is a Predecessor Type constant (ex. FinishToStart), lag is in days and may be negative or 0.
+// The row's id is set from rowNumber when the cell is staged using SheetInfo.AddRow or UpdateRow.
+// Use AddPredecessor to depend on additional rows.
+func NewPredecessorCell(colName string, rowNumber int, predType string, lag float64) Cell {
+	cell := Cell{ColName: colName, ObjectValue: &PredecessorList{ObjectType: ObjectPredecessorList}}
+	AddPredecessor(&cell, rowNumber, predType, lag)
+	return cell
+}
+
+// AddPredecessor adds a predecessor to a cell created by NewPredecessorCell.
+func AddPredecessor(cell *Cell, rowNumber int, predType string, lag float64) {
+	list, ok := cell.ObjectValue.(*PredecessorList)
+	if !ok {
+		list = &PredecessorList{ObjectType: ObjectPredecessorList}
+		cell.ObjectValue = list
+	}
+	pred := Predecessor{RowNumber: rowNumber, Type: predType}
+	if lag != 0 {
+		pred.Lag = &Duration{ObjectType: ObjectDuration, Days: lag}
+		if lag < 0 {
+			pred.Lag.Negative, pred.Lag.Days = true, -lag
+		}
+	}
+	list.Predecessors = append(list.Predecessors, pred)
+}
+
+// NewDurationCell returns a cell for a DURATION column.
+func NewDurationCell(colName string, duration Duration) Cell {
+	duration.ObjectType = ObjectDuration
+	return Cell{ColName: colName, ObjectValue: &duration}
+}
+
+// cellJSON is used by Cell.UnmarshalJSON, the alias type does not have the UnmarshalJSON method.
+type cellJSON Cell
+
+// UnmarshalJSON decodes Cell.ObjectValue into the type matching its objectType.
+func (cell *Cell) UnmarshalJSON(data []byte) error {
+	var decoded struct {
+		cellJSON
+		ObjectValue json.RawMessage `json:"objectValue"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*cell = Cell(decoded.cellJSON)
+	// cells without an object type return their value (ex. text, date) as objectValue, it is the same as Value
+	if len(decoded.ObjectValue) == 0 || decoded.ObjectValue[0] != '{' {
+		return nil
+	}
+	var objectType struct {
+		ObjectType string `json:"objectType"`
+	}
+	json.Unmarshal(decoded.ObjectValue, &objectType)
+	var value ObjectValue
+	switch objectType.ObjectType {
+	case ObjectPredecessorList:
+		value = new(PredecessorList)
+	case ObjectDuration:
+		value = new(Duration)
+	default:
+		value = new(OtherObjectValue)
+	}
+	if err := json.Unmarshal(decoded.ObjectValue, value); err != nil {
+		return fmt.Errorf("Invalid Cell ObjectValue %s - %v", objectType.ObjectType, err)
+	}
+	if other, ok := value.(*OtherObjectValue); ok {
+		value = *other
+	}
+	cell.ObjectValue = value
+	return nil
+}
+
+// resolvePredecessors sets the RowId of predecessors using RowNumber and SheetInfo.Rows.
+func (she *SheetInfo) resolvePredecessors(cells []Cell) error {
+	for _, cell := range cells {
+		list, ok := cell.ObjectValue.(*PredecessorList)
+		if !ok {
+			continue
+		}
+		for i, pred := range list.Predecessors {
+			if pred.RowId != 0 {
+				continue
+			}
+			for _, row := range she.Rows {
+				if row.RowNumber == pred.RowNumber {
+					list.Predecessors[i].RowId = row.Id
+					break
+				}
+			}
+			if list.Predecessors[i].RowId == 0 {
+				return fmt.Errorf("Invalid Predecessor - RowNumber %d not in loaded rows, ColName %s", pred.RowNumber, cell.ColName)
+			}
+		}
+	}
+	return nil
+}
+
+// GetSheetProjectSettings returns whether dependencies are enabled and the working schedule of a sheet.
+// WorkingDays, NonWorkingDays and LengthOfDay are only returned when dependencies are enabled.
+func GetSheetProjectSettings(sheetId int64) (*ProjectSettings, error) {
+	trace("GetSheetProjectSettings")
+	sheet, err := GetSheet(sheetId, &GetSheetOptions{RowIds: []int64{0}, ColumnIds: []int64{0}})
+	if err != nil {
+		return nil, err
+	}
+	settings := sheet.projectSettings()
+	if settings == nil {
+		settings = new(ProjectSettings)
+	}
+	return settings, nil
+}
+
+// UpdateSheetProjectSettings enables or disables dependencies and sets the working schedule, returning the applied settings.
+// The schedule is only sent when settings.DependenciesEnabled is true. Enabling dependencies fails if the sheet
+// does not have the columns required by the api (ex. start and end date), the error wraps the *ApiError.
+func UpdateSheetProjectSettings(sheetId int64, settings ProjectSettings) (*ProjectSettings, error) {
+	trace("UpdateSheetProjectSettings")
+	reqData := map[string]interface{}{"dependenciesEnabled": settings.DependenciesEnabled}
+	if settings.DependenciesEnabled {
+		reqData["projectSettings"] = settings
+	}
+	endPoint := fmt.Sprintf("/sheets/%d", sheetId)
+	req := Put(endPoint, reqData, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		var apiErr *ApiError
+		if errors.As(err, &apiErr) {
+			err = fmt.Errorf("UpdateSheetProjectSettings sheet %d rejected, ErrorCode %d %s: %w", sheetId, apiErr.ErrorCode, apiErr.Message, err)
+		}
+		log.Println("ERROR UpdateSheetProjectSettings", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	apiResp := struct {
+		Message    string `json:"message"`
+		ResultCode int    `json:"resultCode"`
+		Result     Sheet  `json:"result"`
+	}{}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR - UpdateSheetProjectSettings Unmarshal Response Failed", err)
+		return nil, err
+	}
+	applied := apiResp.Result.projectSettings()
+	if applied == nil {
+		applied = new(ProjectSettings)
+	}
+	return applied, nil
+}
+
+// GetSheetUserSettings returns the current user's display settings for a sheet, ex. CriticalPathEnabled.
+// The settings are also set in SheetInfo.UserSettings by Load.
+func GetSheetUserSettings(sheetId int64) (*SheetUserSettings, error) {
+	trace("GetSheetUserSettings")
+	sheet, err := GetSheet(sheetId, &GetSheetOptions{RowIds: []int64{0}, ColumnIds: []int64{0}})
+	if err != nil {
+		return nil, err
+	}
+	if sheet.UserSettings == nil {
+		return new(SheetUserSettings), nil
+	}
+	return sheet.UserSettings, nil
+}
+
+// UpdateSheetUserSettings changes the current user's display settings for a sheet, returning the applied settings.
+// Only the userSettings object is sent, other sheet attributes (ex. name, project settings) are not changed.
+func UpdateSheetUserSettings(sheetId int64, settings SheetUserSettings) (*SheetUserSettings, error) {
+	trace("UpdateSheetUserSettings")
+	reqData := map[string]interface{}{"userSettings": settings}
+	endPoint := fmt.Sprintf("/sheets/%d", sheetId)
+	req := Put(endPoint, reqData, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	apiResp := struct {
+		Message    string `json:"message"`
+		ResultCode int    `json:"resultCode"`
+		Result     Sheet  `json:"result"`
+	}{}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR - UpdateSheetUserSettings Unmarshal Response Failed", err)
+		return nil, err
+	}
+	if apiResp.Result.UserSettings == nil {
+		return &settings, nil // not returned by api, assume applied
+	}
+	return apiResp.Result.UserSettings, nil
+}
+
+// projectSettings returns the sheet's ProjectSettings with DependenciesEnabled set, nil if not returned by api.
+func (sheet *Sheet) projectSettings() *ProjectSettings {
+	if sheet.ProjectSettings == nil {
+		if sheet.DependenciesEnabled {
+			return &ProjectSettings{DependenciesEnabled: true}
+		}
+		return nil
+	}
+	settings := *sheet.ProjectSettings
+	settings.DependenciesEnabled = sheet.DependenciesEnabled
+	return &settings
+}
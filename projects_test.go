@@ -0,0 +1,199 @@
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Test_ProjectSheet loads testdata/project_sheet.json (GetSheet response with include=objectValue).
+func Test_ProjectSheet(t *testing.T) {
+	sheetJSON, err := ioutil.ReadFile("testdata/project_sheet.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reqBody string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			if r.URL.Query().Get("include") != "objectValue" {
+				t.Error("Load expected include=objectValue, got", r.URL.RawQuery)
+			}
+			w.Write(sheetJSON)
+		case "PUT":
+			reqBytes, _ := ioutil.ReadAll(r.Body)
+			reqBody = compactJSON(reqBytes)
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[]}`))
+		}
+	})
+	sheet := new(SheetInfo)
+	if err = sheet.Load(4583173393803140, &GetSheetOptions{IncludeObjectValue: true}); err != nil {
+		t.Fatal("Load Failed", err)
+	}
+	if !sheet.DependenciesEnabled || sheet.ProjectSettings == nil || sheet.ProjectSettings.LengthOfDay != 8 ||
+		sheet.UserSettings == nil || !sheet.UserSettings.CriticalPathEnabled {
+		t.Errorf("Load project settings not set, %v %+v %+v", sheet.DependenciesEnabled, sheet.ProjectSettings, sheet.UserSettings)
+	}
+
+	list, ok := sheet.Rows[1].Cells[2].ObjectValue.(*PredecessorList)
+	if !ok || len(list.Predecessors) != 1 || list.Predecessors[0].RowId != 31 || !list.Predecessors[0].InCriticalPath || list.Predecessors[0].Lag.Days != 2 {
+		t.Errorf("Predecessor ObjectValue wrong, %#v", sheet.Rows[1].Cells[2].ObjectValue)
+	}
+	if duration, ok := sheet.Rows[2].Cells[1].ObjectValue.(*Duration); !ok || !duration.Elapsed || duration.Hours != 4 {
+		t.Errorf("Duration ObjectValue wrong, %#v", sheet.Rows[2].Cells[1].ObjectValue)
+	}
+	if sheet.Rows[0].Cells[2].ObjectValue != nil || sheet.Rows[0].Cells[2].Value != "2020-10-05T08:00:00" {
+		t.Errorf("Date cell expected no ObjectValue, %#v", sheet.Rows[0].Cells[2])
+	}
+	if other, ok := sheet.Rows[2].Cells[3].ObjectValue.(OtherObjectValue); !ok || other.ObjectValueType() != "MULTI_CONTACT" {
+		t.Errorf("Other ObjectValue wrong, %#v", sheet.Rows[2].Cells[3].ObjectValue)
+	}
+
+	// rendered values match the api displayValue
+	for _, row := range sheet.Rows {
+		values := RowValues(sheet, row)
+		for _, cell := range row.Cells {
+			column := sheet.ColumnsById[cell.ColumnId]
+			if cell.DisplayValue != "" && values[column.Title] != cell.DisplayValue {
+				t.Errorf("RowValues %s, Expecting %s, Got %s", column.Title, cell.DisplayValue, values[column.Title])
+			}
+		}
+	}
+
+	// round trip, object values marshal as returned by api
+	var raw struct {
+		Rows []struct {
+			Cells []struct {
+				ColumnId    int64
+				ObjectValue json.RawMessage
+			}
+		}
+	}
+	json.Unmarshal(sheetJSON, &raw)
+	for i, rawRow := range raw.Rows {
+		for j, rawCell := range rawRow.Cells {
+			if len(rawCell.ObjectValue) == 0 || rawCell.ObjectValue[0] != '{' {
+				continue
+			}
+			got, _ := json.Marshal(sheet.Rows[i].Cells[j].ObjectValue)
+			if string(got) != compactJSON(rawCell.ObjectValue) {
+				t.Errorf("ObjectValue round trip, Expecting %s, Got %s", compactJSON(rawCell.ObjectValue), got)
+			}
+		}
+	}
+
+	// write predecessors using row numbers, row ids set from loaded rows
+	cell := NewPredecessorCell("Predecessors", 1, FinishToStart, 0)
+	AddPredecessor(&cell, 2, StartToStart, -1.5)
+	if cell.ObjectValue.(*PredecessorList).String() != "1, 2SS -1.5d" {
+		t.Error("PredecessorList String wrong", cell.ObjectValue)
+	}
+	if err = sheet.UpdateRow(Row{Id: 33, Cells: []Cell{cell, NewDurationCell("Duration", Duration{Days: 3})}}); err != nil {
+		t.Fatal("UpdateRow Failed", err)
+	}
+	if _, err = sheet.UploadUpdateRows(nil); err != nil {
+		t.Fatal("UploadUpdateRows Failed", err)
+	}
+	expect := `[{"cells":[{"columnId":303,"objectValue":{"objectType":"PREDECESSOR_LIST","predecessors":[{"rowId":31,"rowNumber":1,"type":"FS"},` +
+		`{"rowId":32,"rowNumber":2,"type":"SS","lag":{"objectType":"DURATION","negative":true,"days":1.5}}]}},` +
+		`{"columnId":302,"objectValue":{"objectType":"DURATION","days":3}}],"id":"33"}]`
+	if reqBody != expect {
+		t.Errorf("UploadUpdateRows predecessors, Expecting %s, Got %s", expect, reqBody)
+	}
+
+	if err = sheet.UpdateRow(Row{Id: 33, Cells: []Cell{NewPredecessorCell("Predecessors", 9, FinishToStart, 0)}}); err == nil {
+		t.Error("UpdateRow expected error for predecessor row number not loaded")
+	}
+}
+
+func Test_SheetProjectSettings(t *testing.T) {
+	var reqBody, query string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			query = r.URL.RawQuery
+			w.Write([]byte(`{"id":4583173393803140,"name":"Project Plan","dependenciesEnabled":true,
+				"projectSettings":{"workingDays":["MONDAY","TUESDAY"],"nonWorkingDays":["2020-12-25"],"lengthOfDay":8},"columns":[],"rows":[]}`))
+		case "PUT":
+			reqBytes, _ := ioutil.ReadAll(r.Body)
+			reqBody = compactJSON(reqBytes)
+			if r.URL.Path == "/sheets/99" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"errorCode":1212,"message":"Dependencies require Start Date, End Date columns.","refId":"abc"}`))
+				return
+			}
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":4583173393803140,"dependenciesEnabled":true,
+				"projectSettings":{"workingDays":["MONDAY","TUESDAY","WEDNESDAY"],"nonWorkingDays":[],"lengthOfDay":7.5}}}`))
+		}
+	})
+	settings, err := GetSheetProjectSettings(4583173393803140)
+	if err != nil {
+		t.Fatal("GetSheetProjectSettings Failed", err)
+	}
+	if !settings.DependenciesEnabled || len(settings.WorkingDays) != 2 || settings.NonWorkingDays[0] != "2020-12-25" || settings.LengthOfDay != 8 {
+		t.Errorf("GetSheetProjectSettings wrong settings %+v", settings)
+	}
+	if !strings.Contains(query, "rowIds=0") || !strings.Contains(query, "columnIds=0") {
+		t.Error("GetSheetProjectSettings expected no rows or columns requested, got", query)
+	}
+
+	update := ProjectSettings{DependenciesEnabled: true, WorkingDays: []string{"MONDAY", "TUESDAY", "WEDNESDAY"}, NonWorkingDays: []string{}, LengthOfDay: 7.5}
+	applied, err := UpdateSheetProjectSettings(4583173393803140, update)
+	if err != nil {
+		t.Fatal("UpdateSheetProjectSettings Failed", err)
+	}
+	expect := `{"dependenciesEnabled":true,"projectSettings":{"workingDays":["MONDAY","TUESDAY","WEDNESDAY"],"nonWorkingDays":[],"lengthOfDay":7.5}}`
+	if reqBody != expect {
+		t.Errorf("UpdateSheetProjectSettings, Expecting %s, Got %s", expect, reqBody)
+	}
+	if !applied.DependenciesEnabled || applied.LengthOfDay != 7.5 || len(applied.WorkingDays) != 3 {
+		t.Errorf("UpdateSheetProjectSettings wrong applied settings %+v", applied)
+	}
+
+	if _, err = UpdateSheetProjectSettings(4583173393803140, ProjectSettings{LengthOfDay: 8}); err != nil || reqBody != `{"dependenciesEnabled":false}` {
+		t.Error("UpdateSheetProjectSettings disable, expected schedule not sent, got", reqBody, err)
+	}
+
+	_, err = UpdateSheetProjectSettings(99, ProjectSettings{DependenciesEnabled: true})
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != 1212 || !strings.Contains(err.Error(), "ErrorCode 1212") {
+		t.Error("UpdateSheetProjectSettings expected ApiError with ErrorCode 1212, got", err)
+	}
+}
+
+func Test_SheetUserSettings(t *testing.T) {
+	var reqBody, query string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			query = r.URL.RawQuery
+			w.Write([]byte(`{"id":4583173393803140,"name":"Project Plan","dependenciesEnabled":true,
+				"userSettings":{"criticalPathEnabled":false,"displaySummaryTasks":true},"columns":[],"rows":[]}`))
+		case "PUT":
+			reqBytes, _ := ioutil.ReadAll(r.Body)
+			reqBody = compactJSON(reqBytes)
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":4583173393803140,"name":"Project Plan",
+				"userSettings":{"criticalPathEnabled":true,"displaySummaryTasks":true}}}`))
+		}
+	})
+	settings, err := GetSheetUserSettings(4583173393803140)
+	if err != nil || settings.CriticalPathEnabled || !settings.DisplaySummaryTasks {
+		t.Fatalf("GetSheetUserSettings wrong result %+v %v", settings, err)
+	}
+	if !strings.Contains(query, "rowIds=0") || !strings.Contains(query, "columnIds=0") {
+		t.Error("GetSheetUserSettings expected no rows or columns requested, got", query)
+	}
+
+	settings.CriticalPathEnabled = true
+	applied, err := UpdateSheetUserSettings(4583173393803140, *settings)
+	if err != nil || !applied.CriticalPathEnabled || !applied.DisplaySummaryTasks {
+		t.Errorf("UpdateSheetUserSettings wrong result %+v %v", applied, err)
+	}
+	expect := `{"userSettings":{"criticalPathEnabled":true,"displaySummaryTasks":true}}` // no name, dependenciesEnabled, etc.
+	if reqBody != expect {
+		t.Errorf("UpdateSheetUserSettings, Expecting %s, Got %s", expect, reqBody)
+	}
+}
@@ -0,0 +1,174 @@
+// proofs.go contains funcs for row proofs used in review workflows.
+// A proof is a file attached to a row that reviewers approve or reject.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+// Proof is returned by ListRowProofs, CreateProof and GetProof.
+// Versions and RequestActions are only loaded by GetProof.
+type Proof struct {
+	Id             int64                `json:"id"`
+	OriginalId     int64                `json:"originalId"` // id of version 1 of the proof
+	Name           string               `json:"name"`
+	ProofType      string               `json:"proofType"` // ex. "IMAGE", "PDF", "VIDEO"
+	Version        int                  `json:"version"`
+	IsCompleted    bool                 `json:"isCompleted"`
+	LastUpdatedAt  string               `json:"lastUpdatedAt"`
+	LastUpdatedBy  ProofUser            `json:"lastUpdatedBy"`
+	Versions       []Proof              `json:"-"`
+	RequestActions []ProofRequestAction `json:"-"`
+}
+
+type ProofUser struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// ProofRequest is returned by CreateProofRequest.
+type ProofRequest struct {
+	Id      int64            `json:"id"`
+	SendTo  []EmailRecipient `json:"sendTo"`
+	Message string           `json:"message"`
+	SentAt  string           `json:"sentAt"`
+	SentBy  ProofUser        `json:"sentBy"`
+}
+
+// ProofRequestAction is a reviewer's response to a proof request.
+type ProofRequestAction struct {
+	Action     string    `json:"action"` // ex. "APPROVED", "REJECTED"
+	ActionedAt string    `json:"actionedAt"`
+	ActionedBy ProofUser `json:"actionedBy"`
+}
+
+// ListRowProofs returns the proofs attached to a row.
+func ListRowProofs(sheetId, rowId int64) ([]Proof, error) {
+	trace("ListRowProofs")
+	endPoint := fmt.Sprintf("/sheets/%d/rows/%d/proofs", sheetId, rowId)
+	return listProofs(endPoint)
+}
+
+// CreateProof uploads the local file at filePath as a new proof on a row.
+// Expensive operation, occurs 10 additional requests against rate limit.
+func CreateProof(sheetId, rowId int64, filePath string) (*Proof, error) {
+	trace("CreateProof")
+	endPoint := fmt.Sprintf("/sheets/%d/rows/%d/proofs", sheetId, rowId)
+	resp, err := uploadFile(endPoint, filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	var apiResp struct {
+		Message    string `json:"message"`
+		ResultCode int    `json:"resultCode"`
+		Result     Proof  `json:"result"`
+	}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR CreateProof Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return &apiResp.Result, nil
+}
+
+// GetProof returns a proof, including its versions and the actions taken on its proof requests.
+// Uses 3 requests.
+func GetProof(sheetId, proofId int64) (*Proof, error) {
+	trace("GetProof")
+	endPoint := fmt.Sprintf("/sheets/%d/proofs/%d", sheetId, proofId)
+	req := Get(endPoint, nil)
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+
+	proof := new(Proof)
+	if err = json.Unmarshal(respJSON, proof); err != nil {
+		log.Println("ERROR GetProof Unmarshal Response Failed", err)
+		return nil, err
+	}
+	if proof.Versions, err = listProofs(endPoint + "/versions"); err != nil {
+		return nil, err
+	}
+	proof.RequestActions = make([]ProofRequestAction, 0, 10)
+	err = getAllPages(endPoint+"/requestactions", nil, func(data json.RawMessage) error {
+		var page []ProofRequestAction
+		err := json.Unmarshal(data, &page)
+		proof.RequestActions = append(proof.RequestActions, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// CreateProofRequest emails recipients a request to review a proof, see Recipients func.
+func CreateProofRequest(sheetId, proofId int64, recipients []EmailRecipient, message string) (*ProofRequest, error) {
+	trace("CreateProofRequest")
+	problems := make([]string, 0, 5)
+	if len(recipients) == 0 {
+		problems = append(problems, "recipients is empty")
+	}
+	for i, recipient := range recipients {
+		if msg := checkRecipient(recipient); msg != "" {
+			problems = append(problems, fmt.Sprintf("recipients[%d] %s", i, msg))
+		}
+	}
+	if len(problems) > 0 {
+		log.Println("ERROR CreateProofRequest", problems)
+		return nil, errors.New("Invalid ProofRequest - " + strings.Join(problems, "; "))
+	}
+	reqBody := map[string]interface{}{
+		"sendTo":  recipients,
+		"message": message,
+	}
+	endPoint := fmt.Sprintf("/sheets/%d/proofs/%d/requests", sheetId, proofId)
+	req := Post(endPoint, reqBody, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	var apiResp struct {
+		Message    string       `json:"message"`
+		ResultCode int          `json:"resultCode"`
+		Result     ProofRequest `json:"result"`
+	}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR CreateProofRequest Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return &apiResp.Result, nil
+}
+
+func listProofs(endPoint string) ([]Proof, error) {
+	proofs := make([]Proof, 0, 10)
+	err := getAllPages(endPoint, nil, func(data json.RawMessage) error {
+		var page []Proof
+		err := json.Unmarshal(data, &page)
+		proofs = append(proofs, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return proofs, nil
+}
@@ -0,0 +1,81 @@
+package smartsheet
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Proofs(t *testing.T) {
+	var body, lastPath string
+	var header http.Header
+	var contentLength int64
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		lastPath = r.Method + " " + r.URL.Path
+		header = r.Header
+		contentLength = r.ContentLength
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		body = string(reqBytes)
+		switch lastPath {
+		case "POST /sheets/1849449510135684/rows/6935334990440324/proofs":
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":501,"originalId":501,"name":"banner.png","proofType":"IMAGE","version":1}}`))
+		case "GET /sheets/1849449510135684/rows/6935334990440324/proofs":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"id":502,"originalId":501,"name":"banner.png","proofType":"IMAGE","version":2,
+				"lastUpdatedAt":"2024-03-01T10:00:00Z","lastUpdatedBy":{"email":"jay@test.com","name":"Jay"}}]}`))
+		case "GET /sheets/1849449510135684/proofs/502":
+			w.Write([]byte(`{"id":502,"originalId":501,"name":"banner.png","proofType":"IMAGE","version":2,"isCompleted":true}`))
+		case "GET /sheets/1849449510135684/proofs/502/versions":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"id":502,"version":2},{"id":501,"version":1}]}`))
+		case "GET /sheets/1849449510135684/proofs/502/requestactions":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"action":"APPROVED","actionedAt":"2024-03-02T09:00:00Z","actionedBy":{"email":"a@test.com","name":"Ann"}}]}`))
+		case "POST /sheets/1849449510135684/proofs/502/requests":
+			body = compactJSON(reqBytes)
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":77,"sendTo":[{"email":"a@test.com"}],"message":"Please review"}}`))
+		}
+	})
+	var sheetId, rowId int64 = 1849449510135684, 6935334990440324
+
+	filePath := filepath.Join(t.TempDir(), "banner.png")
+	content := "fake png content"
+	ioutil.WriteFile(filePath, []byte(content), 0644)
+
+	proof, err := CreateProof(sheetId, rowId, filePath)
+	if err != nil || proof.Id != 501 || proof.ProofType != "IMAGE" {
+		t.Fatalf("CreateProof wrong result %+v %v", proof, err)
+	}
+	if disposition := header.Get("Content-Disposition"); disposition != `attachment; filename="banner.png"` {
+		t.Error("CreateProof wrong Content-Disposition", disposition)
+	}
+	if contentLength != int64(len(content)) || body != content {
+		t.Error("CreateProof wrong upload, Content-Length", contentLength, "body", body)
+	}
+	if _, err = CreateProof(sheetId, rowId, filepath.Join(t.TempDir(), "missing.png")); err == nil {
+		t.Error("CreateProof expected missing file error")
+	}
+
+	proofs, err := ListRowProofs(sheetId, rowId)
+	if err != nil || len(proofs) != 1 || proofs[0].Version != 2 || proofs[0].LastUpdatedBy.Name != "Jay" {
+		t.Errorf("ListRowProofs wrong result %+v %v", proofs, err)
+	}
+
+	proof, err = GetProof(sheetId, 502)
+	if err != nil || !proof.IsCompleted || len(proof.Versions) != 2 || proof.Versions[1].Id != 501 {
+		t.Fatalf("GetProof wrong result %+v %v", proof, err)
+	}
+	if len(proof.RequestActions) != 1 || proof.RequestActions[0].Action != "APPROVED" || proof.RequestActions[0].ActionedBy.Email != "a@test.com" {
+		t.Errorf("GetProof wrong request actions %+v", proof.RequestActions)
+	}
+
+	request, err := CreateProofRequest(sheetId, 502, Recipients(NewEmailRecipient("a@test.com")), "Please review")
+	if err != nil || request.Id != 77 {
+		t.Errorf("CreateProofRequest wrong result %+v %v", request, err)
+	}
+	expect := `{"message":"Please review","sendTo":[{"email":"a@test.com"}]}`
+	if body != expect {
+		t.Errorf("CreateProofRequest Expecting %s, Got %s", expect, body)
+	}
+	if _, err = CreateProofRequest(sheetId, 502, nil, "Please review"); err == nil {
+		t.Error("CreateProofRequest expected empty recipients error")
+	}
+}
@@ -0,0 +1,125 @@
+// query.go contains queryBuilder, which assembles the url query parameters of requests:
+// include and exclude lists, id lists and flags.
+
+package smartsheet
+
+import (
+	"strconv"
+	"strings"
+)
+
+// maxQueryIdsLen is the maximum length of a comma separated id list sent in a url, longer lists are split (see splitIds)
+// to keep urls under the 8KB limit of the api and proxies.
+const maxQueryIdsLen = 4000
+
+// queryBuilder collects url query parameters, parms returns them for Get, Post, Put and Delete.
+// Include and exclude values are joined in the order added. Empty lists and false flags are not sent.
+type queryBuilder struct {
+	includes []string
+	excludes []string
+	values   map[string]string
+}
+
+func newQuery() *queryBuilder {
+	return &queryBuilder{values: make(map[string]string)}
+}
+
+// include adds value to the include parameter if when is true.
+func (q *queryBuilder) include(value string, when bool) *queryBuilder {
+	if when {
+		q.includes = append(q.includes, value)
+	}
+	return q
+}
+
+// exclude adds value to the exclude parameter if when is true.
+func (q *queryBuilder) exclude(value string, when bool) *queryBuilder {
+	if when {
+		q.excludes = append(q.excludes, value)
+	}
+	return q
+}
+
+// set sets a parameter, replacing an earlier value. Include and exclude values are added to the lists instead.
+func (q *queryBuilder) set(key, value string) *queryBuilder {
+	switch key {
+	case "include":
+		q.includes = append(q.includes, value)
+	case "exclude":
+		q.excludes = append(q.excludes, value)
+	default:
+		q.values[key] = value
+	}
+	return q
+}
+
+// ids sets key to the comma separated ids, not set if ids is empty.
+func (q *queryBuilder) ids(key string, ids []int64) *queryBuilder {
+	if len(ids) > 0 {
+		q.values[key] = joinIds(ids)
+	}
+	return q
+}
+
+// ints sets key to the comma separated values, not set if values is empty.
+func (q *queryBuilder) ints(key string, values []int) *queryBuilder {
+	if len(values) > 0 {
+		strs := make([]string, len(values))
+		for i, value := range values {
+			strs[i] = strconv.Itoa(value)
+		}
+		q.values[key] = strings.Join(strs, ",")
+	}
+	return q
+}
+
+// flag sets key to "true" if on, not set if false.
+func (q *queryBuilder) flag(key string, on bool) *queryBuilder {
+	if on {
+		q.values[key] = "true"
+	}
+	return q
+}
+
+// parms returns the url query parameters, nil if none.
+func (q *queryBuilder) parms() map[string]string {
+	if len(q.includes) > 0 {
+		q.values["include"] = strings.Join(q.includes, ",")
+	}
+	if len(q.excludes) > 0 {
+		q.values["exclude"] = strings.Join(q.excludes, ",")
+	}
+	if len(q.values) == 0 {
+		return nil
+	}
+	return q.values
+}
+
+func joinIds(ids []int64) string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(strs, ",")
+}
+
+// splitIds returns ids in chunks whose comma separated length is at most maxLen, an id longer than maxLen is sent alone.
+func splitIds(ids []int64, maxLen int) [][]int64 {
+	chunks := make([][]int64, 0, 1)
+	first, length := 0, 0
+	for i, id := range ids {
+		idLen := len(strconv.FormatInt(id, 10))
+		if i > first && length+1+idLen > maxLen {
+			chunks = append(chunks, ids[first:i])
+			first, length = i, 0
+		}
+		if i > first {
+			length++ // comma
+		}
+		length += idLen
+	}
+	if len(ids) > first {
+		chunks = append(chunks, ids[first:])
+	}
+	return chunks
+}
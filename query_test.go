@@ -0,0 +1,104 @@
+package smartsheet
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_QueryBuilder(t *testing.T) {
+	query := newQuery().
+		include("attachments", true).
+		include("discussions", false).
+		include("children", true).
+		exclude("nonexistentCells", true).
+		ids("rowIds", []int64{11, 12}).
+		ids("columnIds", nil).
+		ints("rowNumbers", []int{3}).
+		flag("allowPartialSuccess", true).
+		flag("transferSheets", false).
+		set("level", "2")
+	expect := map[string]string{"include": "attachments,children", "exclude": "nonexistentCells", "rowIds": "11,12",
+		"rowNumbers": "3", "allowPartialSuccess": "true", "level": "2"}
+	parms := query.parms()
+	if len(parms) != len(expect) {
+		t.Errorf("queryBuilder, Expecting %v, Got %v", expect, parms)
+	}
+	for key, value := range expect {
+		if parms[key] != value {
+			t.Errorf("queryBuilder %s, Expecting %s, Got %s", key, value, parms[key])
+		}
+	}
+	if parms = newQuery().include("x", false).ids("ids", nil).parms(); parms != nil {
+		t.Error("queryBuilder expected nil parms when empty, got", parms)
+	}
+
+	// id lists split so the joined ids are at most maxLen characters
+	chunks := splitIds([]int64{1111, 2222, 3333, 4444, 5555}, 14)
+	if len(chunks) != 2 || len(chunks[0]) != 3 || len(chunks[1]) != 2 || chunks[1][0] != 4444 {
+		t.Error("splitIds, Expecting [[1111 2222 3333] [4444 5555]], Got", chunks)
+	}
+	if chunks = splitIds([]int64{123456789}, 4); len(chunks) != 1 || len(chunks[0]) != 1 {
+		t.Error("splitIds expected id longer than maxLen in its own chunk, got", chunks)
+	}
+	if chunks = splitIds(nil, 10); len(chunks) != 0 {
+		t.Error("splitIds expected no chunks, got", chunks)
+	}
+}
+
+// Test_RequestQueries sends a request from each function building url query parameters and compares
+// the method, path and query with testdata/request_queries.txt.
+func Test_RequestQueries(t *testing.T) {
+	requests := make([]string, 0)
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query, _ := url.QueryUnescape(r.URL.RawQuery)
+		requests = append(requests, r.Method+" "+r.URL.Path+"?"+query)
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"pageNumber":1,"totalPages":1,"data":[]}`))
+	})
+	since := time.Date(2024, 3, 2, 9, 15, 30, 0, time.UTC)
+	sheetOptions := []*GetSheetOptions{
+		nil,
+		{IncludeNonexistentCells: true},
+		{RowIds: []int64{11, 12}, ColumnIds: []int64{101, 105}, RowsModifiedSince: since},
+		{RowNumbers: []int{10, 25}, FilterId: 77, ExcludeFilteredOutRows: true},
+		{IncludeOwnerInfo: true, IncludeAttachments: true, IncludeDiscussions: true, IncludeRowPermalink: true, IncludeSource: true,
+			IncludeCrossSheetReferences: true, IncludeObjectValue: true, IncludeNonexistentCells: true, IncludeWriterInfo: true},
+		{IncludeSource: true, Extra: map[string]string{"include": "rowWriterInfo", "exclude": "linkInFromCellDetails", "level": "2"}},
+	}
+	for _, options := range sheetOptions {
+		GetSheet(1, options)
+	}
+	GetSheetMeta(1)
+	GetRow(1, 11)
+	GetRowWith(1, 11, &GetRowOptions{IncludeNonexistentCells: true, IncludeWriterInfo: true, IncludeAttachments: true, IncludeDiscussions: true})
+	CopyRows(1, []int64{11}, 2, nil)
+	CopyRows(1, []int64{11}, 2, &CopyOptions{All: true, Children: true})
+	CopyRows(1, []int64{11}, 2, &CopyOptions{Attachments: true, Children: true, Discussions: true})
+	CopyRows(1, []int64{11}, 2, &CopyOptions{Children: true, CellLinks: true})
+	MoveRows(1, []int64{11}, 2, &MoveOptions{Attachments: true, Discussions: true})
+	MoveRows(1, []int64{11}, 2, &MoveOptions{})
+	CopySheet(1, "Copy", nil)
+	CopySheet(1, "Copy", nil, "data", "attachments")
+	CopyWorkspace(3, "Copy", "all")
+	CopySheetWith(1, "Copy", nil, &CopySheetOptions{Data: true, Attachments: true, Discussions: true, CellLinks: true, Filters: true,
+		Forms: true, Rules: true, RuleRecipients: true, ExcludeSheetHyperlinks: true}) // all but shares
+	CopySheetWith(1, "Copy", nil, &CopySheetOptions{All: true, Shares: true})
+	CopySheetWith(1, "Copy", nil, nil)
+	CopyWorkspace(3, "Copy", CopyData, CopyBrand, CopyRules, CopyRuleRecipients)
+	GetHome(nil)
+	GetHome([]string{ExcludePermalinks})
+	GetUser(4)
+	GetUser(4, "profileFields")
+	RemoveUser(4, 0, false)
+	RemoveUser(4, 5, true)
+	DeleteRows(1, 11, 12, 13)
+	SetParentIdWith(&SheetInfo{SheetId: 1}, 11, []int64{12}, &ParentOptions{AllowPartialSuccess: true})
+
+	golden, _ := ioutil.ReadFile("testdata/request_queries.txt")
+	if got := strings.Join(requests, "\n") + "\n"; got != string(golden) {
+		t.Errorf("Request queries, Expecting:\n%s\nGot:\n%s", golden, got)
+	}
+}
@@ -0,0 +1,297 @@
+// recording.go contains RecordingTransport, which writes each request sent by DoRequest and its response to a fixture
+// file, and ReplayTransport, which serves responses from those fixtures, so tests recorded once against the live api
+// run offline. Install either as HttpClient.Transport, see StartRecording and StartReplay.
+
+package smartsheet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrNoFixture is wrapped by the error returned by ReplayTransport when no fixture matches a request.
+var ErrNoFixture = errors.New("No Fixture For Request")
+
+// redacted replaces the access token in fixtures.
+const redacted = "REDACTED"
+
+// fixture is 1 recorded request and response, 1 file per fixture. Path is relative to basePath (ex. "/sheets/123"),
+// Query is sorted by parameter name. Body is the response body if it is json, otherwise BodyText.
+type fixture struct {
+	Method      string          `json:"method"`
+	Path        string          `json:"path"`
+	Query       string          `json:"query,omitempty"`
+	RequestBody json.RawMessage `json:"requestBody,omitempty"`
+	Status      int             `json:"status"`
+	ContentType string          `json:"contentType,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+	BodyText    string          `json:"bodyText,omitempty"`
+
+	file string // fixture file name, set by NewReplayTransport
+}
+
+// key returns the request matched by the fixture, ex. "GET /sheets/123?exclude=nonexistentCells".
+func (f *fixture) key() string {
+	if f.Query == "" {
+		return f.Method + " " + f.Path
+	}
+	return f.Method + " " + f.Path + "?" + f.Query
+}
+
+// requestFixture returns the fixture of req without the response, its path relative to basePath and query sorted.
+func requestFixture(req *http.Request) *fixture {
+	path := req.URL.Path
+	if base, err := url.Parse(basePath); err == nil {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(base.Path, "/"))
+	}
+	return &fixture{Method: req.Method, Path: path, Query: req.URL.Query().Encode()}
+}
+
+// RecordingTransport sends requests using Base (http.DefaultTransport if nil) and writes each request and response
+// to a file in Dir, named by sequence, method and path (ex. "0001_GET_sheets_123.json"). The Authorization header is
+// not written and the access token is replaced in bodies. Uploaded file content is not written.
+type RecordingTransport struct {
+	Dir  string
+	Base http.RoundTripper
+
+	mu  sync.Mutex
+	seq int
+}
+
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	record := requestFixture(req)
+	if req.GetBody != nil { // request bodies created by Post and Put, not file uploads
+		if body, err := req.GetBody(); err == nil {
+			reqBytes, _ := ioutil.ReadAll(body)
+			if json.Valid(reqBytes) {
+				record.RequestBody = redactToken(reqBytes)
+			}
+		}
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBytes))
+
+	record.Status, record.ContentType = resp.StatusCode, resp.Header.Get("Content-Type")
+	if json.Valid(respBytes) {
+		record.Body = redactToken(respBytes)
+	} else {
+		record.BodyText = string(redactToken(respBytes))
+	}
+	if err = t.write(record); err != nil {
+		log.Println("ERROR - RecordingTransport", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// write writes record to the next fixture file.
+func (t *RecordingTransport) write(record *fixture) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := os.MkdirAll(t.Dir, 0755); err != nil {
+		return err
+	}
+	t.seq++
+	name := fmt.Sprintf("%04d_%s_%s.json", t.seq, record.Method, strings.Trim(strings.ReplaceAll(record.Path, "/", "_"), "_"))
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false) // readable queries, ex. "a=1&b=2"
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(record); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(t.Dir, name), buf.Bytes(), 0644)
+}
+
+// redactToken replaces the access token (Token without "Bearer ", or the TokenSource token) in data.
+func redactToken(data []byte) []byte {
+	token := strings.TrimSpace(strings.TrimPrefix(Token, "Bearer "))
+	if TokenSource != nil {
+		token, _ = TokenSource.Token()
+	}
+	if len(token) < 8 { // not set, or too short to replace safely
+		return data
+	}
+	return bytes.ReplaceAll(data, []byte(token), []byte(redacted))
+}
+
+// ReplayTransport serves responses from the fixtures in Dir written by RecordingTransport. A request matches a
+// fixture with the same method, path and query (parameters in any order), request bodies are not compared.
+// Fixtures of identical requests are served in recorded order, the last is served again for further requests.
+// If no fixture matches, the error wraps ErrNoFixture and shows a diff against the closest fixture.
+type ReplayTransport struct {
+	mu       sync.Mutex
+	fixtures []*fixture
+	served   map[*fixture]bool
+}
+
+// NewReplayTransport returns a ReplayTransport serving the fixtures in dir.
+func NewReplayTransport(dir string) (*ReplayTransport, error) {
+	trace("NewReplayTransport")
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files) // recorded order
+	t := &ReplayTransport{served: make(map[*fixture]bool)}
+	for _, file := range files {
+		jsonData, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		f := new(fixture)
+		if err = json.Unmarshal(jsonData, f); err != nil {
+			log.Println("ERROR - NewReplayTransport", file, err)
+			return nil, fmt.Errorf("Invalid Fixture - %s: %w", file, err)
+		}
+		f.file = filepath.Base(file)
+		t.fixtures = append(t.fixtures, f)
+	}
+	if len(t.fixtures) == 0 {
+		return nil, fmt.Errorf("%w - no fixtures in %s", ErrNoFixture, dir)
+	}
+	return t, nil
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	request := requestFixture(req)
+	t.mu.Lock()
+	var match *fixture
+	for _, f := range t.fixtures {
+		if f.key() != request.key() {
+			continue
+		}
+		match = f
+		if !t.served[f] {
+			break
+		}
+	}
+	if match != nil {
+		t.served[match] = true
+	}
+	t.mu.Unlock()
+	if match == nil {
+		err := fmt.Errorf("%w - %s\n%s", ErrNoFixture, request.key(), t.diff(request))
+		log.Println("ERROR - ReplayTransport", err)
+		return nil, err
+	}
+
+	body := []byte(match.Body)
+	if match.Body == nil {
+		body = []byte(match.BodyText)
+	}
+	header := make(http.Header)
+	if match.ContentType != "" {
+		header.Set("Content-Type", match.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", match.Status, http.StatusText(match.Status)),
+		StatusCode:    match.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// diff returns the differences between request and the closest fixture, the 1st with the same method and path,
+// otherwise the 1st not yet served, as lines starting with "-" (fixture) and "+" (request).
+func (t *ReplayTransport) diff(request *fixture) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var closest *fixture
+	for _, f := range t.fixtures {
+		if f.Method == request.Method && f.Path == request.Path {
+			closest = f
+			break
+		}
+		if closest == nil && !t.served[f] {
+			closest = f
+		}
+	}
+	if closest == nil {
+		return "all fixtures served, record the fixtures again"
+	}
+	lines := []string{"closest fixture " + closest.file + ":"}
+	if closest.Method != request.Method || closest.Path != request.Path {
+		return strings.Join(append(lines, "- "+closest.key(), "+ "+request.key()), "\n")
+	}
+	lines = append(lines, "  "+request.Method+" "+request.Path)
+	recorded, _ := url.ParseQuery(closest.Query)
+	sent, _ := url.ParseQuery(request.Query)
+	names := make([]string, 0, len(recorded)+len(sent))
+	for name := range recorded {
+		names = append(names, name)
+	}
+	for name := range sent {
+		if _, found := recorded[name]; !found {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		was, now := strings.Join(recorded[name], ","), strings.Join(sent[name], ",")
+		switch {
+		case was == now:
+			lines = append(lines, "  "+name+"="+now)
+		case recorded[name] == nil:
+			lines = append(lines, "+ "+name+"="+now)
+		case sent[name] == nil:
+			lines = append(lines, "- "+name+"="+was)
+		default:
+			lines = append(lines, "- "+name+"="+was, "+ "+name+"="+now)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// StartRecording installs a RecordingTransport writing fixtures to dir as HttpClient.Transport, requests are still
+// sent to the api. The returned func restores the previous Transport.
+func StartRecording(dir string) (stop func()) {
+	trace("StartRecording")
+	previous := HttpClient.Transport
+	HttpClient.Transport = &RecordingTransport{Dir: dir, Base: previous}
+	return func() { HttpClient.Transport = previous }
+}
+
+// StartReplay installs a ReplayTransport serving the fixtures in dir as HttpClient.Transport, no requests are sent
+// to the api and SkipTokenCheck is set. The returned func restores the previous Transport and SkipTokenCheck.
+func StartReplay(dir string) (stop func(), err error) {
+	trace("StartReplay")
+	replay, err := NewReplayTransport(dir)
+	if err != nil {
+		return nil, err
+	}
+	previous, previousSkip := HttpClient.Transport, SkipTokenCheck
+	HttpClient.Transport, SkipTokenCheck = replay, true // fixtures do not need a token
+	return func() { HttpClient.Transport, SkipTokenCheck = previous, previousSkip }, nil
+}
@@ -0,0 +1,103 @@
+package smartsheet
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_RecordAndReplay(t *testing.T) {
+	saveToken, saveTransport := Token, HttpClient.Transport
+	t.Cleanup(func() { Token, HttpClient.Transport, SkipTokenCheck = saveToken, saveTransport, false })
+	Token = "Bearer secret-token-1234567890"
+
+	calls := 0
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":1,"name":"Sheet` + string(rune('0'+calls)) + `"}`))
+		case "POST":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"token":"secret-token-1234567890"}}`))
+		}
+	})
+
+	dir := t.TempDir()
+	stop := StartRecording(dir)
+	get := func() string {
+		resp, err := DoRequest(Get("/sheets/1", map[string]string{"include": "format", "exclude": "nonexistentCells"}))
+		if err != nil {
+			return "ERROR " + err.Error()
+		}
+		defer resp.Body.Close()
+		respBytes, _ := ioutil.ReadAll(resp.Body)
+		return string(respBytes)
+	}
+	first, second := get(), get()
+	if _, err := DoRequest(Post("/sheets/1/rows", []Row{{Id: 11}}, nil)); err != nil {
+		t.Fatal("Post Failed", err)
+	}
+	stop()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	expectFiles := []string{"0001_GET_sheets_1.json", "0002_GET_sheets_1.json", "0003_POST_sheets_1_rows.json"}
+	if len(files) != len(expectFiles) {
+		t.Fatal("Recording expected 3 fixtures, got", files)
+	}
+	for i, file := range files {
+		if filepath.Base(file) != expectFiles[i] {
+			t.Error("Recording file name, Expecting", expectFiles[i], "Got", filepath.Base(file))
+		}
+		jsonData, _ := ioutil.ReadFile(file)
+		if strings.Contains(string(jsonData), "secret-token-1234567890") {
+			t.Error("Recording token not redacted", file)
+		}
+	}
+	jsonData, _ := ioutil.ReadFile(files[0])
+	if !strings.Contains(string(jsonData), `"query": "exclude=nonexistentCells&include=format"`) {
+		t.Error("Recording expected sorted query, got", string(jsonData))
+	}
+	jsonData, _ = ioutil.ReadFile(files[2])
+	if !strings.Contains(string(jsonData), `"requestBody": [`) || !strings.Contains(string(jsonData), `"token": "REDACTED"`) {
+		t.Error("Recording expected request body and redacted response, got", string(jsonData))
+	}
+
+	// replay, no requests sent
+	calls = 0
+	if _, err := StartReplay(dir); err != nil {
+		t.Fatal("StartReplay Failed", err)
+	}
+	if got := get(); compactJSON([]byte(got)) != first {
+		t.Errorf("Replay 1st request, Expecting %s, Got %s", first, got)
+	}
+	if got := get(); compactJSON([]byte(got)) != second {
+		t.Errorf("Replay 2nd request, Expecting %s, Got %s", second, got)
+	}
+	if got := get(); compactJSON([]byte(got)) != second {
+		t.Errorf("Replay further request, Expecting last fixture %s, Got %s", second, got)
+	}
+	if calls != 0 {
+		t.Error("Replay expected no requests sent, got", calls)
+	}
+
+	// mismatch
+	_, err := DoRequest(Get("/sheets/1", map[string]string{"include": "discussions", "exclude": "nonexistentCells"}))
+	expect := "closest fixture 0001_GET_sheets_1.json:\n  GET /sheets/1\n  exclude=nonexistentCells\n- include=format\n+ include=discussions"
+	if !errors.Is(err, ErrNoFixture) || !strings.HasSuffix(err.Error(), expect) {
+		t.Errorf("Replay mismatch, Expecting ErrNoFixture\n%s\nGot\n%v", expect, err)
+	}
+	_, err = DoRequest(Delete("/sheets/2", nil))
+	expect = "closest fixture 0003_POST_sheets_1_rows.json:\n- POST /sheets/1/rows\n+ DELETE /sheets/2" // 1st not served
+	if !errors.Is(err, ErrNoFixture) || !strings.HasSuffix(err.Error(), expect) {
+		t.Errorf("Replay mismatch, Expecting ErrNoFixture\n%s\nGot\n%v", expect, err)
+	}
+
+	if _, err = NewReplayTransport(t.TempDir()); !errors.Is(err, ErrNoFixture) {
+		t.Error("NewReplayTransport empty dir expected ErrNoFixture, got", err)
+	}
+}
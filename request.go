@@ -4,18 +4,46 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
 	"time"
 )
 
-const basePath = "https://api.smartsheet.com/2.0"
+var basePath = "https://api.smartsheet.com/2.0" // var so tests can direct requests to a local server
 
-var Token string
+var Token string // ex. "Bearer youraccesstoken", not used if TokenSource is set
 
 var RequestDelay time.Duration = 1 * time.Second // delay between API requests, maximum of 100 requests per minute
 
+// HttpClient is used for all requests. Connections are kept open and reused, avoiding a TLS handshake per request.
+// Caller can replace it or modify its Transport (ex. to add a proxy) before sending requests.
+var HttpClient = &http.Client{
+	Timeout: time.Second * 120,
+	Transport: &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 20, // all requests go to 1 host, default of 2 closes connections when goroutines send concurrently
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+		ForceAttemptHTTP2:   true,
+	},
+}
+
+const pageSize = 100 // number of items requested per page by getAllPages
+
+// throttle spaces requests RequestDelay apart, shared by all goroutines sending requests.
+var throttle struct {
+	sync.Mutex
+	next time.Time // earliest time the next request can be sent
+}
+
 // Get returns a GET http.Request object.
 // UrlParms are added to the URL as Query parameters.
 func Get(endPoint string, urlParms map[string]string) *http.Request {
@@ -100,27 +128,223 @@ func Delete(endPoint string, urlParms map[string]string) *http.Request {
 	return req
 }
 
+const errCodeAlreadyExists = 1129 // api errorCode when a single item being added already exists
+
+// ApiError is returned by DoRequest when the API response StatusCode is not 200 (OK).
+// ErrorCode, Message and RefId are loaded from the API error object in the response body.
+type ApiError struct {
+	StatusCode int    `json:"-"`
+	ErrorCode  int    `json:"errorCode"`
+	Message    string `json:"message"`
+	RefId      string `json:"refId"`
+}
+
+func (e *ApiError) Error() string {
+	return fmt.Sprintf("Smartsheet Http API Request Failed - StatusCode %d, ErrorCode %d, %s", e.StatusCode, e.ErrorCode, e.Message)
+}
+
+// ErrNotAuthorized matches an *ApiError (using errors.Is) when the token cannot perform the request,
+// ex. expired token (1003), not authorized (1004) or org admin rights required (4004).
+var ErrNotAuthorized = errors.New("Not Authorized")
+
+// ErrSheetNotFound matches an *ApiError (using errors.Is) when the requested sheet, or an object in it (ex. row),
+// does not exist (errorCode 1006), ex. the sheet was deleted. Retrying will not succeed.
+// Sheets in the Trash are not found, the api does not report whether a sheet was deleted forever or can be restored.
+var ErrSheetNotFound = errors.New("Sheet Not Found")
+
+// ErrNoAccess matches an *ApiError (using errors.Is) when the user cannot access the object (status 403, errorCode 1004),
+// ex. the sheet is no longer shared with the user. ErrNotAuthorized also matches.
+var ErrNoAccess = errors.New("No Access To Object")
+
+// Is allows errors.Is to match an *ApiError to the package's sentinel errors by ErrorCode.
+func (e *ApiError) Is(target error) bool {
+	switch target {
+	case ErrNotAuthorized:
+		return e.ErrorCode == 1003 || e.ErrorCode == 1004 || e.ErrorCode == 4004
+	case ErrSheetNotFound:
+		return e.ErrorCode == 1006
+	case ErrNoAccess:
+		return e.ErrorCode == 1004 && e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// uploadFile posts the local file at filePath to endPoint, the file is streamed as the request body.
+// Content-Type is left empty, Smartsheet determines it from the file name.
+func uploadFile(endPoint, filePath string) (*http.Response, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		log.Println("Upload File Error, Cannot Open File - ", err)
+		return nil, err
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		log.Println("Upload File Error, Cannot Stat File - ", err)
+		return nil, err
+	}
+	return uploadReader(endPoint, filepath.Base(filePath), file, fileInfo.Size())
+}
+
+// uploadReader posts the content of body to endPoint as a file named fileName, see uploadFile.
+// If size is not known (-1), the body is sent chunked.
+func uploadReader(endPoint, fileName string, body io.Reader, size int64) (*http.Response, error) {
+	debugLn("fileName", fileName)
+	debugLn("fileSize", size)
+
+	req, _ := http.NewRequest("POST", basePath+endPoint, body)
+	req.ContentLength = size           // sets Content-Length header, otherwise body is sent chunked
+	req.Header.Set("Content-Type", "") // let Smartsheet figure out from fileName
+	req.Header.Set("Content-Disposition", `attachment; filename="`+fileName+`"`)
+	debugLn("POST - ", req.URL.RequestURI())
+	return DoRequest(WithCost(req, CostHeavy))
+}
+
 // DoRequest executes the supplied http request and returns the http response.
 // If an error occurs, response info is logged.
-// After request completes, execution is paused (based on RequestDelay value) to throttle request frequency.
+// If the response StatusCode is not 2xx (ex. 200 OK, 202 Accepted), the error returned is type *ApiError.
+// If TokenSource is set and the response is 401 (Unauthorized), the token is refreshed and the request sent again.
+// If Token is empty or malformed (see CheckToken), the request is not sent and the error wraps ErrNoToken or ErrInvalidToken.
+// Before request is sent, execution is paused (see waitTurn) to throttle request frequency.
+// Safe for use by multiple goroutines, all requests share the same throttle.
 func DoRequest(req *http.Request) (*http.Response, error) {
-	req.Header.Set("Authorization", Token)
-	client := http.Client{}
-	client.Timeout = time.Second * 120
-	resp, err := client.Do(req)
-	if err != nil || resp.StatusCode != http.StatusOK {
+	if !SkipTokenCheck {
+		if err := CheckToken(); err != nil {
+			log.Println("Smartsheet Error, Request Not Sent - ", err)
+			return nil, err
+		}
+	}
+	if err := setAuthorization(req); err != nil {
+		return nil, err
+	}
+	cost := requestCost(req)
+	countRequest(cost, waitTurn(cost))
+	resp, err := HttpClient.Do(req)
+
+	// if using TokenSource, token may have been revoked or expired early, refresh and retry once
+	canRetry := req.Body == nil || req.GetBody != nil // file uploads cannot be resent
+	if err == nil && resp.StatusCode == http.StatusUnauthorized && TokenSource != nil && canRetry {
+		resp.Body.Close()
+		log.Println("Smartsheet Request Unauthorized, Refreshing Token")
+		if err = TokenSource.Refresh(); err != nil {
+			log.Println("Smartsheet Error, Token Refresh Failed - ", err)
+			return nil, err
+		}
+		if req.GetBody != nil {
+			req.Body, _ = req.GetBody()
+		}
+		if err = setAuthorization(req); err != nil {
+			return nil, err
+		}
+		countRequest(cost, waitTurn(cost))
+		resp, err = HttpClient.Do(req)
+	}
+	if err != nil {
 		log.Println("Smartsheet Error, HTTP Request Failed - ", err)
+		countError(0)
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 { // 202 Accepted is returned by asynchronous requests, see WaitForAsyncResult
+		countError(resp.StatusCode)
+		log.Println("Smartsheet Error, HTTP Request Failed")
 		log.Println("Http Response StatusCode", resp.StatusCode)
 		log.Println("-- resp Header -----")
 		log.Println(resp.Header)
-		if resp.Body != nil {
-			respBody, _ := ioutil.ReadAll(resp.Body)
-			log.Println("-- resp Body -----")
-			log.Println(string(respBody))
-			resp.Body.Close()
-		}
-		return nil, errors.New("Smartsheet Http API Request Failed - See Log For Details")
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		log.Println("-- resp Body -----")
+		log.Println(string(respBody))
+
+		apiErr := &ApiError{StatusCode: resp.StatusCode}
+		json.Unmarshal(respBody, apiErr) // body may not contain error object, StatusCode is still set
+		return nil, apiErr
 	}
-	time.Sleep(RequestDelay) // limit number of requests per minute
 	return resp, nil
 }
+
+// waitTurn reserves the next request slot and sleeps until it arrives, returning the time waited.
+// Slots are RequestDelay apart, limiting the number of requests per minute across all goroutines.
+// A request of cost n (see WithCost) reserves n slots, the next request waits n times RequestDelay.
+func waitTurn(cost int) time.Duration {
+	throttle.Lock()
+	now := time.Now()
+	slot := throttle.next
+	if slot.Before(now) {
+		slot = now
+	}
+	throttle.next = slot.Add(time.Duration(cost) * RequestDelay)
+	throttle.Unlock()
+	wait := time.Until(slot)
+	time.Sleep(wait)
+	return wait
+}
+
+// setAuthorization sets the request Authorization header using TokenSource if set, otherwise Token.
+func setAuthorization(req *http.Request) error {
+	if TokenSource == nil {
+		req.Header.Set("Authorization", Token)
+		return nil
+	}
+	tkn, err := TokenSource.Token()
+	if err != nil {
+		log.Println("Smartsheet Error, TokenSource Failed - ", err)
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+tkn)
+	return nil
+}
+
+// getAllPages requests each page of a list endpoint until all pages are received.
+// The data array of each page is passed to loadPage, which typically unmarshals and appends it to a slice.
+func getAllPages(endPoint string, urlParms map[string]string, loadPage func(data json.RawMessage) error) error {
+	return getAllPagesCost(endPoint, urlParms, 1, loadPage)
+}
+
+// getAllPagesCost is getAllPages for endpoints costing more than 1 request per page, see WithCost.
+func getAllPagesCost(endPoint string, urlParms map[string]string, cost int, loadPage func(data json.RawMessage) error) error {
+	parms := map[string]string{"pageSize": strconv.Itoa(pageSize)}
+	for k, v := range urlParms {
+		parms[k] = v
+	}
+	for page := 1; ; page++ {
+		parms["page"] = strconv.Itoa(page)
+		req := Get(endPoint, parms)
+		resp, err := DoRequest(WithCost(req, cost))
+		if err != nil {
+			return err
+		}
+		respJSON, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		var apiResp struct {
+			PageNumber int             `json:"pageNumber"`
+			TotalPages int             `json:"totalPages"`
+			Data       json.RawMessage `json:"data"`
+		}
+		if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+			log.Println("ERROR getAllPages Unmarshal Response Failed", endPoint, err)
+			return err
+		}
+		if len(apiResp.Data) > 0 {
+			if err = loadPage(apiResp.Data); err != nil {
+				log.Println("ERROR getAllPages Unmarshal Data Failed", endPoint, err)
+				return err
+			}
+		}
+		if page >= apiResp.TotalPages {
+			return nil
+		}
+	}
+}
+
+// deleteObject sends a DELETE request for endPoint, used when the response contains nothing of interest.
+func deleteObject(endPoint string) error {
+	req := Delete(endPoint, nil)
+	resp, err := DoRequest(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,88 @@
+package smartsheet
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Benchmark_GetRow sends 100 sequential GetRow requests to a local TLS server,
+// comparing the shared HttpClient (connections reused) to a client opening a new connection per request.
+// Run with: go test -run XXX -bench GetRow
+func Benchmark_GetRow(b *testing.B) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":6935334990440324,"cells":[{"columnId":101,"value":"1 Main"}]}`))
+	}))
+	defer server.Close()
+
+	saveClient, savePath, saveDelay, saveToken := HttpClient, basePath, RequestDelay, Token
+	basePath, RequestDelay = server.URL, 0
+	if Token == "" {
+		Token = "Bearer stub-token-0123456789abcdef" // passes CheckToken, as set by stubServer
+	}
+	defer func() {
+		HttpClient, basePath, RequestDelay, Token = saveClient, savePath, saveDelay, saveToken
+	}()
+
+	// trust the test server's certificate, otherwise use HttpClient's transport settings
+	pooled := saveClient.Transport.(*http.Transport).Clone()
+	pooled.TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig
+	unpooled := pooled.Clone()
+	unpooled.DisableKeepAlives = true
+
+	clients := []struct {
+		name      string
+		transport *http.Transport
+	}{
+		{"Pooled", pooled},
+		{"NewConnection", unpooled},
+	}
+	for _, client := range clients {
+		HttpClient = &http.Client{Timeout: saveClient.Timeout, Transport: client.transport}
+		b.Run(client.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				for i := 0; i < 100; i++ {
+					if _, err := GetRow(1849449510135684, 6935334990440324); err != nil {
+						b.Fatal("GetRow Failed", err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_ApiErrorSentinels(t *testing.T) {
+	var status int
+	var body string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	})
+	tests := []struct {
+		status int
+		body   string
+		expect error
+		unlike error
+	}{
+		{404, `{"errorCode":1006,"message":"Not Found","refId":"abc"}`, ErrSheetNotFound, ErrNoAccess},
+		{403, `{"errorCode":1004,"message":"You are not authorized to perform this action."}`, ErrNoAccess, ErrSheetNotFound},
+		{403, `{"errorCode":1004,"message":"You are not authorized to perform this action."}`, ErrNotAuthorized, ErrSheetNotFound},
+		{401, `{"errorCode":1003,"message":"Your Access Token has expired."}`, ErrNotAuthorized, ErrNoAccess},
+		{500, `{"errorCode":4000,"message":"An unexpected error has occurred."}`, nil, ErrSheetNotFound},
+	}
+	for _, test := range tests {
+		status, body = test.status, test.body
+		_, sheetErr := GetSheet(1, nil)
+		loadErr := new(SheetInfo).Load(1, nil)
+		_, rowErr := GetRow(1, 11)
+		for name, err := range map[string]error{"GetSheet": sheetErr, "Load": loadErr, "GetRow": rowErr} {
+			if test.expect != nil && !errors.Is(err, test.expect) {
+				t.Errorf("%s %s, Expecting %v, Got %v", name, test.body, test.expect, err)
+			}
+			if err == nil || errors.Is(err, test.unlike) {
+				t.Errorf("%s %s, expected error not matching %v, Got %v", name, test.body, test.unlike, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// requeststats.go contains the rate limit cost of requests and the request statistics kept by DoRequest (and WebhookServer).
+// Some requests (ex. attaching a file, cell history) count as several requests against the api rate limit,
+// DoRequest reserves RequestDelay times the request's cost in the throttle, see WithCost.
+
+package smartsheet
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CostHeavy is the rate limit cost of file uploads and cell history requests, each counts as 10 requests.
+const CostHeavy = 10
+
+// costKey is the request context key of the request cost.
+type costKey struct{}
+
+// WithCost returns req annotated with its rate limit cost, used by DoRequest to throttle and count it.
+// Requests not annotated cost 1.
+func WithCost(req *http.Request, cost int) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), costKey{}, cost))
+}
+
+// requestCost returns the cost of req, 1 if not annotated.
+func requestCost(req *http.Request) int {
+	if cost, ok := req.Context().Value(costKey{}).(int); ok && cost > 0 {
+		return cost
+	}
+	return 1
+}
+
+// RequestStats is returned by Stats, counts are since the last ResetStats (or program start).
+type RequestStats struct {
+	Since          time.Time
+	Requests       int           // requests sent, including token refresh retries
+	Cost           int           // estimated rate limit consumption, sum of request costs
+	CostLastMinute int           // cost of requests sent in the last minute, compare to the api limit (ex. 300 per minute)
+	ThrottleWaits  int           // requests delayed by the RequestDelay throttle
+	ThrottleTime   time.Duration // total time requests were delayed by the throttle
+	Errors         int           // requests failed, http error or non 2xx status
+	RateLimited    int           // requests failed with status 429 (Too Many Requests), included in Errors
+
+	DuplicateCallbacks int // webhook callbacks dropped by WebhookServer, nonce already received (see NonceStore)
+}
+
+// statsCounter is the type of stats, updated by DoRequest and shared by all goroutines.
+type statsCounter struct {
+	sync.Mutex
+	RequestStats
+	recent []costAt // requests sent in the last minute, oldest first
+}
+
+var stats statsCounter
+
+type costAt struct {
+	sent time.Time
+	cost int
+}
+
+// Stats returns the request statistics since the last ResetStats.
+func Stats() RequestStats {
+	stats.Lock()
+	defer stats.Unlock()
+	stats.pruneRecent(time.Now())
+	result := stats.RequestStats
+	result.CostLastMinute = 0
+	for _, request := range stats.recent {
+		result.CostLastMinute += request.cost
+	}
+	return result
+}
+
+// ResetStats sets all request statistics to 0.
+func ResetStats() {
+	stats.Lock()
+	stats.RequestStats = RequestStats{Since: time.Now()}
+	stats.recent = nil
+	stats.Unlock()
+}
+
+// countRequest records a request of cost sent after waiting wait in the throttle.
+func countRequest(cost int, wait time.Duration) {
+	now := time.Now()
+	stats.Lock()
+	if stats.Since.IsZero() {
+		stats.Since = now
+	}
+	stats.Requests++
+	stats.Cost += cost
+	if wait > 0 {
+		stats.ThrottleWaits++
+		stats.ThrottleTime += wait
+	}
+	stats.pruneRecent(now)
+	stats.recent = append(stats.recent, costAt{now, cost})
+	stats.Unlock()
+}
+
+// countError records a failed request, statusCode is 0 if no response was received.
+func countError(statusCode int) {
+	stats.Lock()
+	stats.Errors++
+	if statusCode == http.StatusTooManyRequests {
+		stats.RateLimited++
+	}
+	stats.Unlock()
+}
+
+// countDuplicateCallback records a webhook callback dropped by WebhookServer.
+func countDuplicateCallback() {
+	stats.Lock()
+	if stats.Since.IsZero() {
+		stats.Since = time.Now()
+	}
+	stats.DuplicateCallbacks++
+	stats.Unlock()
+}
+
+// pruneRecent removes requests sent more than 1 minute before now, s must be locked.
+func (s *statsCounter) pruneRecent(now time.Time) {
+	expired := 0
+	for expired < len(s.recent) && now.Sub(s.recent[expired].sent) >= time.Minute {
+		expired++
+	}
+	s.recent = s.recent[expired:]
+}
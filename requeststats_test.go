@@ -0,0 +1,63 @@
+package smartsheet
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_RequestStats(t *testing.T) {
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/history"):
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"columnId":101,"value":"x"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/attachments"):
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":1}}`))
+		case r.URL.Path == "/sheets/1/rows/429":
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"errorCode":4003,"message":"Rate limit exceeded."}`))
+		case r.URL.Path == "/sheets/1/rows/500":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte(`{"id":11,"cells":[]}`))
+		}
+	})
+	RequestDelay = 5 * time.Millisecond
+	filePath := filepath.Join(t.TempDir(), "notes.txt")
+	ioutil.WriteFile(filePath, []byte("notes"), 0644)
+
+	ResetStats()
+	GetRow(1, 11)                                         // cost 1
+	if _, err := GetCellHistory(1, 11, 101); err != nil { // cost 10
+		t.Fatal("GetCellHistory Failed", err)
+	}
+	start := time.Now()
+	GetRow(1, 11) // waits for the 10 slots reserved by GetCellHistory
+	if elapsed := time.Since(start); elapsed < 8*RequestDelay {
+		t.Error("expected request after cell history to wait for its cost, waited", elapsed)
+	}
+	if err := AttachFileToRow(1, 11, filePath); err != nil { // cost 10
+		t.Fatal("AttachFileToRow Failed", err)
+	}
+	GetRow(1, 429)
+	GetRow(1, 500)
+
+	got := Stats()
+	if got.Requests != 6 || got.Cost != 24 || got.CostLastMinute != 24 {
+		t.Errorf("Stats expected 6 requests costing 24, got %+v", got)
+	}
+	if got.Errors != 2 || got.RateLimited != 1 {
+		t.Errorf("Stats expected 2 errors, 1 rate limited, got %+v", got)
+	}
+	if got.ThrottleWaits < 2 || got.ThrottleTime < 8*RequestDelay {
+		t.Errorf("Stats expected throttle waits, got %+v", got)
+	}
+
+	ResetStats()
+	if got = Stats(); got.Requests != 0 || got.Cost != 0 || got.CostLastMinute != 0 || got.Since.IsZero() {
+		t.Errorf("ResetStats expected counts cleared, got %+v", got)
+	}
+}
@@ -7,16 +7,29 @@ import (
 	"io/ioutil"
 	"log"
 	"strconv"
-	"strings"
 )
 
 // GetRow returns specified row from sheet.
+// Cells never containing a value are excluded, see GetRowWith.
 // ### add code to handle row not found
 func GetRow(sheetId, rowId int64) (*Row, error) {
-	trace("GetRow")
+	return GetRowWith(sheetId, rowId, nil)
+}
+
+// GetRowWith returns specified row from sheet using options (nil for defaults, same as GetRow).
+func GetRowWith(sheetId, rowId int64, options *GetRowOptions) (*Row, error) {
+	trace("GetRowWith")
 
-	urlParms := make(map[string]string)
-	urlParms["exclude"] = "nonexistentCells"
+	if options == nil {
+		options = new(GetRowOptions)
+	}
+	urlParms := newQuery().
+		exclude("nonexistentCells", !options.IncludeNonexistentCells).
+		include("rowWriterInfo", options.IncludeWriterInfo).
+		include("attachments", options.IncludeAttachments).
+		include("discussions", options.IncludeDiscussions).
+		include("rowPermalink", options.IncludePermalink).
+		parms()
 
 	endPoint := fmt.Sprintf("/sheets/%d/rows/%d", sheetId, rowId)
 	req := Get(endPoint, urlParms)
@@ -46,6 +59,12 @@ func AddRow(sheet *SheetInfo, newRow Row, location *RowLocation) (*Add1RowRespon
 		log.Println("ERROR AddRow - sheet.SheetId not set")
 		return nil, errors.New("sheet.SheetId empty")
 	}
+	if location != nil {
+		if err := location.validateAdd(); err != nil {
+			log.Println("ERROR AddRow", err)
+			return nil, err
+		}
+	}
 	// load Cell.ColumnId using Cell.colName
 	for i := 0; i < len(newRow.Cells); i++ {
 		colName := newRow.Cells[i].ColName
@@ -56,6 +75,25 @@ func AddRow(sheet *SheetInfo, newRow Row, location *RowLocation) (*Add1RowRespon
 		}
 		newRow.Cells[i].ColumnId = column.Id
 	}
+	cells, err := sheet.checkDuplicateCells(newRow.Cells)
+	if err != nil {
+		log.Println("ERROR AddRow", err)
+		return nil, err
+	}
+	newRow.Cells = cells
+	if err = validateHyperlinks(newRow.Cells); err != nil {
+		log.Println("ERROR AddRow", err)
+		return nil, err
+	}
+	if err = sheet.resolvePredecessors(newRow.Cells); err != nil {
+		log.Println("ERROR AddRow", err)
+		return nil, err
+	}
+	if newRow.ParentId == 0 && (location == nil || location.ParentId == 0 && location.SiblingId == 0) {
+		if err = sheet.checkEmptyPrimary(newRow); err != nil {
+			return nil, err
+		}
+	}
 
 	// create row location map
 	locMap := map[string]interface{}{"toBottom": true}
@@ -65,7 +103,7 @@ func AddRow(sheet *SheetInfo, newRow Row, location *RowLocation) (*Add1RowRespon
 
 	// -- create request body ----------------
 	reqData := make(map[string]interface{})
-	reqData["cells"] = newRow.Cells
+	reqData["cells"] = sheet.writableCells(0, newRow.Cells)
 	if newRow.Locked != nil { // newRow.Locked is *bool
 		reqData["locked"] = *newRow.Locked // dereference, returns value referenced by pointer
 	}
@@ -106,6 +144,12 @@ func UpdateRow(sheet *SheetInfo, updtRow Row, location *RowLocation) (*AddUpdtRo
 		log.Println("ERROR UpdateRow - sheet.SheetId not set")
 		return nil, errors.New("sheet.SheetId empty")
 	}
+	if location != nil {
+		if err := location.Validate(); err != nil {
+			log.Println("ERROR UpdateRow", err)
+			return nil, err
+		}
+	}
 	// -- load Cell.ColumnId using Cell.colName -------------
 	for i := 0; i < len(updtRow.Cells); i++ {
 		colName := updtRow.Cells[i].ColName
@@ -116,6 +160,24 @@ func UpdateRow(sheet *SheetInfo, updtRow Row, location *RowLocation) (*AddUpdtRo
 		}
 		updtRow.Cells[i].ColumnId = column.Id
 	}
+	cells, err := sheet.checkDuplicateCells(updtRow.Cells)
+	if err != nil {
+		log.Println("ERROR UpdateRow", err)
+		return nil, err
+	}
+	updtRow.Cells = cells
+	if err = validateHyperlinks(updtRow.Cells); err != nil {
+		log.Println("ERROR UpdateRow", err)
+		return nil, err
+	}
+	if err = sheet.resolvePredecessors(updtRow.Cells); err != nil {
+		log.Println("ERROR UpdateRow", err)
+		return nil, err
+	}
+	if err = sheet.checkFormulaCells(updtRow.Id, updtRow.Cells); err != nil {
+		log.Println("ERROR UpdateRow", err)
+		return nil, err
+	}
 
 	// -- create row location map ----------------
 	var locMap map[string]interface{}
@@ -126,7 +188,13 @@ func UpdateRow(sheet *SheetInfo, updtRow Row, location *RowLocation) (*AddUpdtRo
 	// -- create request body ----------------
 	reqData := make(map[string]interface{})
 	reqData["id"] = strconv.FormatInt(updtRow.Id, 10) // api expects row id to be a string, don't know why
-	reqData["cells"] = updtRow.Cells
+	writable := sheet.writableCells(updtRow.Id, updtRow.Cells)
+	if len(writable) == 0 && len(updtRow.Cells) > 0 && updtRow.Locked == nil && location == nil {
+		err := fmt.Errorf("Invalid Row - row %d has only system column cells, nothing to update", updtRow.Id)
+		log.Println("ERROR UpdateRow", err)
+		return nil, err
+	}
+	reqData["cells"] = writable
 	if updtRow.Locked != nil { // newRow.Locked is *bool
 		reqData["locked"] = *updtRow.Locked // dereference, returns value referenced by pointer
 	}
@@ -157,26 +225,22 @@ func UpdateRow(sheet *SheetInfo, updtRow Row, location *RowLocation) (*AddUpdtRo
 }
 
 // DeleteRows removes specified rowsIds from sheet.
+// Row ids are sent in the url, a long list is sent in several requests (see maxQueryIdsLen). If a request fails,
+// the rows of earlier requests are deleted.
 func DeleteRows(sheetId int64, rowIds ...int64) error {
-
-	ids := make([]string, len(rowIds))
-	for i, id := range rowIds {
-		ids[i] = strconv.FormatInt(id, 10)
-	}
-	urlParms := make(map[string]string)
-	urlParms["ids"] = strings.Join(ids, ",")
-
 	endPoint := fmt.Sprintf("/sheets/%d/rows", sheetId)
-	req := Delete(endPoint, urlParms)
+	for _, chunk := range splitIds(rowIds, maxQueryIdsLen) {
+		req := Delete(endPoint, newQuery().ids("ids", chunk).parms())
 
-	resp, err := DoRequest(req)
-	if err != nil {
-		log.Println("ERROR - DeleteRows Failed", err)
-		return err
+		resp, err := DoRequest(req)
+		if err != nil {
+			log.Println("ERROR - DeleteRows Failed", err)
+			return err
+		}
+		respJSON, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		debugLn("DeleteRows ---")
+		debugLn(string(respJSON))
 	}
-	defer resp.Body.Close()
-	respJSON, _ := ioutil.ReadAll(resp.Body)
-	debugLn("DeleteRows ---")
-	debugLn(string(respJSON))
 	return nil
 }
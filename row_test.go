@@ -1,10 +1,13 @@
 package smartsheet
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 func Test_Row(t *testing.T) {
@@ -13,14 +16,15 @@ func Test_Row(t *testing.T) {
 
 	var err error
 
-	tkn, _ := ioutil.ReadFile("token.txt")
-	Token = strings.TrimSpace(string(tkn))
+	liveToken(t)
 
 	TraceOn = true
 	DebugOn = true
 
 	sheet := new(SheetInfo)
-	sheet.Restore("/home/jay/sheets/test1_base.json")
+	if err = sheet.Load(sheet1Id, nil); err != nil {
+		t.Fatal("Test_Row Load Failed", err)
+	}
 
 	// === ADD ROW ===========================================
 	newRow := InitRow()
@@ -69,3 +73,65 @@ func Test_Row(t *testing.T) {
 		t.Fatal("Test_Row DeleteRows Failed", err)
 	}
 }
+
+func Test_RowIncludes(t *testing.T) {
+	payload, err := ioutil.ReadFile("testdata/row_include.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var include string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		include = r.URL.Query().Get("include")
+		w.Write(payload)
+	})
+	row, err := GetRowWith(1849449510135684, 4583173393803140, &GetRowOptions{IncludeAttachments: true, IncludeDiscussions: true})
+	if err != nil {
+		t.Fatal("GetRowWith Failed", err)
+	}
+	if include != "attachments,discussions" {
+		t.Error("GetRowWith wrong include parm", include)
+	}
+	if len(row.Attachments) != 2 || len(row.Discussions) != 1 {
+		t.Fatal("GetRowWith expected 2 attachments and 1 discussion, got", len(row.Attachments), len(row.Discussions))
+	}
+	file := row.Attachments[0]
+	if file.Id != 6947599245616004 || file.MimeType != "application/pdf" || file.SizeInKb != 184 || file.ParentType != AttachedToRow ||
+		file.CreatedBy.Email != "ann@example.com" || !file.CreatedAt.Equal(time.Date(2020, 11, 3, 10, 2, 44, 0, time.UTC)) {
+		t.Error("wrong file attachment", file)
+	}
+	if link := row.Attachments[1]; link.AttachmentType != "LINK" || link.Url != "https://orders.example.com/522" || link.SizeInKb != 0 {
+		t.Error("wrong link attachment", link)
+	}
+
+	discussion := row.Discussions[0]
+	if discussion.CommentCount != 2 || len(discussion.Comments) != 2 || discussion.CreatedBy.Name != "Ann Lee" ||
+		discussion.LastCommentedUser.Name != "Jay" || !discussion.LastCommentedAt.Equal(time.Date(2020, 11, 20, 16, 40, 12, 0, time.UTC)) {
+		t.Error("wrong discussion", discussion)
+	}
+	comment := discussion.Comments[1]
+	if comment.DiscussionId != discussion.Id || comment.Text != "Yes, see attached" || comment.CreatedBy.Email != "jay@example.com" ||
+		!comment.ModifiedAt.Equal(discussion.LastCommentedAt) || len(comment.Attachments) != 1 || comment.Attachments[0].ParentType != AttachedToComment {
+		t.Error("wrong comment", comment)
+	}
+
+	// numericDates, null and invalid timestamps
+	var numeric Comment
+	if err = json.Unmarshal([]byte(`{"id":1,"createdAt":1605890412000,"modifiedAt":null}`), &numeric); err != nil {
+		t.Fatal("Comment Unmarshal numeric date Failed", err)
+	}
+	if !numeric.CreatedAt.Equal(time.Date(2020, 11, 20, 16, 40, 12, 0, time.UTC)) || !numeric.ModifiedAt.IsZero() || numeric.Id != 1 {
+		t.Error("Comment Unmarshal wrong numeric date", numeric.CreatedAt, numeric.ModifiedAt)
+	}
+	err = json.Unmarshal([]byte(`{"id":2,"lastCommentedAt":"20/11/2020"}`), new(Discussion))
+	if err == nil || !strings.Contains(err.Error(), "lastCommentedAt") {
+		t.Error("Discussion Unmarshal expected invalid timestamp error, got", err)
+	}
+
+	// round trip, ex. SheetInfo Store and Restore
+	stored, _ := json.Marshal(row)
+	restored := new(Row)
+	if err = json.Unmarshal(stored, restored); err != nil || !restored.Discussions[0].Comments[1].CreatedAt.Equal(comment.CreatedAt) ||
+		restored.Attachments[0].CreatedBy.Name != "Ann Lee" {
+		t.Error("Row round trip lost attachment or discussion values", err)
+	}
+}
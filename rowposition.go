@@ -0,0 +1,110 @@
+// rowposition.go contains funcs moving rows to a position within their sheet (ex. pin a total row to the bottom).
+// Requests contain only row ids and location values, cells are not sent or changed.
+
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// RowPosition is used by MoveRowsToParent.
+type RowPosition int
+
+// Row Positions
+const (
+	PositionTop    RowPosition = iota // 1st child of parent, or top of sheet if no parent
+	PositionBottom                    // last child of parent, or bottom of sheet if no parent
+)
+
+// MoveRowToPosition moves 1 row to location, which is validated before the request is sent.
+// Location must contain a position (ex. ToBottom, SiblingId, ParentId, Indent).
+func MoveRowToPosition(sheet *SheetInfo, rowId int64, loc RowLocation) error {
+	trace("MoveRowToPosition")
+	if err := loc.Validate(); err != nil {
+		log.Println("ERROR MoveRowToPosition", err)
+		return err
+	}
+	locMap := CreateLocationMap(&loc)
+	if len(locMap) == 0 {
+		log.Println("ERROR MoveRowToPosition - location empty")
+		return errors.New("Invalid RowLocation - no location values set")
+	}
+	return sheet.sendRowLocations([]int64{rowId}, locMap)
+}
+
+// MoveRowsToParent moves rows to the top or bottom of parentId's children, keeping their order.
+// Use parentId 0 to move rows to the top or bottom of the sheet (rows become top level).
+// Row ids are sent in chunks of UploadChunkSize. If a chunk fails, rows sent by previous chunks have been moved.
+func MoveRowsToParent(sheet *SheetInfo, parentId int64, rowIds []int64, position RowPosition) error {
+	trace("MoveRowsToParent")
+	if len(rowIds) == 0 {
+		log.Println("MoveRowsToParent - No RowIds Specified")
+		return nil
+	}
+	loc := RowLocation{ParentId: parentId}
+	switch position {
+	case PositionTop:
+		loc.ToTop = true
+	case PositionBottom:
+		loc.ToBottom = true
+	default:
+		return fmt.Errorf("Invalid RowPosition - %d", position)
+	}
+	locMap := CreateLocationMap(&loc)
+
+	// each chunk moved to top is placed above the previous chunk, so chunks are sent in reverse order
+	chunks := make([][]int64, 0, len(rowIds)/UploadChunkSize+1)
+	for first := 0; first < len(rowIds); first += UploadChunkSize {
+		last := first + UploadChunkSize
+		if last > len(rowIds) {
+			last = len(rowIds)
+		}
+		chunks = append(chunks, rowIds[first:last])
+	}
+	if position == PositionTop {
+		for i, j := 0, len(chunks)-1; i < j; i, j = i+1, j-1 {
+			chunks[i], chunks[j] = chunks[j], chunks[i]
+		}
+	}
+	for _, chunk := range chunks {
+		if err := sheet.sendRowLocations(chunk, locMap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PinRowToBottom moves rowId (ex. a TOTAL row) to the bottom of the sheet.
+// Call after rows are added, ex. by UploadNewRows, which places new rows below the pinned row.
+func PinRowToBottom(sheet *SheetInfo, rowId int64) error {
+	trace("PinRowToBottom")
+	return MoveRowToPosition(sheet, rowId, RowLocation{ToBottom: true})
+}
+
+// sendRowLocations sends 1 update rows request setting the same location for each row id.
+func (she *SheetInfo) sendRowLocations(rowIds []int64, locMap map[string]interface{}) error {
+	if she.SheetId == 0 {
+		log.Println("ERROR - sendRowLocations sheet.SheetId not set")
+		return errors.New("sheet.SheetId empty")
+	}
+	reqData := make([]map[string]interface{}, len(rowIds))
+	for i, rowId := range rowIds {
+		item := map[string]interface{}{"id": rowId}
+		for k, v := range locMap {
+			item[k] = v
+		}
+		reqData[i] = item
+	}
+	endPoint := fmt.Sprintf("/sheets/%d/rows", she.SheetId)
+	req := Put(endPoint, reqData, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,100 @@
+package smartsheet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_MoveRowToPosition(t *testing.T) {
+	var bodies []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/sheets/1849449510135684/rows" {
+			t.Error("MoveRowToPosition unexpected request", r.Method, r.URL.Path)
+		}
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, compactJSON(reqBytes))
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[]}`))
+	})
+	sheet := testSheet()
+	tests := []struct {
+		loc    RowLocation
+		expect string
+	}{
+		{RowLocation{ToTop: true}, `[{"id":11,"toTop":true}]`},
+		{RowLocation{ToBottom: true}, `[{"id":11,"toBottom":true}]`},
+		{RowLocation{ParentId: 12}, `[{"id":11,"parentId":12}]`},
+		{RowLocation{ParentId: 12, ToBottom: true}, `[{"id":11,"parentId":12,"toBottom":true}]`},
+		{RowLocation{SiblingId: 13, AboveSibling: true}, `[{"above":true,"id":11,"siblingId":13}]`},
+		{RowLocation{SiblingId: 13, BelowSibling: true}, `[{"above":false,"id":11,"siblingId":13}]`},
+		{RowLocation{Indent: 1}, `[{"id":11,"indent":1}]`},
+		{RowLocation{Outdent: 1}, `[{"id":11,"outdent":1}]`},
+	}
+	for _, test := range tests {
+		bodies = nil
+		if err := MoveRowToPosition(sheet, 11, test.loc); err != nil {
+			t.Errorf("MoveRowToPosition %+v Failed %v", test.loc, err)
+			continue
+		}
+		if len(bodies) != 1 || bodies[0] != test.expect {
+			t.Errorf("MoveRowToPosition %+v, Expecting %s, Got %v", test.loc, test.expect, bodies)
+		}
+	}
+
+	bodies = nil
+	invalid := []RowLocation{{}, {ToTop: true, ToBottom: true}, {SiblingId: 13, ToBottom: true}, {AboveSibling: true}}
+	for _, loc := range invalid {
+		if err := MoveRowToPosition(sheet, 11, loc); err == nil {
+			t.Errorf("MoveRowToPosition expected error for %+v", loc)
+		}
+	}
+	if len(bodies) != 0 {
+		t.Error("MoveRowToPosition invalid locations sent requests", bodies)
+	}
+
+	if err := PinRowToBottom(sheet, 99); err != nil || len(bodies) != 1 || bodies[0] != `[{"id":99,"toBottom":true}]` {
+		t.Error("PinRowToBottom wrong request", bodies, err)
+	}
+}
+
+func Test_MoveRowsToParent(t *testing.T) {
+	var bodies []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, compactJSON(reqBytes))
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[]}`))
+	})
+	sheet := testSheet()
+	if err := MoveRowsToParent(sheet, 12, []int64{21, 22}, PositionBottom); err != nil {
+		t.Fatal("MoveRowsToParent Failed", err)
+	}
+	expect := `[{"id":21,"parentId":12,"toBottom":true},{"id":22,"parentId":12,"toBottom":true}]`
+	if len(bodies) != 1 || bodies[0] != expect {
+		t.Errorf("MoveRowsToParent, Expecting %s, Got %v", expect, bodies)
+	}
+
+	bodies = nil
+	if err := MoveRowsToParent(sheet, 0, []int64{21}, PositionTop); err != nil || len(bodies) != 1 || bodies[0] != `[{"id":21,"toTop":true}]` {
+		t.Error("MoveRowsToParent top of sheet wrong request", bodies, err)
+	}
+
+	// chunks moved to top are sent last chunk 1st, so rows keep their order
+	bodies = nil
+	rowIds := make([]int64, UploadChunkSize+2)
+	for i := range rowIds {
+		rowIds[i] = int64(i + 1)
+	}
+	if err := MoveRowsToParent(sheet, 12, rowIds, PositionTop); err != nil {
+		t.Fatal("MoveRowsToParent Failed", err)
+	}
+	first := fmt.Sprintf(`[{"id":%d,"parentId":12,"toTop":true},{"id":%d,`, UploadChunkSize+1, UploadChunkSize+2)
+	if len(bodies) != 2 || !strings.HasPrefix(bodies[0], first) || !strings.HasPrefix(bodies[1], `[{"id":1,"parentId":12,"toTop":true}`) {
+		t.Errorf("MoveRowsToParent expected 2 chunks, last chunk 1st, got %d", len(bodies))
+	}
+
+	if err := MoveRowsToParent(sheet, 12, []int64{21}, RowPosition(5)); err == nil {
+		t.Error("MoveRowsToParent expected error for invalid position")
+	}
+}
@@ -0,0 +1,82 @@
+// rowsbyids.go contains GetRowsByIds and the splitting of GetSheetOptions.RowIds lists too long for 1 request url
+// (ex. the rows of a webhook batch), used by GetSheet and so by SheetInfo.Load.
+
+package smartsheet
+
+import (
+	"log"
+	"sort"
+)
+
+// uniqueIds returns ids without duplicates, in first seen order.
+func uniqueIds(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// getSheetByRowIds requests the unique options.RowIds in requests of up to maxQueryIdsLen characters of ids (see
+// splitIds). The returned sheet is the
+// 1st response, with the rows of all responses in sheet order (Row.RowNumber), the same as a single request.
+// If a request fails, its error is returned.
+func getSheetByRowIds(sheetId int64, options *GetSheetOptions) (*Sheet, error) {
+	rowIds := uniqueIds(options.RowIds)
+	var merged *Sheet
+	chunks := splitIds(rowIds, maxQueryIdsLen)
+	for i, chunkIds := range chunks {
+		chunk := *options
+		chunk.RowIds = chunkIds
+		sheet, err := requestSheet(sheetId, &chunk)
+		if err != nil {
+			log.Println("ERROR GetSheet RowIds request", i+1, "of", len(chunks), err)
+			return nil, err
+		}
+		if merged == nil {
+			merged = sheet
+		} else {
+			merged.Rows = append(merged.Rows, sheet.Rows...)
+		}
+	}
+	sort.SliceStable(merged.Rows, func(i, j int) bool { return merged.Rows[i].RowNumber < merged.Rows[j].RowNumber })
+	return merged, nil
+}
+
+// GetRowsByIds returns the rows of sheetId with the ids in rowIds, in rowIds order, and the ids not found (ex. deleted
+// rows). Duplicate ids are requested and returned once. A long list is sent in several requests, see getSheetByRowIds.
+// Optional options set the columns and include parameters, its RowIds and RowNumbers are not used.
+func GetRowsByIds(sheetId int64, rowIds []int64, options *GetSheetOptions) (rows []Row, notFound []int64, err error) {
+	trace("GetRowsByIds")
+	rowIds = uniqueIds(rowIds)
+	if len(rowIds) == 0 {
+		return []Row{}, []int64{}, nil // an empty RowIds would request all rows
+	}
+	request := GetSheetOptions{}
+	if options != nil {
+		request = *options
+	}
+	request.RowIds, request.RowNumbers = rowIds, nil
+	sheet, err := GetSheet(sheetId, &request)
+	if err != nil {
+		log.Println("ERROR GetRowsByIds", sheetId, err)
+		return nil, nil, err
+	}
+	returned := make(map[int64]Row, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		returned[row.Id] = row
+	}
+	rows, notFound = make([]Row, 0, len(rowIds)), make([]int64, 0)
+	for _, rowId := range rowIds {
+		if row, found := returned[rowId]; found {
+			rows = append(rows, row)
+		} else {
+			notFound = append(notFound, rowId)
+		}
+	}
+	return rows, notFound, nil
+}
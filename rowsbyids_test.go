@@ -0,0 +1,94 @@
+package smartsheet
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// rowIdBase is added to test row ids, so they have 16 digits as api row ids do. 235 fit in 1 request url (see maxQueryIdsLen).
+const rowIdBase = 1000000000000000
+
+// rowIdsServer returns rows for the requested rowIds, except ids ending in 99 (not found). Row.RowNumber is the id - rowIdBase.
+func rowIdsServer(t *testing.T, requests *[]int) {
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		ids := strings.Split(r.URL.Query().Get("rowIds"), ",")
+		*requests = append(*requests, len(ids))
+		rows := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if !strings.HasSuffix(id, "99") {
+				rowId, _ := strconv.ParseInt(id, 10, 64)
+				rows = append(rows, fmt.Sprintf(`{"id":%s,"rowNumber":%d}`, id, rowId-rowIdBase))
+			}
+		}
+		fmt.Fprintf(w, `{"id":1849449510135684,"name":"Test1","totalRowCount":1000,"columns":[{"id":101,"index":0,"title":"Address","type":"TEXT_NUMBER"}],"rows":[%s]}`,
+			strings.Join(rows, ","))
+	})
+}
+
+func Test_GetRowsByIds(t *testing.T) {
+	var requests []int
+	rowIdsServer(t, &requests)
+
+	rowIds := make([]int64, 0, 452)
+	for id := int64(450); id >= 1; id-- { // reverse of sheet order
+		rowIds = append(rowIds, rowIdBase+id)
+	}
+	rowIds = append(rowIds, rowIdBase+450, rowIdBase+7) // duplicates
+	rows, notFound, err := GetRowsByIds(Test1Id, rowIds, nil)
+	if err != nil {
+		t.Fatal("GetRowsByIds Failed", err)
+	}
+	if fmt.Sprint(requests) != "[235 215]" {
+		t.Error("GetRowsByIds expected requests of 235 and 215 ids, got", requests)
+	}
+	if len(rows) != 446 || rows[0].Id != rowIdBase+450 || rows[1].Id != rowIdBase+449 || rows[445].Id != rowIdBase+1 {
+		t.Error("GetRowsByIds expected 446 rows in rowIds order, got", len(rows))
+	}
+	if fmt.Sprint(notFound) != fmt.Sprint([]int64{rowIdBase + 399, rowIdBase + 299, rowIdBase + 199, rowIdBase + 99}) {
+		t.Error("GetRowsByIds expected not found 399 299 199 99, got", notFound)
+	}
+
+	// 1 request while the ids fit in the url
+	requests = nil
+	if rows, _, err = GetRowsByIds(Test1Id, rowIds[:235], nil); err != nil || len(requests) != 1 || len(rows) != 233 {
+		t.Error("GetRowsByIds expected 1 request of 235 ids, got", err, requests, len(rows))
+	}
+	requests = nil
+	if rows, notFound, err = GetRowsByIds(Test1Id, nil, nil); err != nil || len(requests) != 0 || len(rows) != 0 || len(notFound) != 0 {
+		t.Error("GetRowsByIds no ids expected no request, got", err, requests)
+	}
+}
+
+func Test_LoadRowIdsSplit(t *testing.T) {
+	var requests []int
+	rowIdsServer(t, &requests)
+
+	rowIds := make([]int64, 0, 300)
+	for id := int64(300); id >= 1; id-- {
+		rowIds = append(rowIds, rowIdBase+id)
+	}
+	sheet := new(SheetInfo)
+	if err := sheet.Load(Test1Id, &GetSheetOptions{RowIds: rowIds}); err != nil {
+		t.Fatal("SheetInfo.Load Failed", err)
+	}
+	if fmt.Sprint(requests) != "[235 65]" || len(sheet.Rows) != 297 || sheet.TotalRowCount != 1000 {
+		t.Error("SheetInfo.Load expected requests [235 65] and 297 rows, got", requests, len(sheet.Rows))
+	}
+	for i, row := range sheet.Rows[1:] {
+		if row.RowNumber < sheet.Rows[i].RowNumber {
+			t.Fatal("SheetInfo.Load expected rows in sheet order, got row", row.Id, "after", sheet.Rows[i].Id)
+		}
+	}
+	missing := make([]string, 0)
+	for _, warning := range sheet.Warnings {
+		if warning.Code == WarnRowNotReturned {
+			missing = append(missing, strconv.FormatInt(warning.RowId-rowIdBase, 10))
+		}
+	}
+	if strings.Join(missing, ",") != "299,199,99" {
+		t.Error("SheetInfo.Load expected rows 299,199,99 not returned, got", missing)
+	}
+}
@@ -0,0 +1,414 @@
+// rowswhere.go contains MoveRowsWhere, CopyRowsWhere and ArchiveRows(With) funcs, which select the rows of a loaded sheet using a predicate.
+
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// CopyMoveChunkSize is the maximum number of row ids sent per copy or move request by CopyRowsWhere and MoveRowsWhere.
+const CopyMoveChunkSize = 500
+
+// MoveRowsWhere moves the rows of from where pred returns true to sheet toSheetId, see MoveRows.
+// Parm pred is called with the values of each row (see RowValues). If from.Rows is empty, the sheet is loaded first.
+// Row ids are sent in chunks of CopyMoveChunkSize. Moved rows are removed from from.Rows.
+// Returns the number of rows moved and their row mappings. If a chunk fails, the result includes rows moved by
+// previous chunks. No request is sent if no rows match.
+func MoveRowsWhere(from *SheetInfo, pred func(map[string]string) bool, toSheetId int64, options *MoveOptions) (int, []RowMapping, error) {
+	trace("MoveRowsWhere")
+	rowIds, err := from.rowIdsWhere(pred)
+	if err != nil {
+		return 0, nil, err
+	}
+	count, mappings, err := sendRowIdChunks(rowIds, func(chunk []int64) ([]RowMapping, error) {
+		return moveRows(from.SheetId, chunk, toSheetId, options)
+	})
+	from.removeRows(rowIds[:count])
+	return count, mappings, err
+}
+
+// CopyRowsWhere copies the rows of from where pred returns true to the bottom of sheet toSheetId, see CopyRows.
+// Rows are selected and sent as described for MoveRowsWhere, from.Rows is not changed.
+func CopyRowsWhere(from *SheetInfo, pred func(map[string]string) bool, toSheetId int64, options *CopyOptions) (int, []RowMapping, error) {
+	trace("CopyRowsWhere")
+	rowIds, err := from.rowIdsWhere(pred)
+	if err != nil {
+		return 0, nil, err
+	}
+	return sendRowIdChunks(rowIds, func(chunk []int64) ([]RowMapping, error) {
+		return copyRows(from.SheetId, chunk, toSheetId, options)
+	})
+}
+
+// rowIdsWhere returns the ids of rows where pred returns true, loading the sheet if Rows is empty.
+func (she *SheetInfo) rowIdsWhere(pred func(map[string]string) bool) ([]int64, error) {
+	rows, err := she.rowsWhere(pred)
+	if err != nil {
+		return nil, err
+	}
+	rowIds := make([]int64, len(rows))
+	for i, row := range rows {
+		rowIds[i] = row.Id
+	}
+	return rowIds, nil
+}
+
+// rowsWhere returns the rows where pred returns true, loading the sheet if Rows is empty.
+func (she *SheetInfo) rowsWhere(pred func(map[string]string) bool) ([]Row, error) {
+	if len(she.Rows) == 0 {
+		if err := she.Load(she.SheetId, nil); err != nil {
+			return nil, err
+		}
+	}
+	rows := make([]Row, 0)
+	for _, row := range she.Rows {
+		if pred(RowValues(she, row)) {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// removeRows removes rows with ids in rowIds from SheetInfo.Rows.
+func (she *SheetInfo) removeRows(rowIds []int64) {
+	if len(rowIds) == 0 {
+		return
+	}
+	removed := make(map[int64]bool, len(rowIds))
+	for _, rowId := range rowIds {
+		removed[rowId] = true
+	}
+	remaining := make([]Row, 0, len(she.Rows))
+	for _, row := range she.Rows {
+		if !removed[row.Id] {
+			remaining = append(remaining, row)
+		}
+	}
+	she.Rows = remaining
+}
+
+// ErrRowCountMismatch is wrapped by the error returned by ArchiveRowsWith when the upload response does not have 1 row
+// per row added to dest. Source rows cannot be matched to their dest rows, none are deleted.
+var ErrRowCountMismatch = errors.New("Row Count Mismatch")
+
+// ArchiveResult is returned by ArchiveRows. Row ids are source sheet ids.
+type ArchiveResult struct {
+	Added       []Row   // rows added to dest sheet
+	Archived    []int64 // rows added to dest and deleted from source
+	NotAdded    []int64 // rows in failed upload chunks, not deleted
+	NotDeleted  []int64 // rows added to dest, but delete request failed or dest rows not matched (ErrRowCountMismatch)
+	HasChildren []int64 // rows matching pred with a child (at any level) not matching pred, not copied or deleted
+
+	// only when discussions or attachments are copied, see ArchiveOptions
+	ContentErrors      map[int64]error // rows added to dest, but not all discussions or attachments copied, not deleted
+	SkippedAttachments []Attachment    // file attachments over ArchiveOptions.MaxAttachmentKb, not copied
+}
+
+// ArchiveRows copies the rows of source where pred returns true to dest, then deletes them from source.
+// Unlike MoveRows, the sheets can have different columns: columnMap keys are source column titles,
+// values are dest column titles. Only mapped columns are copied (cell values only, not formulas or formats).
+// Rows are added to the bottom of dest using UploadNewRows (dest.NewRows must be empty), a row is only deleted
+// from source if it was added. Deleting a parent row also deletes its children, so a row with a child (at any level)
+// in source.Rows not matching pred is not copied or deleted, its id is in ArchiveResult.HasChildren.
+// Archived rows are removed from source.Rows. If an upload chunk or delete fails, the error is returned with the result.
+// Discussions and attachments are not copied, see ArchiveRowsWith.
+func ArchiveRows(source *SheetInfo, dest *SheetInfo, pred func(map[string]string) bool, columnMap map[string]string) (*ArchiveResult, error) {
+	return ArchiveRowsWith(source, dest, pred, columnMap, nil)
+}
+
+// ArchiveRowsWith is ArchiveRows using ArchiveOptions (see options.go), nil options copies cell values only.
+// After rows are added to dest, the discussions and attachments of each row are re-created on its dest row before
+// the row is deleted from source. A row whose discussions or attachments are not all copied is left in source and
+// its error is in ArchiveResult.ContentErrors, the other rows are still archived. Running ArchiveRowsWith again
+// for such a row adds it to dest again, including the discussions and attachments copied the first time.
+func ArchiveRowsWith(source *SheetInfo, dest *SheetInfo, pred func(map[string]string) bool, columnMap map[string]string, options *ArchiveOptions) (*ArchiveResult, error) {
+	trace("ArchiveRowsWith")
+	if options == nil {
+		options = new(ArchiveOptions)
+	}
+	if len(dest.NewRows) > 0 {
+		err := errors.New("ArchiveRows dest.NewRows must be empty")
+		log.Println("ERROR -", err)
+		return nil, err
+	}
+	sourceTitles := make([]string, 0, len(columnMap))
+	for sourceTitle := range columnMap {
+		sourceTitles = append(sourceTitles, sourceTitle)
+	}
+	sort.Strings(sourceTitles) // cells and errors in consistent order
+	destTitles := make([]string, len(sourceTitles))
+	sourceIds := make([]int64, len(sourceTitles))
+	unknown := make([]string, 0)
+	for i, sourceTitle := range sourceTitles {
+		destTitle := columnMap[sourceTitle]
+		sourceColumn, found := source.ColumnsByName[sourceTitle]
+		if !found {
+			unknown = append(unknown, "source "+sourceTitle)
+		}
+		if _, found = dest.ColumnsByName[destTitle]; !found {
+			unknown = append(unknown, "dest "+destTitle)
+		}
+		destTitles[i], sourceIds[i] = destTitle, sourceColumn.Id
+	}
+	if len(unknown) > 0 {
+		err := fmt.Errorf("%w - %s", ErrInvalidColumnName, strings.Join(unknown, ", "))
+		log.Println("ERROR - ArchiveRows", err)
+		return nil, err
+	}
+	rows, err := source.rowsWhere(pred)
+	if err != nil {
+		return nil, err
+	}
+	result := new(ArchiveResult)
+	rows, result.HasChildren = source.withoutUnmatchedChildren(rows)
+	if len(result.HasChildren) > 0 {
+		log.Println("WARNING - ArchiveRows rows with children not matching pred, not archived", result.HasChildren)
+	}
+	if len(rows) == 0 {
+		return result, nil
+	}
+
+	// -- Stage Rows On Dest Using Dest Column Titles ----------------
+	for _, row := range rows {
+		values := make(map[int64]interface{}, len(row.Cells))
+		for _, cell := range row.Cells {
+			values[cell.ColumnId] = cell.Value
+		}
+		newRow := InitRow()
+		for i, columnId := range sourceIds {
+			if value := values[columnId]; value != nil {
+				newRow.Cells = append(newRow.Cells, Cell{ColName: destTitles[i], Value: value})
+			}
+		}
+		if err = dest.AddRow(newRow); err != nil {
+			dest.NewRows = nil
+			return nil, err
+		}
+	}
+	apiResp, err := dest.UploadNewRows(nil)
+	dest.NewRows = nil // failed rows are not kept, ArchiveRows can be run again
+	added := make([]bool, len(rows))
+	if err == nil {
+		for i := range added {
+			added[i] = true
+		}
+	} else if uploadErr, ok := err.(*UploadError); ok {
+		for i := range added {
+			added[i] = true
+		}
+		for _, chunk := range uploadErr.Chunks {
+			for i := chunk.FirstRow; i < chunk.FirstRow+chunk.RowCount; i++ {
+				added[i] = false
+			}
+		}
+	} else {
+		return nil, err
+	}
+	if apiResp != nil {
+		result.Added = apiResp.Result
+	}
+	addedCount := 0
+	for _, ok := range added {
+		if ok {
+			addedCount++
+		}
+	}
+	if len(result.Added) != addedCount { // pairing source rows with dest rows would be wrong
+		for i, row := range rows {
+			if added[i] {
+				result.NotDeleted = append(result.NotDeleted, row.Id)
+			} else {
+				result.NotAdded = append(result.NotAdded, row.Id)
+			}
+		}
+		mismatch := fmt.Errorf("%w - %d rows added to dest, response has %d, no rows deleted from source", ErrRowCountMismatch, addedCount, len(result.Added))
+		if err != nil {
+			mismatch = fmt.Errorf("%w, upload error: %v", mismatch, err)
+		}
+		log.Println("ERROR - ArchiveRows", mismatch)
+		return result, mismatch
+	}
+	addedIds := make([]int64, 0, len(rows))
+	destRow := 0 // index in result.Added of the dest row of the next added row
+	for i, row := range rows {
+		if !added[i] {
+			result.NotAdded = append(result.NotAdded, row.Id)
+			continue
+		}
+		destRowId := result.Added[destRow].Id
+		destRow++
+		if (options.Discussions || options.Attachments) && (options.Include == nil || options.Include(row)) {
+			if contentErr := copyRowContent(source.SheetId, row.Id, dest.SheetId, destRowId, options, result); contentErr != nil {
+				log.Println("ERROR - ArchiveRows content of row", row.Id, contentErr)
+				if result.ContentErrors == nil {
+					result.ContentErrors = make(map[int64]error)
+				}
+				result.ContentErrors[row.Id] = contentErr
+				continue // not deleted, discussions and attachments would be lost
+			}
+		}
+		addedIds = append(addedIds, row.Id)
+	}
+
+	// -- Delete Added Rows From Source ----------------
+	deleted, _, deleteErr := sendRowIdChunks(addedIds, func(chunk []int64) ([]RowMapping, error) {
+		return nil, DeleteRows(source.SheetId, chunk...)
+	})
+	result.Archived = addedIds[:deleted]
+	result.NotDeleted = addedIds[deleted:]
+	source.removeRows(result.Archived)
+	if deleteErr != nil {
+		return result, deleteErr
+	}
+	return result, err
+}
+
+// withoutUnmatchedChildren returns the rows (matching a pred) that have no child, at any level, in SheetInfo.Rows missing
+// from rows, and the ids of the rows removed. Deleting such a row from the sheet would also delete the missing children.
+func (she *SheetInfo) withoutUnmatchedChildren(rows []Row) ([]Row, []int64) {
+	matched := make(map[int64]bool, len(rows))
+	for _, row := range rows {
+		matched[row.Id] = true
+	}
+	parents := make(map[int64]int64, len(she.Rows)) // key: row id, value: parent row id
+	for _, row := range she.Rows {
+		parents[row.Id] = row.ParentId
+	}
+	hasUnmatched := make(map[int64]bool)
+	for _, row := range she.Rows {
+		if matched[row.Id] {
+			continue
+		}
+		for parentId := row.ParentId; parentId != 0 && !hasUnmatched[parentId]; parentId = parents[parentId] {
+			hasUnmatched[parentId] = true
+		}
+	}
+	kept := make([]Row, 0, len(rows))
+	var skipped []int64
+	for _, row := range rows {
+		if hasUnmatched[row.Id] {
+			skipped = append(skipped, row.Id)
+		} else {
+			kept = append(kept, row)
+		}
+	}
+	return kept, skipped
+}
+
+// copyRowContent re-creates the discussions and attachments of a source row on the dest row, as set in options.
+// Failures are combined in the returned error, the remaining discussions and attachments are still copied.
+func copyRowContent(sourceSheetId, sourceRowId, destSheetId, destRowId int64, options *ArchiveOptions, result *ArchiveResult) error {
+	problems := make([]string, 0)
+	if options.Discussions {
+		discussions, err := ListRowDiscussions(sourceSheetId, sourceRowId)
+		if err != nil {
+			return err
+		}
+		for _, discussion := range discussions {
+			if err = copyDiscussion(destSheetId, destRowId, discussion); err != nil {
+				problems = append(problems, fmt.Sprintf("discussion %d %v", discussion.Id, err))
+			}
+		}
+	}
+	if options.Attachments {
+		attachments, err := ListRowAttachments(sourceSheetId, sourceRowId)
+		if err != nil {
+			return err
+		}
+		for _, attachment := range attachments {
+			if attachment.ParentType != AttachedToRow {
+				continue
+			}
+			if options.MaxAttachmentKb > 0 && attachment.SizeInKb > options.MaxAttachmentKb {
+				result.SkippedAttachments = append(result.SkippedAttachments, attachment)
+				continue
+			}
+			if err = copyAttachment(sourceSheetId, destSheetId, destRowId, attachment); err != nil {
+				problems = append(problems, fmt.Sprintf("attachment %d %s %v", attachment.Id, attachment.Name, err))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("Copy Row Content Failed - " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// copyDiscussion creates a discussion on the dest row containing the comments of discussion, oldest first.
+func copyDiscussion(destSheetId, destRowId int64, discussion Discussion) error {
+	comments := append([]Comment(nil), discussion.Comments...)
+	sort.SliceStable(comments, func(i, j int) bool { return comments[i].CreatedAt.Before(comments[j].CreatedAt) })
+	var created *Discussion
+	for _, comment := range comments {
+		var err error
+		if created == nil {
+			created, err = CreateRowDiscussion(destSheetId, destRowId, archivedCommentText(comment))
+		} else {
+			_, err = AddComment(destSheetId, created.Id, archivedCommentText(comment))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archivedCommentText returns the comment text prefixed with its original author and date, ex. "[Ann <a@x.com> 2020-11-20 10:00] text".
+func archivedCommentText(comment Comment) string {
+	author := comment.CreatedBy.Email
+	if comment.CreatedBy.Name != "" {
+		author = comment.CreatedBy.Name + " <" + comment.CreatedBy.Email + ">"
+	}
+	return fmt.Sprintf("[%s %s] %s", author, comment.CreatedAt.Format("2006-01-02 15:04"), comment.Text)
+}
+
+// copyAttachment re-uploads a file attachment (downloaded using its temporary url) or re-creates a link attachment on the dest row.
+func copyAttachment(sourceSheetId, destSheetId, destRowId int64, attachment Attachment) error {
+	endPoint := fmt.Sprintf("/sheets/%d/rows/%d/attachments", destSheetId, destRowId)
+	if attachment.AttachmentType != "FILE" {
+		_, err := attachUrl("copyAttachment", endPoint, attachment.Name, attachment.AttachmentType, attachment.Url)
+		return err
+	}
+	file, err := GetAttachment(sourceSheetId, attachment.Id)
+	if err != nil {
+		return err
+	}
+	download, err := HttpClient.Get(file.Url) // temporary url, not an api request
+	if err != nil {
+		return err
+	}
+	defer download.Body.Close()
+	if download.StatusCode != http.StatusOK {
+		return fmt.Errorf("download failed, status %s", download.Status)
+	}
+	resp, err := uploadReader(endPoint, attachment.Name, download.Body, download.ContentLength)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// sendRowIdChunks calls send for each chunk of rowIds, stopping at the 1st error.
+// Returns the number of row ids sent successfully and the combined mappings.
+func sendRowIdChunks(rowIds []int64, send func([]int64) ([]RowMapping, error)) (int, []RowMapping, error) {
+	mappings := make([]RowMapping, 0, len(rowIds))
+	for first := 0; first < len(rowIds); first += CopyMoveChunkSize {
+		last := first + CopyMoveChunkSize
+		if last > len(rowIds) {
+			last = len(rowIds)
+		}
+		chunkMappings, err := send(rowIds[first:last])
+		if err != nil {
+			log.Printf("ERROR - rows %d-%d of %d failed %v", first, last-1, len(rowIds), err)
+			return first, mappings, err
+		}
+		mappings = append(mappings, chunkMappings...)
+	}
+	return len(rowIds), mappings, nil
+}
@@ -0,0 +1,345 @@
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_RowsWhere(t *testing.T) {
+	var paths []string
+	chunkSizes := make([]int, 0)
+	failChunk := -1
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		if r.Method == "GET" { // Load
+			w.Write([]byte(`{"id":1849449510135684,"name":"Test1","columns":[{"id":108,"index":0,"title":"Status"}],
+				"rows":[{"id":1,"cells":[{"columnId":108,"value":"Red"}]},{"id":2,"cells":[{"columnId":108,"value":"Green"}]}]}`))
+			return
+		}
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		var reqData struct{ RowIds []int64 }
+		json.Unmarshal(reqBytes, &reqData)
+		if len(chunkSizes) == failChunk {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		chunkSizes = append(chunkSizes, len(reqData.RowIds))
+		mappings := make([]RowMapping, len(reqData.RowIds))
+		for i, rowId := range reqData.RowIds {
+			mappings[i] = RowMapping{From: rowId, To: rowId + 1000000}
+		}
+		result, _ := json.Marshal(mappings)
+		fmt.Fprintf(w, `{"destinationSheetId":8094487248430980,"rowMappings":%s}`, result)
+	})
+	done := func(values map[string]string) bool { return values["Status"] == "Green" }
+	bigSheet := func() *SheetInfo {
+		sheet := testSheet()
+		for i := 0; i < 1203; i++ { // 602 Green rows
+			status := "Red"
+			if i%2 == 0 {
+				status = "Green"
+			}
+			sheet.Rows = append(sheet.Rows, Row{Id: int64(i + 1), Cells: []Cell{{ColumnId: 108, Value: status}}})
+		}
+		return sheet
+	}
+
+	sheet := bigSheet()
+	count, mappings, err := CopyRowsWhere(sheet, done, 8094487248430980, nil)
+	if err != nil || count != 602 || len(mappings) != 602 || mappings[601] != (RowMapping{From: 1203, To: 1001203}) {
+		t.Fatal("CopyRowsWhere wrong result", count, len(mappings), err)
+	}
+	if fmt.Sprint(chunkSizes) != "[500 102]" || paths[0] != "POST /sheets/1849449510135684/rows/copy" {
+		t.Error("CopyRowsWhere expected chunks of 500 and 102 copy requests, got", chunkSizes, paths[0])
+	}
+	if len(sheet.Rows) != 1203 {
+		t.Error("CopyRowsWhere expected Rows unchanged")
+	}
+
+	chunkSizes, paths, failChunk = nil, nil, 1
+	count, mappings, err = MoveRowsWhere(sheet, done, 8094487248430980, &MoveOptions{Attachments: true})
+	if err == nil || count != 500 || len(mappings) != 500 {
+		t.Error("MoveRowsWhere expected 2nd chunk to fail after 500 rows, got", count, len(mappings), err)
+	}
+	if len(sheet.Rows) != 703 || paths[0] != "POST /sheets/1849449510135684/rows/move" {
+		t.Error("MoveRowsWhere expected 500 moved rows removed from Rows, got", len(sheet.Rows), paths[0])
+	}
+
+	chunkSizes, paths, failChunk = nil, nil, -1
+	nothing := func(values map[string]string) bool { return values["Status"] == "Blue" }
+	if count, _, err = MoveRowsWhere(sheet, nothing, 8094487248430980, nil); err != nil || count != 0 || len(paths) != 0 {
+		t.Error("MoveRowsWhere expected no request when no rows match, got", count, paths, err)
+	}
+
+	unloaded := &SheetInfo{SheetId: 1849449510135684}
+	count, _, err = MoveRowsWhere(unloaded, done, 8094487248430980, nil)
+	if err != nil || count != 1 || len(paths) != 2 || paths[0] != "GET /sheets/1849449510135684" || len(unloaded.Rows) != 1 {
+		t.Error("MoveRowsWhere expected sheet loaded then 1 row moved, got", count, paths, err)
+	}
+}
+
+func Test_ArchiveRows(t *testing.T) {
+	var added [][]Cell  // cells of rows added to archive sheet
+	var deleted []int64 // source row ids deleted
+	posts, failPost, shortResult := 0, -1, false
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/sheets/8094487248430980/rows":
+			posts++
+			if posts == failPost {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			reqBytes, _ := ioutil.ReadAll(r.Body)
+			var items []struct{ Cells []Cell }
+			json.Unmarshal(reqBytes, &items)
+			rows := make([]Row, len(items))
+			for i, item := range items {
+				added = append(added, item.Cells)
+				rows[i] = Row{Id: int64(900000 + len(added)), Cells: item.Cells}
+			}
+			if shortResult {
+				rows = rows[:len(rows)-1]
+			}
+			result, _ := json.Marshal(rows)
+			if len(rows) == 1 {
+				result, _ = json.Marshal(rows[0])
+			}
+			fmt.Fprintf(w, `{"message":"SUCCESS","resultCode":0,"result":%s}`, result)
+		case r.Method == "DELETE" && r.URL.Path == "/sheets/1849449510135684/rows":
+			for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+				rowId, _ := strconv.ParseInt(id, 10, 64)
+				deleted = append(deleted, rowId)
+			}
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+		default:
+			t.Error("ArchiveRows unexpected request", r.Method, r.URL.Path)
+		}
+	})
+	archiveSheet := func() *SheetInfo {
+		sheet := &SheetInfo{SheetId: 8094487248430980, SheetName: "Archive",
+			ColumnsById: make(map[int64]Column), ColumnsByName: make(map[string]Column), ColumnsByIndex: make(map[int]Column)}
+		for _, column := range []Column{{Id: 201, Index: 0, Title: "Location", Primary: true}, {Id: 202, Index: 1, Title: "Order"}, {Id: 203, Index: 2, Title: "Amount"}} {
+			sheet.ColumnsById[column.Id], sheet.ColumnsByName[column.Title], sheet.ColumnsByIndex[column.Index] = column, column, column
+		}
+		return sheet
+	}
+	columnMap := map[string]string{"Address": "Location", "OrderNo": "Order", "Amt": "Amount"}
+	complete := func(values map[string]string) bool { return values["Complete"] == "true" }
+
+	source := testSheet()
+	for i := 1; i <= 5; i++ {
+		source.Rows = append(source.Rows, Row{Id: int64(i), Cells: []Cell{
+			{ColumnId: 101, Value: fmt.Sprintf("%d Main", i)}, {ColumnId: 102, Value: fmt.Sprintf("A-%d", i)},
+			{ColumnId: 105, Value: float64(i * 10)}, {ColumnId: 106, Value: i%2 == 1}, {ColumnId: 108, Value: "Green"}}})
+	}
+	result, err := ArchiveRows(source, archiveSheet(), complete, columnMap)
+	if err != nil {
+		t.Fatal("ArchiveRows Failed", err)
+	}
+	if fmt.Sprint(result.Archived) != "[1 3 5]" || fmt.Sprint(deleted) != "[1 3 5]" || len(result.Added) != 3 {
+		t.Errorf("ArchiveRows wrong result %+v, deleted %v", result, deleted)
+	}
+	if len(source.Rows) != 2 || source.Rows[0].Id != 2 {
+		t.Error("ArchiveRows expected archived rows removed from source.Rows", source.Rows)
+	}
+	cellsJSON, _ := json.Marshal(added[1])
+	expect := `[{"columnId":201,"value":"3 Main"},{"columnId":203,"value":30},{"columnId":202,"value":"A-3"}]` // columnMap order
+	if string(cellsJSON) != expect {
+		t.Errorf("ArchiveRows added cells, Expecting %s, Got %s", expect, cellsJSON)
+	}
+
+	// 2nd upload chunk fails, only rows of 1st chunk are deleted
+	added, deleted, posts, failPost = nil, nil, 0, 2
+	source = testSheet()
+	for i := 1; i <= UploadChunkSize+20; i++ {
+		source.Rows = append(source.Rows, Row{Id: int64(i), Cells: []Cell{{ColumnId: 101, Value: "x"}, {ColumnId: 106, Value: true}}})
+	}
+	dest := archiveSheet()
+	result, err = ArchiveRows(source, dest, complete, columnMap)
+	if _, ok := err.(*UploadError); !ok {
+		t.Fatal("ArchiveRows expected UploadError, got", err)
+	}
+	if len(result.Archived) != UploadChunkSize || len(deleted) != UploadChunkSize || len(result.NotAdded) != 20 || result.NotAdded[0] != int64(UploadChunkSize+1) {
+		t.Errorf("ArchiveRows partial failure, archived %d, deleted %d, not added %d", len(result.Archived), len(deleted), len(result.NotAdded))
+	}
+	if len(source.Rows) != 20 || dest.NewRows != nil {
+		t.Error("ArchiveRows expected 20 source rows remaining and dest.NewRows cleared", len(source.Rows), len(dest.NewRows))
+	}
+
+	// response missing a row, source rows cannot be matched to dest rows, none deleted
+	deleted, posts, failPost, shortResult = nil, 0, -1, true
+	source = testSheet()
+	for i := 1; i <= 3; i++ {
+		source.Rows = append(source.Rows, Row{Id: int64(i), Cells: []Cell{{ColumnId: 101, Value: "x"}, {ColumnId: 106, Value: true}}})
+	}
+	result, err = ArchiveRows(source, dest, complete, columnMap)
+	if !errors.Is(err, ErrRowCountMismatch) || len(deleted) != 0 || fmt.Sprint(result.NotDeleted) != "[1 2 3]" || len(source.Rows) != 3 {
+		t.Errorf("ArchiveRows short response expected ErrRowCountMismatch and no deletes, got %v, deleted %v, %+v", err, deleted, result)
+	}
+
+	// short response and a failed chunk, the error includes the upload error
+	posts, failPost = 0, 2
+	source = testSheet()
+	for i := 1; i <= UploadChunkSize+20; i++ {
+		source.Rows = append(source.Rows, Row{Id: int64(i), Cells: []Cell{{ColumnId: 101, Value: "x"}, {ColumnId: 106, Value: true}}})
+	}
+	result, err = ArchiveRows(source, dest, complete, columnMap)
+	if !errors.Is(err, ErrRowCountMismatch) || !strings.Contains(err.Error(), "upload error") || len(deleted) != 0 || len(result.NotAdded) != 20 {
+		t.Errorf("ArchiveRows short response and failed chunk expected ErrRowCountMismatch with upload error, got %v, deleted %d", err, len(deleted))
+	}
+	shortResult, failPost = false, -1
+
+	// matched parent 1 has unmatched child 2, matched parent 4 has matched child 5 with unmatched child 6
+	added, deleted, posts = nil, nil, 0
+	source = testSheet()
+	for i, parentId := range []int64{0, 1, 0, 0, 4, 5, 0} {
+		source.Rows = append(source.Rows, Row{Id: int64(i + 1), ParentId: parentId, Cells: []Cell{{ColumnId: 101, Value: "x"}, {ColumnId: 106, Value: i != 1 && i != 5}}})
+	}
+	result, err = ArchiveRows(source, archiveSheet(), complete, columnMap)
+	if err != nil || fmt.Sprint(result.HasChildren) != "[1 4 5]" || fmt.Sprint(result.Archived) != "[3 7]" || fmt.Sprint(deleted) != "[3 7]" || len(added) != 2 {
+		t.Errorf("ArchiveRows expected parents with unmatched children skipped, got %v, deleted %v, added %d, %+v", err, deleted, len(added), result)
+	}
+	if len(source.Rows) != 5 {
+		t.Error("ArchiveRows expected skipped parents and their children left in source.Rows", source.Rows)
+	}
+
+	deleted, posts = nil, 0
+	_, err = ArchiveRows(source, dest, complete, map[string]string{"Address": "Location", "Bogus": "Order", "Amt": "Nope"})
+	if !errors.Is(err, ErrInvalidColumnName) || !strings.Contains(err.Error(), "dest Nope, source Bogus") || posts != 0 {
+		t.Error("ArchiveRows expected ErrInvalidColumnName listing unknown columns, got", err)
+	}
+}
+
+func Test_ArchiveRowsWith(t *testing.T) {
+	var comments []string // text of comments created in archive sheet, "discussionId text"
+	var uploads []string  // "rowId fileName content" of files uploaded to archive sheet
+	var links []string    // "rowId name url" of links attached in archive sheet
+	var deleted []int64
+	failDownload := false
+	var server *httptest.Server
+	server = stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/sheets/8094487248430980/rows":
+			var items []struct{ Cells []Cell }
+			json.Unmarshal(reqBytes, &items)
+			rows := make([]Row, len(items))
+			for i := range items {
+				rows[i] = Row{Id: int64(900001 + i)}
+			}
+			result, _ := json.Marshal(rows)
+			fmt.Fprintf(w, `{"message":"SUCCESS","resultCode":0,"result":%s}`, result)
+		case r.Method == "GET" && r.URL.Path == "/sheets/1849449510135684/rows/1/discussions":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"id":71,"comments":[
+				{"id":2,"text":"second","createdAt":"2020-11-21T09:30:00Z","createdBy":{"email":"bob@x.com"}},
+				{"id":1,"text":"first","createdAt":"2020-11-20T10:00:00Z","createdBy":{"email":"ann@x.com","name":"Ann"}}]}]}`))
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/discussions"):
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[]}`))
+		case r.Method == "POST" && r.URL.Path == "/sheets/8094487248430980/rows/900001/discussions":
+			var comment struct{ Comment struct{ Text string } }
+			json.Unmarshal(reqBytes, &comment)
+			comments = append(comments, "81 "+comment.Comment.Text)
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":81}}`))
+		case r.Method == "POST" && r.URL.Path == "/sheets/8094487248430980/discussions/81/comments":
+			var comment struct{ Text string }
+			json.Unmarshal(reqBytes, &comment)
+			comments = append(comments, "81 "+comment.Text)
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":82}}`))
+		case r.Method == "GET" && r.URL.Path == "/sheets/1849449510135684/rows/1/attachments":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[
+				{"id":61,"name":"plan.pdf","attachmentType":"FILE","sizeInKb":2,"parentType":"ROW"},
+				{"id":62,"name":"video.mp4","attachmentType":"FILE","sizeInKb":90000,"parentType":"ROW"},
+				{"id":63,"name":"Site","attachmentType":"LINK","url":"https://example.com/site","parentType":"ROW"},
+				{"id":64,"name":"reply.png","attachmentType":"FILE","sizeInKb":1,"parentType":"COMMENT"}]}`))
+		case r.Method == "GET" && r.URL.Path == "/sheets/1849449510135684/rows/3/attachments":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"id":65,"name":"notes.txt","attachmentType":"FILE","sizeInKb":1,"parentType":"ROW"}]}`))
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/sheets/1849449510135684/attachments/"):
+			id := strings.TrimPrefix(r.URL.Path, "/sheets/1849449510135684/attachments/")
+			fmt.Fprintf(w, `{"id":%s,"attachmentType":"FILE","url":"%s/download/%s"}`, id, server.URL, id)
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/download/"):
+			if failDownload && r.URL.Path == "/download/65" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte("content of " + r.URL.Path))
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/attachments"):
+			rowId := strings.Split(r.URL.Path, "/")[4]
+			if strings.Contains(r.Header.Get("Content-Type"), "json") {
+				var link struct{ Name, Url string }
+				json.Unmarshal(reqBytes, &link)
+				links = append(links, rowId+" "+link.Name+" "+link.Url)
+			} else {
+				uploads = append(uploads, rowId+" "+r.Header.Get("Content-Disposition")+" "+string(reqBytes))
+			}
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":99}}`))
+		case r.Method == "DELETE" && r.URL.Path == "/sheets/1849449510135684/rows":
+			for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+				rowId, _ := strconv.ParseInt(id, 10, 64)
+				deleted = append(deleted, rowId)
+			}
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+		default:
+			t.Error("ArchiveRowsWith unexpected request", r.Method, r.URL.Path)
+		}
+	})
+	archiveSheet := &SheetInfo{SheetId: 8094487248430980, SheetName: "Archive",
+		ColumnsById: map[int64]Column{201: {Id: 201, Title: "Location", Primary: true}}, ColumnsByName: map[string]Column{"Location": {Id: 201, Title: "Location", Primary: true}}}
+	columnMap := map[string]string{"Address": "Location"}
+	all := func(values map[string]string) bool { return true }
+	newSource := func() *SheetInfo {
+		source := testSheet()
+		for _, id := range []int64{1, 3} {
+			source.Rows = append(source.Rows, Row{Id: id, Cells: []Cell{{ColumnId: 101, Value: "x"}}})
+		}
+		return source
+	}
+
+	options := &ArchiveOptions{Discussions: true, Attachments: true, MaxAttachmentKb: 1024}
+	result, err := ArchiveRowsWith(newSource(), archiveSheet, all, columnMap, options)
+	if err != nil {
+		t.Fatal("ArchiveRowsWith Failed", err)
+	}
+	expect := `[81 [Ann <ann@x.com> 2020-11-20 10:00] first 81 [bob@x.com 2020-11-21 09:30] second]`
+	if fmt.Sprint(comments) != expect {
+		t.Errorf("ArchiveRowsWith comments, Expecting %s, Got %v", expect, comments)
+	}
+	expect = `[900001 attachment; filename="plan.pdf" content of /download/61 900002 attachment; filename="notes.txt" content of /download/65]`
+	if fmt.Sprint(uploads) != expect {
+		t.Errorf("ArchiveRowsWith uploads, Expecting %s, Got %v", expect, uploads)
+	}
+	if fmt.Sprint(links) != "[900001 Site https://example.com/site]" {
+		t.Error("ArchiveRowsWith expected link attachment re-created", links)
+	}
+	if len(result.SkippedAttachments) != 1 || result.SkippedAttachments[0].Id != 62 {
+		t.Error("ArchiveRowsWith expected video.mp4 skipped", result.SkippedAttachments)
+	}
+	if fmt.Sprint(deleted) != "[1 3]" || result.ContentErrors != nil {
+		t.Errorf("ArchiveRowsWith expected rows 1 and 3 deleted, deleted %v, content errors %v", deleted, result.ContentErrors)
+	}
+
+	// download of row 3 attachment fails, row 3 is added to dest but not deleted from source
+	comments, uploads, links, deleted, failDownload = nil, nil, nil, nil, true
+	source := newSource()
+	result, err = ArchiveRowsWith(source, archiveSheet, all, columnMap, &ArchiveOptions{Attachments: true,
+		Include: func(row Row) bool { return row.Id == 3 }})
+	if err != nil {
+		t.Fatal("ArchiveRowsWith Failed", err)
+	}
+	if len(result.ContentErrors) != 1 || !strings.Contains(fmt.Sprint(result.ContentErrors[3]), "notes.txt") {
+		t.Error("ArchiveRowsWith expected content error for row 3", result.ContentErrors)
+	}
+	if fmt.Sprint(deleted) != "[1]" || fmt.Sprint(result.Archived) != "[1]" || len(source.Rows) != 1 || source.Rows[0].Id != 3 {
+		t.Errorf("ArchiveRowsWith expected only row 1 archived, deleted %v, archived %v", deleted, result.Archived)
+	}
+	if len(comments) != 0 || len(links) != 0 {
+		t.Error("ArchiveRowsWith expected only row 3 attachments copied", comments, links)
+	}
+}
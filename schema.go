@@ -0,0 +1,398 @@
+// schema.go contains ExportSchema and ApplySchema, for promoting a sheet design (columns and project settings) from one
+// sheet to another using a json schema file that can be kept in source control and diffed.
+// Rows, formulas, formatting and automation are not part of the schema.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"strings"
+)
+
+// SchemaVersion is the version of the schema files written by ExportSchema.
+const SchemaVersion = 1
+
+// SheetSchema is the content of a schema file, columns are in sheet order.
+type SheetSchema struct {
+	Version             int              `json:"version"`
+	Name                string           `json:"name"` // sheet exported, not changed by ApplySchema
+	DependenciesEnabled bool             `json:"dependenciesEnabled,omitempty"`
+	ProjectSettings     *ProjectSettings `json:"projectSettings,omitempty"` // only if DependenciesEnabled
+	Columns             []ColumnSpec     `json:"columns"`
+}
+
+// Schema Step Actions, in the order they are applied
+const (
+	SchemaDelete   = "DELETE"   // delete a column not in the schema, only with ApplyOptions.DeleteExtraColumns
+	SchemaUpdate   = "UPDATE"   // change column attributes, the primary column is renamed if its title differs
+	SchemaAdd      = "ADD"      // add a column
+	SchemaMove     = "MOVE"     // move a column to its schema position
+	SchemaSettings = "SETTINGS" // change dependencies enabled or project settings
+)
+
+// SchemaStep is 1 change made by ApplySchema.
+type SchemaStep struct {
+	Action string // use Schema Step Actions, ex. SchemaAdd
+	Column string // title of the column before the step, empty for SchemaSettings
+	Detail string // description of the change, ex. "width 100 -> 150"
+
+	index    int
+	spec     ColumnSpec
+	update   ColumnUpdate
+	settings ProjectSettings
+}
+
+// SchemaPlan is returned by ApplySchema, the steps needed to make the sheet match the schema.
+type SchemaPlan struct {
+	Steps        []SchemaStep
+	ExtraColumns []string // sheet columns not in the schema
+	Applied      int      // steps applied, less than len(Steps) if a step failed, 0 for ApplyOptions.DryRun
+}
+
+// String returns the plan, 1 line per step, ex. "ADD Region at 2 (TEXT_NUMBER)".
+func (plan *SchemaPlan) String() string {
+	if len(plan.Steps) == 0 {
+		return "no changes\n"
+	}
+	var b strings.Builder
+	for _, step := range plan.Steps {
+		fmt.Fprintln(&b, strings.TrimSpace(step.Action+" "+step.Column+" "+step.Detail))
+	}
+	return b.String()
+}
+
+// ErrInvalidSchema is wrapped by the error returned by ApplySchema when the schema cannot be applied to any sheet.
+var ErrInvalidSchema = errors.New("Invalid Schema")
+
+// ExportSchema writes the schema of a loaded sheet (all columns) to w as indented json.
+func ExportSchema(sheet *SheetInfo, w io.Writer) error {
+	trace("ExportSchema")
+	schema := SheetSchema{Version: SchemaVersion, Name: sheet.SheetName, DependenciesEnabled: sheet.DependenciesEnabled}
+	if sheet.DependenciesEnabled {
+		schema.ProjectSettings = sheet.ProjectSettings
+	}
+	for _, column := range sheet.columnList() {
+		schema.Columns = append(schema.Columns, ColumnSpec{
+			Title:            column.Title,
+			Type:             column.Type,
+			Primary:          column.Primary,
+			Options:          column.Options,
+			Symbol:           column.Symbol,
+			SystemColumnType: column.SystemColumnType,
+			Width:            column.Width,
+			Hidden:           column.Hidden,
+			Locked:           column.Locked,
+			Validation:       column.Validation,
+		})
+	}
+	jsonData, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		log.Println("ERROR - ExportSchema Failed", err)
+		return err
+	}
+	_, err = w.Write(append(jsonData, '\n'))
+	return err
+}
+
+// ApplySchema changes a sheet to match the schema read from r (written by ExportSchema) and returns the steps taken.
+// Columns are matched by title, except the primary column (always matched, renamed if needed). Missing columns are
+// added, columns with different attributes are updated and all are moved to their schema position. Sheet columns not
+// in the schema are kept unless opts.DeleteExtraColumns is set. A schema Width of 0 leaves the column width unchanged.
+// With opts.DryRun set, the plan is returned and the sheet is not changed.
+// Steps are applied in plan order, if one fails the plan is returned with the error (plan.Applied steps were made).
+func ApplySchema(sheetId int64, r io.Reader, opts ApplyOptions) (*SchemaPlan, error) {
+	trace("ApplySchema")
+	schema := new(SheetSchema)
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(schema); err != nil {
+		log.Println("ERROR - ApplySchema", err)
+		return nil, fmt.Errorf("%w - %v", ErrInvalidSchema, err)
+	}
+	if err := schema.validate(); err != nil {
+		log.Println("ERROR - ApplySchema", err)
+		return nil, err
+	}
+	sheet := new(SheetInfo)
+	if err := sheet.Load(sheetId, NoRows); err != nil {
+		return nil, err
+	}
+	plan, err := planSchema(sheet, schema, opts)
+	if err != nil {
+		log.Println("ERROR - ApplySchema", sheet.SheetName, err)
+		return nil, err
+	}
+	if opts.DryRun {
+		return plan, nil
+	}
+	for _, step := range plan.Steps {
+		if err = step.apply(sheet); err != nil {
+			log.Println("ERROR - ApplySchema", sheet.SheetName, "step failed", step.Action, step.Column, err)
+			return plan, err
+		}
+		plan.Applied++
+	}
+	return plan, nil
+}
+
+// validate checks the schema version, that exactly 1 column is primary and column titles are unique.
+func (schema *SheetSchema) validate() error {
+	if schema.Version < 1 || schema.Version > SchemaVersion {
+		return fmt.Errorf("%w - version %d, this package reads up to %d", ErrInvalidSchema, schema.Version, SchemaVersion)
+	}
+	primaries := 0
+	titles := make(map[string]bool, len(schema.Columns))
+	for _, column := range schema.Columns {
+		if column.Primary {
+			primaries++
+		}
+		if column.Title == "" || titles[column.Title] {
+			return fmt.Errorf("%w - empty or duplicate column title %q", ErrInvalidSchema, column.Title)
+		}
+		titles[column.Title] = true
+	}
+	if primaries != 1 {
+		return fmt.Errorf("%w - %d primary columns, expecting 1", ErrInvalidSchema, primaries)
+	}
+	return nil
+}
+
+// planSchema returns the steps changing the loaded sheet to schema. Column positions are tracked in a list of titles
+// as each step is planned, so MOVE steps use the indexes the sheet will have when they are applied.
+func planSchema(sheet *SheetInfo, schema *SheetSchema, opts ApplyOptions) (*SchemaPlan, error) {
+	plan := new(SchemaPlan)
+	primary, err := sheet.PrimaryColumn()
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]Column, len(schema.Columns)) // schema title: matched sheet column
+	for _, want := range schema.Columns {
+		if want.Primary {
+			current[want.Title] = primary
+		} else if column, found := sheet.ColumnsByName[want.Title]; found && !column.Primary {
+			current[want.Title] = column
+		}
+	}
+	matched := make(map[int64]bool, len(current))
+	for _, column := range current {
+		matched[column.Id] = true
+	}
+
+	order := make([]string, 0, len(sheet.ColumnsById)) // titles in sheet order
+	for _, column := range sheet.columnList() {
+		if matched[column.Id] {
+			order = append(order, column.Title)
+			continue
+		}
+		plan.ExtraColumns = append(plan.ExtraColumns, column.Title)
+		if opts.DeleteExtraColumns {
+			plan.Steps = append(plan.Steps, SchemaStep{Action: SchemaDelete, Column: column.Title})
+		} else {
+			order = append(order, column.Title)
+		}
+	}
+	for _, want := range schema.Columns {
+		column, found := current[want.Title]
+		if !found {
+			continue
+		}
+		if update, changes := columnChanges(column, want); len(changes) > 0 {
+			plan.Steps = append(plan.Steps, SchemaStep{Action: SchemaUpdate, Column: column.Title,
+				Detail: strings.Join(changes, ", "), update: update})
+			order[titleIndex(order, column.Title)] = want.Title
+		}
+	}
+	for i, want := range schema.Columns {
+		if _, found := current[want.Title]; found {
+			continue
+		}
+		index := i
+		if index > len(order) {
+			index = len(order)
+		}
+		plan.Steps = append(plan.Steps, SchemaStep{Action: SchemaAdd, Column: want.Title,
+			Detail: fmt.Sprintf("at %d (%s)", index, want.Type), index: index, spec: want})
+		order = append(order[:index], append([]string{want.Title}, order[index:]...)...)
+	}
+	plan.Steps = append(plan.Steps, planMoves(order, schemaOrder(schema, order))...)
+	if step, changed := settingsChanges(sheet, schema); changed {
+		plan.Steps = append(plan.Steps, step)
+	}
+	return plan, nil
+}
+
+// schemaOrder returns the column titles in schema order, followed by the titles in order not in the schema (extra columns kept).
+func schemaOrder(schema *SheetSchema, order []string) []string {
+	target := make([]string, 0, len(order))
+	inSchema := make(map[string]bool, len(schema.Columns))
+	for _, want := range schema.Columns {
+		target = append(target, want.Title)
+		inSchema[want.Title] = true
+	}
+	for _, title := range order {
+		if !inSchema[title] {
+			target = append(target, title)
+		}
+	}
+	return target
+}
+
+// planMoves returns the MOVE steps changing order to target (same titles). The longest run of columns already in
+// target order is kept in place, each other column is moved after the column preceding it in target.
+func planMoves(order, target []string) []SchemaStep {
+	position := make(map[string]int, len(target))
+	for i, title := range target {
+		position[title] = i
+	}
+	// longest increasing subsequence of target positions in order
+	length := make([]int, len(order))
+	previous := make([]int, len(order))
+	last := -1
+	for i := range order {
+		length[i], previous[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if position[order[j]] < position[order[i]] && length[j]+1 > length[i] {
+				length[i], previous[i] = length[j]+1, j
+			}
+		}
+		if last == -1 || length[i] > length[last] {
+			last = i
+		}
+	}
+	inPlace := make(map[string]bool, len(order))
+	for i := last; i != -1; i = previous[i] {
+		inPlace[order[i]] = true
+	}
+
+	order = append([]string{}, order...)
+	steps := make([]SchemaStep, 0)
+	for i, title := range target {
+		if inPlace[title] {
+			continue
+		}
+		from, to := titleIndex(order, title), 0
+		if i > 0 {
+			to = titleIndex(order, target[i-1])
+			if from > to {
+				to++
+			}
+		}
+		steps = append(steps, SchemaStep{Action: SchemaMove, Column: title, Detail: fmt.Sprintf("%d -> %d", from, to), index: to})
+		order = append(order[:from], order[from+1:]...)
+		order = append(order[:to], append([]string{title}, order[to:]...)...)
+	}
+	return steps
+}
+
+// columnChanges returns the update changing column to want, and a description of each change.
+func columnChanges(column Column, want ColumnSpec) (ColumnUpdate, []string) {
+	var update ColumnUpdate
+	changes := make([]string, 0)
+	if column.Title != want.Title {
+		update.Title = want.Title
+		changes = append(changes, fmt.Sprintf("title %s -> %s", column.Title, want.Title))
+	}
+	if column.Type != want.Type || column.SystemColumnType != want.SystemColumnType ||
+		!reflect.DeepEqual(nilIfEmpty(column.Options), nilIfEmpty(want.Options)) || column.Symbol != want.Symbol {
+		update.Type, update.Options, update.Symbol, update.SystemColumnType = want.Type, want.Options, want.Symbol, want.SystemColumnType
+		changes = append(changes, fmt.Sprintf("type %s -> %s", describeType(column.Type, column.SystemColumnType, column.Symbol, column.Options),
+			describeType(want.Type, want.SystemColumnType, want.Symbol, want.Options)))
+	}
+	if want.Width != 0 && column.Width != want.Width {
+		update.Width = want.Width
+		changes = append(changes, fmt.Sprintf("width %d -> %d", column.Width, want.Width))
+	}
+	if column.Hidden != want.Hidden {
+		update.Hidden = &want.Hidden
+		changes = append(changes, fmt.Sprintf("hidden %v", want.Hidden))
+	}
+	if column.Locked != want.Locked {
+		update.Locked = &want.Locked
+		changes = append(changes, fmt.Sprintf("locked %v", want.Locked))
+	}
+	if column.Validation != want.Validation {
+		update.Validation = &want.Validation
+		changes = append(changes, fmt.Sprintf("validation %v", want.Validation))
+	}
+	return update, changes
+}
+
+// describeType returns the type of a column for a plan step, ex. PICKLIST[Red,Green] or TEXT_NUMBER/AUTO_NUMBER.
+func describeType(columnType, systemColumnType, symbol string, options []string) string {
+	description := columnType
+	if systemColumnType != "" {
+		description += "/" + systemColumnType
+	}
+	if symbol != "" {
+		description += "/" + symbol
+	}
+	if len(options) > 0 {
+		description += "[" + strings.Join(options, ",") + "]"
+	}
+	return description
+}
+
+// settingsChanges returns the SchemaSettings step if the sheet's dependencies enabled or project settings differ from schema.
+func settingsChanges(sheet *SheetInfo, schema *SheetSchema) (SchemaStep, bool) {
+	step := SchemaStep{Action: SchemaSettings}
+	step.settings.DependenciesEnabled = schema.DependenciesEnabled
+	if schema.DependenciesEnabled && schema.ProjectSettings != nil {
+		step.settings = *schema.ProjectSettings
+		step.settings.DependenciesEnabled = true
+	}
+	if schema.DependenciesEnabled != sheet.DependenciesEnabled {
+		step.Detail = fmt.Sprintf("dependenciesEnabled %v", schema.DependenciesEnabled)
+		return step, true
+	}
+	if !schema.DependenciesEnabled || schema.ProjectSettings == nil || sheet.ProjectSettings == nil {
+		return step, false
+	}
+	have, want := *sheet.ProjectSettings, step.settings
+	have.DependenciesEnabled = true
+	if reflect.DeepEqual(have, want) {
+		return step, false
+	}
+	step.Detail = "projectSettings"
+	return step, true
+}
+
+// apply makes the change of 1 step, the sheet column maps are updated by the column funcs.
+func (step *SchemaStep) apply(sheet *SheetInfo) error {
+	switch step.Action {
+	case SchemaDelete:
+		return DeleteColumn(sheet, step.Column)
+	case SchemaUpdate:
+		return updateColumns(sheet, []string{step.Column}, step.update)
+	case SchemaAdd:
+		_, err := AddColumn(sheet, step.spec, step.index)
+		return err
+	case SchemaMove:
+		return MoveColumn(sheet, step.Column, step.index)
+	case SchemaSettings:
+		_, err := UpdateSheetProjectSettings(sheet.SheetId, step.settings)
+		return err
+	}
+	return fmt.Errorf("Invalid Schema Step Action - %s", step.Action)
+}
+
+// titleIndex returns the position of title in titles, -1 if not found.
+func titleIndex(titles []string, title string) int {
+	for i, t := range titles {
+		if t == title {
+			return i
+		}
+	}
+	return -1
+}
+
+func nilIfEmpty(options []string) []string {
+	if len(options) == 0 {
+		return nil
+	}
+	return options
+}
@@ -0,0 +1,145 @@
+package smartsheet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_Schema(t *testing.T) {
+	source := testSheet()
+	util := source.ColumnsByName["Util"]
+	util.Validation, util.Width = true, 120
+	amt := source.ColumnsByName["Amt"]
+	amt.Hidden, amt.Locked = true, true
+	source.setColumns(append(source.columnList()[:3], append([]Column{util, amt}, source.columnList()[5:]...)...))
+	var exported bytes.Buffer
+	if err := ExportSchema(source, &exported); err != nil {
+		t.Fatal("ExportSchema Failed", err)
+	}
+
+	// stub sheet, primary column titled Name, an extra column, Amt with different type
+	server := []Column{
+		{Id: 1, Title: "Name", Type: "TEXT_NUMBER", Primary: true},
+		{Id: 2, Title: "Notes", Type: "TEXT_NUMBER"},
+		{Id: 3, Title: "Amt", Type: "PICKLIST", Options: []string{"1", "2"}},
+	}
+	var changes []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		id, _ := strconv.ParseInt(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], 10, 64)
+		position := -1
+		for i, column := range server {
+			if column.Id == id {
+				position = i
+			}
+		}
+		if r.Method != "GET" {
+			changes = append(changes, r.Method+" "+string(reqBytes))
+		}
+		var result interface{}
+		switch r.Method {
+		case "GET":
+			data, _ := json.Marshal(reindexColumns(server))
+			fmt.Fprintf(w, `{"id":1849449510135684,"name":"Test1","columns":%s}`, data)
+			return
+		case "POST":
+			var added []Column
+			json.Unmarshal(reqBytes, &added)
+			added[0].Id = int64(100 + len(changes))
+			server = append(server[:added[0].Index], append(added, server[added[0].Index:]...)...)
+			result = added
+		case "PUT":
+			column := server[position]
+			json.Unmarshal(reqBytes, &column) // fields not sent are unchanged
+			var update ColumnUpdate
+			json.Unmarshal(reqBytes, &update)
+			if update.Type != "" {
+				column.Options = update.Options
+			}
+			server = append(server[:position], server[position+1:]...)
+			if update.Index != nil {
+				position = *update.Index
+			}
+			server = append(server[:position], append([]Column{column}, server[position:]...)...)
+			result = column
+		case "DELETE":
+			server = append(server[:position], server[position+1:]...)
+		}
+		data, _ := json.Marshal(result)
+		fmt.Fprintf(w, `{"message":"SUCCESS","resultCode":0,"result":%s}`, data)
+	})
+
+	plan, err := ApplySchema(1849449510135684, bytes.NewReader(exported.Bytes()), ApplyOptions{DryRun: true, DeleteExtraColumns: true})
+	if err != nil {
+		t.Fatal("ApplySchema DryRun Failed", err)
+	}
+	if len(changes) != 0 || plan.Applied != 0 {
+		t.Error("ApplySchema DryRun expected no changes, got", changes)
+	}
+	expected := []string{
+		"DELETE Notes",
+		"UPDATE Name title Name -> Address",
+		"UPDATE Amt type PICKLIST[1,2] -> TEXT_NUMBER, hidden true, locked true",
+		"ADD OrderNo at 1 (TEXT_NUMBER)",
+		"ADD DueDate at 2 (DATE)",
+		"ADD Util at 3 (PICKLIST)",
+		"ADD Complete at 5 (CHECKBOX)",
+		"ADD Level at 6 (TEXT_NUMBER)",
+		"ADD Status at 7 (PICKLIST)",
+		"ADD Hyperlink at 8 (TEXT_NUMBER)",
+	}
+	if plan.String() != strings.Join(expected, "\n")+"\n" || len(plan.ExtraColumns) != 1 {
+		t.Errorf("ApplySchema DryRun, Expecting\n%s\nGot\n%s", strings.Join(expected, "\n"), plan)
+	}
+
+	// apply, then the sheet exports the same schema
+	if plan, err = ApplySchema(1849449510135684, bytes.NewReader(exported.Bytes()), ApplyOptions{DeleteExtraColumns: true}); err != nil {
+		t.Fatal("ApplySchema Failed", err)
+	}
+	if plan.Applied != len(plan.Steps) || len(changes) != len(expected) {
+		t.Error("ApplySchema expected all steps applied, got", plan.Applied, len(changes))
+	}
+	applied := new(SheetInfo)
+	applied.Load(1849449510135684, NoRows)
+	var reexported bytes.Buffer
+	ExportSchema(applied, &reexported)
+	if reexported.String() != exported.String() {
+		t.Errorf("ExportSchema after ApplySchema, Expecting\n%s\nGot\n%s", exported.String(), reexported.String())
+	}
+	if plan, _ = ApplySchema(1849449510135684, bytes.NewReader(exported.Bytes()), ApplyOptions{}); len(plan.Steps) != 0 {
+		t.Error("ApplySchema expected no steps for a sheet matching the schema, got", plan)
+	}
+
+	// columns out of order and an extra column kept
+	server[1], server[2] = server[2], server[1]
+	server = append(server[:1], append([]Column{{Id: 50, Title: "Extra", Type: "TEXT_NUMBER"}}, server[1:]...)...)
+	changes = nil
+	if plan, err = ApplySchema(1849449510135684, bytes.NewReader(exported.Bytes()), ApplyOptions{}); err != nil {
+		t.Fatal("ApplySchema Failed", err)
+	}
+	if plan.String() != "MOVE OrderNo 3 -> 1\nMOVE Extra 2 -> 9\n" || len(changes) != 2 {
+		t.Error("ApplySchema expected 2 moves, got", plan)
+	}
+	if server[9].Title != "Extra" || server[1].Title != "OrderNo" {
+		t.Error("ApplySchema expected extra column kept after schema columns, got", server)
+	}
+
+	invalid := []string{
+		`{"version":1,"columns":[{"title":"A","type":"TEXT_NUMBER"}]}`,
+		`{"version":1,"columns":[{"title":"A","primary":true},{"title":"A"}]}`,
+		`{"version":2,"columns":[{"title":"A","primary":true}]}`,
+		`{"version":1,"columns":[{"title":"A","primary":true,"colour":"red"}]}`,
+	}
+	for _, schema := range invalid {
+		if _, err = ApplySchema(1849449510135684, strings.NewReader(schema), ApplyOptions{}); !errors.Is(err, ErrInvalidSchema) {
+			t.Error("ApplySchema expected ErrInvalidSchema for", schema, "got", err)
+		}
+	}
+}
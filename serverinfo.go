@@ -0,0 +1,204 @@
+// serverinfo.go contains GetServerInfo and funcs for translating cell format descriptors.
+// A format descriptor (Cell.Format) is a comma separated list of indexes into the FormatTables returned by GetServerInfo.
+// Ex. ",,1,,,,,,2,18,,,,,," is bold with a text color and background color.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ServerInfo is the api response for GetServerInfo.
+type ServerInfo struct {
+	Formats          FormatTables `json:"formats"`
+	SupportedLocales []string     `json:"supportedLocales"` // ex. "en_US"
+}
+
+// FormatTables contains the values referenced by the positions of a format descriptor.
+type FormatTables struct {
+	Defaults           string       `json:"defaults"` // descriptor of default format
+	FontFamily         []FontFamily `json:"fontFamily"`
+	FontSize           []string     `json:"fontSize"`
+	Bold               []string     `json:"bold"`
+	Italic             []string     `json:"italic"`
+	Underline          []string     `json:"underline"`
+	Strikethrough      []string     `json:"strikethrough"`
+	HorizontalAlign    []string     `json:"horizontalAlign"`
+	VerticalAlign      []string     `json:"verticalAlign"`
+	Color              []string     `json:"color"` // hex values, ex. "#FF0000", used for text, background and taskbar
+	Currency           []Currency   `json:"currency"`
+	DecimalCount       []string     `json:"decimalCount"`
+	ThousandsSeparator []string     `json:"thousandsSeparator"`
+	NumberFormat       []string     `json:"numberFormat"`
+	TextWrap           []string     `json:"textWrap"`
+	DateFormat         []string     `json:"dateFormat"`
+}
+
+type FontFamily struct {
+	Name   string   `json:"name"`
+	Traits []string `json:"traits"`
+}
+
+type Currency struct {
+	Code   string `json:"code"` // ex. "USD"
+	Symbol string `json:"symbol"`
+}
+
+// CellFormat contains the named attributes of a format descriptor, see FormatTables.ParseFormat.
+// Empty values indicate the default is used. Colors are hex values.
+type CellFormat struct {
+	FontFamily, FontSize                       string
+	Bold, Italic, Underline, Strikethrough     string // "ON" or ""
+	HorizontalAlign, VerticalAlign             string
+	Color, BackgroundColor, TaskbarColor       string
+	Currency, DecimalCount, ThousandsSeparator string
+	NumberFormat, TextWrap, DateFormat         string
+}
+
+// formatFields defines each position of a format descriptor, in order.
+// Table returns the values the position indexes, value returns the matching CellFormat field.
+var formatFields = []struct {
+	table func(t *FormatTables) []string
+	value func(f *CellFormat) *string
+}{
+	{func(t *FormatTables) []string { return t.fontNames() }, func(f *CellFormat) *string { return &f.FontFamily }},
+	{func(t *FormatTables) []string { return t.FontSize }, func(f *CellFormat) *string { return &f.FontSize }},
+	{func(t *FormatTables) []string { return t.Bold }, func(f *CellFormat) *string { return &f.Bold }},
+	{func(t *FormatTables) []string { return t.Italic }, func(f *CellFormat) *string { return &f.Italic }},
+	{func(t *FormatTables) []string { return t.Underline }, func(f *CellFormat) *string { return &f.Underline }},
+	{func(t *FormatTables) []string { return t.Strikethrough }, func(f *CellFormat) *string { return &f.Strikethrough }},
+	{func(t *FormatTables) []string { return t.HorizontalAlign }, func(f *CellFormat) *string { return &f.HorizontalAlign }},
+	{func(t *FormatTables) []string { return t.VerticalAlign }, func(f *CellFormat) *string { return &f.VerticalAlign }},
+	{func(t *FormatTables) []string { return t.Color }, func(f *CellFormat) *string { return &f.Color }},
+	{func(t *FormatTables) []string { return t.Color }, func(f *CellFormat) *string { return &f.BackgroundColor }},
+	{func(t *FormatTables) []string { return t.Color }, func(f *CellFormat) *string { return &f.TaskbarColor }},
+	{func(t *FormatTables) []string { return t.currencyCodes() }, func(f *CellFormat) *string { return &f.Currency }},
+	{func(t *FormatTables) []string { return t.DecimalCount }, func(f *CellFormat) *string { return &f.DecimalCount }},
+	{func(t *FormatTables) []string { return t.ThousandsSeparator }, func(f *CellFormat) *string { return &f.ThousandsSeparator }},
+	{func(t *FormatTables) []string { return t.NumberFormat }, func(f *CellFormat) *string { return &f.NumberFormat }},
+	{func(t *FormatTables) []string { return t.TextWrap }, func(f *CellFormat) *string { return &f.TextWrap }},
+	{func(t *FormatTables) []string { return t.DateFormat }, func(f *CellFormat) *string { return &f.DateFormat }},
+}
+
+var serverInfo struct {
+	sync.Mutex
+	info *ServerInfo
+}
+
+// GetServerInfo returns format tables and supported locales.
+// The response is cached since it rarely changes, use optional refresh (true) to request it again.
+func GetServerInfo(refresh ...bool) (*ServerInfo, error) {
+	trace("GetServerInfo")
+	serverInfo.Lock()
+	defer serverInfo.Unlock()
+	if serverInfo.info != nil && !(len(refresh) > 0 && refresh[0]) {
+		return serverInfo.info, nil
+	}
+	req := Get("/serverinfo", nil)
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+
+	info := new(ServerInfo)
+	err = json.Unmarshal(respJSON, info)
+	if err != nil {
+		log.Println("ERROR GetServerInfo Unmarshal Response Failed", err)
+		return nil, err
+	}
+	serverInfo.info = info
+	return info, nil
+}
+
+// ParseFormat translates a format descriptor (ex. Cell.Format) into named attributes.
+func (tables *FormatTables) ParseFormat(format string) (*CellFormat, error) {
+	cellFormat := new(CellFormat)
+	if format == "" {
+		return cellFormat, nil
+	}
+	positions := strings.Split(format, ",")
+	if len(positions) > len(formatFields) {
+		return nil, errors.New("Invalid Format, Too Many Positions - " + format)
+	}
+	for i, position := range positions {
+		if position == "" {
+			continue
+		}
+		table := formatFields[i].table(tables)
+		index, err := strconv.Atoi(position)
+		if err != nil || index < 0 || index >= len(table) {
+			return nil, fmt.Errorf("Invalid Format Position %d Value %s - %s", i, position, format)
+		}
+		*formatFields[i].value(cellFormat) = table[index]
+	}
+	return cellFormat, nil
+}
+
+// BuildFormat translates named attributes into a format descriptor, for use as Cell.Format.
+func (tables *FormatTables) BuildFormat(cellFormat CellFormat) (string, error) {
+	positions := make([]string, len(formatFields))
+	for i, field := range formatFields {
+		value := *field.value(&cellFormat)
+		if value == "" {
+			continue
+		}
+		index := indexOf(field.table(tables), value)
+		if index < 0 {
+			return "", fmt.Errorf("Invalid Format Value for Position %d - %s", i, value)
+		}
+		positions[i] = strconv.Itoa(index)
+	}
+	return strings.Join(positions, ","), nil
+}
+
+// ColorHex returns the hex value of a color index used in format descriptors.
+func (tables *FormatTables) ColorHex(index int) (string, error) {
+	if index < 0 || index >= len(tables.Color) {
+		return "", fmt.Errorf("Invalid Color Index %d", index)
+	}
+	return tables.Color[index], nil
+}
+
+// ColorIndex returns the format descriptor index of a hex color value, ex. "#FF0000". Case is ignored.
+func (tables *FormatTables) ColorIndex(hex string) (int, error) {
+	for i, color := range tables.Color {
+		if strings.EqualFold(color, hex) {
+			return i, nil
+		}
+	}
+	return -1, errors.New("Color Not In Format Tables - " + hex)
+}
+
+func (tables *FormatTables) fontNames() []string {
+	names := make([]string, len(tables.FontFamily))
+	for i, font := range tables.FontFamily {
+		names[i] = font.Name
+	}
+	return names
+}
+
+func (tables *FormatTables) currencyCodes() []string {
+	codes := make([]string, len(tables.Currency))
+	for i, currency := range tables.Currency {
+		codes[i] = currency.Code
+	}
+	return codes
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if strings.EqualFold(v, value) {
+			return i
+		}
+	}
+	return -1
+}
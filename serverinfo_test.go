@@ -0,0 +1,86 @@
+package smartsheet
+
+import (
+	"net/http"
+	"testing"
+)
+
+// serverInfoJSON is an abbreviated GET /serverinfo response.
+const serverInfoJSON = `{
+  "formats": {
+    "defaults": ",,,,,,,,,,,,,,,,",
+    "bold": ["DEFAULT", "ON"],
+    "color": ["", "#000000", "#FFFFFF", "#FFEBEE", "#FF0000", "#E6F5FE"],
+    "currency": [{"code": "none", "symbol": "NONE"}, {"code": "EUR", "symbol": "€"}, {"code": "USD", "symbol": "$"}],
+    "dateFormat": ["LOCALE_BASED", "MMMM_D_YYYY", "MMM_D_YYYY"],
+    "decimalCount": ["0", "1", "2", "3", "4", "5"],
+    "fontFamily": [{"name": "Arial", "traits": ["sans-serif"]}, {"name": "Tahoma", "traits": ["sans-serif"]}],
+    "fontSize": ["8", "9", "10", "12", "14"],
+    "horizontalAlign": ["DEFAULT", "LEFT", "CENTER", "RIGHT"],
+    "italic": ["DEFAULT", "ON"],
+    "numberFormat": ["NONE", "NUMBER", "CURRENCY", "PERCENT"],
+    "strikethrough": ["DEFAULT", "ON"],
+    "textWrap": ["DEFAULT", "ON"],
+    "thousandsSeparator": ["DEFAULT", "ON"],
+    "underline": ["DEFAULT", "ON"],
+    "verticalAlign": ["DEFAULT", "TOP", "MIDDLE", "BOTTOM"]
+  },
+  "supportedLocales": ["en_US", "de_DE", "fr_FR"]
+}`
+
+func Test_ServerInfo(t *testing.T) {
+	requests := 0
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(serverInfoJSON))
+	})
+	serverInfo.info = nil
+
+	info, err := GetServerInfo()
+	if err != nil {
+		t.Fatal("GetServerInfo Failed", err)
+	}
+	if len(info.SupportedLocales) != 3 || info.Formats.FontFamily[1].Name != "Tahoma" || info.Formats.Currency[2].Symbol != "$" {
+		t.Errorf("GetServerInfo wrong decode %+v", info)
+	}
+	GetServerInfo()
+	if requests != 1 {
+		t.Error("GetServerInfo expected cached response, requests:", requests)
+	}
+	GetServerInfo(true)
+	if requests != 2 {
+		t.Error("GetServerInfo expected refresh request, requests:", requests)
+	}
+
+	tables := &info.Formats
+	format := "1,3,1,,,,2,,4,3,,2,2,1,2,,"
+	cellFormat, err := tables.ParseFormat(format)
+	if err != nil {
+		t.Fatal("ParseFormat Failed", err)
+	}
+	expect := CellFormat{
+		FontFamily: "Tahoma", FontSize: "12", Bold: "ON", HorizontalAlign: "CENTER",
+		Color: "#FF0000", BackgroundColor: "#FFEBEE", Currency: "USD", DecimalCount: "2",
+		ThousandsSeparator: "ON", NumberFormat: "CURRENCY",
+	}
+	if *cellFormat != expect {
+		t.Errorf("ParseFormat Expecting %+v, Got %+v", expect, *cellFormat)
+	}
+	built, err := tables.BuildFormat(expect)
+	if err != nil || built != format {
+		t.Error("BuildFormat Expecting", format, "Got", built, err)
+	}
+
+	if _, err = tables.ParseFormat(",,9"); err == nil {
+		t.Error("ParseFormat expected invalid index error")
+	}
+	if _, err = tables.BuildFormat(CellFormat{Color: "#123456"}); err == nil {
+		t.Error("BuildFormat expected invalid color error")
+	}
+	if hex, _ := tables.ColorHex(4); hex != "#FF0000" {
+		t.Error("ColorHex wrong result", hex)
+	}
+	if index, _ := tables.ColorIndex("#e6f5fe"); index != 5 {
+		t.Error("ColorIndex wrong result", index)
+	}
+}
@@ -0,0 +1,234 @@
+// sheetcache.go contains SheetCache, an in-memory cache of loaded sheets kept current by webhook callbacks,
+// with a TTL fallback for missed callbacks. Safe for use by multiple goroutines.
+
+package smartsheet
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultCacheMaxLoads is used when SheetCache.MaxLoads is 0.
+const DefaultCacheMaxLoads = 4
+
+// SheetCache holds loaded sheets by sheet id. Get loads a sheet on a miss, HandleWebhookEvent drops or refreshes it
+// when it changes. Sheets returned by Get are shared by all callers and must not be changed, a refresh replaces the
+// cached *SheetInfo rather than changing it, so a sheet already returned stays consistent.
+//
+// Only 1 load or refresh of a sheet runs at a time, concurrent Gets of the same sheet wait for it and share its result.
+// At most MaxLoads sheets are loaded at once, so a burst of misses (ex. after many invalidations) does not reserve a
+// long run of request slots in the RequestDelay throttle ahead of other requests.
+type SheetCache struct {
+	TTL          time.Duration    // age at which a cached sheet is reloaded, in case a webhook callback was missed, 0 for no expiry
+	Options      *GetSheetOptions // Load options of each sheet (nil for all rows and columns), must not select rows (ex. RowIds)
+	DeltaRefresh bool             // HandleWebhookEvent loads only the changed rows for row and cell events, instead of dropping the sheet
+	MaxLoads     int              // maximum concurrent loads, default DefaultCacheMaxLoads
+
+	mu      sync.Mutex
+	entries map[int64]*cacheEntry
+	loads   chan struct{} // semaphore of MaxLoads
+}
+
+// cacheEntry is 1 cached sheet, mu is held while the sheet is loaded or refreshed.
+type cacheEntry struct {
+	mu       sync.Mutex
+	sheet    *SheetInfo // nil until loaded
+	loadedAt time.Time  // local time of the last full load, for TTL
+	cursor   *SyncCursor
+}
+
+// NewSheetCache returns an empty cache, sheets are reloaded when older than ttl (0 for no expiry).
+func NewSheetCache(ttl time.Duration) *SheetCache {
+	return &SheetCache{TTL: ttl}
+}
+
+// Get returns the cached sheet, loading it if not cached or older than TTL.
+// If the load fails, the error is returned and nothing is cached.
+func (c *SheetCache) Get(sheetId int64) (*SheetInfo, error) {
+	trace("SheetCache.Get")
+	entry := c.entry(sheetId, true)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.sheet != nil && (c.TTL == 0 || time.Since(entry.loadedAt) < c.TTL) {
+		return entry.sheet, nil
+	}
+
+	if c.Options != nil && c.Options.selectsRows() {
+		c.remove(sheetId, entry)
+		log.Println("ERROR - SheetCache.Get Options select rows")
+		return nil, errors.New("Invalid SheetCache Options - must not select rows")
+	}
+	sheet, err := c.load(sheetId, time.Time{})
+	if err != nil {
+		log.Println("ERROR - SheetCache.Get", sheetId, err)
+		c.remove(sheetId, entry)
+		return nil, err
+	}
+	entry.sheet, entry.loadedAt = sheet, time.Now()
+	entry.cursor = &SyncCursor{Store: new(memoryCursorStore), Overlap: DefaultSyncOverlap}
+	if !sheet.ModifiedAt.IsZero() {
+		entry.cursor.Commit(sheet.ModifiedAt)
+	}
+	return sheet, nil
+}
+
+// load loads a sheet using Options, only the rows modified since if not zero. Waits while MaxLoads loads are running.
+func (c *SheetCache) load(sheetId int64, since time.Time) (*SheetInfo, error) {
+	options := new(GetSheetOptions)
+	if c.Options != nil {
+		*options = *c.Options // copied, Load changes options (ColumnNames to ColumnIds)
+	}
+	options.RowsModifiedSince = since
+	c.acquireLoad()
+	defer c.releaseLoad()
+	sheet := new(SheetInfo)
+	if err := sheet.Load(sheetId, options); err != nil {
+		return nil, err
+	}
+	return sheet, nil
+}
+
+// Invalidate drops a sheet from the cache, the next Get loads it. A load in progress is not cancelled,
+// its result is returned to the Gets waiting for it but is not cached.
+func (c *SheetCache) Invalidate(sheetId int64) {
+	trace("SheetCache.Invalidate")
+	c.mu.Lock()
+	delete(c.entries, sheetId)
+	c.mu.Unlock()
+}
+
+// HandleWebhookEvent updates the cache for a webhook callback of a sheet scoped webhook, other callbacks are ignored.
+// If DeltaRefresh is set and the callback contains only row and cell events, the rows modified since the last load or
+// refresh are loaded (a SyncCursor per sheet, as LoadDelta) and merged into a new copy of the cached sheet, deleted
+// rows are removed. Otherwise (ex. column or sheet events) the sheet is invalidated. A sheet not cached is not loaded.
+// If the refresh fails, the sheet is invalidated and the error returned.
+func (c *SheetCache) HandleWebhookEvent(cb WebhookCallback) error {
+	trace("SheetCache.HandleWebhookEvent")
+	if cb.Scope != "sheet" || len(cb.Events) == 0 {
+		return nil
+	}
+	rowsOnly := true
+	deleted := make(map[int64]bool)
+	for _, event := range cb.Events {
+		switch {
+		case event.ObjectType == "row" && event.EventType == "deleted":
+			deleted[event.Id] = true
+		case event.ObjectType == "row" || event.ObjectType == "cell":
+		case event.ObjectType == "sheet" && event.EventType == "updated": // sent with every change to the sheet
+		default:
+			rowsOnly = false
+		}
+	}
+	if !c.DeltaRefresh || !rowsOnly {
+		c.Invalidate(cb.ScopeObjectId)
+		return nil
+	}
+
+	entry := c.entry(cb.ScopeObjectId, false)
+	if entry == nil {
+		return nil
+	}
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.sheet == nil { // load failed, next Get loads it
+		return nil
+	}
+	delta, err := c.load(cb.ScopeObjectId, entry.cursor.NextOptions().RowsModifiedSince)
+	if err != nil {
+		log.Println("ERROR - SheetCache.HandleWebhookEvent Load Delta Failed", cb.ScopeObjectId, err)
+		c.remove(cb.ScopeObjectId, entry)
+		return err
+	}
+	entry.sheet = mergeDelta(entry.sheet, delta, deleted)
+	if !delta.ModifiedAt.IsZero() {
+		entry.cursor.Commit(delta.ModifiedAt)
+	}
+	return nil
+}
+
+// mergeDelta returns a copy of sheet with the rows of delta replacing rows with the same id, new rows appended and
+// deleted rows removed. Column maps are shared with sheet, they are not changed by row events.
+func mergeDelta(sheet *SheetInfo, delta *SheetInfo, deleted map[int64]bool) *SheetInfo {
+	merged := *sheet
+	changed := make(map[int64]Row, len(delta.Rows))
+	for _, row := range delta.Rows {
+		changed[row.Id] = row
+	}
+	merged.Rows = make([]Row, 0, len(sheet.Rows)+len(delta.Rows))
+	for _, row := range sheet.Rows {
+		if deleted[row.Id] {
+			continue
+		}
+		if update, found := changed[row.Id]; found {
+			row = update
+			delete(changed, row.Id)
+		}
+		merged.Rows = append(merged.Rows, row)
+	}
+	for _, row := range delta.Rows { // new rows, in delta order
+		if _, found := changed[row.Id]; found && !deleted[row.Id] {
+			merged.Rows = append(merged.Rows, row)
+		}
+	}
+	merged.ModifiedAt = delta.ModifiedAt
+	merged.TotalRowCount = delta.TotalRowCount
+	return &merged
+}
+
+// entry returns the cache entry of sheetId, creating it if create is true, nil if not found and create is false.
+func (c *SheetCache) entry(sheetId int64, create bool) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[int64]*cacheEntry)
+	}
+	entry := c.entries[sheetId]
+	if entry == nil && create {
+		entry = new(cacheEntry)
+		c.entries[sheetId] = entry
+	}
+	return entry
+}
+
+// remove deletes entry from the cache, unless it has already been replaced by a newer entry.
+func (c *SheetCache) remove(sheetId int64, entry *cacheEntry) {
+	c.mu.Lock()
+	if c.entries[sheetId] == entry {
+		delete(c.entries, sheetId)
+	}
+	c.mu.Unlock()
+}
+
+func (c *SheetCache) acquireLoad() {
+	c.mu.Lock()
+	if c.loads == nil {
+		maxLoads := c.MaxLoads
+		if maxLoads < 1 {
+			maxLoads = DefaultCacheMaxLoads
+		}
+		c.loads = make(chan struct{}, maxLoads)
+	}
+	loads := c.loads
+	c.mu.Unlock()
+	loads <- struct{}{}
+}
+
+func (c *SheetCache) releaseLoad() {
+	<-c.loads
+}
+
+// memoryCursorStore is the CursorStore of each cached sheet, the cursor is not persisted.
+type memoryCursorStore struct {
+	cursor time.Time
+}
+
+func (store *memoryCursorStore) LoadCursor() (time.Time, error) {
+	return store.cursor, nil
+}
+
+func (store *memoryCursorStore) SaveCursor(cursor time.Time) error {
+	store.cursor = cursor
+	return nil
+}
@@ -0,0 +1,163 @@
+package smartsheet
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_SheetCache(t *testing.T) {
+	var mu sync.Mutex
+	loads := make(map[string]int) // "sheetId" or "sheetId delta" to number of requests
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		sheetId := strings.TrimPrefix(r.URL.Path, "/sheets/")
+		rows := `[{"id":1,"cells":[{"columnId":101,"value":"a"}]},{"id":2,"cells":[{"columnId":101,"value":"b"}]},{"id":3,"cells":[{"columnId":101,"value":"c"}]}]`
+		key := sheetId
+		if r.URL.Query().Get("rowsModifiedSince") != "" {
+			key += " delta"
+			rows = `[{"id":2,"cells":[{"columnId":101,"value":"b2"}]},{"id":4,"cells":[{"columnId":101,"value":"d"}]}]`
+		}
+		mu.Lock()
+		loads[key]++
+		mu.Unlock()
+		fmt.Fprintf(w, `{"id":%s,"name":"Cached","modifiedAt":"2020-10-10T15:00:00Z","totalRowCount":3,
+			"columns":[{"id":101,"index":0,"title":"Name","type":"TEXT_NUMBER","primary":true}],"rows":%s}`, sheetId, rows)
+	})
+	loadCount := func(key string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return loads[key]
+	}
+
+	// miss then hit
+	cache := NewSheetCache(0)
+	sheet, err := cache.Get(11)
+	if err != nil {
+		t.Fatal("SheetCache.Get Failed", err)
+	}
+	again, _ := cache.Get(11)
+	if again != sheet || len(sheet.Rows) != 3 || sheet.SheetId != 11 || loadCount("11") != 1 {
+		t.Error("SheetCache.Get expected 1 load then cached sheet, loads", loadCount("11"))
+	}
+
+	// webhook invalidation, column event drops the sheet
+	cb := WebhookCallback{Scope: "sheet", ScopeObjectId: 11, Events: []WebhookCallbackEvent{{ObjectType: "column", EventType: "created", Id: 102}}}
+	if err = cache.HandleWebhookEvent(cb); err != nil {
+		t.Fatal("HandleWebhookEvent Failed", err)
+	}
+	if reloaded, _ := cache.Get(11); reloaded == sheet || loadCount("11") != 2 {
+		t.Error("HandleWebhookEvent expected sheet reloaded after column event, loads", loadCount("11"))
+	}
+	cache.HandleWebhookEvent(WebhookCallback{Scope: "sheet", ScopeObjectId: 99, Events: cb.Events}) // not cached, not loaded
+	if loadCount("99") != 0 {
+		t.Error("HandleWebhookEvent expected sheet not cached to be ignored")
+	}
+
+	// delta refresh, row 2 updated, row 4 added, row 3 deleted
+	cache.DeltaRefresh = true
+	sheet, _ = cache.Get(11)
+	cb.Events = []WebhookCallbackEvent{
+		{ObjectType: "cell", EventType: "updated", RowId: 2, ColumnId: 101},
+		{ObjectType: "row", EventType: "created", Id: 4},
+		{ObjectType: "row", EventType: "deleted", Id: 3},
+		{ObjectType: "sheet", EventType: "updated", Id: 11},
+	}
+	if err = cache.HandleWebhookEvent(cb); err != nil {
+		t.Fatal("HandleWebhookEvent Failed", err)
+	}
+	refreshed, _ := cache.Get(11)
+	values := make([]string, 0)
+	for _, row := range refreshed.Rows {
+		values = append(values, strconv.FormatInt(row.Id, 10)+"="+fmt.Sprint(row.Cells[0].Value))
+	}
+	if strings.Join(values, " ") != "1=a 2=b2 4=d" || loadCount("11 delta") != 1 || loadCount("11") != 2 {
+		t.Errorf("HandleWebhookEvent delta, Expecting 1=a 2=b2 4=d, Got %v, loads %v", values, loads)
+	}
+	if len(sheet.Rows) != 3 || sheet.Rows[1].Cells[0].Value != "b" {
+		t.Error("HandleWebhookEvent expected previously returned sheet unchanged", sheet.Rows)
+	}
+
+	// TTL expiry
+	cache = NewSheetCache(30 * time.Millisecond)
+	cache.Get(12)
+	cache.Get(12)
+	if loadCount("12") != 1 {
+		t.Error("SheetCache TTL expected 1 load before expiry, got", loadCount("12"))
+	}
+	time.Sleep(40 * time.Millisecond)
+	cache.Get(12)
+	if loadCount("12") != 2 {
+		t.Error("SheetCache TTL expected reload after expiry, loads", loadCount("12"))
+	}
+
+	cache = &SheetCache{Options: &GetSheetOptions{RowIds: []int64{1}}}
+	if _, err = cache.Get(13); err == nil || loadCount("13") != 0 {
+		t.Error("SheetCache expected error for Options selecting rows, got", err)
+	}
+}
+
+// Test_SheetCacheConcurrent is meant to be run with -race.
+func Test_SheetCacheConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	loads, running, maxRunning := 0, 0, 0
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		loads++
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		sheetId := strings.TrimPrefix(r.URL.Path, "/sheets/")
+		fmt.Fprintf(w, `{"id":%s,"name":"Cached","modifiedAt":"2020-10-10T15:00:00Z","columns":[],"rows":[{"id":1}]}`, sheetId)
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+
+	// concurrent misses of the same sheet share 1 load
+	cache := &SheetCache{MaxLoads: 2, DeltaRefresh: true}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cache.Get(21); err != nil {
+				t.Error("SheetCache.Get Failed", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if loads != 1 {
+		t.Error("SheetCache expected concurrent misses to share 1 load, got", loads)
+	}
+
+	// mixed Get, Invalidate and webhook callbacks on several sheets
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sheetId := int64(30 + i%5)
+			switch i % 3 {
+			case 0:
+				cache.Invalidate(sheetId)
+			case 1:
+				cache.HandleWebhookEvent(WebhookCallback{Scope: "sheet", ScopeObjectId: sheetId,
+					Events: []WebhookCallbackEvent{{ObjectType: "row", EventType: "updated", Id: 1}}})
+			}
+			sheet, err := cache.Get(sheetId)
+			if err != nil || sheet.SheetId != sheetId || len(sheet.Rows) != 1 {
+				t.Error("SheetCache.Get wrong sheet", sheetId, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if maxRunning > 2 {
+		t.Error("SheetCache expected at most MaxLoads concurrent loads, got", maxRunning)
+	}
+}
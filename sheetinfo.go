@@ -4,9 +4,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
+	"os"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 )
 
 // SheetInfo contains information about a sheet and methods for interacting with it.
@@ -15,27 +22,73 @@ type SheetInfo struct {
 	SheetName      string
 	WorkspaceId    int64
 	WorkspaceName  string
+	Permalink      string // sheet url, see BuildSheetURL and BuildRowURL
+	CreatedAt      time.Time
+	ModifiedAt     time.Time
+	Owner          string // owner email, only set when GetSheetOptions.IncludeOwnerInfo used
+	OwnerId        int64
+	Columns        []Column          `json:"-"` // sheet columns in Index order, set by Load, iterate this rather than ColumnsByIndex
 	ColumnsById    map[int64]Column  // sheet columns indexed by Column Id
 	ColumnsByName  map[string]Column // sheet columns indexed by Column Title
-	ColumnsByIndex map[int]Column    // sheet columns indexed by Column Position, 1st col has index of 0
+	ColumnsByIndex map[int]Column    // sheet columns indexed by Column Position, 1st col has index of 0, may have gaps
 	Rows           []Row             // rows returned by Load method
+	TotalRowCount  int               // number of rows in sheet, set by Load, see IsComplete
+	RowsSelected   bool              // Load options selected a subset of rows (ex. RowIds), Rows is not expected to contain TotalRowCount rows
 	NewRows        []Row             // used by AddRow & UploadNewRows methods
 	UpdateRows     []Row             // used by UpdateRow & UploadUpdateRows methods
+	Meta           *SheetMeta        // set by RefreshMeta method
+	Warnings       []Warning         // non-fatal issues found by the last Load or upload method, see warnings.go
+	LastLoadedAt   time.Time         // time of the last successful Load
+	LastLoadErr    error             `json:"-"` // error of the last Load, nil if it succeeded
+
+	ColumnsSelected     bool               // Load options selected a subset of columns (ColumnIds or ColumnNames), see AddColumn
+	DependenciesEnabled bool               // project sheet, see projects.go
+	ProjectSettings     *ProjectSettings   // nil unless DependenciesEnabled
+	UserSettings        *SheetUserSettings // current user's settings, ex. CriticalPathEnabled
+
+	KeepLastDuplicate bool // when a staged row has 2 cells for the same column, keep the last instead of returning an error
+	EmptyPrimary      int  // action when a new top level row has no primary column value, see Empty Primary Actions
+	ProtectFormulas   bool // return an error when an update would replace a formula in Rows with a value, see Cell.OverwriteFormula
+	LockedCells       int  // action when a staged update changes a locked row or column, see Locked Cell Actions
+	NameMatcher       int  // how column names match column titles (ex. ignore case), see Name Matchers
+	DuplicateUpdates  int  // action when a row id is staged for update more than once, see Duplicate Update Actions
+
+	CheckboxText map[string]BoolText // text of CHECKBOX values in RowValues by Column.Symbol ("" for plain checkboxes), see checkbox.go
+
+	VerifyColumns  bool // before uploading staged rows, check their columns were not renamed, retyped or deleted, see ErrColumnDrift
+	CoerceValues   bool // convert string values of staged cells to their column type (ex. "true" in a CHECKBOX column), see CoerceValue
+	ValidateValues bool // before uploading staged rows, check values against restricted columns (Column.Validation), see ValidateRows
+
+	stagedWarnings []Warning // rows merged by UpdateRow (WarnDuplicateRow), moved to Warnings by UploadUpdateRows
 }
 
+// Empty Primary Actions, used by SheetInfo.EmptyPrimary
+const (
+	EmptyPrimaryWarn   = iota // log a warning (default)
+	EmptyPrimaryIgnore        // no check
+	EmptyPrimaryReject        // return error wrapping ErrEmptyPrimary
+)
+
 // Load method downloads sheet info by calling GetSheet func and pulling data from the returned sheet info.
 // Optional GetSheetOptions is defined in options.go.
 // If only specific columns are needed, options.ColumnNames are converted to ColumnIds.
+// If Load fails, the previously loaded sheet (Rows, columns, Warnings) is kept unchanged and LastLoadErr is set,
+// so callers refreshing a sheet can keep using the stale data (see LastLoadedAt).
 func (she *SheetInfo) Load(sheetId int64, options *GetSheetOptions) error {
-
 	// if specified, convert columnNames to columnIds
 	if options != nil && len(options.ColumnNames) > 0 {
 		options.ColumnIds = make([]int64, len(options.ColumnNames))
 		for i, colName := range options.ColumnNames {
-			column, found := she.ColumnsByName[colName]
+			column, found, err := she.columnNamed(colName)
+			if err != nil {
+				log.Println("ERROR SheetInfo.Load", err)
+				she.LastLoadErr = err
+				return err
+			}
 			if !found {
 				log.Println("ERROR SheetInfo.Load Invalid ColName in options", colName)
-				return errors.New("Invalid ColName - " + colName)
+				she.LastLoadErr = errors.New("Invalid ColName - " + colName)
+				return she.LastLoadErr
 			}
 			options.ColumnIds[i] = column.Id
 		}
@@ -43,27 +96,61 @@ func (she *SheetInfo) Load(sheetId int64, options *GetSheetOptions) error {
 	sheet, err := GetSheet(sheetId, options)
 	if err != nil {
 		log.Println("ERROR SheetInfo.load failed", she.SheetName, she.SheetId, err)
+		she.LastLoadErr = err
 		return err
 	}
-	she.SheetId = sheet.Id
-	she.SheetName = sheet.Name
-	she.WorkspaceId = sheet.Workspace.Id
-	she.WorkspaceName = sheet.Workspace.Name
-	she.ColumnsById = make(map[int64]Column)
-	she.ColumnsByName = make(map[string]Column)
-	she.ColumnsByIndex = make(map[int]Column)
-	she.Rows = sheet.Rows
+	// build the loaded sheet apart, so a failed Load leaves the previous sheet unchanged
+	loaded := *she
+	loaded.Warnings = nil
+	loaded.SheetId = sheet.Id
+	loaded.SheetName = sheet.Name
+	loaded.WorkspaceId = sheet.Workspace.Id
+	loaded.WorkspaceName = sheet.Workspace.Name
+	loaded.Permalink = sheet.Permalink
+	loaded.CreatedAt, _ = time.Parse(time.RFC3339, sheet.CreatedAt) // zero time if not returned
+	loaded.ModifiedAt, _ = time.Parse(time.RFC3339, sheet.ModifiedAt)
+	loaded.Owner = sheet.Owner
+	loaded.OwnerId = sheet.OwnerId
+	loaded.DependenciesEnabled = sheet.DependenciesEnabled
+	loaded.ProjectSettings = sheet.projectSettings()
+	loaded.UserSettings = sheet.UserSettings
+	loaded.setColumns(sheet.Columns)
+	loaded.Rows = sheet.Rows
+	loaded.TotalRowCount = sheet.TotalRowCount
+	loaded.RowsSelected = options != nil && options.selectsRows()
+	loaded.ColumnsSelected = options != nil && len(options.ColumnIds) > 0
+	loaded.loadWarnings(sheet, options)
+	loaded.LastLoadedAt = time.Now()
+	loaded.LastLoadErr = nil
+	*she = loaded
+	return nil
+}
 
-	for _, column := range sheet.Columns {
-		she.ColumnsById[column.Id] = column
-		she.ColumnsByName[column.Title] = column
-		she.ColumnsByIndex[column.Index] = column
+// IsComplete returns true if Load returned every row of the sheet (len(Rows) equals TotalRowCount).
+// When Load options selected a subset of rows (RowIds, RowsModifiedSince, RowsModifiedMins, ExcludeFilteredOutRows),
+// completeness is undefined, false is returned and RowsSelected is true.
+func (she *SheetInfo) IsComplete() bool {
+	if she.RowsSelected {
+		return false
 	}
+	return len(she.Rows) == she.TotalRowCount
+}
+
+// RefreshMeta sets SheetInfo.Meta using GetSheetMeta, without reloading rows or columns.
+// SheetInfo.SheetId must be set.
+func (she *SheetInfo) RefreshMeta() error {
+	trace("SheetInfo.RefreshMeta")
+	meta, err := GetSheetMeta(she.SheetId)
+	if err != nil {
+		log.Println("ERROR SheetInfo.RefreshMeta failed", she.SheetName, she.SheetId, err)
+		return err
+	}
+	she.Meta = meta
 	return nil
 }
 
 // MatchSheet compares this sheetInfo instance to another instance and returns true if they match.
-// Rows are not included in the comparison.
+// Rows are not included in the comparison, nor are CreatedAt, ModifiedAt and owner.
 // Useful to determine if a sheet's attributes have changed compared to a previous version.
 func (she *SheetInfo) MatchSheet(base *SheetInfo) bool {
 	if she.SheetId != base.SheetId {
@@ -99,33 +186,232 @@ func (she *SheetInfo) MatchSheet(base *SheetInfo) bool {
 	return true
 }
 
-// Show displays SheetInfo values in easy to read format.
+// ErrColumnDrift is wrapped by the error returned by UploadNewRows and UploadUpdateRows when SheetInfo.VerifyColumns
+// is set and a column referenced by a staged cell was renamed, had its type changed or was deleted since the sheet was loaded.
+var ErrColumnDrift = errors.New("Sheet Columns Changed Since Load")
+
+// verifyStagedColumns returns an error wrapping ErrColumnDrift if a column referenced by the cells of rows no longer
+// matches (title and type) the column the rows were staged with. The current columns are requested using ListColumns.
+// Columns not referenced by rows are not compared, adding or moving other columns does not affect the upload.
+func (she *SheetInfo) verifyStagedColumns(rows []Row) error {
+	if !she.VerifyColumns || len(rows) == 0 {
+		return nil
+	}
+	columns, err := ListColumns(she.SheetId)
+	if err != nil {
+		log.Println("ERROR - SheetInfo.verifyStagedColumns ListColumns Failed", she.SheetName, err)
+		return err
+	}
+	current := make(map[int64]Column, len(columns))
+	for _, column := range columns {
+		current[column.Id] = column
+	}
+	checked := make(map[int64]bool)
+	changes := make([]string, 0)
+	for _, row := range rows {
+		for _, cell := range row.Cells {
+			if checked[cell.ColumnId] {
+				continue
+			}
+			checked[cell.ColumnId] = true
+			staged := she.ColumnsById[cell.ColumnId]
+			column, found := current[cell.ColumnId]
+			switch {
+			case !found:
+				changes = append(changes, fmt.Sprintf("%s deleted", staged.Title))
+			case column.Title != staged.Title:
+				changes = append(changes, fmt.Sprintf("%s renamed %s", staged.Title, column.Title))
+			case column.Type != staged.Type:
+				changes = append(changes, fmt.Sprintf("%s type %s changed to %s", staged.Title, staged.Type, column.Type))
+			}
+		}
+	}
+	if len(changes) > 0 {
+		log.Println("ERROR - SheetInfo Columns Changed Since Load", she.SheetName, changes)
+		return fmt.Errorf("%w - %s", ErrColumnDrift, strings.Join(changes, ", "))
+	}
+	return nil
+}
+
+// Show displays SheetInfo values in easy to read format, see Render.
 // To limit number of rows shown, use optional rowLimit.
 func (she *SheetInfo) Show(rowLimit ...int) {
-	fmt.Println("Sheet Name:", she.SheetName, "Sheet Id:", she.SheetId)
-	fmt.Println("Workspace Name:", she.WorkspaceName, "Workspace Id:", she.WorkspaceId)
+	options := RenderOptions{}
+	if len(rowLimit) > 0 {
+		options.RowLimit = rowLimit[0]
+	}
+	she.Render(os.Stdout, &options)
+}
 
-	fmt.Println("--- COLUMNS ---")
-	for index := 0; index < len(she.ColumnsByIndex); index++ {
-		column, _ := she.ColumnsByIndex[index]
-		fmt.Printf("%2d %15.15s %15.15s %d \n", column.Index, column.Title, column.Type, column.Id)
+// DefaultShowPageSize is used by ShowPage when pageSize is less than 1.
+const DefaultShowPageSize = 50
+
+// ShowPage displays SheetInfo values like Show, only the rows of page (1st page is 1) of pageSize rows.
+// Rows outside the page are not formatted, use it to look through very large sheets.
+func (she *SheetInfo) ShowPage(page, pageSize int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultShowPageSize
 	}
+	she.Render(os.Stdout, &RenderOptions{RowOffset: (page - 1) * pageSize, RowLimit: pageSize})
+}
 
-	fmt.Println("--- ROWS ---")
+// DefaultRenderWidth is the width of column titles and types written by Render when RenderOptions.Width is 0.
+const DefaultRenderWidth = 15
 
-	rowCount := len(she.Rows)
-	fmt.Println("Total Row Count is", rowCount)
-	if len(rowLimit) > 0 && rowLimit[0] < rowCount {
-		rowCount = rowLimit[0]
+// RenderOptions is used by SheetInfo.Render.
+type RenderOptions struct {
+	RowLimit    int      // maximum number of rows written, 0 for no limit
+	RowOffset   int      // number of rows skipped before the 1st row written, for paging (see ShowPage)
+	ParentsOnly bool     // only write top level rows (rows without a parent)
+	ColumnNames []string // only write these columns, nil for all
+	Width       int      // width of column titles and types, longer text is truncated ending with "…", default DefaultRenderWidth
+	ValueWidth  int      // cell values longer than ValueWidth characters are truncated ending with "…", 0 for no limit
+}
+
+// Render writes SheetInfo values to w in easy to read format.
+// Rows are written in RowNumber order, child rows are indented under their parent.
+// A row whose parent is not in Rows (ex. excluded by GetSheetOptions) is written at top level, marked "(parent not loaded)".
+// When RowOffset or RowLimit is set, the rows written and the number of rows that could be written end the output.
+func (she *SheetInfo) Render(w io.Writer, options *RenderOptions) {
+	if options == nil {
+		options = new(RenderOptions)
+	}
+	width := options.Width
+	if width < 1 {
+		width = DefaultRenderWidth
+	}
+	var columnIds map[int64]bool // nil for all columns
+	if options.ColumnNames != nil {
+		columnIds = make(map[int64]bool, len(options.ColumnNames))
+		for _, name := range options.ColumnNames {
+			column, found := she.ColumnsByName[name]
+			if !found {
+				log.Println("ERROR - SheetInfo.Render column not found", she.SheetName, name)
+				continue
+			}
+			columnIds[column.Id] = true
+		}
+	}
+	fmt.Fprintln(w, "Sheet Name:", she.SheetName, "Sheet Id:", she.SheetId)
+	fmt.Fprintln(w, "Workspace Name:", she.WorkspaceName, "Workspace Id:", she.WorkspaceId)
+	if !she.ModifiedAt.IsZero() {
+		fmt.Fprintln(w, "Modified:", she.ModifiedAt.Local().Format("2006-01-02 15:04:05"))
 	}
-	for i := 0; i < rowCount; i++ {
-		row := she.Rows[i]
-		fmt.Printf("Row %d, id: %d --- \n", i+1, row.Id)
+
+	fmt.Fprintln(w, "--- COLUMNS ---")
+	for _, column := range she.columnList() {
+		if columnIds != nil && !columnIds[column.Id] {
+			continue
+		}
+		restricted := ""
+		if column.Validation {
+			restricted = "restricted"
+		}
+		fmt.Fprintf(w, "%2d %*s %*s %d %s\n", column.Index, width, truncate(column.Title, width), width, truncate(column.Type, width),
+			column.Id, restricted)
+	}
+
+	fmt.Fprintln(w, "--- ROWS ---")
+	totalRowCount := she.TotalRowCount
+	if totalRowCount == 0 { // not loaded, ex. rows created locally
+		totalRowCount = len(she.Rows)
+	}
+	fmt.Fprintf(w, "Loaded %d of %d rows\n", len(she.Rows), totalRowCount)
+
+	rows := sortedRows(she.Rows)
+	levels := rowLevels(rows)
+	eligible, shown := 0, 0 // rows passing ParentsOnly, rows written
+	for i, row := range rows {
+		level := levels[row.Id]
+		if options.ParentsOnly && level > 0 {
+			continue
+		}
+		eligible++
+		if eligible <= options.RowOffset || (options.RowLimit > 0 && shown >= options.RowLimit) {
+			continue // outside the window, counted only
+		}
+		shown++
+		rowNumber := row.RowNumber
+		if rowNumber == 0 { // not returned by api, ex. rows created locally
+			rowNumber = i + 1
+		}
+		indent := strings.Repeat("    ", level)
+		notes := ""
+		if row.ParentId != 0 && level == 0 {
+			notes += " (parent not loaded)"
+		}
+		if row.FilteredOut {
+			notes += " (filtered out)"
+		}
+		fmt.Fprintf(w, "%sRow %d, id: %d%s --- \n", indent, rowNumber, row.Id, notes)
 		for _, cell := range row.Cells {
+			if columnIds != nil && !columnIds[cell.ColumnId] {
+				continue
+			}
 			name := she.ColumnsById[cell.ColumnId].Title
-			fmt.Printf("%15s %v \n", name, cell.Value)
+			value := fmt.Sprintf("%v", cell.Value)
+			if options.ValueWidth > 0 {
+				value = truncate(value, options.ValueWidth)
+			}
+			fmt.Fprintf(w, "%s%*s %s \n", indent, width, truncate(name, width), value)
+		}
+	}
+	if options.RowOffset > 0 || options.RowLimit > 0 {
+		if shown == 0 {
+			fmt.Fprintf(w, "--- no rows after row %d of %d ---\n", options.RowOffset, eligible)
+		} else {
+			fmt.Fprintf(w, "--- rows %d to %d of %d ---\n", options.RowOffset+1, options.RowOffset+shown, eligible)
+		}
+	}
+}
+
+// truncate returns text shortened to width characters, ending with "…", if it is longer.
+func truncate(text string, width int) string {
+	if utf8.RuneCountInString(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:width-1]) + "…"
+}
+
+// sortedRows returns a copy of rows sorted by RowNumber.
+// If any row has no RowNumber (ex. rows created locally), the original order is kept.
+func sortedRows(rows []Row) []Row {
+	sorted := make([]Row, len(rows))
+	copy(sorted, rows)
+	for _, row := range rows {
+		if row.RowNumber == 0 {
+			return sorted
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].RowNumber < sorted[j].RowNumber
+	})
+	return sorted
+}
+
+// rowLevels returns the hierarchy level of each row (key is row id), top level rows are 0.
+// A row whose parent is not in rows is level 0.
+func rowLevels(rows []Row) map[int64]int {
+	parents := make(map[int64]int64, len(rows)) // key: row id, value: parent id
+	for _, row := range rows {
+		parents[row.Id] = row.ParentId
+	}
+	levels := make(map[int64]int, len(rows))
+	for _, row := range rows {
+		level := 0
+		for parentId := row.ParentId; parentId != 0; parentId = parents[parentId] {
+			if _, loaded := parents[parentId]; !loaded || level > len(rows) { // level check guards against a cycle
+				break
+			}
+			level++
 		}
+		levels[row.Id] = level
 	}
+	return levels
 }
 
 // AddRow adds a row to SheetInfo.NewRows.
@@ -135,13 +421,37 @@ func (she *SheetInfo) AddRow(newRow Row) error {
 	// load Cell.ColumnId using Cell.ColName
 	for i := 0; i < len(newRow.Cells); i++ {
 		colName := newRow.Cells[i].ColName
-		column, found := she.ColumnsByName[colName]
+		column, found, err := she.columnNamed(colName)
+		if err != nil {
+			log.Println("ERROR - SheetInfo.AddRow", she.SheetName, err)
+			return err
+		}
 		if !found {
 			log.Println("ERROR - SheetInfo.AddRow column not found", she.SheetName, colName)
 			return errors.New("Invalid ColumnName - " + colName)
 		}
 		newRow.Cells[i].ColumnId = column.Id
 	}
+	cells, err := she.checkDuplicateCells(newRow.Cells)
+	if err != nil {
+		log.Println("ERROR - SheetInfo.AddRow", she.SheetName, err)
+		return err
+	}
+	newRow.Cells = cells
+	she.coerceCells(0, newRow.Cells)
+	if err = validateHyperlinks(newRow.Cells); err != nil {
+		log.Println("ERROR - SheetInfo.AddRow", she.SheetName, err)
+		return err
+	}
+	if err = she.resolvePredecessors(newRow.Cells); err != nil {
+		log.Println("ERROR - SheetInfo.AddRow", she.SheetName, err)
+		return err
+	}
+	if newRow.ParentId == 0 {
+		if err = she.checkEmptyPrimary(newRow); err != nil {
+			return err
+		}
+	}
 	if she.NewRows == nil { // set to nil by UploadNewRows
 		she.NewRows = make([]Row, 0, 100)
 	}
@@ -151,18 +461,189 @@ func (she *SheetInfo) AddRow(newRow Row) error {
 
 // UpdateRow adds a row to SheetInfo.UpdateRows.
 // All updated rows are processed in a batch using UploadUpdateRows() method.
+// If the row id is already staged, the rows are merged or an error returned, see SheetInfo.DuplicateUpdates.
 func (she *SheetInfo) UpdateRow(updtRow Row) error {
 	trace("SheetInfo.UpdateRow")
 	// load Cell.ColumnId using Cell.colName
 	for i := 0; i < len(updtRow.Cells); i++ {
 		colName := updtRow.Cells[i].ColName
-		column, found := she.ColumnsByName[colName]
+		column, found, err := she.columnNamed(colName)
+		if err != nil {
+			log.Println("ERROR - SheetInfo.UpdateRow", she.SheetName, err)
+			return err
+		}
 		if !found {
 			log.Println("ERROR - SheetInfo.UpdateRow column not found", she.SheetName, colName)
 			return errors.New("Invalid ColumnName - " + colName)
 		}
 		updtRow.Cells[i].ColumnId = column.Id
 	}
+	cells, err := she.checkDuplicateCells(updtRow.Cells)
+	if err != nil {
+		log.Println("ERROR - SheetInfo.UpdateRow", she.SheetName, err)
+		return err
+	}
+	updtRow.Cells = cells
+	she.coerceCells(updtRow.Id, updtRow.Cells)
+	if err = validateHyperlinks(updtRow.Cells); err != nil {
+		log.Println("ERROR - SheetInfo.UpdateRow", she.SheetName, err)
+		return err
+	}
+	if err = she.resolvePredecessors(updtRow.Cells); err != nil {
+		log.Println("ERROR - SheetInfo.UpdateRow", she.SheetName, err)
+		return err
+	}
+	if err = she.checkFormulaCells(updtRow.Id, updtRow.Cells); err != nil {
+		log.Println("ERROR - SheetInfo.UpdateRow", she.SheetName, err)
+		return err
+	}
+	if err = she.stageUpdateRow(updtRow); err != nil {
+		log.Println("ERROR - SheetInfo.UpdateRow", she.SheetName, err)
+		return err
+	}
+	return nil
+}
+
+// ErrDuplicateColumn is wrapped by the error returned when a staged row has more than 1 cell for the same column.
+var ErrDuplicateColumn = errors.New("Duplicate Column In Row")
+
+// checkDuplicateCells returns cells with no more than 1 cell per column, ColumnIds must be loaded.
+// If SheetInfo.KeepLastDuplicate is false, an error naming the duplicated column is returned instead.
+// If true, the last cell for a column is kept, the order of kept cells is unchanged.
+func (she *SheetInfo) checkDuplicateCells(cells []Cell) ([]Cell, error) {
+	lastIndex := make(map[int64]int, len(cells)) // key: columnId
+	for i, cell := range cells {
+		if _, found := lastIndex[cell.ColumnId]; found && !she.KeepLastDuplicate {
+			return nil, fmt.Errorf("%w - %s", ErrDuplicateColumn, she.ColumnsById[cell.ColumnId].Title)
+		}
+		lastIndex[cell.ColumnId] = i
+	}
+	if len(lastIndex) == len(cells) {
+		return cells, nil
+	}
+	kept := make([]Cell, 0, len(lastIndex))
+	for i, cell := range cells {
+		if lastIndex[cell.ColumnId] == i {
+			kept = append(kept, cell)
+		}
+	}
+	return kept, nil
+}
+
+// ErrRowNotFound is returned when a row id is not in SheetInfo.Rows.
+var ErrRowNotFound = errors.New("Row Not Found")
+
+// CellValue returns the value of 1 cell of a row in SheetInfo.Rows.
+// Parm found is false if the row has no cell for the column (cells never containing a value are not returned by GetSheet).
+// Error wraps ErrInvalidColumnName or ErrRowNotFound.
+func (she *SheetInfo) CellValue(rowId int64, columnName string) (value interface{}, found bool, err error) {
+	column, ok := she.ColumnsByName[columnName]
+	if !ok {
+		return nil, false, fmt.Errorf("%w - %s", ErrInvalidColumnName, columnName)
+	}
+	for _, row := range she.Rows {
+		if row.Id != rowId {
+			continue
+		}
+		for _, cell := range row.Cells {
+			if cell.ColumnId == column.Id {
+				return cell.Value, true, nil
+			}
+		}
+		return nil, false, nil
+	}
+	return nil, false, fmt.Errorf("%w - %d", ErrRowNotFound, rowId)
+}
+
+// ErrNoPrimaryColumn is returned by PrimaryColumn when SheetInfo does not contain the primary column,
+// ex. loaded using GetSheetOptions.ColumnNames.
+var ErrNoPrimaryColumn = errors.New("Primary Column Not Loaded")
+
+// ErrEmptyPrimary is wrapped by the error returned when SheetInfo.EmptyPrimary is EmptyPrimaryReject.
+var ErrEmptyPrimary = errors.New("Primary Column Value Empty")
+
+// PrimaryColumn returns the sheet's primary column.
+func (she *SheetInfo) PrimaryColumn() (Column, error) {
+	for _, column := range she.ColumnsById {
+		if column.Primary {
+			return column, nil
+		}
+	}
+	return Column{}, fmt.Errorf("%w - %s", ErrNoPrimaryColumn, she.SheetName)
+}
+
+// PrimaryValue returns a row's primary column value as a string, empty string if none or primary column not loaded.
+// Cells are matched by ColumnId, or ColName if ColumnId not loaded.
+func (she *SheetInfo) PrimaryValue(row Row) string {
+	primary, err := she.PrimaryColumn()
+	if err != nil {
+		return ""
+	}
+	for _, cell := range row.Cells {
+		if cell.ColumnId == primary.Id || cell.ColumnId == 0 && cell.ColName == primary.Title {
+			if cell.Value == nil {
+				return ""
+			}
+			return fmt.Sprintf("%v", cell.Value)
+		}
+	}
+	return ""
+}
+
+// checkEmptyPrimary applies SheetInfo.EmptyPrimary to a new top level row.
+// Rows without a primary value show as blank lines in hierarchies and search results.
+// If the primary column is not loaded, the row is not checked.
+func (she *SheetInfo) checkEmptyPrimary(row Row) error {
+	if she.EmptyPrimary == EmptyPrimaryIgnore {
+		return nil
+	}
+	primary, err := she.PrimaryColumn()
+	if err != nil || strings.TrimSpace(she.PrimaryValue(row)) != "" {
+		return nil
+	}
+	if she.EmptyPrimary == EmptyPrimaryReject {
+		err = fmt.Errorf("%w - %s", ErrEmptyPrimary, primary.Title)
+		log.Println("ERROR - SheetInfo", she.SheetName, err)
+		return err
+	}
+	log.Println("WARNING - SheetInfo new row has empty primary column", she.SheetName, primary.Title)
+	return nil
+}
+
+// StageCellUpdate adds a cell value to SheetInfo.UpdateRows, to be sent by UploadUpdateRows.
+// If UpdateRows already contains the row, the cell is added to it (replacing an earlier value for the same column),
+// so multiple calls for 1 row result in 1 updated row.
+func (she *SheetInfo) StageCellUpdate(rowId int64, columnName string, value interface{}) error {
+	trace("SheetInfo.StageCellUpdate")
+	column, found := she.ColumnsByName[columnName]
+	if !found {
+		log.Println("ERROR - SheetInfo.StageCellUpdate column not found", she.SheetName, columnName)
+		return fmt.Errorf("%w - %s", ErrInvalidColumnName, columnName)
+	}
+	newCells := []Cell{{ColName: columnName, ColumnId: column.Id, Value: value}}
+	she.coerceCells(rowId, newCells)
+	newCell := newCells[0]
+	if err := she.checkFormulaCells(rowId, newCells); err != nil {
+		log.Println("ERROR - SheetInfo.StageCellUpdate", she.SheetName, err)
+		return err
+	}
+
+	for i := range she.UpdateRows {
+		row := &she.UpdateRows[i]
+		if row.Id != rowId {
+			continue
+		}
+		for j := range row.Cells {
+			if row.Cells[j].ColumnId == column.Id {
+				row.Cells[j] = newCell // keep latest value
+				return nil
+			}
+		}
+		row.Cells = append(row.Cells, newCell)
+		return nil
+	}
+	updtRow := InitRow(rowId)
+	updtRow.Cells = append(updtRow.Cells, newCell)
 	if she.UpdateRows == nil { // set to nil by UploadUpdateRows
 		she.UpdateRows = make([]Row, 0, 100)
 	}
@@ -170,28 +651,79 @@ func (she *SheetInfo) UpdateRow(updtRow Row) error {
 	return nil
 }
 
+// UploadError is returned by UploadNewRowsWith when 1 or more chunks fail.
+type UploadError struct {
+	Chunks []ChunkError // in NewRows order
+}
+
+// ChunkError describes a failed chunk, FirstRow is the index in NewRows of the chunk's 1st row.
+type ChunkError struct {
+	FirstRow, RowCount int
+	Err                error
+}
+
+func (e *UploadError) Error() string {
+	msgs := make([]string, len(e.Chunks))
+	for i, chunk := range e.Chunks {
+		msgs[i] = fmt.Sprintf("rows %d-%d %v", chunk.FirstRow, chunk.FirstRow+chunk.RowCount-1, chunk.Err)
+	}
+	return fmt.Sprintf("Upload Failed For %d Chunks - %s", len(e.Chunks), strings.Join(msgs, "; "))
+}
+
 // UploadNewRows adds new rows to sheet using SheetInfo.NewRows.
 // After process is complete, NewRows is set to nil.
 // If location is nil, rows added to bottom of sheet.
 // If optional rowLevelField is specified, each group of child rows will be indented (using SetParentId), based on value of rowLevelField.
 // Parent rows must contain "0" and child rows must contain "1" in this field/column.
+// Children that cannot be indented (ex. deleted after being added) are returned in a *ParentError, the others are indented.
+// Rows are sent in chunks of UploadChunkSize rows, one chunk at a time. See UploadNewRowsWith to send chunks concurrently.
+// Chunks over UploadMaxBytes are split, a single row over UploadMaxBytes returns an error wrapping ErrRowTooLarge.
+// If SheetInfo.VerifyColumns is set, the columns are requested first and no rows are sent if they changed, see ErrColumnDrift.
 func (she *SheetInfo) UploadNewRows(location *RowLocation, rowLevelField ...string) (*AddUpdtRowsResponse, error) {
 	trace("UploadNewRows")
+	return she.UploadNewRowsWith(location, nil, rowLevelField...)
+}
+
+// UploadNewRowsWith is UploadNewRows using UploadOptions (see options.go), nil options uses the defaults.
+// Result rows are in the same order as NewRows, regardless of the order chunks complete.
+// If a chunk fails, the error returned is type *UploadError, rows of successful chunks are in the response Result
+// and NewRows is set to the rows of failed chunks so they can be uploaded again. The rowLevelField process is skipped.
+func (she *SheetInfo) UploadNewRowsWith(location *RowLocation, options *UploadOptions, rowLevelField ...string) (*AddUpdtRowsResponse, error) {
+	trace("UploadNewRowsWith")
+	she.Warnings = nil
 	if len(she.NewRows) == 0 {
 		log.Println("UploadNewRows .NewRows is empty")
 		return nil, nil
 	}
+	if options == nil {
+		options = new(UploadOptions)
+	}
 	locMap := map[string]interface{}{"toBottom": true}
 	if location != nil {
+		if err := location.validateAdd(); err != nil {
+			log.Println("ERROR UploadNewRows", err)
+			return nil, err
+		}
 		locMap = CreateLocationMap(location) // see util.go
 	}
+	if err := she.verifyStagedColumns(she.NewRows); err != nil {
+		return nil, err
+	}
+	if err := she.validateStagedRows(she.NewRows); err != nil {
+		return nil, err
+	}
+	if options.ImportKeyColumn != "" {
+		if err := she.setImportKeys(options.ImportKeyColumn); err != nil {
+			log.Println("ERROR UploadNewRows", err)
+			return nil, err
+		}
+	}
 	// -- Create Request Body ----------------
-	type reqItem map[string]interface{}
-	reqData := make([]reqItem, 0, len(she.NewRows))
+	reqData := make([]map[string]interface{}, 0, len(she.NewRows))
 
 	for _, newRow := range she.NewRows {
-		item := make(reqItem)
-		item["cells"] = newRow.Cells
+		item := make(map[string]interface{})
+		item["cells"] = she.writableCells(0, newRow.Cells)
 		if newRow.Locked != nil { // newRow.Locked is *bool
 			item["locked"] = *newRow.Locked // dereference, returns value referenced by pointer
 		}
@@ -201,38 +733,63 @@ func (she *SheetInfo) UploadNewRows(location *RowLocation, rowLevelField ...stri
 		reqData = append(reqData, item)
 	}
 	endPoint := fmt.Sprintf("/sheets/%d/rows", she.SheetId)
-	req := Post(endPoint, reqData, nil)
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := DoRequest(req)
+	// -- Divide Into Chunks of ChunkSize Rows, Split Further If Over UploadMaxBytes ----------------
+	chunks, err := she.sizedChunks(reqData, options.chunkSize(), UploadMaxBytes)
 	if err != nil {
+		log.Println("ERROR UploadNewRows", err)
 		return nil, err
 	}
-	defer resp.Body.Close()
+	chunkCount := len(chunks)
+	chunkBounds := func(chunk int) (int, int) { // index of first row and last row + 1
+		return chunks[chunk].first, chunks[chunk].last
+	}
 
-	respJSON, _ := ioutil.ReadAll(resp.Body)
+	// -- Send Chunks, Up To options.Parallelism At A Time ----------------
+	chunkRows := make([][]Row, chunkCount)
+	chunkErrs := make([]error, chunkCount)
 
-	if len(she.NewRows) == 1 { // response.Result is 1 row (not a slice) when adding 1 row
-		apiResp1 := new(Add1RowResponse)
-		err = json.Unmarshal(respJSON, apiResp1)
-		if err != nil {
-			log.Println("ERROR - UploadAddRows Unmarshal Response for Single Row Failed", err)
-			return nil, err
-		}
-		she.NewRows = nil
-		apiResp := AddUpdtRowsResponse{
-			Message:    apiResp1.Message,
-			ResultCode: apiResp1.ResultCode,
-			Result:     []Row{apiResp1.Result},
-		}
-		return &apiResp, nil
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < options.parallelism(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range jobs {
+				first, last := chunkBounds(chunk)
+				chunkRows[chunk], chunkErrs[chunk] = postNewRows(endPoint, reqData[first:last])
+			}
+		}()
+	}
+	for chunk := 0; chunk < chunkCount; chunk++ {
+		jobs <- chunk
 	}
+	close(jobs)
+	wg.Wait()
 
-	apiResp := new(AddUpdtRowsResponse) // same response object when adding or updating rows
-	err = json.Unmarshal(respJSON, apiResp)
-	if err != nil {
-		log.Println("ERROR - UploadAddRows Unmarshal Response Failed", err)
-		return nil, err
+	// -- Combine Chunk Results In Original Order ----------------
+	apiResp := &AddUpdtRowsResponse{Message: "SUCCESS", Result: make([]Row, 0, len(reqData))}
+	uploadErr := new(UploadError)
+	failedRows := make([]Row, 0)
+	for chunk, rows := range chunkRows {
+		first, last := chunkBounds(chunk)
+		if chunkErrs[chunk] == nil {
+			if len(rows) != last-first {
+				she.warn(WarnRowCountMismatch, fmt.Sprintf("rows %d-%d sent %d rows, result has %d", first, last-1, last-first, len(rows)), 0, 0)
+			}
+			apiResp.Result = append(apiResp.Result, rows...)
+			continue
+		}
+		uploadErr.Chunks = append(uploadErr.Chunks, ChunkError{FirstRow: first, RowCount: last - first, Err: chunkErrs[chunk]})
+		failedRows = append(failedRows, she.NewRows[first:last]...)
+	}
+	if len(uploadErr.Chunks) > 0 {
+		log.Println("ERROR UploadNewRows", uploadErr)
+		if len(uploadErr.Chunks) == chunkCount {
+			return nil, uploadErr // nothing added, NewRows unchanged
+		}
+		she.NewRows = failedRows
+		return apiResp, uploadErr
 	}
 
 	defer func() {
@@ -248,6 +805,16 @@ func (she *SheetInfo) UploadNewRows(location *RowLocation, rowLevelField ...stri
 	//   child rows: Level 1
 	//   child rows must be immediately after parent row in prev api response
 	debugLn("Set ParentId on Child Rows ---")
+	parentErr := new(ParentError) // failed children of all parents
+	setParent := func(parentId int64, childIds []int64) error {
+		_, err := SetParentIdWith(she, parentId, childIds, &ParentOptions{AllowPartialSuccess: true})
+		if failed, ok := err.(*ParentError); ok {
+			parentErr.FailedChildIds = append(parentErr.FailedChildIds, failed.FailedChildIds...)
+			parentErr.Failures = append(parentErr.Failures, failed.Failures...)
+			return nil // continue with next parent
+		}
+		return err
+	}
 	var parentId int64
 	var childIds []int64
 	for _, row := range apiResp.Result {
@@ -258,10 +825,10 @@ func (she *SheetInfo) UploadNewRows(location *RowLocation, rowLevelField ...stri
 		debugLn("rowLevel", rowLevel)
 		if rowLevel == "0" { // if header row
 			if len(childIds) > 0 {
-				err = SetParentId(she, parentId, childIds) // indent child rows for prev parent
+				err = setParent(parentId, childIds) // indent child rows for prev parent
 				childIds = make([]int64, 0, 20)
 				if err != nil {
-					break
+					return apiResp, err
 				}
 			}
 			parentId = row.Id
@@ -272,24 +839,121 @@ func (she *SheetInfo) UploadNewRows(location *RowLocation, rowLevelField ...stri
 		}
 	}
 	if len(childIds) > 0 {
-		err = SetParentId(she, parentId, childIds) // indent child rows for prev parent
+		err = setParent(parentId, childIds) // indent child rows for prev parent
+	}
+	if err == nil && len(parentErr.FailedChildIds) > 0 {
+		err = parentErr
 	}
 	return apiResp, err
 }
 
+// setImportKeys adds a cell containing a new import key to each row in NewRows without a value in keyColumn.
+// Rows already having a key (ex. from a previous failed upload) keep it.
+func (she *SheetInfo) setImportKeys(keyColumn string) error {
+	column, found := she.ColumnsByName[keyColumn]
+	if !found {
+		return fmt.Errorf("%w - ImportKeyColumn %s", ErrInvalidColumnName, keyColumn)
+	}
+	for i, row := range she.NewRows {
+		if importKey(row, column.Id) != "" {
+			continue
+		}
+		cells := make([]Cell, 0, len(row.Cells)+1) // copy, row.Cells may be shared with caller
+		for _, cell := range row.Cells {
+			if cell.ColumnId != column.Id {
+				cells = append(cells, cell)
+			}
+		}
+		she.NewRows[i].Cells = append(cells, Cell{ColumnId: column.Id, Value: NewImportKey()})
+	}
+	return nil
+}
+
+// importKey returns the string value of a row's key cell, empty string if none.
+func importKey(row Row, keyColumnId int64) string {
+	for _, cell := range row.Cells {
+		if cell.ColumnId == keyColumnId {
+			key, _ := cell.Value.(string)
+			return key
+		}
+	}
+	return ""
+}
+
+// ReconcileNewRows removes rows from NewRows whose keyColumn value is already in the sheet.
+// Use before retrying UploadNewRowsWith after a failure, when rows were uploaded using UploadOptions.ImportKeyColumn.
+// A chunk may have been added even though its request failed (ex. timeout), retrying it would duplicate the rows.
+// Only keyColumn is requested from the sheet, SheetInfo.Rows is not changed. Returns the number of rows removed.
+func (she *SheetInfo) ReconcileNewRows(keyColumn string) (int, error) {
+	trace("SheetInfo.ReconcileNewRows")
+	column, found := she.ColumnsByName[keyColumn]
+	if !found {
+		log.Println("ERROR - SheetInfo.ReconcileNewRows column not found", she.SheetName, keyColumn)
+		return 0, fmt.Errorf("%w - %s", ErrInvalidColumnName, keyColumn)
+	}
+	if len(she.NewRows) == 0 {
+		return 0, nil
+	}
+	sheet, err := GetSheet(she.SheetId, &GetSheetOptions{ColumnIds: []int64{column.Id}})
+	if err != nil {
+		return 0, err
+	}
+	landed := make(map[string]bool, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		if key := importKey(row, column.Id); key != "" {
+			landed[key] = true
+		}
+	}
+	pending := make([]Row, 0, len(she.NewRows))
+	for _, row := range she.NewRows {
+		if key := importKey(row, column.Id); key == "" || !landed[key] {
+			pending = append(pending, row)
+		}
+	}
+	removed := len(she.NewRows) - len(pending)
+	she.NewRows = pending
+	return removed, nil
+}
+
+// postNewRows sends 1 chunk of new rows and returns the added rows.
+func postNewRows(endPoint string, reqData []map[string]interface{}) ([]Row, error) {
+	req := Post(endPoint, reqData, nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+
+	apiResp := new(AddUpdtRowsResponse) // result is 1 row (not a slice) when adding 1 row, see UnmarshalJSON
+	err = json.Unmarshal(respJSON, apiResp)
+	if err != nil {
+		log.Println("ERROR - UploadAddRows Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return apiResp.Result, nil
+}
+
 // getRowLevel returns the value of cell containing a rows parent-child indicator.
 // Parm rowLevelField is the column name, for example "Level".
 // If cell does not exist, empty string is returned.
 func (she *SheetInfo) GetRowLevel(row Row, rowLevelField string) (string, error) {
-	column, found := she.ColumnsByName[rowLevelField]
+	column, found, err := she.columnNamed(rowLevelField)
+	if err != nil {
+		log.Println("ERROR - SheetInfo.GetRowLevel", err)
+		return "", err
+	}
 	if !found {
 		log.Println("ERROR - SheetInfo.GetRowLevel invalid rowLevelFld", rowLevelField)
 		return "", errors.New("Invalid RowLevel Field")
 	}
 	rowLevel := ""
 	for _, cell := range row.Cells {
-		if cell.ColumnId == column.Id {
-			rowLevel = cell.Value.(string)
+		if cell.ColumnId == column.Id && cell.Value != nil {
+			rowLevel = fmt.Sprint(cell.Value) // number if sent as a number, ex. SheetInfo.CoerceValues set
 			break
 		}
 	}
@@ -299,22 +963,51 @@ func (she *SheetInfo) GetRowLevel(row Row, rowLevelField string) (string, error)
 // UploadUpdateRows updates rows using SheetInfo.UpdateRows.
 // After process is complete, UpdateRows is set to nil.
 // If location is nil, row position is not changed.
+// Rows are sent in 1 request, split into several if over UploadMaxBytes. If a later request fails, the rows
+// already updated are in the response Result and UpdateRows is set to the rows not updated.
+// If SheetInfo.VerifyColumns is set, the columns are requested first and no rows are sent if they changed, see ErrColumnDrift.
+// Staged cells of locked rows and columns are checked first, see SheetInfo.LockedCells.
+// Rows with the same id (ex. appended to UpdateRows directly) are merged first, see SheetInfo.DuplicateUpdates.
 func (she *SheetInfo) UploadUpdateRows(location *RowLocation) (*AddUpdtRowsResponse, error) {
 	trace("SheetInfo.UploadUpdateRows")
+	she.Warnings, she.stagedWarnings = she.stagedWarnings, nil // report rows merged when staged
 
 	var locMap map[string]interface{}
 	if location != nil {
+		if err := location.Validate(); err != nil {
+			log.Println("ERROR UploadUpdateRows", err)
+			return nil, err
+		}
 		locMap = CreateLocationMap(location) // see util.go
 	}
+	if err := she.mergeDuplicateUpdates(); err != nil {
+		log.Println("ERROR UploadUpdateRows", err)
+		return nil, err
+	}
+	if err := she.verifyStagedColumns(she.UpdateRows); err != nil {
+		return nil, err
+	}
+	if err := she.validateStagedRows(she.UpdateRows); err != nil {
+		return nil, err
+	}
+	if err := she.checkLockedCells(); err != nil {
+		return nil, err
+	}
 	// -- Create Request Body ----------------
-	type reqItem map[string]interface{}
-	reqData := make([]reqItem, 0, len(she.UpdateRows))
+	reqData := make([]map[string]interface{}, 0, len(she.UpdateRows))
+	sent := make([]Row, 0, len(she.UpdateRows)) // rows in reqData order, without rows dropped (only system cells)
 
 	for _, updateRow := range she.UpdateRows {
-		item := make(reqItem)
+		item := make(map[string]interface{})
 		item["id"] = strconv.FormatInt(updateRow.Id, 10) // api expects row id to be a string, don't know why
-		if len(updateRow.Cells) > 0 {
-			item["cells"] = updateRow.Cells
+		cells := she.writableCells(updateRow.Id, updateRow.Cells)
+		if len(cells) == 0 && len(updateRow.Cells) > 0 && updateRow.Locked == nil && locMap == nil {
+			she.warn(WarnSystemColumn, "row has only system column cells, not sent", updateRow.Id, 0)
+			continue
+		}
+		sent = append(sent, updateRow)
+		if len(cells) > 0 {
+			item["cells"] = cells
 		}
 		if updateRow.Locked != nil { // updateRow.Locked is *bool
 			item["locked"] = *updateRow.Locked // dereference, returns value referenced by pointer
@@ -324,7 +1017,42 @@ func (she *SheetInfo) UploadUpdateRows(location *RowLocation) (*AddUpdtRowsRespo
 		}
 		reqData = append(reqData, item)
 	}
+	she.UpdateRows = sent
+	if len(she.UpdateRows) == 0 {
+		log.Println("UploadUpdateRows .UpdateRows is empty")
+		she.UpdateRows = nil
+		return nil, nil
+	}
+	// all rows are sent in 1 request, unless over UploadMaxBytes
+	chunks, err := she.sizedChunks(reqData, len(reqData), UploadMaxBytes)
+	if err != nil {
+		log.Println("ERROR UploadUpdateRows", err)
+		return nil, err
+	}
 	endPoint := fmt.Sprintf("/sheets/%d/rows", she.SheetId)
+	apiResp := &AddUpdtRowsResponse{Message: "SUCCESS", Result: make([]Row, 0, len(reqData))}
+
+	for _, chunk := range chunks {
+		rows, err := putUpdateRows(endPoint, reqData[chunk.first:chunk.last])
+		if err != nil {
+			if chunk.first == 0 {
+				return nil, err // nothing updated, UpdateRows unchanged
+			}
+			log.Println("ERROR UploadUpdateRows, rows", chunk.first, "and after not updated", err)
+			she.UpdateRows = she.UpdateRows[chunk.first:]
+			return apiResp, err
+		}
+		apiResp.Result = append(apiResp.Result, rows...)
+	}
+	if len(apiResp.Result) != len(reqData) {
+		she.warn(WarnRowCountMismatch, fmt.Sprintf("sent %d rows, result has %d", len(reqData), len(apiResp.Result)), 0, 0)
+	}
+	she.UpdateRows = nil
+	return apiResp, nil
+}
+
+// putUpdateRows sends 1 chunk of UploadUpdateRows and returns the updated rows.
+func putUpdateRows(endPoint string, reqData []map[string]interface{}) ([]Row, error) {
 	req := Put(endPoint, reqData, nil)
 	req.Header.Set("Content-Type", "application/json")
 
@@ -342,13 +1070,17 @@ func (she *SheetInfo) UploadUpdateRows(location *RowLocation) (*AddUpdtRowsRespo
 		log.Println("ERROR - UploadUpdateRows Unmarshal Response Failed", err)
 		return nil, err
 	}
-	she.UpdateRows = nil
-	return apiResp, nil
+	return apiResp.Result, nil
 }
 
+// ErrCrossSheetRefExists is returned by CreateCrossSheetReference when the sheet already has a reference with the same name.
+var ErrCrossSheetRefExists = errors.New("Cross Sheet Reference Name Already Exists")
+
 // CreateCrossSheetReference creates an external-sheet-reference required for cross sheet formulas.
 // The CrossSheetReference parameter specifies the sheet, rows, and columns.
-func (she *SheetInfo) CreateCrossSheetReference(ref *CrossSheetReference) error {
+// The created reference (including Id) is returned.
+// If the name is already used in this sheet, ErrCrossSheetRefExists is returned.
+func (she *SheetInfo) CreateCrossSheetReference(ref *CrossSheetReference) (*CrossSheetReferenceInfo, error) {
 	trace("CreateCrossSheetReference")
 
 	endPoint := fmt.Sprintf("/sheets/%d/crosssheetreferences", she.SheetId)
@@ -357,35 +1089,89 @@ func (she *SheetInfo) CreateCrossSheetReference(ref *CrossSheetReference) error
 
 	httpResp, err := DoRequest(req)
 	if err != nil {
-		fmt.Println("ERROR - CreateCrossSheetReference request failed", err)
+		if apiErr, ok := err.(*ApiError); ok && apiErr.ErrorCode == errCodeAlreadyExists {
+			return nil, ErrCrossSheetRefExists
+		}
+		log.Println("ERROR - CreateCrossSheetReference request failed", err)
+		return nil, err
 	}
 	defer httpResp.Body.Close()
 
 	responseJSON, _ := ioutil.ReadAll(httpResp.Body)
 	debugLn(string(responseJSON))
-	return err
-}
 
-// Store saves SheetInfo instance as json encrypted file in indented (readable) format.
-func (she *SheetInfo) Store(filePath string) error {
-	jsonData, err := json.MarshalIndent(she, "", "  ")
+	var apiResp struct {
+		Message    string                  `json:"message"`
+		ResultCode int                     `json:"resultCode"`
+		Result     CrossSheetReferenceInfo `json:"result"`
+	}
+	err = json.Unmarshal(responseJSON, &apiResp)
 	if err != nil {
-		log.Println("ERROR - Store Failed", err)
-		return err
+		log.Println("ERROR - CreateCrossSheetReference Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return &apiResp.Result, nil
+}
+
+// ErrInvalidColumnName is wrapped by errors returned when a column name is not in SheetInfo.ColumnsByName.
+// Use errors.Is(err, ErrInvalidColumnName) to distinguish from API errors (type *ApiError).
+var ErrInvalidColumnName = errors.New("Invalid ColumnName")
+
+// ErrInvalidColumnRange is wrapped by the error returned by CreateCrossSheetReferenceByName when startCol is positioned
+// after endCol.
+var ErrInvalidColumnRange = errors.New("Invalid Column Range")
+
+// CreateCrossSheetReferenceByName creates a cross sheet reference in target sheet using source sheet column names.
+// Parm source is used to convert startCol & endCol to column ids and must contain SheetId.
+// StartCol must not be positioned after endCol (ErrInvalidColumnRange). Use 0 for startRowId & endRowId to reference all rows.
+func CreateCrossSheetReferenceByName(target *SheetInfo, name string, source *SheetInfo, startCol, endCol string, startRowId, endRowId int64) (*CrossSheetReferenceInfo, error) {
+	trace("CreateCrossSheetReferenceByName")
+
+	startColumn, found := source.ColumnsByName[startCol]
+	if !found {
+		log.Println("ERROR - CreateCrossSheetReferenceByName column not found", source.SheetName, startCol)
+		return nil, fmt.Errorf("%w - %s", ErrInvalidColumnName, startCol)
+	}
+	endColumn, found := source.ColumnsByName[endCol]
+	if !found {
+		log.Println("ERROR - CreateCrossSheetReferenceByName column not found", source.SheetName, endCol)
+		return nil, fmt.Errorf("%w - %s", ErrInvalidColumnName, endCol)
+	}
+	if startColumn.Index > endColumn.Index {
+		log.Println("ERROR - CreateCrossSheetReferenceByName startCol after endCol", startCol, endCol)
+		return nil, fmt.Errorf("%w - startCol %s is after endCol %s", ErrInvalidColumnRange, startCol, endCol)
+	}
+	ref := CrossSheetReference{
+		Name:          name,
+		SourceSheetId: source.SheetId,
+		StartRowId:    startRowId,
+		EndRowId:      endRowId,
+		StartColumnId: startColumn.Id,
+		EndColumnId:   endColumn.Id,
 	}
-	err = ioutil.WriteFile(filePath, jsonData, 0644)
-	return err
+	return target.CreateCrossSheetReference(&ref)
 }
 
-// Restore loads SheetInfo instance from json encrypted file created by Store method.
-func (she *SheetInfo) Restore(filePath string) error {
-	jsonData, err := ioutil.ReadFile(filePath)
+// EnsureCrossSheetReference creates a cross sheet reference if one with the same name does not exist.
+// If the name already exists, the existing reference is returned (its source sheet, rows and columns are not compared).
+func (she *SheetInfo) EnsureCrossSheetReference(ref *CrossSheetReference) (*CrossSheetReferenceInfo, error) {
+	trace("EnsureCrossSheetReference")
+
+	created, err := she.CreateCrossSheetReference(ref)
+	if err != ErrCrossSheetRefExists {
+		return created, err
+	}
+	refs, err := GetCrossSheetRefs(she.SheetId)
 	if err != nil {
-		log.Println("ERROR - Restore Failed", err)
-		return err
+		return nil, err
+	}
+	for _, existing := range refs {
+		if existing.Name == ref.Name {
+			return &existing, nil
+		}
 	}
-	err = json.Unmarshal(jsonData, she)
-	return err
+	log.Println("ERROR - EnsureCrossSheetReference name exists but not found in list", ref.Name)
+	return nil, ErrCrossSheetRefExists
 }
 
 // ===================================================
@@ -1,10 +1,17 @@
 package smartsheet
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -26,13 +33,12 @@ var testData = []rec{
 func Test_SheetInfo(t *testing.T) {
 	var err error
 
-	tkn, _ := ioutil.ReadFile("token.txt")
-	Token = strings.TrimSpace(string(tkn))
+	liveToken(t)
 
 	//TraceOn = true
 	//DebugOn = true
 
-	if err = series1(); err != nil {
+	if err = series1(t.TempDir()); err != nil {
 		t.Error("Test_SheetInfo series1 Failed", err)
 	}
 	if err = series2(); err != nil {
@@ -43,18 +49,46 @@ func Test_SheetInfo(t *testing.T) {
 	}
 }
 
+// Test_SheetInfoSeries1 runs series1 offline, from the fixtures in testdata/fixtures/series1. The fixtures are
+// hand-written in the format of RecordingTransport (column objects on 1 line for readability). Run with
+// SMARTSHEET_RECORD=1 and token.txt to replace them with a recording of the live sheet.
+func Test_SheetInfoSeries1(t *testing.T) {
+	const fixtures = "testdata/fixtures/series1"
+	saveTransport, saveDelay, saveToken := HttpClient.Transport, RequestDelay, Token
+	t.Cleanup(func() { HttpClient.Transport, RequestDelay, Token = saveTransport, saveDelay, saveToken })
+
+	if os.Getenv("SMARTSHEET_RECORD") != "" {
+		tkn, _ := ioutil.ReadFile("token.txt")
+		Token = strings.TrimSpace(string(tkn))
+		os.RemoveAll(fixtures)
+		stop := StartRecording(fixtures)
+		defer stop()
+	} else {
+		RequestDelay = 0
+		stop, err := StartReplay(fixtures)
+		if err != nil {
+			t.Fatal("StartReplay Failed", err)
+		}
+		defer stop()
+	}
+	if err := series1(t.TempDir()); err != nil {
+		t.Error("Test_SheetInfoSeries1 Failed", err)
+	}
+}
+
 // Load, Store, Restore, Match
-func series1() error {
+func series1(storeDir string) error {
 	var err error
+	storePath := filepath.Join(storeDir, "test1_base.json")
 	test1 := new(SheetInfo)
 	if err = test1.Load(Test1Id, NoRows); err != nil {
 		return err
 	}
-	if err = test1.Store("/home/jay/sheets/test1_base.json"); err != nil {
+	if err = test1.Store(storePath); err != nil {
 		return err
 	}
 	test1Base := new(SheetInfo)
-	if err = test1Base.Restore("/home/jay/sheets/test1_base.json"); err != nil {
+	if err = test1Base.Restore(storePath); err != nil {
 		return err
 	}
 	if matched := test1.MatchSheet(test1Base); !matched {
@@ -177,3 +211,817 @@ func series3() error {
 	sheet.Show()
 	return err
 }
+
+// testSheet returns an in-memory SheetInfo used by tests not requiring the API.
+func testSheet() *SheetInfo {
+	sheet := &SheetInfo{
+		SheetId:        1849449510135684,
+		SheetName:      "Test1",
+		ColumnsById:    make(map[int64]Column),
+		ColumnsByName:  make(map[string]Column),
+		ColumnsByIndex: make(map[int]Column),
+	}
+	columns := []Column{
+		{Id: 101, Index: 0, Title: "Address", Type: "TEXT_NUMBER", Primary: true},
+		{Id: 102, Index: 1, Title: "OrderNo", Type: "TEXT_NUMBER"},
+		{Id: 103, Index: 2, Title: "DueDate", Type: "DATE"},
+		{Id: 104, Index: 3, Title: "Util", Type: "PICKLIST", Options: []string{"Elec", "Water", "Gas"}},
+		{Id: 105, Index: 4, Title: "Amt", Type: "TEXT_NUMBER"},
+		{Id: 106, Index: 5, Title: "Complete", Type: "CHECKBOX"},
+		{Id: 107, Index: 6, Title: "Level", Type: "TEXT_NUMBER"},
+		{Id: 108, Index: 7, Title: "Status", Type: "PICKLIST", Options: []string{"Red", "Yellow", "Green"}},
+		{Id: 109, Index: 8, Title: "Hyperlink", Type: "TEXT_NUMBER"},
+	}
+	for _, column := range columns {
+		sheet.ColumnsById[column.Id] = column
+		sheet.ColumnsByName[column.Title] = column
+		sheet.ColumnsByIndex[column.Index] = column
+	}
+	return sheet
+}
+
+func Test_CrossSheetRefs(t *testing.T) {
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sheets/1849449510135684/crosssheetreferences" {
+			t.Error("Test_CrossSheetRefs wrong path", r.URL.Path)
+		}
+		if r.Method == "GET" {
+			if r.URL.Query().Get("page") == "1" {
+				w.Write([]byte(`{"pageNumber":1,"totalPages":2,"data":[{"id":11,"name":"Orders","status":"OK","sourceSheetId":8094487248430980,"startColumnId":101,"endColumnId":105}]}`))
+			} else {
+				w.Write([]byte(`{"pageNumber":2,"totalPages":2,"data":[{"id":12,"name":"Dup","status":"OK","sourceSheetId":8094487248430980,"startRowId":7,"endRowId":9,"startColumnId":101,"endColumnId":101}]}`))
+			}
+			return
+		}
+		var ref CrossSheetReference
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &ref)
+		if ref.Name == "Dup" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorCode":1129,"message":"A cross-sheet reference named Dup already exists.","refId":"abc"}`))
+			return
+		}
+		if ref.Name == "Bad" { // other error, message not checked
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorCode":1008,"message":"Unable to parse request, Bad already exists.","refId":"abd"}`))
+			return
+		}
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":13,"name":"` + ref.Name + `","status":"OK"}}`))
+	})
+	sheet := testSheet()
+
+	refs, err := GetCrossSheetRefs(sheet.SheetId)
+	if err != nil {
+		t.Fatal("Test_CrossSheetRefs GetCrossSheetRefs Failed", err)
+	}
+	if len(refs) != 2 || refs[0].Id != 11 || refs[1].Name != "Dup" || refs[1].EndRowId != 9 {
+		t.Errorf("Test_CrossSheetRefs GetCrossSheetRefs wrong result %+v", refs)
+	}
+
+	ref := CrossSheetReference{Name: "New", SourceSheetId: 8094487248430980, StartColumnId: 101, EndColumnId: 101}
+	created, err := sheet.CreateCrossSheetReference(&ref)
+	if err != nil || created.Id != 13 || created.Name != "New" {
+		t.Errorf("Test_CrossSheetRefs Create wrong result %+v %v", created, err)
+	}
+
+	ref.Name = "Dup"
+	if _, err = sheet.CreateCrossSheetReference(&ref); err != ErrCrossSheetRefExists {
+		t.Error("Test_CrossSheetRefs Create expected ErrCrossSheetRefExists, got", err)
+	}
+	ref.Name = "Bad"
+	if _, err = sheet.CreateCrossSheetReference(&ref); err == ErrCrossSheetRefExists {
+		t.Error("Test_CrossSheetRefs Create expected *ApiError for errorCode 1008, got", err)
+	}
+	ref.Name = "Dup"
+	existing, err := sheet.EnsureCrossSheetReference(&ref)
+	if err != nil || existing.Id != 12 {
+		t.Errorf("Test_CrossSheetRefs Ensure existing wrong result %+v %v", existing, err)
+	}
+	ref.Name = "Another"
+	created, err = sheet.EnsureCrossSheetReference(&ref)
+	if err != nil || created.Id != 13 {
+		t.Errorf("Test_CrossSheetRefs Ensure new wrong result %+v %v", created, err)
+	}
+}
+
+func Test_CreateCrossSheetReferenceByName(t *testing.T) {
+	var received CrossSheetReference
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &received)
+		if received.Name == "Fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorCode":1012,"message":"Required object attribute(s) are missing from your request."}`))
+			return
+		}
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":14,"name":"` + received.Name + `"}}`))
+	})
+	target := testSheet()
+	source := testSheet()
+	source.SheetId = 8094487248430980
+	source.SheetName = "Test2"
+
+	created, err := CreateCrossSheetReferenceByName(target, "Amounts", source, "OrderNo", "Amt", 0, 0)
+	if err != nil || created.Id != 14 {
+		t.Fatalf("Test_CreateCrossSheetReferenceByName wrong result %+v %v", created, err)
+	}
+	if received.SourceSheetId != source.SheetId || received.StartColumnId != 102 || received.EndColumnId != 105 {
+		t.Errorf("Test_CreateCrossSheetReferenceByName wrong request %+v", received)
+	}
+
+	_, err = CreateCrossSheetReferenceByName(target, "Amounts", source, "OrderNo", "Bogus", 0, 0)
+	if !errors.Is(err, ErrInvalidColumnName) || !strings.Contains(err.Error(), "Bogus") {
+		t.Error("Test_CreateCrossSheetReferenceByName expected ErrInvalidColumnName, got", err)
+	}
+	_, err = CreateCrossSheetReferenceByName(target, "Amounts", source, "Amt", "OrderNo", 0, 0)
+	if !errors.Is(err, ErrInvalidColumnRange) || errors.Is(err, ErrInvalidColumnName) {
+		t.Error("Test_CreateCrossSheetReferenceByName expected ErrInvalidColumnRange, got", err)
+	}
+	_, err = CreateCrossSheetReferenceByName(target, "Fail", source, "OrderNo", "Amt", 0, 0)
+	if _, ok := err.(*ApiError); !ok || errors.Is(err, ErrInvalidColumnName) {
+		t.Error("Test_CreateCrossSheetReferenceByName expected *ApiError, got", err)
+	}
+}
+
+// Test_UploadNewRowsParallel sends chunks concurrently to a stub server with varied latency.
+// Added rows are given id 1000 + OrderNo, so result order and parent/child ids can be checked.
+func Test_UploadNewRowsParallel(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	parents := make(map[int64][]int64) // parentId: childIds, from SetParentId requests
+	failOrderNo := -1
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		if r.Method == "PUT" {
+			var items []struct{ Id, ParentId int64 }
+			json.Unmarshal(reqBytes, &items)
+			mu.Lock()
+			for _, item := range items {
+				parents[item.ParentId] = append(parents[item.ParentId], item.Id)
+			}
+			mu.Unlock()
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+			return
+		}
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		var items []struct{ Cells []Cell }
+		json.Unmarshal(reqBytes, &items)
+		rows := make([]Row, len(items))
+		for i, item := range items {
+			orderNo, _ := strconv.Atoi(item.Cells[1].Value.(string))
+			if orderNo == failOrderNo {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"errorCode":4000,"message":"An unexpected error has occurred."}`))
+				return
+			}
+			rows[i] = Row{Id: int64(1000 + orderNo), Cells: item.Cells}
+		}
+		time.Sleep(time.Duration(rand.Intn(15)) * time.Millisecond)
+		result, _ := json.Marshal(rows)
+		if len(rows) == 1 {
+			result, _ = json.Marshal(rows[0])
+		}
+		fmt.Fprintf(w, `{"message":"SUCCESS","resultCode":0,"result":%s}`, result)
+	})
+
+	addRows := func(sheet *SheetInfo) {
+		for orderNo := 0; orderNo < 61; orderNo++ {
+			level := "1"
+			if orderNo%3 == 0 {
+				level = "0"
+			}
+			sheet.AddRow(Row{Cells: []Cell{{ColName: "Level", Value: level}, {ColName: "OrderNo", Value: strconv.Itoa(orderNo)}}})
+		}
+	}
+	sheet := testSheet()
+	addRows(sheet)
+	options := UploadOptions{ChunkSize: 5, Parallelism: 3} // last chunk has 1 row
+	response, err := sheet.UploadNewRowsWith(nil, &options, "Level")
+	if err != nil || len(response.Result) != 61 {
+		t.Fatal("UploadNewRowsWith Failed", err)
+	}
+	for i, row := range response.Result {
+		if row.Id != int64(1000+i) {
+			t.Fatalf("UploadNewRowsWith wrong result order at %d, got row id %d", i, row.Id)
+		}
+	}
+	if maxInFlight > 3 || maxInFlight < 2 {
+		t.Error("UploadNewRowsWith expected at most 3 concurrent requests, got", maxInFlight)
+	}
+	if sheet.NewRows != nil {
+		t.Error("UploadNewRowsWith expected NewRows to be nil")
+	}
+	if len(parents) != 20 {
+		t.Error("UploadNewRowsWith expected 20 parents, got", len(parents))
+	}
+	for parentId, childIds := range parents {
+		if len(childIds) != 2 || childIds[0] != parentId+1 || childIds[1] != parentId+2 {
+			t.Error("UploadNewRowsWith wrong children for parent", parentId, childIds)
+		}
+	}
+
+	// chunk containing OrderNo 27 (rows 25-29) fails, other chunks are added
+	failOrderNo = 27
+	parents = make(map[int64][]int64)
+	addRows(sheet)
+	response, err = sheet.UploadNewRowsWith(nil, &options, "Level")
+	uploadErr, ok := err.(*UploadError)
+	if !ok || len(uploadErr.Chunks) != 1 || uploadErr.Chunks[0].FirstRow != 25 || uploadErr.Chunks[0].RowCount != 5 {
+		t.Fatal("UploadNewRowsWith expected UploadError for rows 25-29, got", err)
+	}
+	if len(response.Result) != 56 || response.Result[25].Id != 1030 {
+		t.Error("UploadNewRowsWith wrong result for successful chunks", len(response.Result))
+	}
+	if len(sheet.NewRows) != 5 || sheet.NewRows[0].Cells[1].Value != "25" {
+		t.Error("UploadNewRowsWith expected NewRows to contain failed rows", sheet.NewRows)
+	}
+	if len(parents) != 0 {
+		t.Error("UploadNewRowsWith expected no SetParentId requests after failure", parents)
+	}
+}
+
+// Test_ReconcileNewRows simulates a timeout after the server added the 2nd chunk,
+// the retry after ReconcileNewRows must only send the rows not added.
+func Test_ReconcileNewRows(t *testing.T) {
+	var stored []Row // rows added to stub sheet
+	var columnIds string
+	timeouts := map[int]bool{2: true, 3: false} // chunk: added before timing out
+	chunk := 0
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			columnIds = r.URL.Query().Get("columnIds")
+			result, _ := json.Marshal(stored)
+			fmt.Fprintf(w, `{"id":1849449510135684,"rows":%s}`, result)
+			return
+		}
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		var items []struct{ Cells []Cell }
+		json.Unmarshal(reqBytes, &items)
+		chunk++
+		added, timeout := timeouts[chunk]
+		if timeout && !added {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		rows := make([]Row, len(items))
+		for i, item := range items {
+			rows[i] = Row{Id: int64(1000 + len(stored)), Cells: item.Cells}
+			stored = append(stored, rows[i])
+		}
+		if timeout {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		result, _ := json.Marshal(rows)
+		fmt.Fprintf(w, `{"message":"SUCCESS","resultCode":0,"result":%s}`, result)
+	})
+
+	sheet := testSheet()
+	key := Column{Id: 110, Index: 9, Title: "ImportKey", Type: "TEXT_NUMBER"}
+	sheet.ColumnsById[key.Id], sheet.ColumnsByName[key.Title], sheet.ColumnsByIndex[key.Index] = key, key, key
+	for orderNo := 0; orderNo < 9; orderNo++ {
+		sheet.AddRow(Row{Cells: []Cell{{ColName: "OrderNo", Value: strconv.Itoa(orderNo)}}})
+	}
+	options := UploadOptions{ChunkSize: 3, ImportKeyColumn: "ImportKey"}
+	_, err := sheet.UploadNewRowsWith(nil, &options)
+	if uploadErr, ok := err.(*UploadError); !ok || len(uploadErr.Chunks) != 2 {
+		t.Fatal("UploadNewRowsWith expected UploadError for 2 chunks, got", err)
+	}
+	if len(stored) != 6 || len(sheet.NewRows) != 6 {
+		t.Fatalf("UploadNewRowsWith expected 6 rows added and 6 failed, got %d %d", len(stored), len(sheet.NewRows))
+	}
+	keys := make(map[string]bool)
+	for _, row := range sheet.NewRows {
+		keys[importKey(row, key.Id)] = true
+	}
+	if len(keys) != 6 || keys[""] {
+		t.Error("UploadNewRowsWith expected unique import keys in NewRows", keys)
+	}
+
+	removed, err := sheet.ReconcileNewRows("ImportKey")
+	if err != nil || removed != 3 {
+		t.Fatal("ReconcileNewRows expected 3 rows removed, got", removed, err)
+	}
+	if columnIds != "110" {
+		t.Error("ReconcileNewRows expected only key column requested, got", columnIds)
+	}
+	if len(sheet.NewRows) != 3 || sheet.NewRows[0].Cells[0].Value != "6" {
+		t.Error("ReconcileNewRows wrong remaining rows", sheet.NewRows)
+	}
+
+	timeouts = nil
+	if _, err = sheet.UploadNewRowsWith(nil, &options); err != nil {
+		t.Fatal("UploadNewRowsWith retry Failed", err)
+	}
+	orderNos := make(map[interface{}]int)
+	for _, row := range stored {
+		orderNos[row.Cells[0].Value]++
+	}
+	if len(stored) != 9 || len(orderNos) != 9 {
+		t.Errorf("expected 9 rows without duplicates after retry, got %d rows %v", len(stored), orderNos)
+	}
+
+	if _, err = sheet.ReconcileNewRows("Missing"); !errors.Is(err, ErrInvalidColumnName) {
+		t.Error("ReconcileNewRows expected ErrInvalidColumnName, got", err)
+	}
+}
+
+func Test_VerifyColumns(t *testing.T) {
+	sheet := testSheet()
+	server := sheet.columnList() // columns of the stub server
+	var requests []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == "GET" {
+			data, _ := json.Marshal(server)
+			fmt.Fprintf(w, `{"pageNumber":1,"totalPages":1,"data":%s}`, data)
+			return
+		}
+		fmt.Fprint(w, `{"message":"SUCCESS","resultCode":0,"result":[{"id":1001}]}`)
+	})
+	sheet.VerifyColumns = true
+	sheet.AddRow(Row{Cells: []Cell{{ColName: "Address", Value: "1 Main"}, {ColName: "Amt", Value: 10}}})
+	sheet.UpdateRow(Row{Id: 1, Cells: []Cell{{ColName: "Amt", Value: 20}}})
+
+	// renaming a column not referenced by staged cells does not stop the upload
+	server[2].Title = "Due"
+	if _, err := sheet.UploadNewRows(nil); err != nil {
+		t.Fatal("UploadNewRows expected unreferenced rename to be ignored, got", err)
+	}
+	if strings.Join(requests, ", ") != "GET /sheets/1849449510135684/columns, POST /sheets/1849449510135684/rows" {
+		t.Error("UploadNewRows expected columns requested before rows sent, got", requests)
+	}
+
+	// Amt renamed between staging and upload
+	requests = nil
+	server[4].Title = "Amount"
+	_, err := sheet.UploadUpdateRows(nil)
+	if !errors.Is(err, ErrColumnDrift) || !strings.Contains(err.Error(), "Amt renamed Amount") {
+		t.Error("UploadUpdateRows expected ErrColumnDrift naming Amt, got", err)
+	}
+	if len(requests) != 1 || len(sheet.UpdateRows) != 1 {
+		t.Error("UploadUpdateRows expected no rows sent and UpdateRows unchanged, got", requests, len(sheet.UpdateRows))
+	}
+
+	server[4].Title, server[4].Type = "Amt", "DATE"
+	if _, err = sheet.UploadUpdateRows(nil); !errors.Is(err, ErrColumnDrift) || !strings.Contains(err.Error(), "TEXT_NUMBER changed to DATE") {
+		t.Error("UploadUpdateRows expected ErrColumnDrift for type change, got", err)
+	}
+	server = append(server[:4], server[5:]...)
+	if _, err = sheet.UploadUpdateRows(nil); !errors.Is(err, ErrColumnDrift) || !strings.Contains(err.Error(), "Amt deleted") {
+		t.Error("UploadUpdateRows expected ErrColumnDrift for deleted column, got", err)
+	}
+
+	// check skipped
+	requests = nil
+	sheet.VerifyColumns = false
+	if _, err = sheet.UploadUpdateRows(nil); err != nil || len(requests) != 1 || requests[0] != "PUT /sheets/1849449510135684/rows" {
+		t.Error("UploadUpdateRows expected rows sent without column request, got", err, requests)
+	}
+}
+
+func Test_PrimaryColumn(t *testing.T) {
+	sheet := testSheet()
+	primary, err := sheet.PrimaryColumn()
+	if err != nil || primary.Title != "Address" {
+		t.Error("PrimaryColumn expected Address, got", primary.Title, err)
+	}
+	row := Row{Cells: []Cell{{ColumnId: 102, Value: "1001"}, {ColumnId: 101, Value: "1 Main"}}}
+	if value := sheet.PrimaryValue(row); value != "1 Main" {
+		t.Error("PrimaryValue wrong value", value)
+	}
+	if value := sheet.PrimaryValue(Row{Cells: []Cell{{ColName: "Address", Value: 22.0}}}); value != "22" {
+		t.Error("PrimaryValue by ColName wrong value", value)
+	}
+
+	noValue := Row{Cells: []Cell{{ColName: "OrderNo", Value: "1002"}}}
+	sheet.EmptyPrimary = EmptyPrimaryReject
+	if err = sheet.AddRow(noValue); !errors.Is(err, ErrEmptyPrimary) {
+		t.Error("AddRow expected ErrEmptyPrimary, got", err)
+	}
+	blank := Row{Cells: []Cell{{ColName: "Address", Value: "  "}}}
+	if err = sheet.AddRow(blank); !errors.Is(err, ErrEmptyPrimary) {
+		t.Error("AddRow expected ErrEmptyPrimary for blank value, got", err)
+	}
+	if _, err = AddRow(sheet, Row{Cells: []Cell{{ColName: "OrderNo", Value: "1003"}}}, nil); !errors.Is(err, ErrEmptyPrimary) {
+		t.Error("AddRow func expected ErrEmptyPrimary, got", err)
+	}
+	child := Row{ParentId: 11, Cells: []Cell{{ColName: "OrderNo", Value: "1004"}}}
+	if err = sheet.AddRow(child); err != nil {
+		t.Error("AddRow expected child row to be accepted, got", err)
+	}
+	sheet.EmptyPrimary = EmptyPrimaryWarn
+	if err = sheet.AddRow(Row{Cells: []Cell{{ColName: "OrderNo", Value: "1005"}}}); err != nil {
+		t.Error("AddRow expected warning only, got", err)
+	}
+	if len(sheet.NewRows) != 2 {
+		t.Error("AddRow expected 2 staged rows, got", len(sheet.NewRows))
+	}
+
+	// sheet loaded without primary column
+	delete(sheet.ColumnsById, 101)
+	delete(sheet.ColumnsByName, "Address")
+	delete(sheet.ColumnsByIndex, 0)
+	if _, err = sheet.PrimaryColumn(); !errors.Is(err, ErrNoPrimaryColumn) {
+		t.Error("PrimaryColumn expected ErrNoPrimaryColumn, got", err)
+	}
+	if value := sheet.PrimaryValue(row); value != "" {
+		t.Error("PrimaryValue expected empty value, got", value)
+	}
+	sheet.EmptyPrimary = EmptyPrimaryReject
+	if err = sheet.AddRow(Row{Cells: []Cell{{ColName: "OrderNo", Value: "1006"}}}); err != nil {
+		t.Error("AddRow expected no primary check when primary column not loaded, got", err)
+	}
+}
+
+func Test_SheetInfoLoadOwnerInfo(t *testing.T) {
+	var query string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("include")
+		w.Write([]byte(`{"id":1849449510135684,"name":"Test1","createdAt":"2023-05-01T14:00:00Z","modifiedAt":"2024-03-02T09:15:30Z",
+			"permalink":"https://app.smartsheet.com/sheets/abc","owner":"jay@test.com","ownerId":2331373580117892,"workspace":{"id":55,"name":"Ops"},
+			"columns":[{"id":101,"index":0,"title":"Address","type":"TEXT_NUMBER","primary":true}],"rows":[]}`))
+	})
+
+	sheet := new(SheetInfo)
+	if err := sheet.Load(Test1Id, &GetSheetOptions{IncludeOwnerInfo: true}); err != nil {
+		t.Fatal("SheetInfo.Load Failed", err)
+	}
+	if query != "ownerInfo" {
+		t.Error("SheetInfo.Load expected include=ownerInfo, got", query)
+	}
+	createdAt := time.Date(2023, 5, 1, 14, 0, 0, 0, time.UTC)
+	modifiedAt := time.Date(2024, 3, 2, 9, 15, 30, 0, time.UTC)
+	if !sheet.CreatedAt.Equal(createdAt) || !sheet.ModifiedAt.Equal(modifiedAt) {
+		t.Error("SheetInfo.Load wrong times", sheet.CreatedAt, sheet.ModifiedAt)
+	}
+	if sheet.Owner != "jay@test.com" || sheet.OwnerId != 2331373580117892 || sheet.WorkspaceName != "Ops" || sheet.Permalink == "" {
+		t.Errorf("SheetInfo.Load wrong owner %+v", sheet)
+	}
+
+	base := *sheet
+	base.ModifiedAt = base.ModifiedAt.Add(time.Hour)
+	if !sheet.MatchSheet(&base) {
+		t.Error("MatchSheet expected ModifiedAt to be ignored")
+	}
+}
+
+func Test_StageCellUpdate(t *testing.T) {
+	var body string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		body = compactJSON(reqBytes)
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[]}`))
+	})
+
+	sheet := testSheet()
+	sheet.Rows = []Row{
+		{Id: 11, Cells: []Cell{{ColumnId: 101, Value: "1 Main"}, {ColumnId: 105, Value: 74.2}}},
+		{Id: 12, Cells: []Cell{{ColumnId: 101, Value: "2 Elm"}}},
+	}
+	if value, found, err := sheet.CellValue(11, "Amt"); value != 74.2 || !found || err != nil {
+		t.Error("CellValue wrong result", value, found, err)
+	}
+	if value, found, err := sheet.CellValue(12, "Amt"); value != nil || found || err != nil {
+		t.Error("CellValue expected cell not found", value, found, err)
+	}
+	if _, _, err := sheet.CellValue(13, "Amt"); !errors.Is(err, ErrRowNotFound) {
+		t.Error("CellValue expected ErrRowNotFound, got", err)
+	}
+	if _, _, err := sheet.CellValue(11, "Bogus"); !errors.Is(err, ErrInvalidColumnName) {
+		t.Error("CellValue expected ErrInvalidColumnName, got", err)
+	}
+
+	sheet.StageCellUpdate(11, "Status", "Red")
+	sheet.StageCellUpdate(12, "Amt", 10)
+	sheet.StageCellUpdate(11, "Amt", 80)
+	sheet.StageCellUpdate(11, "Status", "Green") // replaces Red
+	if err := sheet.StageCellUpdate(11, "Bogus", 1); !errors.Is(err, ErrInvalidColumnName) {
+		t.Error("StageCellUpdate expected ErrInvalidColumnName, got", err)
+	}
+	if len(sheet.UpdateRows) != 2 || len(sheet.UpdateRows[0].Cells) != 2 {
+		t.Fatalf("StageCellUpdate expected 2 coalesced rows, got %+v", sheet.UpdateRows)
+	}
+
+	if _, err := sheet.UploadUpdateRows(nil); err != nil {
+		t.Fatal("UploadUpdateRows Failed", err)
+	}
+	expect := `[{"cells":[{"columnId":108,"value":"Green"},{"columnId":105,"value":80}],"id":"11"},{"cells":[{"columnId":105,"value":10}],"id":"12"}]`
+	if body != expect {
+		t.Errorf("UploadUpdateRows Expecting %s, Got %s", expect, body)
+	}
+}
+
+func Test_RowsResponseShape(t *testing.T) {
+	const rowJSON = `{"id":77,"rowNumber":3,"cells":[{"columnId":101,"value":"7 Pine"}]}`
+	shapes := map[string]string{"object": rowJSON, "array": "[" + rowJSON + "]"}
+	var result string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"message":"SUCCESS","resultCode":0,"version":12,"result":%s}`, result)
+	})
+	for shape, shapeJSON := range shapes {
+		result = shapeJSON
+
+		sheet := testSheet()
+		sheet.AddRow(Row{Cells: []Cell{{ColName: "Address", Value: "7 Pine"}}})
+		added, err := sheet.UploadNewRows(nil)
+		if err != nil {
+			t.Fatal("UploadNewRows", shape, "Failed", err)
+		}
+		if len(added.Result) != 1 || added.Result[0].Id != 77 || added.Result[0].Cells[0].Value != "7 Pine" {
+			t.Errorf("UploadNewRows %s response, Got %+v", shape, added.Result)
+		}
+
+		sheet.StageCellUpdate(77, "Address", "7 Pine")
+		updated, err := sheet.UploadUpdateRows(nil)
+		if err != nil {
+			t.Fatal("UploadUpdateRows", shape, "Failed", err)
+		}
+		if len(updated.Result) != 1 || updated.Result[0].Id != 77 || updated.Result[0].RowNumber != 3 {
+			t.Errorf("UploadUpdateRows %s response, Got %+v", shape, updated.Result)
+		}
+	}
+
+	var resp AddUpdtRowsResponse
+	err := json.Unmarshal([]byte(`{"message":"PARTIAL_SUCCESS","resultCode":3,"result":null,"failedItems":[{"index":0,"rowId":5}]}`), &resp)
+	if err != nil || resp.Result != nil || resp.ResultCode != 3 || len(resp.FailedItems) != 1 || resp.FailedItems[0].RowId != 5 {
+		t.Errorf("AddUpdtRowsResponse null result, Got %+v %v", resp, err)
+	}
+	if err = json.Unmarshal([]byte(`{"result":"bogus"}`), &resp); err == nil {
+		t.Error("AddUpdtRowsResponse expected error for string result")
+	}
+}
+
+func Test_DuplicateCells(t *testing.T) {
+	sheet := testSheet()
+	newRow := Row{Cells: []Cell{
+		{ColName: "Status", Value: "Red"},
+		{ColName: "Amt", Value: 5},
+		{ColName: "Status", Value: "Green"},
+	}}
+	err := sheet.AddRow(newRow)
+	if !errors.Is(err, ErrDuplicateColumn) || !strings.Contains(err.Error(), "Status") || len(sheet.NewRows) != 0 {
+		t.Error("AddRow expected ErrDuplicateColumn naming Status, got", err)
+	}
+	if err = sheet.UpdateRow(Row{Id: 11, Cells: newRow.Cells}); !errors.Is(err, ErrDuplicateColumn) {
+		t.Error("UpdateRow expected ErrDuplicateColumn, got", err)
+	}
+	if _, err = AddRow(sheet, newRow, nil); !errors.Is(err, ErrDuplicateColumn) {
+		t.Error("AddRow func expected ErrDuplicateColumn, got", err)
+	}
+
+	sheet.KeepLastDuplicate = true
+	if err = sheet.UpdateRow(Row{Id: 11, Cells: newRow.Cells}); err != nil {
+		t.Fatal("UpdateRow Failed", err)
+	}
+	cells := sheet.UpdateRows[0].Cells
+	if len(cells) != 2 || cells[0].ColName != "Amt" || cells[1].Value != "Green" {
+		t.Errorf("UpdateRow expected last Status cell kept, got %+v", cells)
+	}
+}
+
+// renderGolden is the expected Render output for the renderSheet fixture.
+const renderGolden = `Sheet Name: Render Sheet Id: 1849449510135684
+Workspace Name: Ops Workspace Id: 55
+--- COLUMNS ---
+ 0         Address     TEXT_NUMBER 101 
+ 1           Level     TEXT_NUMBER 107 
+--- ROWS ---
+Loaded 7 of 7 rows
+Row 1, id: 1 --- 
+        Address Main St 
+          Level 0 
+    Row 2, id: 2 --- 
+            Address Unit A 
+              Level 1 
+        Row 3, id: 3 --- 
+                Address Room 1 
+                  Level 2 
+    Row 4, id: 4 --- 
+            Address Unit B 
+              Level 1 
+Row 5, id: 5 --- 
+        Address Elm St 
+          Level 0 
+Row 7, id: 7 (parent not loaded) --- 
+        Address Room 9 
+          Level 2 
+    Row 8, id: 8 (filtered out) --- 
+            Address Unit C 
+              Level 1 
+`
+
+func renderSheet() *SheetInfo {
+	sheet := &SheetInfo{
+		SheetId:        1849449510135684,
+		SheetName:      "Render",
+		WorkspaceId:    55,
+		WorkspaceName:  "Ops",
+		ColumnsById:    make(map[int64]Column),
+		ColumnsByName:  make(map[string]Column),
+		ColumnsByIndex: make(map[int]Column),
+	}
+	for _, column := range []Column{{Id: 101, Index: 0, Title: "Address", Type: "TEXT_NUMBER"}, {Id: 107, Index: 1, Title: "Level", Type: "TEXT_NUMBER"}} {
+		sheet.ColumnsById[column.Id] = column
+		sheet.ColumnsByName[column.Title] = column
+		sheet.ColumnsByIndex[column.Index] = column
+	}
+	row := func(id, parentId int64, rowNumber int, address, level string) Row {
+		return Row{Id: id, ParentId: parentId, RowNumber: rowNumber, Cells: []Cell{{ColumnId: 101, Value: address}, {ColumnId: 107, Value: level}}}
+	}
+	// out of order, row 6 (parent of row 7) not loaded
+	sheet.Rows = []Row{
+		row(5, 0, 5, "Elm St", "0"),
+		row(3, 2, 3, "Room 1", "2"),
+		row(1, 0, 1, "Main St", "0"),
+		row(2, 1, 2, "Unit A", "1"),
+		row(4, 1, 4, "Unit B", "1"),
+		row(7, 6, 7, "Room 9", "2"),
+		row(8, 5, 8, "Unit C", "1"),
+	}
+	sheet.Rows[6].FilteredOut = true
+	return sheet
+}
+
+func Test_Render(t *testing.T) {
+	sheet := renderSheet()
+	var buf strings.Builder
+	sheet.Render(&buf, nil)
+	if buf.String() != renderGolden {
+		t.Errorf("Render Expecting:\n%s\nGot:\n%s", renderGolden, buf.String())
+	}
+
+	buf.Reset()
+	sheet.Render(&buf, &RenderOptions{ParentsOnly: true, RowLimit: 2})
+	output := buf.String()
+	if !strings.Contains(output, "Row 1, id: 1 ---") || !strings.Contains(output, "Row 5, id: 5 ---") || strings.Contains(output, "Row 7") || strings.Contains(output, "Unit") {
+		t.Errorf("Render ParentsOnly wrong output:\n%s", output)
+	}
+}
+
+// renderHeader is the start of the Render output for the renderSheet fixture, before the rows.
+const renderHeader = `Sheet Name: Render Sheet Id: 1849449510135684
+Workspace Name: Ops Workspace Id: 55
+--- COLUMNS ---
+ 0         Address     TEXT_NUMBER 101 
+ 1           Level     TEXT_NUMBER 107 
+--- ROWS ---
+Loaded 7 of 7 rows
+`
+
+func Test_RenderPaging(t *testing.T) {
+	sheet := renderSheet()
+	tests := []struct {
+		options RenderOptions
+		golden  string
+	}{
+		{RenderOptions{RowOffset: 2, RowLimit: 2}, renderHeader + `        Row 3, id: 3 --- 
+                Address Room 1 
+                  Level 2 
+    Row 4, id: 4 --- 
+            Address Unit B 
+              Level 1 
+--- rows 3 to 4 of 7 ---
+`},
+		{RenderOptions{RowOffset: 6, RowLimit: 2}, renderHeader + `    Row 8, id: 8 (filtered out) --- 
+            Address Unit C 
+              Level 1 
+--- rows 7 to 7 of 7 ---
+`},
+		{RenderOptions{RowOffset: 7, RowLimit: 2}, renderHeader + `--- no rows after row 7 of 7 ---
+`},
+		{RenderOptions{ParentsOnly: true, RowOffset: 1, ColumnNames: []string{"Address"}, Width: 5, ValueWidth: 4},
+			`Sheet Name: Render Sheet Id: 1849449510135684
+Workspace Name: Ops Workspace Id: 55
+--- COLUMNS ---
+ 0 Addr… TEXT… 101 
+--- ROWS ---
+Loaded 7 of 7 rows
+Row 5, id: 5 --- 
+Addr… Elm… 
+Row 7, id: 7 (parent not loaded) --- 
+Addr… Roo… 
+--- rows 2 to 3 of 3 ---
+`},
+	}
+	for _, test := range tests {
+		var buf strings.Builder
+		sheet.Render(&buf, &test.options)
+		if buf.String() != test.golden {
+			t.Errorf("Render %+v Expecting:\n%s\nGot:\n%s", test.options, test.golden, buf.String())
+		}
+	}
+	if truncate("Main St", 7) != "Main St" || truncate("Main St", 6) != "Main …" || truncate("Über", 1) != "…" {
+		t.Error("truncate wrong result")
+	}
+}
+
+func Test_IsComplete(t *testing.T) {
+	rowsJSON := `[{"id":11,"cells":[]},{"id":12,"cells":[]},{"id":13,"cells":[]}]`
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		rows := rowsJSON
+		if r.URL.Query().Get("rowIds") != "" || r.URL.Query().Get("rowsModifiedSince") != "" || r.URL.Query().Get("filterId") != "" {
+			rows = `[{"id":12,"cells":[]}]`
+		}
+		fmt.Fprintf(w, `{"id":1849449510135684,"name":"Test1","totalRowCount":3,"columns":[{"id":101,"index":0,"title":"Address","primary":true}],"rows":%s}`, rows)
+	})
+	sheet := new(SheetInfo)
+	if err := sheet.Load(1849449510135684, nil); err != nil {
+		t.Fatal("Load Failed", err)
+	}
+	if !sheet.IsComplete() || sheet.TotalRowCount != 3 || len(sheet.Warnings) != 0 {
+		t.Error("IsComplete expected full load complete", sheet.TotalRowCount, sheet.Warnings)
+	}
+	var buf strings.Builder
+	sheet.Render(&buf, &RenderOptions{RowLimit: 1})
+	if !strings.Contains(buf.String(), "Loaded 3 of 3 rows") {
+		t.Error("Render expected loaded row count, got", buf.String())
+	}
+
+	filtered := []*GetSheetOptions{
+		{RowIds: []int64{12}},
+		{RowsModifiedMins: 60},
+		{RowsModifiedSince: time.Now().Add(-time.Hour)},
+		{FilterId: 42, ExcludeFilteredOutRows: true},
+	}
+	for _, options := range filtered {
+		if err := sheet.Load(1849449510135684, options); err != nil {
+			t.Fatal("Load Failed", err)
+		}
+		if sheet.IsComplete() || !sheet.RowsSelected || len(sheet.Warnings) != 0 {
+			t.Errorf("IsComplete expected undefined for options %+v, warnings %v", options, sheet.Warnings)
+		}
+	}
+
+	rowsJSON = `[{"id":11,"cells":[]},{"id":12,"cells":[]}]` // truncated
+	if err := sheet.Load(1849449510135684, nil); err != nil {
+		t.Fatal("Load Failed", err)
+	}
+	if sheet.IsComplete() || sheet.RowsSelected || len(sheet.Warnings) != 1 || sheet.Warnings[0].Code != WarnRowsTruncated {
+		t.Error("IsComplete expected truncated load warning, got", sheet.Warnings)
+	}
+	buf.Reset()
+	sheet.Render(&buf, &RenderOptions{RowLimit: 1})
+	if !strings.Contains(buf.String(), "Loaded 2 of 3 rows") {
+		t.Error("Render expected loaded row count, got", buf.String())
+	}
+}
+
+func Test_SheetInfoLoadFailureKeepsSheet(t *testing.T) {
+	response, status := `{"id":1849449510135684,"name":"Test1","totalRowCount":1,
+		"columns":[{"id":101,"index":0,"title":"Address","type":"TEXT_NUMBER","primary":true}],
+		"rows":[{"id":11,"cells":[{"columnId":101,"value":"100 Main"}]}]}`, http.StatusOK
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	})
+
+	sheet := new(SheetInfo)
+	if err := sheet.Load(Test1Id, nil); err != nil {
+		t.Fatal("SheetInfo.Load Failed", err)
+	}
+	if sheet.LastLoadedAt.IsZero() || sheet.LastLoadErr != nil {
+		t.Error("SheetInfo.Load expected LastLoadedAt set and no LastLoadErr, got", sheet.LastLoadedAt, sheet.LastLoadErr)
+	}
+	loaded, _ := json.Marshal(sheet)
+	loadedAt := sheet.LastLoadedAt
+
+	failures := []struct {
+		name     string
+		status   int
+		response string
+	}{
+		{"not found", http.StatusNotFound, `{"errorCode":1006,"message":"Not Found"}`},
+		{"bad json", http.StatusOK, `{"id":1849449510135684,"name":"Test1","rows":[{"id":`},
+	}
+	for _, failure := range failures {
+		status, response = failure.status, failure.response
+		err := sheet.Load(Test1Id, nil)
+		if err == nil || sheet.LastLoadErr != err {
+			t.Errorf("SheetInfo.Load %s expected error in LastLoadErr, got %v %v", failure.name, err, sheet.LastLoadErr)
+		}
+		sheet.LastLoadErr = nil
+		if after, _ := json.Marshal(sheet); string(after) != string(loaded) || !sheet.LastLoadedAt.Equal(loadedAt) {
+			t.Errorf("SheetInfo.Load %s changed the loaded sheet\n%s\n%s", failure.name, loaded, after)
+		}
+	}
+	if _, found := sheet.ColumnsByName["Address"]; !found || len(sheet.Rows) != 1 {
+		t.Error("SheetInfo.Load failures expected loaded columns and rows kept")
+	}
+
+	err := sheet.Load(Test1Id, &GetSheetOptions{ColumnNames: []string{"Missing"}})
+	if err == nil || sheet.LastLoadErr != err || len(sheet.Rows) != 1 {
+		t.Error("SheetInfo.Load invalid ColumnNames expected LastLoadErr and rows kept, got", err, sheet.LastLoadErr)
+	}
+}
@@ -0,0 +1,189 @@
+// sights.go contains GetSight, returning a Sight (dashboard) with its widgets, and ResolveMetricWidget, which requests
+// the current values of the sheet cells shown by a metric widget, for rendering widgets outside Smartsheet.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+)
+
+// Widget Types, values of Widget.Type
+const (
+	WidgetMetric   = "METRIC"    // sheet cell values, Contents.SheetId and Contents.CellData
+	WidgetChart    = "CHART"     // Contents.SheetId or Contents.ReportId, Contents.SelectionRanges
+	WidgetReport   = "GRIDGANTT" // Contents.ReportId
+	WidgetRichText = "RICHTEXT"  // Contents.HtmlContent
+	WidgetShortcut = "SHORTCUT"
+	WidgetImage    = "IMAGE"
+	WidgetTitle    = "TITLE"
+)
+
+// Sight is a dashboard, returned by GetSight. HomeItem contains the listing attributes (ex. Name, Permalink).
+type Sight struct {
+	HomeItem
+	ColumnCount     int      `json:"columnCount"` // dashboard grid columns
+	BackgroundColor string   `json:"backgroundColor"`
+	Widgets         []Widget `json:"widgets"`
+	Workspace       *struct {
+		Id   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"workspace"` // nil if not in a workspace
+}
+
+// Widget is 1 widget of a Sight, Contents attributes depend on Type (see Widget Types).
+type Widget struct {
+	Id        int64          `json:"id"`
+	Type      string         `json:"type"`
+	Title     string         `json:"title"`
+	ShowTitle bool           `json:"showTitle"`
+	XPosition int            `json:"xPosition"`
+	YPosition int            `json:"yPosition"`
+	Width     int            `json:"width"`
+	Height    int            `json:"height"`
+	Version   int            `json:"version"`
+	Contents  WidgetContents `json:"contents"`
+}
+
+// WidgetContents contains the content attributes of all widget types, only those of the widget's Type are set.
+type WidgetContents struct {
+	SheetId           int64                  `json:"sheetId"`           // METRIC, CHART of a sheet
+	ReportId          int64                  `json:"reportId"`          // GRIDGANTT, CHART of a report
+	CellData          []WidgetCellData       `json:"cellData"`          // METRIC
+	SelectionRanges   []WidgetSelectionRange `json:"selectionRanges"`   // CHART, sheet cells charted
+	IncludedColumnIds []int64                `json:"includedColumnIds"` // CHART, GRIDGANTT
+	Series            json.RawMessage        `json:"series"`            // CHART, chart series as returned by the api
+	HtmlContent       string                 `json:"htmlContent"`       // RICHTEXT, GRIDGANTT
+	Hyperlink         *WidgetHyperlink       `json:"hyperlink"`         // link of the widget, ex. to its sheet
+}
+
+// WidgetCellData is 1 labeled cell of a METRIC widget. Cell contains the value when the sight was requested.
+type WidgetCellData struct {
+	Label       string `json:"label"`
+	LabelFormat string `json:"labelFormat"`
+	ValueFormat string `json:"valueFormat"`
+	ColumnId    int64  `json:"columnId"`
+	ColumnType  string `json:"columnType"`
+	RowId       int64  `json:"rowId"` // 0 if not returned by the api
+	Order       int    `json:"order"`
+	Cell        *Cell  `json:"cell"`
+}
+
+// WidgetSelectionRange is a range of sheet cells, from row 1 column 1 to row 2 column 2.
+type WidgetSelectionRange struct {
+	SourceRowId1    int64 `json:"sourceRowId1"`
+	SourceColumnId1 int64 `json:"sourceColumnId1"`
+	SourceRowId2    int64 `json:"sourceRowId2"`
+	SourceColumnId2 int64 `json:"sourceColumnId2"`
+}
+
+// WidgetHyperlink is the link of a widget, to a url, sheet, report or sight.
+type WidgetHyperlink struct {
+	Url      string `json:"url"`
+	SheetId  int64  `json:"sheetId"`
+	ReportId int64  `json:"reportId"`
+	SightId  int64  `json:"sightId"`
+}
+
+// GetSight returns the sight with its widgets.
+func GetSight(sightId int64) (*Sight, error) {
+	trace("GetSight")
+	resp, err := DoRequest(Get(fmt.Sprintf("/sights/%d", sightId), nil))
+	if err != nil {
+		log.Println("ERROR GetSight", sightId, err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	sight := new(Sight)
+	if err = json.Unmarshal(respJSON, sight); err != nil {
+		log.Println("ERROR GetSight JSON Unmarshal Failed - ", err)
+		return nil, err
+	}
+	return sight, nil
+}
+
+// ErrWidgetNotFound is returned by ResolveMetricWidget when the sight has no widget with the id.
+var ErrWidgetNotFound = errors.New("Widget Not Found")
+
+// MetricValue is 1 cell of a METRIC widget, returned by ResolveMetricWidget.
+type MetricValue struct {
+	Label        string
+	RowId        int64 // 0 if the api did not return the row of the cell
+	ColumnId     int64
+	Value        interface{}
+	DisplayValue string
+	Current      bool // value requested from the sheet, false if it is the widget's value (row unknown or not returned)
+}
+
+// ResolveMetricWidget returns the values of the cells shown by a METRIC widget of sight, in widget order.
+// Cells whose row is known are requested from the widget's sheet (1 GetSheet request of those rows and columns),
+// the others, and cells of rows no longer in the sheet, have the value of the widget when the sight was requested.
+func ResolveMetricWidget(sight *Sight, widgetId int64) ([]MetricValue, error) {
+	trace("ResolveMetricWidget")
+	var widget *Widget
+	for i := range sight.Widgets {
+		if sight.Widgets[i].Id == widgetId {
+			widget = &sight.Widgets[i]
+		}
+	}
+	if widget == nil {
+		err := fmt.Errorf("%w - sight %d widget %d", ErrWidgetNotFound, sight.Id, widgetId)
+		log.Println("ERROR ResolveMetricWidget", err)
+		return nil, err
+	}
+	if widget.Type != WidgetMetric || widget.Contents.SheetId == 0 {
+		err := fmt.Errorf("Invalid Metric Widget - widget %d is type %s, sheetId %d", widgetId, widget.Type, widget.Contents.SheetId)
+		log.Println("ERROR ResolveMetricWidget", err)
+		return nil, err
+	}
+
+	rowIds, columnIds := make([]int64, 0), make([]int64, 0)
+	rowRequested, columnRequested := make(map[int64]bool), make(map[int64]bool)
+	for _, data := range widget.Contents.CellData {
+		if data.RowId == 0 {
+			continue
+		}
+		if !rowRequested[data.RowId] {
+			rowRequested[data.RowId] = true
+			rowIds = append(rowIds, data.RowId)
+		}
+		if !columnRequested[data.ColumnId] {
+			columnRequested[data.ColumnId] = true
+			columnIds = append(columnIds, data.ColumnId)
+		}
+	}
+	current := make(map[[2]int64]Cell)
+	returned := make(map[int64]bool)
+	if len(rowIds) > 0 {
+		sheet, err := GetSheet(widget.Contents.SheetId, &GetSheetOptions{RowIds: rowIds, ColumnIds: columnIds})
+		if err != nil {
+			log.Println("ERROR ResolveMetricWidget", widgetId, err)
+			return nil, err
+		}
+		for _, row := range sheet.Rows {
+			returned[row.Id] = true
+			for _, cell := range row.Cells {
+				current[[2]int64{row.Id, cell.ColumnId}] = cell
+			}
+		}
+	}
+
+	values := make([]MetricValue, 0, len(widget.Contents.CellData))
+	for _, data := range widget.Contents.CellData {
+		value := MetricValue{Label: data.Label, RowId: data.RowId, ColumnId: data.ColumnId}
+		if returned[data.RowId] { // cell not returned if empty
+			cell := current[[2]int64{data.RowId, data.ColumnId}]
+			value.Value, value.DisplayValue, value.Current = cell.Value, cell.DisplayValue, true
+		} else if data.Cell != nil {
+			value.Value, value.DisplayValue = data.Cell.Value, data.Cell.DisplayValue
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
@@ -0,0 +1,63 @@
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func Test_GetSight(t *testing.T) {
+	var sheetQuery string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sights/2591554075418573":
+			sightJSON, _ := ioutil.ReadFile("testdata/sight.json")
+			w.Write(sightJSON)
+		case "/sheets/1849449510135684":
+			sheetQuery = r.URL.RawQuery
+			w.Write([]byte(`{"id":1849449510135684,"rows":[{"id":11,"cells":[{"columnId":105,"value":1350,"displayValue":"$1,350.00"}]}]}`))
+		default:
+			t.Error("GetSight wrong request", r.URL.Path)
+		}
+	})
+	sight, err := GetSight(2591554075418573)
+	if err != nil {
+		t.Fatal("GetSight Failed", err)
+	}
+	if sight.Name != "Operations Status" || sight.ColumnCount != 6 || sight.Workspace == nil || sight.Workspace.Id != 55 || len(sight.Widgets) != 3 {
+		t.Fatalf("GetSight wrong sight %+v", sight)
+	}
+	metric := sight.Widgets[1]
+	if metric.Type != WidgetMetric || metric.Contents.SheetId != 1849449510135684 || len(metric.Contents.CellData) != 3 ||
+		metric.Contents.CellData[0].RowId != 11 || metric.Contents.CellData[1].Cell.Value != "Yellow" {
+		t.Errorf("GetSight wrong metric widget %+v", metric)
+	}
+	chart := sight.Widgets[2]
+	ranges := chart.Contents.SelectionRanges
+	if chart.Type != WidgetChart || len(ranges) != 1 || ranges[0].SourceRowId2 != 14 || ranges[0].SourceColumnId1 != 104 ||
+		fmt.Sprint(chart.Contents.IncludedColumnIds) != "[104 105]" || len(chart.Contents.Series) == 0 ||
+		chart.Contents.Hyperlink.Url != "https://app.smartsheet.com/sheets/orders" {
+		t.Errorf("GetSight wrong chart widget %+v", chart)
+	}
+
+	values, err := ResolveMetricWidget(sight, metric.Id)
+	if err != nil {
+		t.Fatal("ResolveMetricWidget Failed", err)
+	}
+	if sheetQuery != "columnIds=105%2C108&exclude=nonexistentCells&rowIds=11" {
+		t.Error("ResolveMetricWidget wrong sheet query", sheetQuery)
+	}
+	expect := "[{Amount 11 105 1350 $1,350.00 true} {Status 11 108 <nil>  true} {Due 0 103 2024-05-10 05/10/24 false}]"
+	if fmt.Sprint(values) != expect {
+		t.Errorf("ResolveMetricWidget, Expecting\n%s\nGot\n%v", expect, values)
+	}
+
+	if _, err = ResolveMetricWidget(sight, chart.Id); err == nil {
+		t.Error("ResolveMetricWidget expected error for chart widget")
+	}
+	if _, err = ResolveMetricWidget(sight, 99); !errors.Is(err, ErrWidgetNotFound) {
+		t.Error("ResolveMetricWidget expected ErrWidgetNotFound, got", err)
+	}
+}
@@ -9,8 +9,8 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 )
@@ -37,10 +37,36 @@ const (
 	CSV         = "csv"
 )
 
+// Paper Sizes, used by GetSheetAs with PDF format
+const (
+	PaperSizeLetter = "LETTER"
+	PaperSizeLegal  = "LEGAL"
+	PaperSizeWide   = "WIDE"
+	PaperSizeArchD  = "ARCHD"
+	PaperSizeA4     = "A4"
+	PaperSizeA3     = "A3"
+	PaperSizeA2     = "A2"
+	PaperSizeA1     = "A1"
+	PaperSizeA0     = "A0"
+)
+
+// paperSizes are the paper sizes accepted by the api, in Paper Sizes order.
+var paperSizes = []string{PaperSizeLetter, PaperSizeLegal, PaperSizeWide, PaperSizeArchD,
+	PaperSizeA4, PaperSizeA3, PaperSizeA2, PaperSizeA1, PaperSizeA0}
+
+// sheetAsAccept is the Accept header of each GetSheetAs format.
+var sheetAsAccept = map[string]string{
+	EXCEL: "application/vnd.ms-excel",
+	CSV:   "text/csv",
+	PDF:   "application/pdf",
+}
+
 // GetSheet downloads specified sheet info based on GetSheetOptions and returns *Sheet.
 // Typically called by SheetInfo.Load().
 // If options is nil, all rows and columns are requested.
-// Cells never containing a value are automatically excluded.
+// Cells never containing a value are excluded, unless GetSheetOptions.IncludeNonexistentCells is set.
+// Include parameters (attachments, discussions, etc.) are set using GetSheetOptions Include fields.
+// RowIds longer than maxQueryIdsLen characters are requested in several requests, see getSheetByRowIds.
 func GetSheet(sheetId int64, options *GetSheetOptions) (*Sheet, error) {
 	trace("GetSheet")
 	if options == nil {
@@ -48,34 +74,21 @@ func GetSheet(sheetId int64, options *GetSheetOptions) (*Sheet, error) {
 	}
 	debugLn("GetSheetOptions ---")
 	debugObj(options)
+	if err := options.Validate(); err != nil {
+		log.Println("ERROR GetSheet", err)
+		return nil, err
+	}
+	if len(splitIds(options.RowIds, maxQueryIdsLen)) > 1 {
+		return getSheetByRowIds(sheetId, options)
+	}
+	return requestSheet(sheetId, options)
+}
 
+// requestSheet sends 1 GetSheet request.
+func requestSheet(sheetId int64, options *GetSheetOptions) (*Sheet, error) {
 	endPoint := fmt.Sprintf("/sheets/%d", sheetId)
 
-	urlParms := make(map[string]string)
-	urlParms["exclude"] = "nonexistentCells"
-	if len(options.RowIds) > 0 {
-		rowIds := make([]string, len(options.RowIds))
-		for i, rowId := range options.RowIds {
-			rowIds[i] = fmt.Sprintf("%d", rowId)
-		}
-		urlParms["rowIds"] = strings.Join(rowIds, ",")
-	}
-	if len(options.ColumnIds) > 0 {
-		colIds := make([]string, len(options.ColumnIds))
-		for i, colId := range options.ColumnIds {
-			colIds[i] = fmt.Sprintf("%d", colId)
-		}
-		urlParms["columnIds"] = strings.Join(colIds, ",")
-	}
-	if !options.RowsModifiedSince.IsZero() {
-		urlParms["rowsModifiedSince"] = options.RowsModifiedSince.Format(time.RFC3339)
-	}
-	if options.RowsModifiedMins > 0 {
-		d := time.Duration(options.RowsModifiedMins) * time.Minute // convert mins to duration type & compute duration
-		rowsModifiedSince := time.Now().Add(-d).Format(time.RFC3339)
-		debugLn("rowsModifiedSince: ", rowsModifiedSince)
-		urlParms["rowsModifiedSince"] = rowsModifiedSince
-	}
+	urlParms := options.urlParms()
 	req := Get(endPoint, urlParms)
 	resp, err := DoRequest(req)
 	if err != nil {
@@ -93,29 +106,97 @@ func GetSheet(sheetId int64, options *GetSheetOptions) (*Sheet, error) {
 	return sheet, err
 }
 
-// GetSheetAs creates file containing all rows, 1st line is column headers.
-// Use const CSV, EXCEL, or PDF for parm "format".
-// Optional paperSize parm can only be used with PDF format. See API doc for choices.
-func GetSheetAs(sheetId int64, filePath string, format string, paperSize ...string) error {
+// GetSheetMeta returns sheet attributes, such as name and modified time, without rows or columns.
+// A cheap way to check a sheet still exists or has changed (compare Version) before loading it.
+func GetSheetMeta(sheetId int64) (*SheetMeta, error) {
+	trace("GetSheetMeta")
+	endPoint := fmt.Sprintf("/sheets/%d", sheetId)
+
+	urlParms := newQuery().
+		ids("rowIds", []int64{0}).    // no rows
+		ids("columnIds", []int64{0}). // no columns
+		include("ownerInfo", true).
+		exclude("nonexistentCells", true).
+		parms()
+
+	req := Get(endPoint, urlParms)
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+
+	meta := new(SheetMeta)
+	err = json.Unmarshal(respJSON, meta)
+	if err != nil {
+		log.Println("ERROR GetSheetMeta JSON Unmarshal Failed - ", err)
+		return nil, err
+	}
+	return meta, nil
+}
 
+// GetSheetAs creates file containing all rows, 1st line is column headers, and returns the number of bytes written.
+// Use const CSV, EXCEL, or PDF for parm "format".
+// Optional paperSize parm (see Paper Sizes) can only be used with PDF format, the api default is LETTER.
+// Format and paperSize are checked before the request is sent, the file is not created if they are invalid.
+// To change the csv delimiter, line ending or add a byte order mark, see GetSheetAsCSV.
+func GetSheetAs(sheetId int64, filePath string, format string, paperSize ...string) (int64, error) {
+	trace("GetSheetAs")
+	if err := validateSheetAs(format, paperSize); err != nil {
+		log.Println("ERROR GetSheetAs", err)
+		return 0, err
+	}
 	var urlParms map[string]string
 	if len(paperSize) > 0 {
 		urlParms = map[string]string{"paperSize": paperSize[0]}
 	}
-	endPoint := fmt.Sprintf("/sheets/%d", sheetId)
-	req := Get(endPoint, urlParms)
+	resp, err := requestSheetAs(sheetId, format, urlParms)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
 
-	switch format {
-	case EXCEL:
-		req.Header.Set("Accept", "application/vnd.ms-excel")
-	case CSV:
-		req.Header.Set("Accept", "text/csv")
-	case PDF:
-		req.Header.Set("Accept", "application/pdf")
-	default:
+	file, err := os.Create(filePath)
+	if err != nil {
+		log.Println("ERROR GetSheetAs Failed Creating Local File - ", err)
+		return 0, err
+	}
+	defer file.Close()
+
+	written, err := io.Copy(file, resp.Body)
+	if err != nil {
+		log.Println("ERROR GetSheetAs Failed Writing Local File - ", err)
+	}
+	return written, err
+}
+
+// validateSheetAs returns an error if format is unknown, or paperSize is unknown, not 1 value or used without PDF.
+func validateSheetAs(format string, paperSize []string) error {
+	if _, found := sheetAsAccept[format]; !found {
 		return errors.New("Invalid Format - " + format)
 	}
-	resp, err := DoRequest(req)
+	if len(paperSize) == 0 {
+		return nil
+	}
+	if len(paperSize) > 1 {
+		return fmt.Errorf("Invalid PaperSize - 1 value allowed, got %v", paperSize)
+	}
+	if format != PDF {
+		return fmt.Errorf("Invalid PaperSize - %s only allowed with format %s, not %s", paperSize[0], PDF, format)
+	}
+	for _, size := range paperSizes {
+		if paperSize[0] == size {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid PaperSize - %s, use one of %s", paperSize[0], strings.Join(paperSizes, ", "))
+}
+
+// GetSheetAsCSV creates csv file containing all rows, converted using opts as the response is received (see ConvertCSV).
+func GetSheetAsCSV(sheetId int64, filePath string, opts ExportOptions) error {
+	trace("GetSheetAsCSV")
+	resp, err := requestSheetAs(sheetId, CSV, nil)
 	if err != nil {
 		return err
 	}
@@ -123,64 +204,128 @@ func GetSheetAs(sheetId int64, filePath string, format string, paperSize ...stri
 
 	file, err := os.Create(filePath)
 	if err != nil {
-		log.Println("ERROR GetSheetAs Failed Creating Local File - ", err)
+		log.Println("ERROR GetSheetAsCSV Failed Creating Local File - ", err)
 		return err
 	}
 	defer file.Close()
 
-	_, err = io.Copy(file, resp.Body)
-	if err != nil {
-		log.Println("ERROR GetSheetAs Failed Writing Local File - ", err)
+	if err = ConvertCSV(file, resp.Body, opts); err != nil {
+		log.Println("ERROR GetSheetAsCSV Failed Writing Local File - ", err)
 	}
 	return err
 }
 
+// requestSheetAs sends the GetSheetAs request, Accept header is based on format.
+func requestSheetAs(sheetId int64, format string, urlParms map[string]string) (*http.Response, error) {
+	endPoint := fmt.Sprintf("/sheets/%d", sheetId)
+	req := Get(endPoint, urlParms)
+
+	accept, found := sheetAsAccept[format]
+	if !found {
+		return nil, errors.New("Invalid Format - " + format)
+	}
+	req.Header.Set("Accept", accept)
+	return DoRequest(req)
+}
+
 // RowValues returns a row's cell values as map[string]string.
 // The key of each entry is column name.
-// If cell contains hyperlink, the url is returned as entry value.
+// If cell contains hyperlink, the url is returned as entry value (not the cell text), for sheet and report links see
+// Hyperlink.Target. Use RowValuesDetailed for both the text and url.
 // If cell contains multiple values, all values are concatenated into 1 string, ex: "light, sour".
 // If cell contains number value, it is converted to string (formatting such as $, commas are not included).
-// Cells with no value have an entry value of empty string, "".
-// Formula cells return the computed value (not the formula).
+// Cells with no value have an entry value of empty string, "", except FLAG & STAR symbol columns, which are "false".
+// CHECKBOX values use the text of sheet.CheckboxText for the column's Symbol when set (ex. "Yes", "No").
+// Formula cells return the computed value (not the formula), see RowFormulas.
 // Use func CellInfo() to access all cell attributes.
 func RowValues(sheet *SheetInfo, row Row) map[string]string {
 	trace("RowValues")
 	rowValues := make(map[string]string)
 	for _, cell := range row.Cells {
 		column := sheet.ColumnsById[cell.ColumnId]
-		colName := column.Title
-		switch {
-		case cell.Hyperlink != nil && cell.Hyperlink.Url != "":
-			rowValues[colName] = cell.Hyperlink.Url
-		case cell.Value == nil:
-			rowValues[colName] = ""
-		default:
-			rowValues[colName] = fmt.Sprintf("%v", cell.Value)
+		if cell.Hyperlink != nil && cell.Hyperlink.Target() != "" {
+			rowValues[column.Title] = cell.Hyperlink.Target()
+		} else {
+			rowValues[column.Title] = sheet.valueText(cell, column)
 		}
 	}
-	// load missing columns with "" (cells never having value are not returned by GetSheet() func)
-	for colName, _ := range sheet.ColumnsByName {
+	// load missing columns with "" (cells never having value are not returned by GetSheet() func, unless IncludeNonexistentCells)
+	for colName, column := range sheet.ColumnsByName {
 		if _, found := rowValues[colName]; !found {
-			rowValues[colName] = ""
+			rowValues[colName] = sheet.valueText(Cell{ColumnId: column.Id}, column) // unflagged FLAG & STAR cells are "false", same as cleared cells
 		}
 	}
 	debugObj(rowValues)
 	return rowValues
 }
 
+// cellText returns the value of a cell as a string, as returned by RowValues for cells without a hyperlink.
+func cellText(cell Cell, column Column) string {
+	switch {
+	case cell.ObjectValue != nil && (column.Type == PREDECESSOR || column.Type == DURATION):
+		return fmt.Sprintf("%v", cell.ObjectValue) // ex. "3FS +2d", see projects.go
+	case cell.Value == nil && isCheckboxSymbol(column):
+		return "false"
+	case cell.Value == nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", cell.Value)
+	}
+}
+
+// CellValueDetail is the value of 1 cell returned by RowValuesDetailed.
+type CellValueDetail struct {
+	Value        string // as returned by RowValues, except hyperlink cells contain the cell text rather than the url
+	DisplayValue string // as shown in Smartsheet UI (ex. "$74.20"), Value if not returned by api
+	HyperlinkURL string // url of a hyperlink cell (see Hyperlink.Target), empty if the cell has no hyperlink
+	IsFormula    bool   // Value is the computed value of a formula, see RowFormulas
+}
+
+// RowValuesDetailed returns a row's cell values keyed by column name, as RowValues, but keeps the text and url of
+// hyperlink cells apart, with the display value and whether the cell is a formula.
+// Cells missing from the row are included, with the same Value as RowValues.
+func RowValuesDetailed(sheet *SheetInfo, row Row) map[string]CellValueDetail {
+	trace("RowValuesDetailed")
+	details := make(map[string]CellValueDetail)
+	for _, cell := range row.Cells {
+		column := sheet.ColumnsById[cell.ColumnId]
+		detail := CellValueDetail{Value: sheet.valueText(cell, column), DisplayValue: cell.DisplayValue, IsFormula: cell.Formula != ""}
+		if cell.Hyperlink != nil {
+			detail.HyperlinkURL = cell.Hyperlink.Target()
+		}
+		if _, found := sheet.checkboxText(cell, column); found || detail.DisplayValue == "" {
+			detail.DisplayValue = detail.Value
+		}
+		details[column.Title] = detail
+	}
+	for colName, column := range sheet.ColumnsByName {
+		if _, found := details[colName]; !found {
+			value := sheet.valueText(Cell{ColumnId: column.Id}, column)
+			details[colName] = CellValueDetail{Value: value, DisplayValue: value}
+		}
+	}
+	debugObj(details)
+	return details
+}
+
 // CellInfo returns pointer to copy of a specific cell in a row.
 // Parm columnName determines which cell in row to return. Must be in sheet.ColumnNames.
 // Parm row is the row containing the cell. It is not required to be in sheet.Rows.
 // Type Cell provides access to all cell attributes, such as formula which is not returned by RowValues().
-// If requested cell does not exist in the row an empty Cell is returned.
-// If columnName is not in sheet.ColumnsByName map, an error is logged and nil is returned.
+// If requested cell does not exist in the row an empty Cell (only ColumnId set) is returned, the same as a
+// cell never containing a value when GetSheetOptions.IncludeNonexistentCells is set.
+// ColumnName is matched using sheet.NameMatcher. If it is not found or is ambiguous, an error is logged and nil is returned.
 func CellInfo(sheet *SheetInfo, row Row, columnName string) *Cell {
-	response := new(Cell)
-	column, found := sheet.ColumnsByName[columnName]
+	column, found, err := sheet.columnNamed(columnName)
+	if err != nil {
+		log.Println("ERROR - CellInfo", err)
+		return nil
+	}
 	if !found {
 		log.Println("ERROR - CellInfo, columnName not found in sheet.ColumnsByName: ", columnName)
 		return nil
 	}
+	response := &Cell{ColumnId: column.Id}
 	for _, cell := range row.Cells { // range returns copy of value
 		if cell.ColumnId == column.Id {
 			response = &cell
@@ -190,11 +335,44 @@ func CellInfo(sheet *SheetInfo, row Row, columnName string) *Cell {
 	return response
 }
 
+// ErrNoWriterInfo is wrapped by the error returned by RowAudit when the row was loaded without IncludeWriterInfo.
+var ErrNoWriterInfo = errors.New("Row Writer Info Not Loaded")
+
+// RowAuditInfo is returned by RowAudit, who created and last modified a row and when.
+type RowAuditInfo struct {
+	RowId      int64
+	CreatedAt  time.Time
+	CreatedBy  WriterInfo
+	ModifiedAt time.Time
+	ModifiedBy WriterInfo
+}
+
+// RowAudit returns the creator and last modifier of a row in sheet (or returned by GetRowWith).
+// The row must be loaded using GetSheetOptions.IncludeWriterInfo (or GetRowOptions.IncludeWriterInfo),
+// otherwise the error wraps ErrNoWriterInfo. Cell level history is available using GetCellHistory.
+func RowAudit(sheet *SheetInfo, row Row) (*RowAuditInfo, error) {
+	if row.CreatedBy == nil || row.ModifiedBy == nil {
+		err := fmt.Errorf("%w - sheet %s, rowId %d, load with IncludeWriterInfo", ErrNoWriterInfo, sheet.SheetName, row.Id)
+		log.Println("ERROR - RowAudit", err)
+		return nil, err
+	}
+	audit := &RowAuditInfo{RowId: row.Id, CreatedBy: *row.CreatedBy, ModifiedBy: *row.ModifiedBy}
+	audit.CreatedAt, _ = time.Parse(time.RFC3339, row.CreatedAt) // zero time if not returned
+	audit.ModifiedAt, _ = time.Parse(time.RFC3339, row.ModifiedAt)
+	return audit, nil
+}
+
 // CopyRows copies specified rows from 1 sheet to bottom of another (RowLocation not supported).
 // Optional CopyOptions indicates what elements, attached to each row, are included.
 // If CopyOptions is nil, only the row cells are copied.
 func CopyRows(fromSheetId int64, rowIds []int64, toSheetId int64, options *CopyOptions) error {
 	trace("CopyRows")
+	_, err := copyRows(fromSheetId, rowIds, toSheetId, options)
+	return err
+}
+
+// copyRows is CopyRows returning the row mappings.
+func copyRows(fromSheetId int64, rowIds []int64, toSheetId int64, options *CopyOptions) ([]RowMapping, error) {
 	var reqData struct {
 		RowIds []int64 `json:"rowIds"`
 		To     struct {
@@ -204,37 +382,19 @@ func CopyRows(fromSheetId int64, rowIds []int64, toSheetId int64, options *CopyO
 	reqData.RowIds = rowIds
 	reqData.To.SheetId = toSheetId
 
-	var urlParms map[string]string
-	if options != nil {
-		ops := make([]string, 0, 3)
-		if options.All {
-			ops = append(ops, "all")
-		} else {
-			if options.Attachments {
-				ops = append(ops, "attachments")
-			}
-			if options.Children {
-				ops = append(ops, "children")
-			}
-			if options.Discussions {
-				ops = append(ops, "discussions")
-			}
-		}
-		if len(ops) > 0 {
-			urlParms = make(map[string]string)
-			urlParms["include"] = strings.Join(ops, ",")
-		}
-	}
+	if options == nil {
+		options = new(CopyOptions)
+	}
+	// All includes the other elements, they are not sent with it
+	urlParms := newQuery().
+		include("all", options.All).
+		include("attachments", options.Attachments && !options.All).
+		include("children", options.Children && !options.All).
+		include("discussions", options.Discussions && !options.All).
+		include("cellLinks", options.CellLinks && !options.All).
+		parms()
 	endPoint := fmt.Sprintf("/sheets/%d/rows/copy", fromSheetId)
-	req := Post(endPoint, reqData, urlParms)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := DoRequest(req)
-	if err != nil {
-		return err
-	}
-	resp.Body.Close()
-	return nil
+	return sendCopyMove(endPoint, reqData, urlParms)
 }
 
 // MoveRows moves specified rows from 1 sheet to another.
@@ -242,6 +402,12 @@ func CopyRows(fromSheetId int64, rowIds []int64, toSheetId int64, options *CopyO
 // If MoveOptions is nil, only the row cells are moved.
 func MoveRows(fromSheetId int64, rowIds []int64, toSheetId int64, options *MoveOptions) error {
 	trace("MoveRows")
+	_, err := moveRows(fromSheetId, rowIds, toSheetId, options)
+	return err
+}
+
+// moveRows is MoveRows returning the row mappings.
+func moveRows(fromSheetId int64, rowIds []int64, toSheetId int64, options *MoveOptions) ([]RowMapping, error) {
 	var reqData struct {
 		RowIds []int64 `json:"rowIds"`
 		To     struct {
@@ -251,46 +417,88 @@ func MoveRows(fromSheetId int64, rowIds []int64, toSheetId int64, options *MoveO
 	reqData.RowIds = rowIds
 	reqData.To.SheetId = toSheetId
 
-	var urlParms map[string]string
-	if options != nil {
-		ops := make([]string, 0, 2)
-		if options.Attachments {
-			ops = append(ops, "attachments")
-		}
-		if options.Discussions {
-			ops = append(ops, "discussions")
-		}
-		if len(ops) > 0 {
-			urlParms = make(map[string]string)
-			urlParms["include"] = strings.Join(ops, ",")
-		}
+	if options == nil {
+		options = new(MoveOptions)
 	}
+	urlParms := newQuery().
+		include("attachments", options.Attachments).
+		include("discussions", options.Discussions).
+		parms()
 	endPoint := fmt.Sprintf("/sheets/%d/rows/move", fromSheetId)
+	return sendCopyMove(endPoint, reqData, urlParms)
+}
+
+// RowMapping is the id of a copied or moved row in the source sheet (From) and destination sheet (To).
+type RowMapping struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// sendCopyMove sends a copy or move rows request and returns the response row mappings.
+func sendCopyMove(endPoint string, reqData interface{}, urlParms map[string]string) ([]RowMapping, error) {
 	req := Post(endPoint, reqData, urlParms)
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := DoRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	resp.Body.Close()
-	return nil
+	defer resp.Body.Close()
+
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	apiResp := struct {
+		DestinationSheetId int64        `json:"destinationSheetId"`
+		RowMappings        []RowMapping `json:"rowMappings"`
+	}{}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR - Copy/Move Rows Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return apiResp.RowMappings, nil
 }
 
-// SetParentId sets parent (indents) specified child rows.
+// ParentError is returned by SetParentIdWith when partial success is allowed and some children were not set,
+// ex. a child row deleted before the request. The other children were set.
+type ParentError struct {
+	FailedChildIds []int64 // in request order
+	Failures       []FailedItem
+}
+
+func (e *ParentError) Error() string {
+	msgs := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		msgs[i] = fmt.Sprintf("rowId %d %s", failure.RowId, failure.Error.Message)
+	}
+	return fmt.Sprintf("SetParentId Failed For %d Child Rows - %s", len(e.FailedChildIds), strings.Join(msgs, "; "))
+}
+
+// SetParentId sets parent (indents) specified child rows and returns the updated rows.
 // Parm parentId is rowId of parent row.
 // If multiple childIds, row ordering not changed.
 // If single childId, optional toBottom can be used. Default location is 1st child of parent.
-func SetParentId(sheet *SheetInfo, parentId int64, childIds []int64, toBottom ...bool) error {
-	trace("SetParentId")
+func SetParentId(sheet *SheetInfo, parentId int64, childIds []int64, toBottom ...bool) (*AddUpdtRowsResponse, error) {
+	options := ParentOptions{ToBottom: len(toBottom) > 0 && toBottom[0]}
+	return SetParentIdWith(sheet, parentId, childIds, &options)
+}
+
+// SetParentIdWith is SetParentId using ParentOptions (see options.go), nil options uses the defaults.
+// If options.AllowPartialSuccess is set and some children fail, the response (rows updated) and a *ParentError are returned.
+// Otherwise a failed child fails the whole request, no children are set.
+func SetParentIdWith(sheet *SheetInfo, parentId int64, childIds []int64, options *ParentOptions) (*AddUpdtRowsResponse, error) {
+	trace("SetParentIdWith")
 
 	if sheet.SheetId == 0 {
 		log.Println("ERROR SetParentId - sheet.SheetId not set")
-		return errors.New("sheet.SheetId empty")
+		return nil, errors.New("sheet.SheetId empty")
 	}
 	if len(childIds) == 0 {
 		log.Println("SetParentId - No ChildIds Specified")
-		return nil
+		return nil, nil
+	}
+	if options == nil {
+		options = new(ParentOptions)
 	}
 	type reqItem struct {
 		Id       int64 `json:"id"`
@@ -302,34 +510,58 @@ func SetParentId(sheet *SheetInfo, parentId int64, childIds []int64, toBottom ..
 	for i, childId := range childIds {
 		reqData[i] = reqItem{Id: childId, ParentId: parentId}
 	}
-	if len(toBottom) > 0 && len(childIds) == 1 && toBottom[0] {
+	if options.ToBottom && len(childIds) == 1 {
 		reqData[0].ToBottom = &IsTrue
 	}
+	urlParms := newQuery().flag("allowPartialSuccess", options.AllowPartialSuccess).parms()
 	endPoint := fmt.Sprintf("/sheets/%d/rows", sheet.SheetId)
-	req := Put(endPoint, reqData, nil)
+	req := Put(endPoint, reqData, urlParms)
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := DoRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	resp.Body.Close()
-	return nil
+	defer resp.Body.Close()
+
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+	apiResp := new(AddUpdtRowsResponse)
+	if err = json.Unmarshal(respJSON, apiResp); err != nil {
+		log.Println("ERROR SetParentId Unmarshal Response Failed", err)
+		return nil, err
+	}
+	if len(apiResp.FailedItems) > 0 {
+		parentErr := &ParentError{Failures: apiResp.FailedItems}
+		for _, failure := range apiResp.FailedItems {
+			childId := failure.RowId
+			if childId == 0 && failure.Index >= 0 && failure.Index < len(childIds) {
+				childId = childIds[failure.Index]
+			}
+			parentErr.FailedChildIds = append(parentErr.FailedChildIds, childId)
+		}
+		log.Println("ERROR SetParentId", parentErr)
+		return apiResp, parentErr
+	}
+	return apiResp, nil
 }
 
-// GetCrossSheetRefs displays cross sheet references for sheet.
-func GetCrossSheetRefs(sheetId int64) error {
+// GetCrossSheetRefs returns all cross sheet references defined in sheet.
+// All pages of the API response are requested.
+func GetCrossSheetRefs(sheetId int64) ([]CrossSheetReferenceInfo, error) {
+	trace("GetCrossSheetRefs")
+	refs := make([]CrossSheetReferenceInfo, 0, 10)
 	endPoint := fmt.Sprintf("/sheets/%d/crosssheetreferences", sheetId)
-	req := Get(endPoint, nil)
-
-	resp, err := DoRequest(req)
-	if err != nil {
+	err := getAllPages(endPoint, nil, func(data json.RawMessage) error {
+		var pageRefs []CrossSheetReferenceInfo
+		err := json.Unmarshal(data, &pageRefs)
+		refs = append(refs, pageRefs...)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
-	respJSON, _ := ioutil.ReadAll(resp.Body)
-	fmt.Println("-- CrossSheetRefs --\n", string(respJSON))
-	return nil
+	return refs, nil
 }
 
 // AttachFileToRow attaches a file to the specified row.
@@ -339,27 +571,8 @@ func GetCrossSheetRefs(sheetId int64) error {
 func AttachFileToRow(sheetId, rowId int64, filePath string) error {
 	trace("AttachFileToRow")
 
-	fileName := filepath.Base(filePath)
-	debugLn("fileName", fileName)
-
-	file, err := os.Open(filePath)
-	if err != nil {
-		log.Println("Attach File Error, Cannot Open File - ", err)
-		return err
-	}
-	defer file.Close()
-
-	fileInfo, _ := file.Stat()
-	fileSize := fmt.Sprintf("%d", fileInfo.Size())
-	debugLn("fileSize", fileSize)
-
-	url := fmt.Sprintf(basePath+"/sheets/%d/rows/%d/attachments", sheetId, rowId)
-	req, _ := http.NewRequest("POST", url, file)
-	req.Header.Set("Content-Type", "") // let Smartsheet figure out from fileName
-	req.Header.Set("Content-Disposition", "attachment; filename="+fileName)
-	req.Header.Set("Content-Length", fileSize)
-
-	resp, err := DoRequest(req)
+	endPoint := fmt.Sprintf("/sheets/%d/rows/%d/attachments", sheetId, rowId)
+	resp, err := uploadFile(endPoint, filePath)
 	if err != nil {
 		return err
 	}
@@ -367,11 +580,35 @@ func AttachFileToRow(sheetId, rowId int64, filePath string) error {
 	return nil
 }
 
-// AttachUrlToRow attaches a url link to a row.
+// AttachUrlToRow attaches a url link to a row and returns the created attachment.
 // Parm attachmentName is a reference name for user.
-// Parm attachmentType uses one of the following constants: LINK,BOX,DROPBOX,EVERNOTE,GOOGLEDRIVE,ONEDRIVE
-func AttachUrlToRow(sheetId, rowId int64, attachmentName, attachmentType, linkUrl string) error {
+// Parm attachmentType uses one of the following constants: LINK,BOX,DROPBOX,EGNYTE,EVERNOTE,GOOGLEDRIVE,ONEDRIVE
+// Parm linkUrl must be an absolute http or https url.
+func AttachUrlToRow(sheetId, rowId int64, attachmentName, attachmentType, linkUrl string) (*Attachment, error) {
 	trace("AttachUrlToRow")
+	endPoint := fmt.Sprintf("/sheets/%d/rows/%d/attachments", sheetId, rowId)
+	return attachUrl("AttachUrlToRow", endPoint, attachmentName, attachmentType, linkUrl)
+}
+
+// AttachUrlToSheet attaches a url link to a sheet (not to a row) and returns the created attachment.
+// Parms are the same as AttachUrlToRow.
+func AttachUrlToSheet(sheetId int64, attachmentName, attachmentType, linkUrl string) (*Attachment, error) {
+	trace("AttachUrlToSheet")
+	endPoint := fmt.Sprintf("/sheets/%d/attachments", sheetId)
+	return attachUrl("AttachUrlToSheet", endPoint, attachmentName, attachmentType, linkUrl)
+}
+
+// attachUrl validates attachmentType and linkUrl, then posts the url attachment to endPoint.
+func attachUrl(funcName, endPoint, attachmentName, attachmentType, linkUrl string) (*Attachment, error) {
+	if !validAttachmentType(attachmentType) {
+		log.Println("ERROR", funcName, "invalid attachment type", attachmentType)
+		return nil, errors.New("Invalid Attachment Type - " + attachmentType + ", use LINK,BOX,DROPBOX,EGNYTE,EVERNOTE,GOOGLEDRIVE,ONEDRIVE")
+	}
+	parsedUrl, err := url.Parse(linkUrl)
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		log.Println("ERROR", funcName, "invalid url", linkUrl, err)
+		return nil, errors.New("Invalid Attachment Url - must be an absolute http or https url, " + linkUrl)
+	}
 
 	var reqData struct {
 		Name           string `json:"name"`
@@ -382,16 +619,35 @@ func AttachUrlToRow(sheetId, rowId int64, attachmentName, attachmentType, linkUr
 	reqData.AttachmentType = attachmentType
 	reqData.Url = linkUrl
 
-	endPoint := fmt.Sprintf("/sheets/%d/rows/%d/attachments", sheetId, rowId)
 	req := Post(endPoint, reqData, nil)
-	req.Header.Set("Content-Type", "application/json") // let Smartsheet figure out from fileName
+	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := DoRequest(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	resp.Body.Close()
-	return nil
+	defer resp.Body.Close()
+
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+	var apiResp struct {
+		Message    string     `json:"message"`
+		ResultCode int        `json:"resultCode"`
+		Result     Attachment `json:"result"`
+	}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR", funcName, "Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return &apiResp.Result, nil
+}
+
+func validAttachmentType(attachmentType string) bool {
+	switch attachmentType {
+	case LINK, BOX, DROPBOX, EGNYTE, EVERNOTE, GOOGLEDRIVE, ONEDRIVE:
+		return true
+	}
+	return false
 }
 
 func trace(stepName string) {
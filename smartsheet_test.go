@@ -1,10 +1,20 @@
 package smartsheet
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func Test_Smartsheet(t *testing.T) {
@@ -13,30 +23,30 @@ func Test_Smartsheet(t *testing.T) {
 
 	var err error
 
-	tkn, _ := ioutil.ReadFile("token.txt")
-	Token = strings.TrimSpace(string(tkn))
+	liveToken(t)
 
 	TraceOn = true
 	DebugOn = true
+	tempDir := t.TempDir()
 
 	// === CREATE SHEET FILE ===========================================
-	err = GetSheetAs(sheet1Id, "/home/jay/sheets/sheet1.xlsx", EXCEL)
+	_, err = GetSheetAs(sheet1Id, filepath.Join(tempDir, "sheet1.xlsx"), EXCEL)
 	if err != nil {
 		t.Fatal("Test_Smartsheet GetSheetRows Failed", err)
 	}
-	err = GetSheetAs(sheet1Id, "/home/jay/sheets/sheet1.pdf", PDF, "WIDE")
+	_, err = GetSheetAs(sheet1Id, filepath.Join(tempDir, "sheet1.pdf"), PDF, PaperSizeWide)
 	if err != nil {
 		t.Fatal("Test_Smartsheet GetSheetRows Failed", err)
 	}
-	err = GetSheetAs(sheet1Id, "/home/jay/sheets/sheet1.csv", CSV)
+	_, err = GetSheetAs(sheet1Id, filepath.Join(tempDir, "sheet1.csv"), CSV)
 	if err != nil {
 		t.Fatal("Test_Smartsheet GetSheetRows Failed", err)
 	}
 	// === CELLINFO ===========================================
 	sheet1 := new(SheetInfo)
-	//sheet1.Load(sheet1Id, nil)
-	//sheet1.Store("/home/jay/sheets/sheet1.json")
-	sheet1.Restore("/home/jay/sheets/sheet1.json")
+	if err = sheet1.Load(sheet1Id, nil); err != nil {
+		t.Fatal("Test_Smartsheet Load Failed", err)
+	}
 
 	var orderNoCell *Cell
 	colName := "Hyperlink"
@@ -63,14 +73,377 @@ func Test_Smartsheet(t *testing.T) {
 	}
 
 	// === ATTACH FILE TO ROW =================================
-	err = AttachFileToRow(sheet1Id, 6840477608372100, "/home/jay/test/attachment.txt")
+	attachmentPath := filepath.Join(tempDir, "attachment.txt")
+	ioutil.WriteFile(attachmentPath, []byte("attachment\n"), 0600)
+	err = AttachFileToRow(sheet1Id, 6840477608372100, attachmentPath)
 	if err != nil {
 		t.Fatal("Test_Smartsheet AttachFileToRow Failed", err)
 	}
 
 	// === ATTACH URL TO ROW ==================================
-	err = AttachUrlToRow(sheet1Id, 6840477608372100, "parrot.jpeg", LINK, "https://unsplash.com/photos/QxHJ9lkXYNk")
+	_, err = AttachUrlToRow(sheet1Id, 6840477608372100, "parrot.jpeg", LINK, "https://unsplash.com/photos/QxHJ9lkXYNk")
 	if err != nil {
 		t.Fatal("Test_Smartsheet AttachFileToRow Failed", err)
 	}
 }
+
+// liveToken sets Token from token.txt for the tests run against the author's sheets, skipping the test if it is
+// missing. See Test_Integration, which creates its own sheet.
+func liveToken(t *testing.T) {
+	t.Helper()
+	tkn, err := ioutil.ReadFile("token.txt")
+	if err != nil {
+		t.Skip("token.txt not found, live test skipped")
+	}
+	Token = strings.TrimSpace(string(tkn))
+}
+
+// stubServer starts a local http server that passes each request to handler.
+// While the test runs, basePath points to the server and RequestDelay is 0.
+func stubServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	server := httptest.NewServer(handler)
+	savePath, saveDelay, saveToken := basePath, RequestDelay, Token
+	basePath, RequestDelay = server.URL, 0
+	if Token == "" {
+		Token = "Bearer stub-token-0123456789abcdef" // passes CheckToken
+	}
+	t.Cleanup(func() {
+		basePath, RequestDelay, Token = savePath, saveDelay, saveToken
+		throttle.next = time.Time{} // test may have reserved slots using its own RequestDelay
+		server.Close()
+	})
+	return server
+}
+
+// compactJSON removes insignificant space from jsonData, used to compare request bodies.
+func compactJSON(jsonData []byte) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, jsonData); err != nil {
+		return string(jsonData)
+	}
+	return buf.String()
+}
+
+func Test_GetSheetMeta(t *testing.T) {
+	var query url.Values
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"id":1849449510135684,"name":"Test1","version":42,"totalRowCount":318,
+			"permalink":"https://app.smartsheet.com/sheets/abc","createdAt":"2023-05-01T14:00:00Z","modifiedAt":"2024-03-02T09:15:30Z",
+			"owner":"jay@test.com","ownerId":2331373580117892,"columns":[],"rows":[]}`))
+	})
+
+	sheet := testSheet()
+	if err := sheet.RefreshMeta(); err != nil {
+		t.Fatal("RefreshMeta Failed", err)
+	}
+	for key, val := range map[string]string{"rowIds": "0", "columnIds": "0", "include": "ownerInfo"} {
+		if query.Get(key) != val {
+			t.Errorf("GetSheetMeta query %s Expecting %s, Got %s", key, val, query.Get(key))
+		}
+	}
+	meta := sheet.Meta
+	modifiedAt := time.Date(2024, 3, 2, 9, 15, 30, 0, time.UTC)
+	if meta.Name != "Test1" || meta.Version != 42 || meta.TotalRowCount != 318 || meta.OwnerId != 2331373580117892 || !meta.ModifiedAt.Equal(modifiedAt) {
+		t.Errorf("GetSheetMeta wrong decode %+v", meta)
+	}
+}
+
+func Test_GetSheetQuery(t *testing.T) {
+	var query string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query, _ = url.QueryUnescape(r.URL.RawQuery)
+		w.Write([]byte(`{"id":1849449510135684,"name":"Test1","source":{"id":8094487248430980,"type":"template"},
+			"rows":[{"id":11,"permalink":"https://app.smartsheet.com/sheets/abc?rowId=11","discussions":[{"id":5,"title":"Late","commentCount":2}]}]}`))
+	})
+	tests := []struct {
+		options *GetSheetOptions
+		expect  string
+	}{
+		{nil, "exclude=nonexistentCells"},
+		{&GetSheetOptions{IncludeNonexistentCells: true}, ""},
+		{&GetSheetOptions{IncludeNonexistentCells: true, FilterId: 77, ExcludeFilteredOutRows: true}, "exclude=filteredOutRows&filterId=77"},
+		{&GetSheetOptions{RowIds: []int64{11, 12}, ColumnIds: []int64{101}},
+			"columnIds=101&exclude=nonexistentCells&rowIds=11,12"},
+		{&GetSheetOptions{RowNumbers: []int{10, 11, 25}, ColumnIds: []int64{101, 105}},
+			"columnIds=101,105&exclude=nonexistentCells&rowNumbers=10,11,25"},
+		{&GetSheetOptions{IncludeOwnerInfo: true, IncludeAttachments: true, IncludeDiscussions: true},
+			"exclude=nonexistentCells&include=ownerInfo,attachments,discussions"},
+		{&GetSheetOptions{IncludeRowPermalink: true, IncludeSource: true, IncludeCrossSheetReferences: true, FilterId: 77, ExcludeFilteredOutRows: true},
+			"exclude=nonexistentCells,filteredOutRows&filterId=77&include=rowPermalink,source,crossSheetReferences"},
+		{&GetSheetOptions{IncludeSource: true, Extra: map[string]string{"include": "rowWriterInfo", "exclude": "linkInFromCellDetails", "level": "2"}},
+			"exclude=nonexistentCells,linkInFromCellDetails&include=source,rowWriterInfo&level=2"},
+	}
+	for _, test := range tests {
+		sheet, err := GetSheet(1849449510135684, test.options)
+		if err != nil {
+			t.Fatal("GetSheet Failed", err)
+		}
+		if query != test.expect {
+			t.Errorf("GetSheet query, Expecting %s, Got %s", test.expect, query)
+		}
+		if sheet.Source == nil || sheet.Source.Id != 8094487248430980 || sheet.Source.Type != "template" {
+			t.Errorf("GetSheet wrong source %+v", sheet.Source)
+		}
+		row := sheet.Rows[0]
+		if row.Permalink == "" || len(row.Discussions) != 1 || row.Discussions[0].CommentCount != 2 {
+			t.Errorf("GetSheet wrong row %+v", row)
+		}
+	}
+}
+
+func Test_GetSheetAs(t *testing.T) {
+	var accept, query string
+	requests := 0
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		accept, query = r.Header.Get("Accept"), r.URL.RawQuery
+		w.Write([]byte("exported"))
+	})
+	filePath := filepath.Join(t.TempDir(), "sheet1")
+
+	tests := []struct {
+		format      string
+		paperSize   []string
+		expectQuery string
+		expect      string // Accept header
+	}{
+		{CSV, nil, "", "text/csv"},
+		{EXCEL, nil, "", "application/vnd.ms-excel"},
+		{PDF, nil, "", "application/pdf"},
+		{PDF, []string{PaperSizeA4}, "paperSize=A4", "application/pdf"},
+	}
+	for _, test := range tests {
+		written, err := GetSheetAs(1849449510135684, filePath, test.format, test.paperSize...)
+		if err != nil {
+			t.Fatal("GetSheetAs Failed", test.format, err)
+		}
+		if accept != test.expect || query != test.expectQuery || written != int64(len("exported")) {
+			t.Errorf("GetSheetAs %s, Expecting %s %q 8 bytes, Got %s %q %d bytes", test.format, test.expect, test.expectQuery, accept, query, written)
+		}
+	}
+
+	requests = 0
+	os.Remove(filePath)
+	invalid := []struct {
+		format    string
+		paperSize []string
+		expect    string
+	}{
+		{"xlsx", nil, "Invalid Format - xlsx"},
+		{"googlesheet", nil, "Invalid Format - googlesheet"}, // not an export format of the api
+		{PDF, []string{"A4_WIDE"}, "Invalid PaperSize - A4_WIDE, use one of LETTER, LEGAL, WIDE, ARCHD, A4, A3, A2, A1, A0"},
+		{CSV, []string{PaperSizeA4}, "Invalid PaperSize - A4 only allowed with format pdf, not csv"},
+		{PDF, []string{PaperSizeA4, PaperSizeA3}, "Invalid PaperSize - 1 value allowed, got [A4 A3]"},
+	}
+	for _, test := range invalid {
+		written, err := GetSheetAs(1849449510135684, filePath, test.format, test.paperSize...)
+		if err == nil || err.Error() != test.expect || written != 0 {
+			t.Errorf("GetSheetAs invalid, Expecting %s, Got %v %d", test.expect, err, written)
+		}
+	}
+	if _, err := os.Stat(filePath); requests != 0 || !os.IsNotExist(err) {
+		t.Error("GetSheetAs invalid expected no request and no file, got", requests, err)
+	}
+}
+
+// Test_NonexistentCells loads testdata/sheet_cells.json (cells never having a value excluded) and
+// testdata/sheet_cells_nonexistent.json (same sheet, empty cells returned), RowValues and CellInfo must match.
+func Test_NonexistentCells(t *testing.T) {
+	excludedJSON, err := ioutil.ReadFile("testdata/sheet_cells.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	includedJSON, err := ioutil.ReadFile("testdata/sheet_cells_nonexistent.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path+"?"+r.URL.RawQuery)
+		sheetJSON := excludedJSON
+		if r.URL.Query().Get("exclude") == "" {
+			sheetJSON = includedJSON
+		}
+		if strings.Contains(r.URL.Path, "/rows/") { // GetRow, return row 12
+			var sheet Sheet
+			json.Unmarshal(sheetJSON, &sheet)
+			sheetJSON, _ = json.Marshal(sheet.Rows[1])
+		}
+		w.Write(sheetJSON)
+	})
+
+	excluded, included := new(SheetInfo), new(SheetInfo)
+	if err = excluded.Load(1849449510135684, nil); err != nil {
+		t.Fatal("Load Failed", err)
+	}
+	if err = included.Load(1849449510135684, &GetSheetOptions{IncludeNonexistentCells: true}); err != nil {
+		t.Fatal("Load IncludeNonexistentCells Failed", err)
+	}
+	if len(excluded.Rows[1].Cells) != 1 || len(included.Rows[1].Cells) != 4 {
+		t.Fatalf("fixtures wrong cell count %d %d", len(excluded.Rows[1].Cells), len(included.Rows[1].Cells))
+	}
+	for i := range excluded.Rows {
+		excludedValues := fmt.Sprint(RowValues(excluded, excluded.Rows[i]))
+		includedValues := fmt.Sprint(RowValues(included, included.Rows[i]))
+		if excludedValues != includedValues {
+			t.Errorf("RowValues row %d differ, excluded %s, included %s", i, excludedValues, includedValues)
+		}
+		for colName := range excluded.ColumnsByName {
+			excludedCell := CellInfo(excluded, excluded.Rows[i], colName)
+			includedCell := CellInfo(included, included.Rows[i], colName)
+			if !reflect.DeepEqual(excludedCell, includedCell) {
+				t.Errorf("CellInfo row %d %s differ, excluded %+v, included %+v", i, colName, *excludedCell, *includedCell)
+			}
+		}
+	}
+	if values := RowValues(excluded, excluded.Rows[1]); values["Flagged"] != "false" || values["OrderNo"] != "" {
+		t.Errorf("RowValues wrong empty values %v", values)
+	}
+	if cell := CellInfo(excluded, excluded.Rows[1], "OrderNo"); cell.ColumnId != 102 || cell.Value != nil {
+		t.Errorf("CellInfo missing cell wrong result %+v", *cell)
+	}
+
+	paths = nil
+	row, err := GetRowWith(1849449510135684, 12, &GetRowOptions{IncludeNonexistentCells: true})
+	if err != nil || len(row.Cells) != 4 {
+		t.Errorf("GetRowWith wrong result %+v %v", row, err)
+	}
+	if row, err = GetRow(1849449510135684, 12); err != nil || len(row.Cells) != 1 {
+		t.Errorf("GetRow wrong result %+v %v", row, err)
+	}
+	expect := "[/sheets/1849449510135684/rows/12? /sheets/1849449510135684/rows/12?exclude=nonexistentCells]"
+	if fmt.Sprint(paths) != expect {
+		t.Errorf("GetRow Expecting %s, Got %s", expect, paths)
+	}
+}
+
+// Test_SetParentIdPartial simulates 1 failed child (deleted before the request) among 3.
+func Test_SetParentIdPartial(t *testing.T) {
+	const deletedId = 1002
+	var query, body string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		if r.Method == "POST" { // UploadNewRows, added rows are given id 1000 + index
+			var items []struct{ Cells []Cell }
+			json.Unmarshal(reqBytes, &items)
+			rows := make([]Row, len(items))
+			for i, item := range items {
+				rows[i] = Row{Id: int64(1000 + i), Cells: item.Cells}
+			}
+			result, _ := json.Marshal(rows)
+			fmt.Fprintf(w, `{"message":"SUCCESS","resultCode":0,"result":%s}`, result)
+			return
+		}
+		query, body = r.URL.RawQuery, compactJSON(reqBytes)
+		var items []struct{ Id, ParentId int64 }
+		json.Unmarshal(reqBytes, &items)
+		rows := make([]Row, 0, len(items))
+		failed := make([]string, 0, 1)
+		for i, item := range items {
+			if item.Id == deletedId {
+				failed = append(failed, fmt.Sprintf(`{"index":%d,"rowId":%d,"error":{"errorCode":1006,"message":"Not Found"}}`, i, item.Id))
+				continue
+			}
+			rows = append(rows, Row{Id: item.Id, ParentId: item.ParentId})
+		}
+		if len(failed) > 0 && query == "" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorCode":1006,"message":"Not Found"}`))
+			return
+		}
+		result, _ := json.Marshal(rows)
+		resultCode := 0
+		if len(failed) > 0 {
+			resultCode = 3
+		}
+		fmt.Fprintf(w, `{"message":"PARTIAL_SUCCESS","resultCode":%d,"result":%s,"failedItems":[%s]}`, resultCode, result, strings.Join(failed, ","))
+	})
+	sheet := testSheet()
+
+	resp, err := SetParentId(sheet, 1000, []int64{1001}, true)
+	if err != nil || len(resp.Result) != 1 || resp.Result[0].ParentId != 1000 {
+		t.Fatalf("SetParentId wrong result %+v %v", resp, err)
+	}
+	if query != "" || body != `[{"id":1001,"parentId":1000,"toBottom":true}]` {
+		t.Errorf("SetParentId wrong request %s %s", query, body)
+	}
+
+	resp, err = SetParentIdWith(sheet, 1000, []int64{1001, deletedId, 1003}, &ParentOptions{AllowPartialSuccess: true})
+	parentErr, ok := err.(*ParentError)
+	if !ok || len(parentErr.FailedChildIds) != 1 || parentErr.FailedChildIds[0] != deletedId || parentErr.Failures[0].Index != 1 {
+		t.Fatal("SetParentIdWith expected ParentError for child", deletedId, "got", err)
+	}
+	if len(resp.Result) != 2 || resp.ResultCode != 3 || resp.Result[1].Id != 1003 {
+		t.Errorf("SetParentIdWith wrong partial result %+v", resp)
+	}
+
+	if _, err = SetParentId(sheet, 1000, []int64{1001, deletedId, 1003}); err == nil {
+		t.Error("SetParentId without partial success expected error")
+	} else if _, ok = err.(*ApiError); !ok {
+		t.Error("SetParentId expected *ApiError, got", err)
+	}
+
+	for _, level := range []string{"0", "1", "1", "1", "0", "1"} { // rows 1000-1005, parents 1000 and 1004
+		sheet.AddRow(Row{Cells: []Cell{{ColName: "Level", Value: level}}})
+	}
+	response, err := sheet.UploadNewRows(nil, "Level")
+	if parentErr, ok = err.(*ParentError); !ok || len(parentErr.FailedChildIds) != 1 || parentErr.FailedChildIds[0] != deletedId {
+		t.Fatal("UploadNewRows expected ParentError for child", deletedId, "got", err)
+	}
+	if !strings.Contains(err.Error(), "rowId 1002 Not Found") || len(response.Result) != 6 {
+		t.Errorf("UploadNewRows wrong result %d %v", len(response.Result), err)
+	}
+	if body != `[{"id":1005,"parentId":1004}]` {
+		t.Error("UploadNewRows expected 2nd parent to be set after failure, last request", body)
+	}
+}
+
+func Test_RowAudit(t *testing.T) {
+	sheetJSON, err := ioutil.ReadFile("testdata/sheet_writerinfo.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var includes []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		includes = append(includes, r.URL.Query().Get("include"))
+		responseJSON := sheetJSON
+		if strings.Contains(r.URL.Path, "/rows/") { // GetRowWith, return row 11
+			var sheet Sheet
+			json.Unmarshal(sheetJSON, &sheet)
+			responseJSON, _ = json.Marshal(sheet.Rows[0])
+		}
+		w.Write(responseJSON)
+	})
+
+	sheet := new(SheetInfo)
+	if err = sheet.Load(1849449510135684, &GetSheetOptions{IncludeWriterInfo: true}); err != nil {
+		t.Fatal("Load Failed", err)
+	}
+	audit, err := RowAudit(sheet, sheet.Rows[0])
+	if err != nil {
+		t.Fatal("RowAudit Failed", err)
+	}
+	expect := RowAuditInfo{RowId: 11,
+		CreatedAt: time.Date(2020, 11, 2, 8, 15, 0, 0, time.UTC), CreatedBy: WriterInfo{"ann@example.com", "Ann Lee"},
+		ModifiedAt: time.Date(2020, 11, 20, 16, 40, 12, 0, time.UTC), ModifiedBy: WriterInfo{"bob@example.com", "Bob Cruz"}}
+	if *audit != expect {
+		t.Errorf("RowAudit, Expecting %+v, Got %+v", expect, *audit)
+	}
+
+	row, err := GetRowWith(1849449510135684, 11, &GetRowOptions{IncludeWriterInfo: true})
+	if err != nil {
+		t.Fatal("GetRowWith Failed", err)
+	}
+	if audit, err = RowAudit(sheet, *row); err != nil || *audit != expect {
+		t.Errorf("RowAudit of GetRowWith row, Expecting %+v, Got %+v %v", expect, audit, err)
+	}
+	if strings.Join(includes, " ") != "writerInfo rowWriterInfo" {
+		t.Error("IncludeWriterInfo wrong include parameters", includes)
+	}
+
+	sheet.Rows[1].ModifiedBy = nil // as loaded without IncludeWriterInfo
+	if _, err = RowAudit(sheet, sheet.Rows[1]); !errors.Is(err, ErrNoWriterInfo) {
+		t.Error("RowAudit expected ErrNoWriterInfo, got", err)
+	}
+}
@@ -0,0 +1,177 @@
+// snapshot.go contains SheetInfo Store and Restore. Snapshots are versioned, Restore migrates snapshots written by
+// older versions of this package and checks the restored sheet is consistent before using it.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sort"
+)
+
+// SnapshotVersion is the version of the snapshots written by Store.
+// Snapshots written before versioning have no version and are treated as version 0.
+const SnapshotVersion = 1
+
+// ErrSnapshotTooOld is wrapped by the error returned by Restore for a snapshot version that cannot be migrated.
+var ErrSnapshotTooOld = errors.New("Snapshot Version Too Old")
+
+// ErrSnapshotTooNew is wrapped by the error returned by Restore for a snapshot written by a newer version of this package.
+var ErrSnapshotTooNew = errors.New("Snapshot Version Too New")
+
+// ErrInvalidSnapshot is wrapped by the error returned by Restore when the snapshot is not valid json,
+// has no SheetId or its columns are not consistent.
+var ErrInvalidSnapshot = errors.New("Invalid Snapshot")
+
+// snapshot is the json written by Store, the SheetInfo fields plus the version and the column list.
+// Restore rebuilds the column maps from Columns, the maps are written for readability and older versions of Restore.
+type snapshot struct {
+	SnapshotVersion int      `json:"snapshotVersion"`
+	Columns         []Column `json:"columns"` // in index order
+	*SheetInfo
+}
+
+// snapshotMigrations converts the json of a snapshot version to the next version, keyed by the version converted.
+// The json is changed before it is decoded, so a migration can handle fields whose type changed.
+var snapshotMigrations = map[int]func(fields map[string]json.RawMessage) error{
+	0: migrateSnapshotV0,
+}
+
+// migrateSnapshotV0 sets TotalRowCount, not in snapshots stored before it was added, to the number of stored rows.
+// Without it IsComplete returns false for every restored sheet.
+func migrateSnapshotV0(fields map[string]json.RawMessage) error {
+	if _, found := fields["TotalRowCount"]; found {
+		return nil
+	}
+	var rows []json.RawMessage
+	if raw, found := fields["Rows"]; found {
+		if err := json.Unmarshal(raw, &rows); err != nil {
+			return err
+		}
+	}
+	fields["TotalRowCount"] = json.RawMessage(fmt.Sprint(len(rows)))
+	return nil
+}
+
+// Store saves SheetInfo instance as json file in indented (readable) format, see Restore.
+func (she *SheetInfo) Store(filePath string) error {
+	jsonData, err := json.MarshalIndent(snapshot{SnapshotVersion, she.columnList(), she}, "", "  ")
+	if err != nil {
+		log.Println("ERROR - Store Failed", err)
+		return err
+	}
+	err = ioutil.WriteFile(filePath, jsonData, 0644)
+	return err
+}
+
+// Restore loads SheetInfo instance from json file created by Store method.
+// Snapshots of older versions are migrated, a snapshot written by a newer version returns ErrSnapshotTooNew.
+// The column maps are rebuilt from the stored column list (for unversioned snapshots, from ColumnsById after checking
+// the 3 maps agree). SheetInfo is only changed if the snapshot is valid.
+func (she *SheetInfo) Restore(filePath string) error {
+	jsonData, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		log.Println("ERROR - Restore Failed", err)
+		return err
+	}
+	restored, err := decodeSnapshot(jsonData)
+	if err != nil {
+		log.Println("ERROR - Restore Failed", filePath, err)
+		return err
+	}
+	*she = *restored
+	return nil
+}
+
+// decodeSnapshot migrates, decodes and validates the json of a snapshot.
+func decodeSnapshot(jsonData []byte) (*SheetInfo, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(jsonData, &fields); err != nil {
+		return nil, fmt.Errorf("%w - %v", ErrInvalidSnapshot, err)
+	}
+	version := 0
+	if raw, found := fields["snapshotVersion"]; found {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, fmt.Errorf("%w - snapshotVersion %v", ErrInvalidSnapshot, err)
+		}
+	}
+	if version > SnapshotVersion {
+		return nil, fmt.Errorf("%w - version %d, this package reads up to %d", ErrSnapshotTooNew, version, SnapshotVersion)
+	}
+	for ; version < SnapshotVersion; version++ {
+		migrate, found := snapshotMigrations[version]
+		if !found {
+			return nil, fmt.Errorf("%w - version %d, oldest supported is 0", ErrSnapshotTooOld, version)
+		}
+		if err := migrate(fields); err != nil {
+			return nil, fmt.Errorf("%w - migrate version %d: %v", ErrInvalidSnapshot, version, err)
+		}
+	}
+	migrated, _ := json.Marshal(fields)
+
+	sheet := new(SheetInfo)
+	snap := snapshot{SheetInfo: sheet}
+	if err := json.Unmarshal(migrated, &snap); err != nil {
+		return nil, fmt.Errorf("%w - %v", ErrInvalidSnapshot, err)
+	}
+	if sheet.SheetId == 0 {
+		return nil, fmt.Errorf("%w - SheetId is 0", ErrInvalidSnapshot)
+	}
+	columns := snap.Columns
+	if _, found := fields["columns"]; !found {
+		var err error
+		if columns, err = sheet.mapColumns(); err != nil {
+			return nil, fmt.Errorf("%w - %v", ErrInvalidSnapshot, err)
+		}
+	}
+	if err := checkColumnList(columns); err != nil {
+		return nil, fmt.Errorf("%w - %v", ErrInvalidSnapshot, err)
+	}
+	sheet.setColumns(columns)
+	return sheet, nil
+}
+
+// mapColumns returns the columns of ColumnsById in index order, if ColumnsByName and ColumnsByIndex contain the same columns.
+func (she *SheetInfo) mapColumns() ([]Column, error) {
+	if len(she.ColumnsByName) != len(she.ColumnsById) || len(she.ColumnsByIndex) != len(she.ColumnsById) {
+		return nil, fmt.Errorf("column map sizes differ, ById %d, ByName %d, ByIndex %d",
+			len(she.ColumnsById), len(she.ColumnsByName), len(she.ColumnsByIndex))
+	}
+	columns := make([]Column, 0, len(she.ColumnsById))
+	for id, column := range she.ColumnsById {
+		if column.Id != id {
+			return nil, fmt.Errorf("ColumnsById key %d contains column %d", id, column.Id)
+		}
+		if she.ColumnsByName[column.Title].Id != id {
+			return nil, fmt.Errorf("ColumnsByName %s is not column %d", column.Title, id)
+		}
+		if she.ColumnsByIndex[column.Index].Id != id {
+			return nil, fmt.Errorf("ColumnsByIndex %d is not column %d", column.Index, id)
+		}
+		columns = append(columns, column)
+	}
+	sort.Slice(columns, func(i, j int) bool { return columns[i].Index < columns[j].Index })
+	return columns, nil
+}
+
+// checkColumnList returns an error if 2 columns have the same id or title. Columns with the same index are accepted,
+// Load accepts them with a warning (WarnColumnIndex) and the stored list keeps their order.
+func checkColumnList(columns []Column) error {
+	ids := make(map[int64]bool, len(columns))
+	titles := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		switch {
+		case column.Id == 0:
+			return fmt.Errorf("column %s has no id", column.Title)
+		case ids[column.Id]:
+			return fmt.Errorf("duplicate column id %d", column.Id)
+		case titles[column.Title]:
+			return fmt.Errorf("duplicate column title %s", column.Title)
+		}
+		ids[column.Id], titles[column.Title] = true, true
+	}
+	return nil
+}
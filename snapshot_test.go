@@ -0,0 +1,114 @@
+package smartsheet
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_Snapshot(t *testing.T) {
+	dir := t.TempDir()
+
+	// store and restore current version
+	sheet := testSheet()
+	sheet.Rows = []Row{{Id: 1, Cells: []Cell{{ColumnId: 101, Value: "1 Main"}}}}
+	sheet.TotalRowCount = 5
+	sheet.VerifyColumns = true
+	filePath := filepath.Join(dir, "sheet.json")
+	if err := sheet.Store(filePath); err != nil {
+		t.Fatal("Store Failed", err)
+	}
+	stored, _ := ioutil.ReadFile(filePath)
+	if !strings.Contains(string(stored), `"snapshotVersion": 1`) {
+		t.Error("Store expected snapshotVersion written")
+	}
+	restored := new(SheetInfo)
+	if err := restored.Restore(filePath); err != nil {
+		t.Fatal("Restore Failed", err)
+	}
+	if !restored.MatchSheet(sheet) || restored.TotalRowCount != 5 || len(restored.Rows) != 1 || !restored.VerifyColumns ||
+		restored.ColumnsByIndex[8].Title != "Hyperlink" {
+		t.Error("Restore expected the stored sheet, got", restored.SheetName, restored.TotalRowCount, len(restored.Rows))
+	}
+
+	// unversioned snapshot, TotalRowCount set by migration
+	old := new(SheetInfo)
+	if err := old.Restore("testdata/snapshot_v0.json"); err != nil {
+		t.Fatal("Restore v0 Failed", err)
+	}
+	if old.TotalRowCount != 2 || !old.IsComplete() || old.ColumnsByName["DueDate"].Id != 103 || len(old.ColumnsByIndex) != 3 {
+		t.Error("Restore v0 expected TotalRowCount 2 and 3 columns, got", old.TotalRowCount, len(old.ColumnsByIndex))
+	}
+
+	// column list is canonical, stale maps are rebuilt
+	stale := strings.Replace(string(stored), `"Hyperlink": {`, `"Link": {`, 1)
+	writeSnapshot := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		ioutil.WriteFile(path, []byte(content), 0644)
+		return path
+	}
+	if err := restored.Restore(writeSnapshot("stale.json", stale)); err != nil || restored.ColumnsByName["Hyperlink"].Id != 109 {
+		t.Error("Restore expected column maps rebuilt from column list, got", err)
+	}
+
+	tests := []struct {
+		name, content string
+		expected      error
+		contains      string
+	}{
+		{"corrupt maps", "", ErrInvalidSnapshot, "ColumnsByIndex 1 is not column 102"},
+		{"too new", `{"snapshotVersion":2,"SheetId":1}`, ErrSnapshotTooNew, "version 2"},
+		{"too old", `{"snapshotVersion":-1,"SheetId":1}`, ErrSnapshotTooOld, "version -1"},
+		{"no sheet id", `{"snapshotVersion":1,"SheetName":"x","columns":[]}`, ErrInvalidSnapshot, "SheetId is 0"},
+		{"truncated", `{"snapshotVersion":1,"SheetId":1,"Rows":[{"id":`, ErrInvalidSnapshot, ""},
+		{"wrong type", `{"snapshotVersion":1,"SheetId":"abc"}`, ErrInvalidSnapshot, ""},
+		{"duplicate column", `{"snapshotVersion":1,"SheetId":1,"columns":[{"id":1,"index":0,"title":"A"},{"id":2,"index":1,"title":"A"}]}`,
+			ErrInvalidSnapshot, "duplicate column title A"},
+	}
+	for _, test := range tests {
+		path := "testdata/snapshot_corrupt.json"
+		if test.content != "" {
+			path = writeSnapshot("test.json", test.content)
+		}
+		sheet := &SheetInfo{SheetId: 99}
+		err := sheet.Restore(path)
+		if !errors.Is(err, test.expected) || !strings.Contains(err.Error(), test.contains) {
+			t.Errorf("%s, Expecting %v %s, Got %v", test.name, test.expected, test.contains, err)
+		}
+		if sheet.SheetId != 99 {
+			t.Error(test.name, "expected SheetInfo unchanged after failed Restore")
+		}
+	}
+}
+
+func Test_SnapshotColumnIndex(t *testing.T) {
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1849449510135684,"name":"Test1","columns":[{"id":101,"index":0,"title":"Address","primary":true},` +
+			`{"id":103,"index":1,"title":"DueDate"},{"id":102,"index":1,"title":"OrderNo"},{"id":104,"index":2,"title":"Util"}],"rows":[]}`))
+	})
+	sheet := new(SheetInfo)
+	if err := sheet.Load(Test1Id, nil); err != nil {
+		t.Fatal("Load Failed", err)
+	}
+	if len(sheet.Warnings) != 1 || sheet.Warnings[0].Code != WarnColumnIndex {
+		t.Fatal("Load expected COLUMN_INDEX warning, got", sheet.Warnings)
+	}
+	filePath := filepath.Join(t.TempDir(), "sheet.json")
+	if err := sheet.Store(filePath); err != nil {
+		t.Fatal("Store Failed", err)
+	}
+	restored := new(SheetInfo)
+	if err := restored.Restore(filePath); err != nil {
+		t.Fatal("Restore of a sheet with duplicate column index Failed", err)
+	}
+	titles := make([]string, 0, len(restored.Columns))
+	for _, column := range restored.Columns {
+		titles = append(titles, column.Title)
+	}
+	if strings.Join(titles, ",") != "Address,DueDate,OrderNo,Util" || restored.ColumnsById[102].Index != 1 || len(restored.ColumnsByName) != 4 {
+		t.Error("Restore expected the loaded column order, got", titles)
+	}
+}
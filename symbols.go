@@ -0,0 +1,138 @@
+// symbols.go contains constants for symbol columns (ex. RYG balls, Harvey balls, flags) and SheetInfo.SetSymbolCell,
+// which validates a value against the symbol set of its column (Column.Symbol).
+
+package smartsheet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Column Types used by symbol columns
+const (
+	PICKLIST = "PICKLIST"
+	CHECKBOX = "CHECKBOX"
+)
+
+// Symbol Sets, values of Column.Symbol.
+// FLAG and STAR are CHECKBOX columns, their values are true or false. The others are PICKLIST columns.
+const (
+	SymbolFlag            = "FLAG"
+	SymbolStar            = "STAR"
+	SymbolRYG             = "RYG"
+	SymbolRYGB            = "RYGB"
+	SymbolRYGG            = "RYGG"
+	SymbolHarveyBalls     = "HARVEY_BALLS"
+	SymbolProgress        = "PROGRESS"
+	SymbolStarRating      = "STAR_RATING"
+	SymbolPriority        = "PRIORITY"
+	SymbolPriorityHML     = "PRIORITY_HML"
+	SymbolDecisionSymbols = "DECISION_SYMBOLS"
+	SymbolArrows3Way      = "ARROWS_3_WAY"
+	SymbolArrows4Way      = "ARROWS_4_WAY"
+)
+
+// RYG, RYGB, RYGG values
+const (
+	Red    = "Red"
+	Yellow = "Yellow"
+	Green  = "Green"
+	Blue   = "Blue" // RYGB only
+	Gray   = "Gray" // RYGG only
+)
+
+// HARVEY_BALLS, PROGRESS values
+const (
+	BallEmpty        = "Empty"
+	BallQuarter      = "Quarter"
+	BallHalf         = "Half"
+	BallThreeQuarter = "Three Quarter"
+	BallFull         = "Full"
+)
+
+// STAR_RATING values
+const (
+	StarsOne   = "One"
+	StarsTwo   = "Two"
+	StarsThree = "Three"
+	StarsFour  = "Four"
+	StarsFive  = "Five"
+)
+
+// PRIORITY (High, Low), PRIORITY_HML values
+const (
+	PriorityHigh   = "High"
+	PriorityMedium = "Medium"
+	PriorityLow    = "Low"
+)
+
+// DECISION_SYMBOLS values
+const (
+	DecisionYes  = "Yes"
+	DecisionHold = "Hold"
+	DecisionNo   = "No"
+)
+
+// ARROWS_3_WAY (Up, Sideways, Down), ARROWS_4_WAY (Up, Angle Up, Angle Down, Down) values
+const (
+	ArrowUp        = "Up"
+	ArrowAngleUp   = "Angle Up"
+	ArrowSideways  = "Sideways"
+	ArrowAngleDown = "Angle Down"
+	ArrowDown      = "Down"
+)
+
+// symbolValues contains the valid values of each PICKLIST symbol set.
+var symbolValues = map[string][]string{
+	SymbolRYG:             {Red, Yellow, Green},
+	SymbolRYGB:            {Red, Yellow, Green, Blue},
+	SymbolRYGG:            {Red, Yellow, Green, Gray},
+	SymbolHarveyBalls:     {BallEmpty, BallQuarter, BallHalf, BallThreeQuarter, BallFull},
+	SymbolProgress:        {BallEmpty, BallQuarter, BallHalf, BallThreeQuarter, BallFull},
+	SymbolStarRating:      {StarsOne, StarsTwo, StarsThree, StarsFour, StarsFive},
+	SymbolPriority:        {PriorityHigh, PriorityLow},
+	SymbolPriorityHML:     {PriorityHigh, PriorityMedium, PriorityLow},
+	SymbolDecisionSymbols: {DecisionYes, DecisionHold, DecisionNo},
+	SymbolArrows3Way:      {ArrowUp, ArrowSideways, ArrowDown},
+	SymbolArrows4Way:      {ArrowUp, ArrowAngleUp, ArrowAngleDown, ArrowDown},
+}
+
+// SetSymbolCell returns a cell for symbol column colName after checking value is in the column's symbol set.
+// For FLAG and STAR columns use "true" or "false", the cell value is a bool.
+// Values of symbol sets not listed in Symbol Sets are not checked. Use "" to clear the cell.
+func (she *SheetInfo) SetSymbolCell(colName, value string) (Cell, error) {
+	column, found := she.ColumnsByName[colName]
+	if !found {
+		return Cell{}, fmt.Errorf("%w - %s", ErrInvalidColumnName, colName)
+	}
+	if column.Symbol == "" {
+		return Cell{}, fmt.Errorf("Invalid Symbol Column - %s is type %s, not a symbol column", colName, column.Type)
+	}
+	cell := Cell{ColName: colName, ColumnId: column.Id}
+	if value == "" {
+		return cell, nil
+	}
+	if isCheckboxSymbol(column) {
+		if value != "true" && value != "false" {
+			return Cell{}, fmt.Errorf("Invalid Symbol Value - %s column %s uses true or false, not %q", column.Symbol, colName, value)
+		}
+		cell.Value = value == "true"
+		return cell, nil
+	}
+	if values, known := symbolValues[column.Symbol]; known {
+		valid := false
+		for _, symbolValue := range values {
+			valid = valid || value == symbolValue
+		}
+		if !valid {
+			return Cell{}, fmt.Errorf("Invalid Symbol Value - %q not in %s (%s), column %s", value, column.Symbol, strings.Join(values, ", "), colName)
+		}
+	}
+	cell.Value = value
+	return cell, nil
+}
+
+// isCheckboxSymbol returns true for FLAG and STAR columns, CHECKBOX columns shown as a symbol.
+func isCheckboxSymbol(column Column) bool {
+	return column.Type == CHECKBOX && (column.Symbol == SymbolFlag || column.Symbol == SymbolStar)
+}
@@ -0,0 +1,60 @@
+package smartsheet
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_SetSymbolCell(t *testing.T) {
+	sheet := testSheet()
+	for _, column := range []Column{
+		{Id: 110, Index: 9, Title: "Health", Type: PICKLIST, Symbol: SymbolRYG},
+		{Id: 111, Index: 10, Title: "Done", Type: PICKLIST, Symbol: SymbolHarveyBalls},
+		{Id: 112, Index: 11, Title: "Flag", Type: CHECKBOX, Symbol: SymbolFlag},
+	} {
+		sheet.ColumnsById[column.Id], sheet.ColumnsByName[column.Title], sheet.ColumnsByIndex[column.Index] = column, column, column
+	}
+
+	cell, err := sheet.SetSymbolCell("Health", Green)
+	if err != nil || cell.Value != "Green" || cell.ColumnId != 110 {
+		t.Error("SetSymbolCell RYG wrong cell", cell, err)
+	}
+	if cell, err = sheet.SetSymbolCell("Done", BallThreeQuarter); err != nil || cell.Value != "Three Quarter" {
+		t.Error("SetSymbolCell HARVEY_BALLS wrong cell", cell, err)
+	}
+	if cell, err = sheet.SetSymbolCell("Flag", "true"); err != nil || cell.Value != true {
+		t.Error("SetSymbolCell FLAG wrong cell", cell, err)
+	}
+	if err = sheet.AddRow(Row{Cells: []Cell{{ColName: "Address", Value: "1 Main"}, cell}}); err != nil {
+		t.Error("AddRow symbol cell Failed", err)
+	}
+
+	if _, err = sheet.SetSymbolCell("Health", BallQuarter); err == nil || !strings.Contains(err.Error(), "Red, Yellow, Green") {
+		t.Error("SetSymbolCell expected error for value of another symbol set, got", err)
+	}
+	if _, err = sheet.SetSymbolCell("Health", "green"); err == nil {
+		t.Error("SetSymbolCell expected error for wrong case value")
+	}
+	if _, err = sheet.SetSymbolCell("Flag", Red); err == nil {
+		t.Error("SetSymbolCell expected error for non bool FLAG value")
+	}
+	if _, err = sheet.SetSymbolCell("Util", "Gas"); err == nil || !strings.Contains(err.Error(), "not a symbol column") {
+		t.Error("SetSymbolCell expected error for non symbol column, got", err)
+	}
+	if _, err = sheet.SetSymbolCell("Bogus", Red); !errors.Is(err, ErrInvalidColumnName) {
+		t.Error("SetSymbolCell expected ErrInvalidColumnName, got", err)
+	}
+
+	// flags are "true" or "false", never returned and cleared cells are "false"
+	row := Row{Id: 11, Cells: []Cell{{ColumnId: 110, Value: "Yellow"}, {ColumnId: 112, Value: true}}}
+	values := RowValues(sheet, row)
+	if values["Health"] != "Yellow" || values["Flag"] != "true" || values["Done"] != "" {
+		t.Error("RowValues wrong symbol values", values)
+	}
+	for _, cells := range [][]Cell{{}, {{ColumnId: 112}}} {
+		if values = RowValues(sheet, Row{Id: 12, Cells: cells}); values["Flag"] != "false" {
+			t.Errorf("RowValues expected unset flag false, got %q", values["Flag"])
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// synccursor.go contains SyncCursor, which persists the time of the last successful sync between runs
+// and computes GetSheetOptions.RowsModifiedSince for the next incremental load.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"time"
+)
+
+// DefaultSyncOverlap is used when SyncCursor.Overlap is 0.
+const DefaultSyncOverlap = 5 * time.Minute
+
+// CursorStore saves and loads the committed cursor of a SyncCursor.
+// LoadCursor returns the zero time and nil error if no cursor has been saved.
+type CursorStore interface {
+	LoadCursor() (time.Time, error)
+	SaveCursor(cursor time.Time) error
+}
+
+// FileCursorStore is a CursorStore saving the cursor in a json file, ex. {"lastSync":"2020-10-10T14:30:00Z"}.
+type FileCursorStore struct {
+	Path string
+}
+
+type fileCursor struct {
+	LastSync time.Time `json:"lastSync"`
+}
+
+func (store FileCursorStore) LoadCursor() (time.Time, error) {
+	jsonData, err := ioutil.ReadFile(store.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	var data fileCursor
+	err = json.Unmarshal(jsonData, &data)
+	return data.LastSync, err
+}
+
+// SaveCursor writes a temporary file and renames it, so an interrupted save does not corrupt the previous cursor.
+func (store FileCursorStore) SaveCursor(cursor time.Time) error {
+	jsonData, err := json.Marshal(fileCursor{LastSync: cursor})
+	if err != nil {
+		return err
+	}
+	tmpPath := store.Path + ".tmp"
+	if err = ioutil.WriteFile(tmpPath, jsonData, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, store.Path)
+}
+
+// SyncCursor tracks the last successful sync of a sheet.
+// Each run calls LoadDelta (or Load with NextOptions), processes the rows, then calls Commit.
+// A run that fails or is skipped does not commit, so the next run requests every row modified since the last commit.
+type SyncCursor struct {
+	Store   CursorStore
+	Overlap time.Duration // subtracted from the cursor, so edits saved while the last sync ran are not missed, default DefaultSyncOverlap
+
+	lastSync time.Time
+}
+
+// NewSyncCursor returns a SyncCursor using the cursor saved in store.
+func NewSyncCursor(store CursorStore, overlap time.Duration) (*SyncCursor, error) {
+	trace("NewSyncCursor")
+	lastSync, err := store.LoadCursor()
+	if err != nil {
+		log.Println("ERROR - NewSyncCursor LoadCursor Failed", err)
+		return nil, err
+	}
+	return &SyncCursor{Store: store, Overlap: overlap, lastSync: lastSync}, nil
+}
+
+// LastSync returns the committed cursor, zero if no sync has been committed.
+func (cursor *SyncCursor) LastSync() time.Time {
+	return cursor.lastSync
+}
+
+// NextOptions returns GetSheetOptions for the next load, RowsModifiedSince is the committed cursor less Overlap.
+// If no sync has been committed, all rows are requested (RowsModifiedSince is zero).
+func (cursor *SyncCursor) NextOptions() *GetSheetOptions {
+	options := new(GetSheetOptions)
+	if cursor.lastSync.IsZero() {
+		return options
+	}
+	overlap := cursor.Overlap
+	if overlap == 0 {
+		overlap = DefaultSyncOverlap
+	}
+	options.RowsModifiedSince = cursor.lastSync.Add(-overlap)
+	return options
+}
+
+// LoadDelta loads the rows of sheetId modified since the last sync using NextOptions. The cursor is not committed,
+// call Commit(sheet.ModifiedAt) after the rows are processed.
+func (cursor *SyncCursor) LoadDelta(sheet *SheetInfo, sheetId int64) error {
+	trace("SyncCursor.LoadDelta")
+	return sheet.Load(sheetId, cursor.NextOptions())
+}
+
+// Commit saves t as the cursor. Use the loaded sheet's ModifiedAt (Smartsheet server time) rather than local time,
+// so clock skew between the local machine and Smartsheet does not cause edits to be missed.
+// A t before the committed cursor is ignored, the cursor never moves backwards.
+func (cursor *SyncCursor) Commit(t time.Time) error {
+	trace("SyncCursor.Commit")
+	if t.IsZero() {
+		log.Println("ERROR - SyncCursor.Commit zero time, sheet ModifiedAt not loaded")
+		return errors.New("Invalid SyncCursor Commit - zero time")
+	}
+	if t.Before(cursor.lastSync) {
+		log.Println("WARNING - SyncCursor.Commit time before committed cursor, ignored", t, cursor.lastSync)
+		return nil
+	}
+	if err := cursor.Store.SaveCursor(t); err != nil {
+		log.Println("ERROR - SyncCursor.Commit SaveCursor Failed", err)
+		return err
+	}
+	cursor.lastSync = t
+	return nil
+}
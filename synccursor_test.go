@@ -0,0 +1,60 @@
+package smartsheet
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_SyncCursor(t *testing.T) {
+	var since []string // rowsModifiedSince of each request
+	modifiedAt := "2020-10-10T14:30:00Z"
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		since = append(since, r.URL.Query().Get("rowsModifiedSince"))
+		fmt.Fprintf(w, `{"id":1849449510135684,"name":"Test1","modifiedAt":"%s","columns":[],"rows":[]}`, modifiedAt)
+	})
+	store := FileCursorStore{Path: filepath.Join(t.TempDir(), "cursor.json")}
+
+	// run 1, no cursor saved, all rows requested
+	cursor, err := NewSyncCursor(store, time.Minute)
+	if err != nil {
+		t.Fatal("NewSyncCursor Failed", err)
+	}
+	sheet := new(SheetInfo)
+	if err = cursor.LoadDelta(sheet, 1849449510135684); err != nil {
+		t.Fatal("LoadDelta Failed", err)
+	}
+	if err = cursor.Commit(sheet.ModifiedAt); err != nil {
+		t.Fatal("Commit Failed", err)
+	}
+
+	// run 2, new cursor reads saved cursor, requests rows since server ModifiedAt less overlap
+	modifiedAt = "2020-10-10T15:00:00Z"
+	cursor, _ = NewSyncCursor(store, time.Minute)
+	if err = cursor.LoadDelta(sheet, 1849449510135684); err != nil {
+		t.Fatal("LoadDelta Failed", err)
+	}
+	cursor.Commit(sheet.ModifiedAt)
+
+	// run 3 loads but fails before commit, run 4 requests the same rows
+	modifiedAt = "2020-10-10T15:30:00Z"
+	cursor, _ = NewSyncCursor(store, time.Minute)
+	cursor.LoadDelta(sheet, 1849449510135684)
+	cursor, _ = NewSyncCursor(store, 0)
+	cursor.LoadDelta(sheet, 1849449510135684)
+
+	expect := "[ 2020-10-10T14:29:00Z 2020-10-10T14:59:00Z 2020-10-10T14:55:00Z]"
+	if fmt.Sprint(since) != expect {
+		t.Errorf("SyncCursor rowsModifiedSince, Expecting %s, Got %v", expect, since)
+	}
+
+	if err = cursor.Commit(time.Time{}); err == nil {
+		t.Error("Commit expected error for zero time")
+	}
+	older, _ := time.Parse(time.RFC3339, "2020-10-10T12:00:00Z")
+	if err = cursor.Commit(older); err != nil || cursor.LastSync().Format(time.RFC3339) != "2020-10-10T15:00:00Z" {
+		t.Error("Commit expected older time ignored, cursor is", cursor.LastSync(), err)
+	}
+}
@@ -0,0 +1,66 @@
+// systemcolumns.go contains funcs for system columns (ex. AUTO_NUMBER), whose values are set by Smartsheet.
+// System column cells are removed from add & update requests, ValuesFor reads generated values from the responses.
+
+package smartsheet
+
+import "fmt"
+
+// System Column Types, values of Column.SystemColumnType
+const (
+	AutoNumber   = "AUTO_NUMBER"
+	CreatedBy    = "CREATED_BY"
+	CreatedDate  = "CREATED_DATE"
+	ModifiedBy   = "MODIFIED_BY"
+	ModifiedDate = "MODIFIED_DATE"
+)
+
+// ValuesFor returns the value of columnName in each result row, in result order (the order rows were sent).
+// Ex. the AUTO_NUMBER values generated for added rows. Rows with no cell for the column have a value of "".
+func (resp *AddUpdtRowsResponse) ValuesFor(sheet *SheetInfo, columnName string) ([]string, error) {
+	column, found := sheet.ColumnsByName[columnName]
+	if !found {
+		return nil, fmt.Errorf("%w - %s", ErrInvalidColumnName, columnName)
+	}
+	values := make([]string, len(resp.Result))
+	for i, row := range resp.Result {
+		values[i] = cellString(row, column.Id)
+	}
+	return values, nil
+}
+
+// ValuesFor returns the value of columnName in the added row, see AddUpdtRowsResponse.ValuesFor.
+func (resp *Add1RowResponse) ValuesFor(sheet *SheetInfo, columnName string) ([]string, error) {
+	rowsResp := AddUpdtRowsResponse{Result: []Row{resp.Result}}
+	return rowsResp.ValuesFor(sheet, columnName)
+}
+
+// cellString returns the value of a row's cell for columnId as a string, "" if no value.
+func cellString(row Row, columnId int64) string {
+	for _, cell := range row.Cells {
+		if cell.ColumnId == columnId && cell.Value != nil {
+			return fmt.Sprintf("%v", cell.Value)
+		}
+	}
+	return ""
+}
+
+// writableCells returns cells of row rowId (0 for a new row) without system column cells, which the api does not allow
+// in requests. Each removed cell is reported in Warnings (WarnSystemColumn). The cells slice is returned unchanged
+// if it has no system column cells.
+func (she *SheetInfo) writableCells(rowId int64, cells []Cell) []Cell {
+	for i, cell := range cells {
+		if she.ColumnsById[cell.ColumnId].SystemColumnType == "" {
+			continue
+		}
+		writable := append(make([]Cell, 0, len(cells)-1), cells[:i]...)
+		for _, cell := range cells[i:] {
+			if column := she.ColumnsById[cell.ColumnId]; column.SystemColumnType != "" {
+				she.warn(WarnSystemColumn, "cell of "+column.SystemColumnType+" column "+column.Title+" not sent", rowId, cell.ColumnId)
+			} else {
+				writable = append(writable, cell)
+			}
+		}
+		return writable
+	}
+	return cells
+}
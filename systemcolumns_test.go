@@ -0,0 +1,94 @@
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func Test_AutoNumberValues(t *testing.T) {
+	var bodies []string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, compactJSON(reqBytes))
+		switch {
+		case r.Method == "POST" && len(bodies) == 1: // canned add rows response, Ticket values generated
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[
+				{"id":21,"rowNumber":4,"cells":[{"columnId":101,"value":"2 Elm"},{"columnId":120,"value":"TKT-0041","displayValue":"TKT-0041"},{"columnId":121,"value":"2020-10-10T14:30:00Z"}]},
+				{"id":22,"rowNumber":5,"cells":[{"columnId":101,"value":"3 Oak"},{"columnId":120,"value":"TKT-0042","displayValue":"TKT-0042"}]}]}`))
+		case r.Method == "POST":
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":23,"cells":[{"columnId":120,"value":"TKT-0043"}]}}`))
+		default:
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[{"id":11,"cells":[]}]}`))
+		}
+	})
+	sheet := testSheet()
+	for _, column := range []Column{
+		{Id: 120, Index: 9, Title: "Ticket", Type: "TEXT_NUMBER", SystemColumnType: AutoNumber},
+		{Id: 121, Index: 10, Title: "Created", Type: DATETIME, SystemColumnType: CreatedDate},
+	} {
+		sheet.ColumnsById[column.Id], sheet.ColumnsByName[column.Title], sheet.ColumnsByIndex[column.Index] = column, column, column
+	}
+
+	sheet.AddRow(Row{Cells: []Cell{{ColName: "Address", Value: "2 Elm"}, {ColName: "Ticket", Value: "copied"}}})
+	sheet.AddRow(Row{Cells: []Cell{{ColName: "Ticket", Value: "copied"}, {ColName: "Address", Value: "3 Oak"}, {ColName: "Created", Value: "x"}}})
+	resp, err := sheet.UploadNewRows(nil)
+	if err != nil {
+		t.Fatal("UploadNewRows Failed", err)
+	}
+	expect := `[{"cells":[{"columnId":101,"value":"2 Elm"}],"toBottom":true},{"cells":[{"columnId":101,"value":"3 Oak"}],"toBottom":true}]`
+	if bodies[0] != expect {
+		t.Errorf("UploadNewRows expected system cells removed, Expecting %s, Got %s", expect, bodies[0])
+	}
+	tickets, err := resp.ValuesFor(sheet, "Ticket")
+	if err != nil || fmt.Sprint(tickets) != "[TKT-0041 TKT-0042]" {
+		t.Error("ValuesFor wrong values", tickets, err)
+	}
+	if created, _ := resp.ValuesFor(sheet, "Created"); fmt.Sprint(created) != "[2020-10-10T14:30:00Z ]" {
+		t.Errorf("ValuesFor expected empty value for row without cell, got %q", created)
+	}
+	if _, err = resp.ValuesFor(sheet, "Bogus"); !errors.Is(err, ErrInvalidColumnName) {
+		t.Error("ValuesFor expected ErrInvalidColumnName, got", err)
+	}
+
+	if len(sheet.Warnings) != 3 || sheet.Warnings[2].ColumnId != 121 {
+		t.Error("UploadNewRows expected a warning per system cell, got", sheet.Warnings)
+	}
+
+	sheet.Warnings = nil
+	add1, err := AddRow(sheet, Row{Cells: []Cell{{ColName: "Address", Value: "4 Pine"}, {ColName: "Ticket", Value: "x"}}}, nil)
+	if err != nil {
+		t.Fatal("AddRow Failed", err)
+	}
+	if tickets, _ = add1.ValuesFor(sheet, "Ticket"); fmt.Sprint(tickets) != "[TKT-0043]" || bodies[1] != `{"cells":[{"columnId":101,"value":"4 Pine"}],"toBottom":true}` {
+		t.Error("AddRow wrong request or ValuesFor", bodies[1], tickets)
+	}
+
+	expectWarnings := []Warning{{Code: WarnSystemColumn, Message: "cell of AUTO_NUMBER column Ticket not sent", ColumnId: 120}}
+	if fmt.Sprint(sheet.Warnings) != fmt.Sprint(expectWarnings) {
+		t.Errorf("AddRow Warnings, Expecting %v, Got %v", expectWarnings, sheet.Warnings)
+	}
+
+	// update with only system cells is not sent, other rows are
+	sheet.UpdateRow(Row{Id: 11, Cells: []Cell{{ColName: "Ticket", Value: "x"}}})
+	sheet.UpdateRow(Row{Id: 12, Cells: []Cell{{ColName: "Created", Value: "x"}, {ColName: "Amt", Value: 5}}})
+	if _, err = sheet.UploadUpdateRows(nil); err != nil {
+		t.Fatal("UploadUpdateRows Failed", err)
+	}
+	if len(bodies) != 3 || bodies[2] != `[{"cells":[{"columnId":105,"value":5}],"id":"12"}]` {
+		t.Error("UploadUpdateRows expected row 11 dropped and system cells removed, got", bodies[2:])
+	}
+	expectWarnings = []Warning{
+		{Code: WarnSystemColumn, Message: "cell of AUTO_NUMBER column Ticket not sent", RowId: 11, ColumnId: 120},
+		{Code: WarnSystemColumn, Message: "row has only system column cells, not sent", RowId: 11},
+		{Code: WarnSystemColumn, Message: "cell of CREATED_DATE column Created not sent", RowId: 12, ColumnId: 121},
+	}
+	if fmt.Sprint(sheet.Warnings) != fmt.Sprint(expectWarnings) || sheet.UpdateRows != nil {
+		t.Errorf("UploadUpdateRows Warnings, Expecting %v, Got %v", expectWarnings, sheet.Warnings)
+	}
+	if _, err = UpdateRow(sheet, Row{Id: 11, Cells: []Cell{{ColName: "Ticket", Value: "x"}}}, nil); err == nil || len(bodies) != 3 {
+		t.Error("UpdateRow with only system cells expected error and no request, got", err, len(bodies))
+	}
+}
@@ -0,0 +1,48 @@
+// token.go contains CheckToken, used by DoRequest to reject an empty or malformed Token before any request is sent,
+// so a missing token is reported at the first request instead of as a 401 deep inside a batch job.
+
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrNoToken is returned by DoRequest (and CheckToken) when Token is not set and TokenSource is nil.
+var ErrNoToken = errors.New("No Access Token")
+
+// ErrInvalidToken is wrapped by the error returned by DoRequest (and CheckToken) when Token is malformed.
+var ErrInvalidToken = errors.New("Invalid Access Token")
+
+// SkipTokenCheck disables CheckToken in DoRequest, ex. when a custom HttpClient.Transport sets the Authorization header.
+var SkipTokenCheck bool
+
+const minTokenLength = 20 // api and OAuth access tokens are longer, shorter values are placeholders or truncated
+
+// CheckToken checks the Token var is set and well formed: "Bearer " followed by the access token, with no spaces or
+// line breaks (ex. a token read from a file without strings.TrimSpace). The token is not sent, see Healthcheck to
+// verify the api accepts it. Nil is returned if TokenSource is set, its tokens are requested when needed.
+func CheckToken() error {
+	if TokenSource != nil {
+		return nil
+	}
+	if strings.TrimSpace(Token) == "" || strings.EqualFold(strings.TrimSpace(Token), "Bearer") {
+		return fmt.Errorf("%w - set smartsheet.Token (\"Bearer \" + token) or TokenSource", ErrNoToken)
+	}
+	if strings.TrimSpace(Token) != Token {
+		return fmt.Errorf("%w - Token starts or ends with a space or line break, use strings.TrimSpace", ErrInvalidToken)
+	}
+	if len(Token) < 7 || !strings.EqualFold(Token[:7], "Bearer ") {
+		return fmt.Errorf("%w - Token must start with \"Bearer \"", ErrInvalidToken)
+	}
+	accessToken := Token[7:]
+	if strings.IndexFunc(accessToken, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		return fmt.Errorf("%w - access token contains a space or line break", ErrInvalidToken)
+	}
+	if len(accessToken) < minTokenLength {
+		return fmt.Errorf("%w - access token has %d characters, expected at least %d", ErrInvalidToken, len(accessToken), minTokenLength)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package smartsheet
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_CheckToken(t *testing.T) {
+	saveToken := Token
+	t.Cleanup(func() { Token = saveToken })
+
+	valid := "Bearer ll352u9jujauoqz4gstvsae05"
+	tests := []struct {
+		token  string
+		expect error
+	}{
+		{"", ErrNoToken},
+		{"Bearer ", ErrNoToken},
+		{" \n", ErrNoToken},
+		{valid + "\n", ErrInvalidToken}, // read from token.txt without TrimSpace
+		{" " + valid, ErrInvalidToken},
+		{valid + "\r\n", ErrInvalidToken},
+		{"ll352u9jujauoqz4gstvsae05", ErrInvalidToken}, // no "Bearer "
+		{"Bearer ll352u9jujau oqz4gstvsae05", ErrInvalidToken},
+		{"Bearer ll352u9jujau\noqz4gstvsae05", ErrInvalidToken},
+		{"Bearer youraccesstoken", ErrInvalidToken}, // too short
+		{valid, nil},
+		{"bearer ll352u9jujauoqz4gstvsae05", nil},
+	}
+	for _, test := range tests {
+		Token = test.token
+		err := CheckToken()
+		if !errors.Is(err, test.expect) || (test.expect == nil && err != nil) {
+			t.Errorf("CheckToken %q, Expecting %v, Got %v", test.token, test.expect, err)
+		}
+		if err != nil && strings.Contains(err.Error(), "ll352u9jujau") {
+			t.Error("CheckToken error must not contain the token", err)
+		}
+	}
+}
+
+func Test_DoRequestTokenCheck(t *testing.T) {
+	requests := 0
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"id":1,"email":"jay@example.com"}`))
+	})
+	saveToken := Token
+	t.Cleanup(func() { Token, TokenSource, SkipTokenCheck = saveToken, nil, false })
+
+	Token = "Bearer ll352u9jujauoqz4gstvsae05\n"
+	if _, err := DoRequest(Get("/users/me", nil)); !errors.Is(err, ErrInvalidToken) || requests != 0 {
+		t.Error("DoRequest expected ErrInvalidToken and no request, got", err, requests)
+	}
+	Token = ""
+	report, err := Healthcheck()
+	if !errors.Is(err, ErrNoToken) || requests != 0 || !strings.Contains(report.Problem, "set smartsheet.Token") {
+		t.Error("Healthcheck expected ErrNoToken problem and no request, got", err, report.Problem, requests)
+	}
+
+	// tokens of TokenSource are requested when needed, not checked
+	TokenSource = NewOAuthProvider("client1", "secret1", OAuthToken{AccessToken: "access-1", RefreshToken: "refresh-1"})
+	if _, err = DoRequest(Get("/users/me", nil)); err != nil || requests != 1 {
+		t.Error("DoRequest with TokenSource expected request sent, got", err, requests)
+	}
+	TokenSource, SkipTokenCheck = nil, true
+	if _, err = DoRequest(Get("/users/me", nil)); err != nil || requests != 2 {
+		t.Error("DoRequest with SkipTokenCheck expected request sent, got", err, requests)
+	}
+}
@@ -0,0 +1,186 @@
+// updatequeue.go contains UpdateQueue, which sends cell updates in the background so callers (ex. webhook handlers)
+// do not wait for the api. Updates are coalesced per row and sent in batches by UploadUpdateRows, failed batches are
+// retried with backoff.
+
+package smartsheet
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// UpdateQueue defaults, see UpdateQueueOptions.
+const (
+	DefaultQueueInterval   = 5 * time.Second
+	DefaultQueueBatchSize  = 100
+	DefaultQueueRetries    = 4
+	DefaultQueueRetryDelay = 5 * time.Second // with 4 retries, the last is 75 seconds after the failure, past the api rate limit window
+)
+
+// ErrQueueClosed is returned by UpdateQueue.Enqueue after Close.
+var ErrQueueClosed = errors.New("Update Queue Closed")
+
+// UpdateQueue stages cell updates of 1 sheet and sends them from a single goroutine, so the requests go through the
+// RequestDelay throttle 1 at a time. Safe for use by multiple goroutines. Create it using NewUpdateQueue.
+//
+// Enqueue stages a cell as SheetInfo.StageCellUpdate, several updates of a row are sent as 1 row and a later value of a
+// cell replaces the earlier. Staged rows are sent every Interval, or as soon as BatchSize rows are staged.
+// A failed batch is retried when the api may accept it later (network errors, rate limited, server errors), other
+// errors (ex. invalid value) and batches still failing after MaxRetries are passed to OnError and dropped.
+// While a batch is retried, later updates wait, so an older value is never sent after a newer one.
+// Warnings of staged updates (ex. WarnNotCoerced when CoerceValues is set) are logged, they are not kept by the queue.
+type UpdateQueue struct {
+	opts     UpdateQueueOptions
+	staging  SheetInfo // copy of the sheet holding staged rows in UpdateRows, guarded by mu
+	uploader SheetInfo // copy of the sheet used by the goroutine to send batches
+
+	mu      sync.Mutex
+	closed  bool
+	full    chan struct{} // BatchSize rows staged
+	closing chan struct{}
+	done    chan struct{}
+	err     error // of the last batch sent by Close
+}
+
+// NewUpdateQueue returns a queue of updates to sheet and starts its goroutine, use Close to stop it.
+// Column names are resolved using sheet's columns, which must be loaded. The sheet is copied and its settings
+// (ex. CoerceValues, ProtectFormulas, VerifyColumns) apply to queued updates. Opts may be nil for the defaults.
+// The sheet's columns and Rows must not be changed while the queue is open.
+func NewUpdateQueue(sheet *SheetInfo, opts *UpdateQueueOptions) *UpdateQueue {
+	trace("NewUpdateQueue")
+	q := &UpdateQueue{
+		staging:  *sheet,
+		uploader: *sheet,
+		full:     make(chan struct{}, 1),
+		closing:  make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if opts != nil {
+		q.opts = *opts
+	}
+	if q.opts.Interval <= 0 {
+		q.opts.Interval = DefaultQueueInterval
+	}
+	if q.opts.BatchSize < 1 {
+		q.opts.BatchSize = DefaultQueueBatchSize
+	}
+	if q.opts.MaxRetries == 0 {
+		q.opts.MaxRetries = DefaultQueueRetries
+	}
+	if q.opts.RetryDelay <= 0 {
+		q.opts.RetryDelay = DefaultQueueRetryDelay
+	}
+	q.staging.UpdateRows, q.uploader.UpdateRows = nil, nil
+	q.staging.NewRows, q.uploader.NewRows = nil, nil
+	go q.run()
+	return q
+}
+
+// Enqueue stages a cell update, it is sent later by the queue's goroutine.
+// An error is returned if the queue is closed or the update is invalid (ex. unknown column, see StageCellUpdate).
+func (q *UpdateQueue) Enqueue(rowId int64, columnName string, value interface{}) error {
+	trace("UpdateQueue.Enqueue")
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	if err := q.staging.StageCellUpdate(rowId, columnName, value); err != nil {
+		return err
+	}
+	if len(q.staging.UpdateRows) >= q.opts.BatchSize {
+		select {
+		case q.full <- struct{}{}:
+		default: // already signaled
+		}
+	}
+	return nil
+}
+
+// Close stops accepting updates, sends all staged updates (retrying as needed) and stops the queue's goroutine.
+// The error of the last failed batch is returned, it was also passed to OnError. Close may be called more than once.
+func (q *UpdateQueue) Close() error {
+	trace("UpdateQueue.Close")
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.closing)
+	}
+	q.mu.Unlock()
+	<-q.done
+	return q.err
+}
+
+func (q *UpdateQueue) run() {
+	defer close(q.done)
+	ticker := time.NewTicker(q.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			q.flush()
+		case <-q.full:
+			q.flush()
+		case <-q.closing:
+			q.err = q.flush()
+			return
+		}
+	}
+}
+
+// flush sends the staged rows in batches of BatchSize, returning the error of the last failed batch.
+func (q *UpdateQueue) flush() error {
+	var lastErr error
+	for {
+		q.mu.Lock()
+		rows := q.staging.UpdateRows
+		if len(rows) > q.opts.BatchSize {
+			rows = rows[:q.opts.BatchSize]
+		}
+		q.staging.UpdateRows = q.staging.UpdateRows[len(rows):]
+		q.staging.Warnings = nil // logged when staged, staging is never uploaded to reset them
+		q.mu.Unlock()
+		if len(rows) == 0 {
+			return lastErr
+		}
+		if err := q.send(rows); err != nil {
+			lastErr = err
+		}
+	}
+}
+
+// send uploads 1 batch, retrying with backoff. The rows not updated are passed to OnError if it fails.
+func (q *UpdateQueue) send(rows []Row) error {
+	q.uploader.UpdateRows = rows
+	delay := q.opts.RetryDelay
+	for retry := 0; ; retry++ {
+		_, err := q.uploader.UploadUpdateRows(nil)
+		if err == nil {
+			return nil
+		}
+		if retry >= q.opts.MaxRetries || !retryable(err) {
+			log.Println("ERROR - UpdateQueue batch failed,", len(q.uploader.UpdateRows), "rows not updated", err)
+			if q.opts.OnError != nil {
+				q.opts.OnError(q.uploader.UpdateRows, err)
+			}
+			q.uploader.UpdateRows = nil
+			return err
+		}
+		log.Println("WARNING - UpdateQueue batch failed, retry in", delay, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// retryable returns true if a request failing with err may succeed later: no response, rate limited or server error.
+func retryable(err error) bool {
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return errors.As(err, new(*url.Error)) // ex. connection refused, timeout
+}
@@ -0,0 +1,149 @@
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_UpdateQueue(t *testing.T) {
+	var mu sync.Mutex
+	batches := make([]string, 0) // each request's rows, "rowId:column=value,..."
+	failures := 0                // number of requests to fail with status 503
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var reqRows []struct {
+			Id    string `json:"id"`
+			Cells []Cell `json:"cells"`
+		}
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(reqBytes, &reqRows)
+		mu.Lock()
+		defer mu.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"errorCode":4002,"message":"Server timeout exceeded."}`))
+			return
+		}
+		rows := make([]string, 0, len(reqRows))
+		for _, row := range reqRows {
+			cells := make([]string, 0, len(row.Cells))
+			for _, cell := range row.Cells {
+				cells = append(cells, fmt.Sprintf("%d=%v", cell.ColumnId, cell.Value))
+			}
+			rows = append(rows, row.Id+":"+strings.Join(cells, ","))
+		}
+		sort.Strings(rows)
+		batches = append(batches, strings.Join(rows, " "))
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[]}`))
+	})
+	sent := func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		result := strings.Join(batches, " | ")
+		batches = batches[:0]
+		return result
+	}
+
+	// coalescing and flush on close
+	q := NewUpdateQueue(testSheet(), &UpdateQueueOptions{Interval: time.Hour})
+	q.Enqueue(1, "Status", "Red")
+	q.Enqueue(2, "Status", "Green")
+	q.Enqueue(1, "Amt", 10.0)
+	q.Enqueue(1, "Status", "Yellow")
+	if err := q.Enqueue(1, "Bogus", 1); !errors.Is(err, ErrInvalidColumnName) {
+		t.Error("Enqueue expected ErrInvalidColumnName, got", err)
+	}
+	if got := sent(); got != "" {
+		t.Error("UpdateQueue expected nothing sent before interval, got", got)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatal("UpdateQueue.Close Failed", err)
+	}
+	if got, expect := sent(), "1:108=Yellow,105=10 2:108=Green"; got != expect {
+		t.Errorf("UpdateQueue coalesce, Expecting %s, Got %s", expect, got)
+	}
+	if err := q.Enqueue(3, "Status", "Red"); err != ErrQueueClosed {
+		t.Error("Enqueue expected ErrQueueClosed, got", err)
+	}
+
+	// flush on size, batches of BatchSize
+	q = NewUpdateQueue(testSheet(), &UpdateQueueOptions{Interval: time.Hour, BatchSize: 2})
+	for rowId := int64(1); rowId <= 5; rowId++ {
+		q.Enqueue(rowId, "Level", rowId)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		count := len(batches)
+		mu.Unlock()
+		if count >= 2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	q.Close()
+	if got, expect := sent(), "1:107=1 2:107=2 | 3:107=3 4:107=4 | 5:107=5"; got != expect {
+		t.Errorf("UpdateQueue batch size, Expecting %s, Got %s", expect, got)
+	}
+
+	// flush on interval
+	q = NewUpdateQueue(testSheet(), &UpdateQueueOptions{Interval: 10 * time.Millisecond})
+	q.Enqueue(7, "Status", "Red")
+	time.Sleep(50 * time.Millisecond)
+	if got := sent(); got != "7:108=Red" {
+		t.Error("UpdateQueue expected flush after interval, got", got)
+	}
+	q.Close()
+
+	// retry with backoff, then OnError after MaxRetries
+	var failed []Row
+	var failedErr error
+	opts := &UpdateQueueOptions{Interval: time.Hour, MaxRetries: 2, RetryDelay: time.Millisecond,
+		OnError: func(rows []Row, err error) { failed, failedErr = rows, err }}
+	mu.Lock()
+	failures = 2
+	mu.Unlock()
+	q = NewUpdateQueue(testSheet(), opts)
+	q.Enqueue(8, "Status", "Red")
+	if err := q.Close(); err != nil || failed != nil {
+		t.Error("UpdateQueue expected success after 2 retries, got", err, failed)
+	}
+	if got := sent(); got != "8:108=Red" {
+		t.Error("UpdateQueue retry, got", got)
+	}
+
+	mu.Lock()
+	failures = 3
+	mu.Unlock()
+	q = NewUpdateQueue(testSheet(), opts)
+	q.Enqueue(9, "Status", "Red")
+	err := q.Close()
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 503 || len(failed) != 1 || failed[0].Id != 9 || failedErr != err {
+		t.Error("UpdateQueue expected OnError after MaxRetries, got", err, failed)
+	}
+	if got := sent(); got != "" {
+		t.Error("UpdateQueue expected failed batch dropped, got", got)
+	}
+
+	// warnings of staged updates are not kept after a flush
+	sheet := testSheet()
+	sheet.CoerceValues = true
+	q = NewUpdateQueue(sheet, &UpdateQueueOptions{Interval: time.Hour})
+	q.Enqueue(10, "DueDate", "soon")
+	if len(q.staging.Warnings) != 1 || q.staging.Warnings[0].Code != WarnNotCoerced {
+		t.Error("Enqueue expected WarnNotCoerced, got", q.staging.Warnings)
+	}
+	q.Close()
+	if got := sent(); got != "10:103=soon" || q.staging.Warnings != nil {
+		t.Error("UpdateQueue expected warnings cleared by flush, got", got, q.staging.Warnings)
+	}
+}
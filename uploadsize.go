@@ -0,0 +1,86 @@
+// uploadsize.go contains the request body size check used by UploadNewRows and UploadUpdateRows.
+// Chunks whose serialized rows exceed UploadMaxBytes are split in half until they fit, down to single rows.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// UploadMaxBytes is the maximum size of the request body sent by UploadNewRows and UploadUpdateRows.
+// The default is safely under the api's request size limit.
+var UploadMaxBytes = 4 * 1024 * 1024
+
+// ErrRowTooLarge is wrapped by the error returned when 1 row alone exceeds UploadMaxBytes, no rows are sent.
+var ErrRowTooLarge = errors.New("Row Too Large")
+
+// rowChunk is the index of the first row and last row + 1 of a chunk.
+type rowChunk struct {
+	first, last int
+}
+
+// sizedChunks divides items into chunks of at most chunkSize items, splitting any chunk whose json exceeds maxBytes.
+// Returns an error wrapping ErrRowTooLarge, naming the row and its largest cells, if a single item exceeds maxBytes.
+func (she *SheetInfo) sizedChunks(items []map[string]interface{}, chunkSize, maxBytes int) ([]rowChunk, error) {
+	chunks := make([]rowChunk, 0, (len(items)+chunkSize-1)/chunkSize)
+	var split func(first, last int) error
+	split = func(first, last int) error {
+		body, err := json.Marshal(items[first:last])
+		if err != nil {
+			return err
+		}
+		if len(body) <= maxBytes {
+			chunks = append(chunks, rowChunk{first, last})
+			return nil
+		}
+		if last-first == 1 {
+			return she.rowTooLarge(first, items[first], len(body), maxBytes)
+		}
+		middle := first + (last-first)/2
+		if err = split(first, middle); err != nil {
+			return err
+		}
+		return split(middle, last)
+	}
+	for first := 0; first < len(items); first += chunkSize {
+		last := first + chunkSize
+		if last > len(items) {
+			last = len(items)
+		}
+		if err := split(first, last); err != nil {
+			return nil, err
+		}
+	}
+	return chunks, nil
+}
+
+// rowTooLarge returns the error for an item exceeding maxBytes, listing its 3 largest cells.
+func (she *SheetInfo) rowTooLarge(index int, item map[string]interface{}, size, maxBytes int) error {
+	type cellSize struct {
+		colName string
+		size    int
+	}
+	cells, _ := item["cells"].([]Cell)
+	sizes := make([]cellSize, 0, len(cells))
+	for _, cell := range cells {
+		cellJSON, _ := json.Marshal(cell)
+		sizes = append(sizes, cellSize{she.ColumnsById[cell.ColumnId].Title, len(cellJSON)})
+	}
+	sort.SliceStable(sizes, func(i, j int) bool { return sizes[i].size > sizes[j].size })
+	if len(sizes) > 3 {
+		sizes = sizes[:3]
+	}
+	largest := make([]string, len(sizes))
+	for i, cell := range sizes {
+		largest[i] = fmt.Sprintf("%s %d bytes", cell.colName, cell.size)
+	}
+	rowId := ""
+	if id, found := item["id"]; found {
+		rowId = fmt.Sprintf(" (rowId %v)", id)
+	}
+	return fmt.Errorf("%w - row %d%s is %d bytes, limit %d, largest cells: %s", ErrRowTooLarge, index, rowId, size, maxBytes, strings.Join(largest, ", "))
+}
@@ -0,0 +1,72 @@
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// Test_UploadMaxBytes sends rows with megabyte text cells, chunks over UploadMaxBytes (4 MB) are split.
+func Test_UploadMaxBytes(t *testing.T) {
+	var requestRows []int // rows per request, in order sent
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		if len(reqBytes) > UploadMaxBytes {
+			t.Errorf("request body %d bytes exceeds UploadMaxBytes", len(reqBytes))
+		}
+		var items []struct{ Id string }
+		json.Unmarshal(reqBytes, &items)
+		requestRows = append(requestRows, len(items))
+		rows := make([]Row, len(items))
+		result, _ := json.Marshal(rows)
+		if len(rows) == 1 && r.Method == "POST" { // result is 1 row (not a slice) when adding 1 row
+			result, _ = json.Marshal(rows[0])
+		}
+		fmt.Fprintf(w, `{"message":"SUCCESS","resultCode":0,"result":%s}`, result)
+	})
+	megabyte := strings.Repeat("x", 1024*1024)
+	sheet := testSheet()
+
+	for i := 0; i < 10; i++ {
+		sheet.AddRow(Row{Cells: []Cell{{ColName: "Address", Value: megabyte}, {ColName: "OrderNo", Value: i}}})
+	}
+	response, err := sheet.UploadNewRows(nil)
+	if err != nil || len(response.Result) != 10 {
+		t.Fatal("UploadNewRows Failed", err)
+	}
+	if fmt.Sprint(requestRows) != "[2 3 2 3]" { // 10 rows split in half until each chunk is under 4 MB
+		t.Error("UploadNewRows wrong split, rows per request", requestRows)
+	}
+
+	requestRows = nil
+	for i := 0; i < 3; i++ {
+		sheet.StageCellUpdate(int64(11+i), "Address", megabyte+megabyte[:512*1024])
+	}
+	if _, err = sheet.UploadUpdateRows(nil); err != nil {
+		t.Fatal("UploadUpdateRows Failed", err)
+	}
+	if fmt.Sprint(requestRows) != "[1 2]" {
+		t.Error("UploadUpdateRows wrong split, rows per request", requestRows)
+	}
+
+	requestRows = nil
+	sheet.AddRow(Row{Cells: []Cell{{ColName: "OrderNo", Value: 1}}})
+	sheet.AddRow(Row{Cells: []Cell{{ColName: "Address", Value: strings.Repeat(megabyte, 5)}, {ColName: "OrderNo", Value: 2}}})
+	_, err = sheet.UploadNewRows(nil)
+	if !errors.Is(err, ErrRowTooLarge) || !strings.Contains(err.Error(), "row 1 is") || !strings.Contains(err.Error(), "largest cells: Address 5242") {
+		t.Error("UploadNewRows expected ErrRowTooLarge naming row 1 and Address, got", err)
+	}
+	if len(requestRows) != 0 || len(sheet.NewRows) != 2 {
+		t.Error("UploadNewRows expected no rows sent", requestRows)
+	}
+
+	sheet.StageCellUpdate(11, "Address", strings.Repeat(megabyte, 5))
+	_, err = sheet.UploadUpdateRows(nil)
+	if !errors.Is(err, ErrRowTooLarge) || !strings.Contains(err.Error(), "rowId 11") {
+		t.Error("UploadUpdateRows expected ErrRowTooLarge naming rowId 11, got", err)
+	}
+}
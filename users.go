@@ -0,0 +1,163 @@
+// users.go contains org admin funcs for provisioning users.
+// The token must belong to a System Admin, otherwise errors.Is(err, ErrNotAuthorized) is true.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// User is returned by GetUser, AddUser and UpdateUser.
+type User struct {
+	Id                   int64  `json:"id"`
+	Email                string `json:"email"`
+	Name                 string `json:"name"`
+	FirstName            string `json:"firstName"`
+	LastName             string `json:"lastName"`
+	Admin                bool   `json:"admin"`
+	LicensedSheetCreator bool   `json:"licensedSheetCreator"`
+	GroupAdmin           bool   `json:"groupAdmin"`
+	ResourceViewer       bool   `json:"resourceViewer"`
+	Status               string `json:"status"` // ACTIVE, PENDING, DECLINED
+
+	// profile fields, returned by GetUser when requested with parm include
+	Title           string           `json:"title"`
+	Department      string           `json:"department"`
+	MobilePhone     string           `json:"mobilePhone"`
+	AlternateEmails []AlternateEmail `json:"alternateEmails"` // also see ListAlternateEmails
+}
+
+// AlternateEmail is an additional email address of a user.
+// Unconfirmed addresses are not used by Smartsheet to identify the user.
+type AlternateEmail struct {
+	Id        int64  `json:"id"`
+	Email     string `json:"email"`
+	Confirmed bool   `json:"confirmed"`
+}
+
+// UserUpdate contains the user attributes changed by UpdateUser.
+// Nil or empty fields are not changed.
+type UserUpdate struct {
+	FirstName            string `json:"firstName,omitempty"`
+	LastName             string `json:"lastName,omitempty"`
+	Admin                *bool  `json:"admin,omitempty"` // use &IsTrue or &IsFalse
+	LicensedSheetCreator *bool  `json:"licensedSheetCreator,omitempty"`
+	GroupAdmin           *bool  `json:"groupAdmin,omitempty"`
+	ResourceViewer       *bool  `json:"resourceViewer,omitempty"`
+}
+
+// GetUser returns a user in the organization.
+// Optional parm include is sent as the include parameter, ex. to request the profile fields (Title, Department, MobilePhone).
+func GetUser(userId int64, include ...string) (*User, error) {
+	trace("GetUser")
+	endPoint := fmt.Sprintf("/users/%d", userId)
+	query := newQuery()
+	for _, value := range include {
+		query.include(value, true)
+	}
+	req := Get(endPoint, query.parms())
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+
+	user := new(User)
+	if err = json.Unmarshal(respJSON, user); err != nil {
+		log.Println("ERROR GetUser Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// ListAlternateEmails returns the alternate email addresses of a user, confirmed and unconfirmed.
+func ListAlternateEmails(userId int64) ([]AlternateEmail, error) {
+	trace("ListAlternateEmails")
+	endPoint := fmt.Sprintf("/users/%d/alternateemails", userId)
+	emails := make([]AlternateEmail, 0, 5)
+	err := getAllPages(endPoint, nil, func(data json.RawMessage) error {
+		var page []AlternateEmail
+		err := json.Unmarshal(data, &page)
+		emails = append(emails, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return emails, nil
+}
+
+// AddUser adds a user to the organization.
+// If sendEmail is true, the user is sent an email invitation.
+func AddUser(email, firstName, lastName string, licensed, admin bool, sendEmail bool) (*User, error) {
+	trace("AddUser")
+	reqData := map[string]interface{}{
+		"email":                email,
+		"firstName":            firstName,
+		"lastName":             lastName,
+		"licensedSheetCreator": licensed,
+		"admin":                admin,
+	}
+	urlParms := map[string]string{"sendEmail": strconv.FormatBool(sendEmail)}
+	req := Post("/users", reqData, urlParms)
+	req.Header.Set("Content-Type", "application/json")
+	return doUserRequest("AddUser", req)
+}
+
+// UpdateUser changes user attributes and returns the updated user.
+func UpdateUser(userId int64, changes UserUpdate) (*User, error) {
+	trace("UpdateUser")
+	endPoint := fmt.Sprintf("/users/%d", userId)
+	req := Put(endPoint, changes, nil)
+	req.Header.Set("Content-Type", "application/json")
+	return doUserRequest("UpdateUser", req)
+}
+
+// RemoveUser removes a user from the organization.
+// If transferToUserId is not 0, the user's groups and sheets are transferred to that user.
+// If removeFromSharing is true, the user is also removed from all sharing.
+func RemoveUser(userId int64, transferToUserId int64, removeFromSharing bool) error {
+	trace("RemoveUser")
+	query := newQuery().flag("removeFromSharing", removeFromSharing)
+	if transferToUserId != 0 {
+		query.set("transferTo", strconv.FormatInt(transferToUserId, 10)).flag("transferSheets", true)
+	}
+	endPoint := fmt.Sprintf("/users/%d", userId)
+	req := Delete(endPoint, query.parms())
+
+	resp, err := DoRequest(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// doUserRequest executes req and returns the User in the api response result.
+func doUserRequest(funcName string, req *http.Request) (*User, error) {
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	var apiResp struct {
+		Message    string `json:"message"`
+		ResultCode int    `json:"resultCode"`
+		Result     User   `json:"result"`
+	}
+	if err = json.Unmarshal(respJSON, &apiResp); err != nil {
+		log.Println("ERROR", funcName, "Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return &apiResp.Result, nil
+}
@@ -0,0 +1,84 @@
+package smartsheet
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func Test_Users(t *testing.T) {
+	var body, lastPath, lastQuery string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		lastPath = r.Method + " " + r.URL.Path
+		lastQuery = r.URL.RawQuery
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		body = compactJSON(reqBytes)
+		switch lastPath {
+		case "GET /users/99":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errorCode":1004,"message":"You are not authorized to perform this action."}`))
+		case "GET /users/48569348493401200":
+			w.Write([]byte(`{"id":48569348493401200,"email":"a@test.com","firstName":"Ann","lastName":"Lee","admin":false,"licensedSheetCreator":true,"status":"ACTIVE",` +
+				`"title":"Buyer","department":"Purchasing","mobilePhone":"555-0100","alternateEmails":[{"id":5,"email":"ann.lee@test.com","confirmed":true}]}`))
+		case "GET /users/48569348493401200/alternateemails":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"id":5,"email":"ann.lee@test.com","confirmed":true},{"id":6,"email":"al@test.com","confirmed":false}]}`))
+		case "DELETE /users/48569348493401200":
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+		default:
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":48569348493401200,"email":"a@test.com","firstName":"Ann","lastName":"Lee","admin":true,"licensedSheetCreator":true}}`))
+		}
+	})
+
+	user, err := AddUser("a@test.com", "Ann", "Lee", true, false, true)
+	if err != nil || user.Id != 48569348493401200 {
+		t.Fatalf("AddUser wrong result %+v %v", user, err)
+	}
+	expect := `{"admin":false,"email":"a@test.com","firstName":"Ann","lastName":"Lee","licensedSheetCreator":true}`
+	if lastPath != "POST /users" || lastQuery != "sendEmail=true" || body != expect {
+		t.Errorf("AddUser wrong request %s %s %s", lastPath, lastQuery, body)
+	}
+
+	user, err = UpdateUser(user.Id, UserUpdate{Admin: &IsTrue})
+	if err != nil || !user.Admin {
+		t.Errorf("UpdateUser wrong result %+v %v", user, err)
+	}
+	if lastPath != "PUT /users/48569348493401200" || body != `{"admin":true}` {
+		t.Errorf("UpdateUser wrong request %s %s", lastPath, body)
+	}
+
+	user, err = GetUser(48569348493401200)
+	if err != nil || user.Email != "a@test.com" || user.Status != "ACTIVE" {
+		t.Errorf("GetUser wrong result %+v %v", user, err)
+	}
+
+	user, err = GetUser(48569348493401200, "profile")
+	if err != nil || lastQuery != "include=profile" || user.Title != "Buyer" || user.Department != "Purchasing" || user.MobilePhone != "555-0100" ||
+		len(user.AlternateEmails) != 1 || !user.AlternateEmails[0].Confirmed {
+		t.Errorf("GetUser with include wrong result %s %+v %v", lastQuery, user, err)
+	}
+
+	emails, err := ListAlternateEmails(48569348493401200)
+	if err != nil || len(emails) != 2 || emails[1].Email != "al@test.com" || emails[1].Confirmed {
+		t.Errorf("ListAlternateEmails wrong result %+v %v", emails, err)
+	}
+
+	if err = RemoveUser(48569348493401200, 2331373580117892, true); err != nil {
+		t.Error("RemoveUser Failed", err)
+	}
+	if lastQuery != "removeFromSharing=true&transferSheets=true&transferTo=2331373580117892" {
+		t.Error("RemoveUser wrong query", lastQuery)
+	}
+	RemoveUser(48569348493401200, 0, false)
+	if lastQuery != "" {
+		t.Error("RemoveUser expected no query", lastQuery)
+	}
+
+	_, err = GetUser(99)
+	if !errors.Is(err, ErrNotAuthorized) {
+		t.Error("GetUser expected ErrNotAuthorized, got", err)
+	}
+	if apiErr, ok := err.(*ApiError); !ok || apiErr.StatusCode != http.StatusForbidden {
+		t.Error("GetUser expected *ApiError, got", err)
+	}
+}
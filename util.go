@@ -1,5 +1,10 @@
 package smartsheet
 
+import (
+	"crypto/rand"
+	"fmt"
+)
+
 // InitRow returns new instance of Row.
 // If optional parm rowId specified, row.Id field is loaded.
 // Row.Cells is also initialized.
@@ -15,6 +20,7 @@ func InitRow(rowId ...int64) Row {
 // CreateLocationMap accepts struct type RowLocation and returns a map.
 // Map keys match api location specifier values.
 // Fields with value of 0 or false are not included in the map.
+// Location is not validated, see RowLocation.Validate.
 func CreateLocationMap(location *RowLocation) map[string]interface{} {
 
 	locMap := make(map[string]interface{})
@@ -34,6 +40,9 @@ func CreateLocationMap(location *RowLocation) map[string]interface{} {
 	if location.AboveSibling {
 		locMap["above"] = true
 	}
+	if location.BelowSibling {
+		locMap["above"] = false // api default, included when requested
+	}
 	if location.Indent != 0 {
 		locMap["indent"] = 1
 	}
@@ -42,3 +51,12 @@ func CreateLocationMap(location *RowLocation) map[string]interface{} {
 	}
 	return locMap
 }
+
+// NewImportKey returns a random (version 4) uuid, used by UploadNewRowsWith to set UploadOptions.ImportKeyColumn.
+func NewImportKey() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
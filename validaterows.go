@@ -0,0 +1,90 @@
+// validaterows.go contains SheetInfo.ValidateRows, which checks staged cell values against the validation of their
+// columns before upload. The api only rejects a PICKLIST value not in Options, or text in a DATE column, when the column
+// is restricted (Column.Validation, "Restrict to list values only" / "Restrict to dates only" in the Smartsheet UI).
+
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// ErrInvalidValue is wrapped by the error returned by ValidateRows when a value would be rejected by a restricted column.
+var ErrInvalidValue = errors.New("Invalid Cell Value")
+
+// ValidateRows checks the values of the cells of rows (ex. NewRows or UpdateRows) against their columns:
+//   - PICKLIST: value not in the column's Options (or its symbol set) is an error if the column is restricted,
+//     otherwise it is accepted by the api and reported in Warnings (WarnPicklistValue)
+//   - DATE: value that is not a date (DateFormat) is an error if the column is restricted
+//
+// Formula cells, empty values and cells of other column types are not checked. ColumnIds must be loaded.
+// The error wraps ErrInvalidValue and lists every invalid value.
+func (she *SheetInfo) ValidateRows(rows []Row) error {
+	trace("SheetInfo.ValidateRows")
+	problems := make([]string, 0)
+	for _, row := range rows {
+		for _, cell := range row.Cells {
+			if cell.Formula != "" || cell.Value == nil || cell.Value == "" {
+				continue
+			}
+			column := she.ColumnsById[cell.ColumnId]
+			value := fmt.Sprint(cell.Value)
+			switch column.Type {
+			case PICKLIST:
+				if picklistValue(column, value) {
+					continue
+				}
+				if !column.Validation {
+					she.warn(WarnPicklistValue, fmt.Sprintf("%q not in column %s options", value, column.Title), row.Id, column.Id)
+					continue
+				}
+				problems = append(problems, fmt.Sprintf("row %d column %s %q not in options", row.Id, column.Title, value))
+			case DATE:
+				if !column.Validation {
+					continue
+				}
+				if _, isTime := cell.Value.(time.Time); isTime {
+					continue
+				}
+				if _, err := time.Parse(DateFormat, value); err != nil {
+					problems = append(problems, fmt.Sprintf("row %d column %s %q not a date", row.Id, column.Title, value))
+				}
+			}
+		}
+	}
+	if len(problems) > 0 {
+		err := fmt.Errorf("%w - %s", ErrInvalidValue, strings.Join(problems, "; "))
+		log.Println("ERROR - SheetInfo.ValidateRows", she.SheetName, err)
+		return err
+	}
+	return nil
+}
+
+// validateStagedRows calls ValidateRows if SheetInfo.ValidateValues is set.
+func (she *SheetInfo) validateStagedRows(rows []Row) error {
+	if !she.ValidateValues {
+		return nil
+	}
+	return she.ValidateRows(rows)
+}
+
+// picklistValue returns true if value is one of the column's Options, or of its symbol set for symbol columns.
+// Any value is accepted if the options are not known.
+func picklistValue(column Column, value string) bool {
+	options := column.Options
+	if values, known := symbolValues[column.Symbol]; known {
+		options = values
+	}
+	if len(options) == 0 {
+		return true
+	}
+	for _, option := range options {
+		if value == option {
+			return true
+		}
+	}
+	return false
+}
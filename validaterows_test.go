@@ -0,0 +1,87 @@
+package smartsheet
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_ValidateRows(t *testing.T) {
+	sheet := testSheet()
+	restrict := func(title string) {
+		column := sheet.ColumnsByName[title]
+		column.Validation = true
+		sheet.ColumnsById[column.Id], sheet.ColumnsByName[title], sheet.ColumnsByIndex[column.Index] = column, column, column
+	}
+	rows := []Row{
+		{Id: 11, Cells: []Cell{{ColumnId: 104, Value: "Elec"}, {ColumnId: 108, Value: "Blue"}, {ColumnId: 103, Value: "soon"}}},
+		{Id: 12, Cells: []Cell{{ColumnId: 104, Value: "Oil"}, {ColumnId: 108, Formula: "=Status1"}, {ColumnId: 103, Value: "2024-05-02"}}},
+		{Id: 13, Cells: []Cell{{ColumnId: 104, Value: ""}, {ColumnId: 105, Value: "Oil"}}},
+	}
+
+	// unrestricted columns, picklist mismatches are warnings, text in a date column is accepted
+	if err := sheet.ValidateRows(rows); err != nil {
+		t.Fatal("ValidateRows unrestricted expected no error, got", err)
+	}
+	warned := make([]string, 0)
+	for _, warning := range sheet.Warnings {
+		if warning.Code == WarnPicklistValue {
+			warned = append(warned, warning.Message)
+		}
+	}
+	if strings.Join(warned, "; ") != `"Blue" not in column Status options; "Oil" not in column Util options` {
+		t.Error("ValidateRows expected picklist warnings, got", sheet.Warnings)
+	}
+
+	// restricted columns, mismatches are errors
+	sheet.Warnings = nil
+	restrict("Util")
+	restrict("DueDate")
+	err := sheet.ValidateRows(rows)
+	expect := `row 11 column DueDate "soon" not a date; row 12 column Util "Oil" not in options`
+	if !errors.Is(err, ErrInvalidValue) || !strings.HasSuffix(err.Error(), expect) {
+		t.Errorf("ValidateRows restricted, Expecting %s, Got %v", expect, err)
+	}
+	if len(sheet.Warnings) != 1 || sheet.Warnings[0].RowId != 11 || sheet.Warnings[0].ColumnId != 108 {
+		t.Error("ValidateRows expected warning for unrestricted Status only, got", sheet.Warnings)
+	}
+
+	// symbol columns are checked against their symbol set
+	status := sheet.ColumnsByName["Status"]
+	status.Symbol, status.Options, status.Validation = SymbolRYG, nil, true
+	sheet.ColumnsById[108] = status
+	if err = sheet.ValidateRows([]Row{{Id: 14, Cells: []Cell{{ColumnId: 108, Value: Green}}}}); err != nil {
+		t.Error("ValidateRows expected symbol value accepted, got", err)
+	}
+
+	var buf bytes.Buffer
+	sheet.Render(&buf, nil)
+	if !strings.Contains(buf.String(), " 3            Util        PICKLIST 104 restricted\n") {
+		t.Error("Render expected restricted column marked, got\n", buf.String())
+	}
+}
+
+func Test_UploadValidateValues(t *testing.T) {
+	requests := 0
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[{"id":11}]}`))
+	})
+	sheet := testSheet()
+	column := sheet.ColumnsById[104]
+	column.Validation = true
+	sheet.ColumnsById[104] = column
+	sheet.ValidateValues = true
+	if err := sheet.UpdateRow(Row{Id: 11, Cells: []Cell{{ColName: "Util", Value: "Oil"}}}); err != nil {
+		t.Fatal("UpdateRow Failed", err)
+	}
+	if _, err := sheet.UploadUpdateRows(nil); !errors.Is(err, ErrInvalidValue) || requests != 0 {
+		t.Error("UploadUpdateRows expected ErrInvalidValue and no request, got", err, requests)
+	}
+	sheet.ValidateValues = false
+	if _, err := sheet.UploadUpdateRows(nil); err != nil || requests != 1 {
+		t.Error("UploadUpdateRows without ValidateValues expected upload, got", err, requests)
+	}
+}
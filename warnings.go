@@ -0,0 +1,97 @@
+// warnings.go contains the Warning type, used to report non-fatal conditions found by SheetInfo Load and upload methods.
+// Warnings are logged and collected in SheetInfo.Warnings, so callers can check for them (ex. fail a CI job).
+
+package smartsheet
+
+import (
+	"fmt"
+	"log"
+)
+
+// Warning Codes
+const (
+	WarnDuplicateColumnTitle = "DUPLICATE_COLUMN_TITLE" // 2 columns have the same title, ColumnsByName contains the last
+	WarnUnknownColumn        = "UNKNOWN_COLUMN"         // row cell references a column not in the sheet response
+	WarnRowNotReturned       = "ROW_NOT_RETURNED"       // row requested by GetSheetOptions.RowIds not in sheet, ex. deleted
+	WarnRowCountMismatch     = "ROW_COUNT_MISMATCH"     // api result contains a different number of rows than sent
+	WarnRowsTruncated        = "ROWS_TRUNCATED"         // Load of all rows returned fewer rows than the sheet's TotalRowCount
+	WarnNotCoerced           = "NOT_COERCED"            // staged cell value could not be converted to its column type, see CoerceValue
+	WarnPicklistValue        = "PICKLIST_VALUE"         // staged value not in the options of an unrestricted PICKLIST column, see ValidateRows
+	WarnLockedCell           = "LOCKED_CELL"            // staged update changes a locked row or column, see SheetInfo.LockedCells
+	WarnColumnIndex          = "COLUMN_INDEX"           // column indexes have a gap or duplicate, ColumnsByIndex is incomplete, use Columns
+	WarnDuplicateRow         = "DUPLICATE_ROW"          // UpdateRows had a row id more than once, the rows were merged, see SheetInfo.DuplicateUpdates
+	WarnSystemColumn         = "SYSTEM_COLUMN"          // staged cell of a system column (ex. AUTO_NUMBER) not sent, its value is set by Smartsheet
+)
+
+// Warning describes a non-fatal condition. RowId and ColumnId are 0 when not applicable.
+type Warning struct {
+	Code     string // use Warning Code constants, ex. WarnUnknownColumn
+	Message  string
+	RowId    int64
+	ColumnId int64
+}
+
+func (w Warning) String() string {
+	msg := w.Code + " " + w.Message
+	if w.RowId != 0 {
+		msg += fmt.Sprintf(", RowId %d", w.RowId)
+	}
+	if w.ColumnId != 0 {
+		msg += fmt.Sprintf(", ColumnId %d", w.ColumnId)
+	}
+	return msg
+}
+
+// warn logs and adds a warning to SheetInfo.Warnings.
+func (she *SheetInfo) warn(code, message string, rowId, columnId int64) {
+	warning := Warning{Code: code, Message: message, RowId: rowId, ColumnId: columnId}
+	log.Println("WARNING - SheetInfo", she.SheetName, warning)
+	she.Warnings = append(she.Warnings, warning)
+}
+
+// loadWarnings checks a loaded sheet for duplicate column titles, gaps or duplicates in column indexes (when all
+// columns are loaded), cells with unknown columns, requested rows not returned and a full load returning fewer rows
+// than TotalRowCount.
+func (she *SheetInfo) loadWarnings(sheet *Sheet, options *GetSheetOptions) {
+	titles := make(map[string]bool, len(sheet.Columns))
+	for _, column := range sheet.Columns {
+		if titles[column.Title] {
+			she.warn(WarnDuplicateColumnTitle, "column title "+column.Title, 0, column.Id)
+		}
+		titles[column.Title] = true
+	}
+	for i, column := range she.Columns { // sorted by Index
+		if options != nil && len(options.ColumnIds) > 0 {
+			break // subset of columns, indexes have gaps
+		}
+		switch {
+		case i > 0 && column.Index == she.Columns[i-1].Index:
+			she.warn(WarnColumnIndex, fmt.Sprintf("column %s has the index %d of column %s", column.Title, column.Index, she.Columns[i-1].Title), 0, column.Id)
+		case column.Index != i && (i == 0 || column.Index != she.Columns[i-1].Index+1):
+			she.warn(WarnColumnIndex, fmt.Sprintf("column %s has index %d, expected %d", column.Title, column.Index, i), 0, column.Id)
+		}
+	}
+	returned := make(map[int64]bool, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		returned[row.Id] = true
+		for _, cell := range row.Cells {
+			if _, found := she.ColumnsById[cell.ColumnId]; !found {
+				she.warn(WarnUnknownColumn, "cell column not in sheet columns", row.Id, cell.ColumnId)
+			}
+		}
+	}
+	if options == nil || !options.selectsRows() {
+		if len(sheet.Rows) < sheet.TotalRowCount {
+			she.warn(WarnRowsTruncated, fmt.Sprintf("loaded %d of %d rows", len(sheet.Rows), sheet.TotalRowCount), 0, 0)
+		}
+		return
+	}
+	if options == NoRows {
+		return
+	}
+	for _, rowId := range options.RowIds {
+		if !returned[rowId] {
+			she.warn(WarnRowNotReturned, "requested row not returned", rowId, 0)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package smartsheet
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func Test_Warnings(t *testing.T) {
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"id":1849449510135684,"name":"Test1",
+				"columns":[{"id":101,"index":0,"title":"Address","primary":true},{"id":102,"index":1,"title":"OrderNo"},{"id":103,"index":2,"title":"OrderNo"}],
+				"rows":[{"id":11,"cells":[{"columnId":101,"value":"1 Main"},{"columnId":999,"value":"x"}]},{"id":12,"cells":[]}]}`))
+		case "POST": // 1 of 2 rows returned
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[{"id":21,"cells":[]}]}`))
+		case "PUT":
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[]}`))
+		}
+	})
+	sheet := new(SheetInfo)
+	if err := sheet.Load(1849449510135684, &GetSheetOptions{RowIds: []int64{11, 12, 13}}); err != nil {
+		t.Fatal("Load Failed", err)
+	}
+	expect := []Warning{
+		{Code: WarnDuplicateColumnTitle, Message: "column title OrderNo", ColumnId: 103},
+		{Code: WarnUnknownColumn, Message: "cell column not in sheet columns", RowId: 11, ColumnId: 999},
+		{Code: WarnRowNotReturned, Message: "requested row not returned", RowId: 13},
+	}
+	if fmt.Sprint(sheet.Warnings) != fmt.Sprint(expect) {
+		t.Errorf("Load Warnings, Expecting %v, Got %v", expect, sheet.Warnings)
+	}
+
+	sheet.AddRow(Row{Cells: []Cell{{ColName: "Address", Value: "2 Elm"}}})
+	sheet.AddRow(Row{Cells: []Cell{{ColName: "Address", Value: "3 Oak"}}})
+	if _, err := sheet.UploadNewRows(nil); err != nil {
+		t.Fatal("UploadNewRows Failed", err)
+	}
+	if len(sheet.Warnings) != 1 || sheet.Warnings[0].Code != WarnRowCountMismatch {
+		t.Error("UploadNewRows expected only ROW_COUNT_MISMATCH warning, got", sheet.Warnings)
+	}
+
+	sheet.UpdateRow(Row{Id: 11, Cells: []Cell{{ColName: "Address", Value: "1 Main St"}}})
+	if _, err := sheet.UploadUpdateRows(nil); err != nil {
+		t.Fatal("UploadUpdateRows Failed", err)
+	}
+	if len(sheet.Warnings) != 1 || sheet.Warnings[0].String() != "ROW_COUNT_MISMATCH sent 1 rows, result has 0" {
+		t.Error("UploadUpdateRows wrong warnings", sheet.Warnings)
+	}
+
+	if err := sheet.Load(1849449510135684, NoRows); err != nil {
+		t.Fatal("Load Failed", err)
+	}
+	if len(sheet.Warnings) != 2 {
+		t.Error("Load expected previous warnings cleared, got", sheet.Warnings)
+	}
+}
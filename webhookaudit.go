@@ -0,0 +1,171 @@
+// webhookaudit.go contains AuditWebhooks, which classifies the user's webhooks (sheet and workspace scopes) to find
+// those left behind by deleted sheets or decommissioned callback services, and optionally deletes the orphaned ones.
+
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+)
+
+// WebHook Statuses, values of WebHook.Status
+const (
+	WebHookStatusEnabled              = "ENABLED"
+	WebHookStatusNewNotVerified       = "NEW_NOT_VERIFIED"
+	WebHookStatusCallbackFailed       = "DISABLED_CALLBACK_FAILED"
+	WebHookStatusVerificationFailed   = "DISABLED_VERIFICATION_FAILED"
+	WebHookStatusScopeInaccessible    = "DISABLED_SCOPE_INACCESSIBLE" // sheet or workspace deleted, or access removed
+	WebHookStatusDisabledByOwner      = "DISABLED_BY_OWNER"
+	WebHookStatusDisabledAdmin        = "DISABLED_ADMINISTRATIVE"
+	WebHookStatusDisabledAppRevoked   = "DISABLED_APP_REVOKED"
+	WebHookStatusDisabledCustomerPlan = "DISABLED_CUSTOMER_PLAN"
+)
+
+// Webhook Audit Classes, values of WebhookAuditEntry.Class, in order of precedence.
+const (
+	WebhookOrphaned       = "ORPHANED"        // scope inaccessible, the webhook can never be called again
+	WebhookCallbackFailed = "CALLBACK_FAILED" // disabled by the api after callback or verification failures
+	WebhookUnknownHost    = "UNKNOWN_HOST"    // callback host not in WebhookAuditOptions.AllowedHosts
+	WebhookDisabled       = "DISABLED"        // other disabled or not yet enabled webhooks
+	WebhookHealthy        = "HEALTHY"
+)
+
+// ErrTooManyDeletes is wrapped by WebhookAudit.DeleteErr when more webhooks qualify for deletion than
+// WebhookAuditOptions.MaxDeletes, none are deleted.
+var ErrTooManyDeletes = errors.New("Too Many Webhooks To Delete")
+
+// WebhookAuditOptions are used by AuditWebhooks.
+type WebhookAuditOptions struct {
+	// AllowedHosts are the hosts of the callback services in use, ex. "hooks.example.com", or "*.example.com" for
+	// any subdomain. Webhooks calling other hosts are UNKNOWN_HOST. If empty, hosts are not checked.
+	AllowedHosts []string
+
+	DeleteOrphaned bool // delete ORPHANED webhooks, after confirming their status with GetWebHook
+
+	// MaxDeletes, if more than 0, is the most webhooks deleted by 1 audit. If more are orphaned, none are deleted
+	// (ex. a token that lost access to a workspace makes all its webhooks look orphaned).
+	MaxDeletes int
+
+	// Confirm, if set, is called before each delete, return false to keep the webhook.
+	Confirm func(hook WebHook) bool
+}
+
+// WebhookAuditEntry is the classification of 1 webhook.
+type WebhookAuditEntry struct {
+	WebHook
+	Class     string // use Webhook Audit Classes constants, ex. WebhookOrphaned
+	Reason    string // ex. "status DISABLED_SCOPE_INACCESSIBLE"
+	Deleted   bool
+	DeleteErr error // error deleting the webhook, nil if not deleted or deleted
+}
+
+// WebhookAudit is the report returned by AuditWebhooks.
+type WebhookAudit struct {
+	Entries   []WebhookAuditEntry // all webhooks, in ListWebHooks order
+	Counts    map[string]int      // number of webhooks by Class
+	Deleted   []int64             // ids of deleted webhooks
+	DeleteErr error               // set when deletion was skipped, ex. ErrTooManyDeletes
+}
+
+// AuditWebhooks lists all webhooks of the user and classifies each (see Webhook Audit Classes). With DeleteOrphaned
+// set, ORPHANED webhooks are deleted: each is requested again (GetWebHook) and only deleted if its status is still
+// DISABLED_SCOPE_INACCESSIBLE and Confirm, if set, returns true. A webhook already deleted is reported as Deleted.
+// Errors deleting a webhook are set in its entry, the returned error is only set if the webhooks cannot be listed.
+func AuditWebhooks(opts WebhookAuditOptions) (*WebhookAudit, error) {
+	trace("AuditWebhooks")
+	hooks, err := ListWebHooks()
+	if err != nil {
+		log.Println("ERROR AuditWebhooks", err)
+		return nil, err
+	}
+	audit := &WebhookAudit{Entries: make([]WebhookAuditEntry, 0, len(hooks)), Counts: make(map[string]int), Deleted: make([]int64, 0)}
+	orphaned := make([]int, 0)
+	for _, hook := range hooks {
+		class, reason := classifyWebhook(hook, opts.AllowedHosts)
+		audit.Entries = append(audit.Entries, WebhookAuditEntry{WebHook: hook, Class: class, Reason: reason})
+		audit.Counts[class]++
+		if class == WebhookOrphaned {
+			orphaned = append(orphaned, len(audit.Entries)-1)
+		}
+	}
+	if !opts.DeleteOrphaned || len(orphaned) == 0 {
+		return audit, nil
+	}
+	if opts.MaxDeletes > 0 && len(orphaned) > opts.MaxDeletes {
+		audit.DeleteErr = fmt.Errorf("%w - %d orphaned, MaxDeletes %d", ErrTooManyDeletes, len(orphaned), opts.MaxDeletes)
+		log.Println("ERROR AuditWebhooks", audit.DeleteErr)
+		return audit, nil
+	}
+	for _, i := range orphaned {
+		entry := &audit.Entries[i]
+		entry.Deleted, entry.DeleteErr = deleteOrphanedWebhook(entry.WebHook, opts.Confirm)
+		if entry.Deleted {
+			audit.Deleted = append(audit.Deleted, entry.Id)
+		}
+	}
+	return audit, nil
+}
+
+// deleteOrphanedWebhook deletes hook if it is still orphaned and confirm (if set) returns true.
+// Deleted is true if the webhook no longer exists.
+func deleteOrphanedWebhook(hook WebHook, confirm func(WebHook) bool) (deleted bool, err error) {
+	current, err := GetWebHook(hook.Id)
+	if errors.Is(err, ErrSheetNotFound) { // errorCode 1006, the webhook was deleted since listed
+		return true, nil
+	}
+	if err != nil {
+		log.Println("ERROR AuditWebhooks delete", hook.Id, err)
+		return false, err
+	}
+	if current.Status != WebHookStatusScopeInaccessible {
+		return false, fmt.Errorf("Webhook Not Deleted - %d status changed to %s", hook.Id, current.Status)
+	}
+	if confirm != nil && !confirm(*current) {
+		return false, nil
+	}
+	if err = deleteObject(fmt.Sprintf("/webhooks/%d", hook.Id)); err != nil {
+		log.Println("ERROR AuditWebhooks delete", hook.Id, err)
+		return false, err
+	}
+	return true, nil
+}
+
+// classifyWebhook returns the Webhook Audit Class of hook and the reason.
+func classifyWebhook(hook WebHook, allowedHosts []string) (class, reason string) {
+	switch hook.Status {
+	case WebHookStatusScopeInaccessible:
+		return WebhookOrphaned, fmt.Sprintf("status %s, %s %d", hook.Status, hook.Scope, hook.ScopeObjectId)
+	case WebHookStatusCallbackFailed, WebHookStatusVerificationFailed:
+		return WebhookCallbackFailed, "status " + hook.Status
+	}
+	if len(allowedHosts) > 0 {
+		host := ""
+		if callback, err := url.Parse(hook.CallbackUrl); err == nil {
+			host = strings.ToLower(callback.Hostname())
+		}
+		if !hostAllowed(host, allowedHosts) {
+			return WebhookUnknownHost, "callback host " + host + " not allowed"
+		}
+	}
+	if !hook.Enabled || hook.Status != WebHookStatusEnabled {
+		return WebhookDisabled, "status " + hook.Status
+	}
+	return WebhookHealthy, ""
+}
+
+// hostAllowed returns true if host equals an allowed host, or is a subdomain of a "*." allowed host.
+func hostAllowed(host string, allowedHosts []string) bool {
+	if host == "" {
+		return false
+	}
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if host == allowed || (strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:])) {
+			return true
+		}
+	}
+	return false
+}
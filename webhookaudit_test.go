@@ -0,0 +1,110 @@
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// auditServer serves the webhooks of testdata/webhooks_audit.json. Webhook 207 is deleted after being listed,
+// webhook 206 becomes enabled again after being listed.
+func auditServer(t *testing.T, requests *[]string) {
+	hooks, err := ioutil.ReadFile("testdata/webhooks_audit.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+		id, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/webhooks/"), 10, 64)
+		switch {
+		case r.URL.Path == "/webhooks":
+			fmt.Fprintf(w, `{"pageNumber":1,"totalPages":1,"data":%s}`, hooks)
+		case r.Method == "GET" && id == 207:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorCode":1006,"message":"Not Found"}`))
+		case r.Method == "GET" && id == 206:
+			fmt.Fprintf(w, `{"id":206,"enabled":true,"status":"ENABLED"}`)
+		case r.Method == "GET":
+			fmt.Fprintf(w, `{"id":%d,"enabled":false,"status":"DISABLED_SCOPE_INACCESSIBLE"}`, id)
+		case r.Method == "DELETE":
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+		}
+	})
+}
+
+func Test_AuditWebhooks(t *testing.T) {
+	var requests []string
+	auditServer(t, &requests)
+
+	audit, err := AuditWebhooks(WebhookAuditOptions{AllowedHosts: []string{"hooks.example.com", "*.HOOKS.example.com"}})
+	if err != nil {
+		t.Fatal("AuditWebhooks Failed", err)
+	}
+	classes := make([]string, 0)
+	for _, entry := range audit.Entries {
+		classes = append(classes, fmt.Sprintf("%d %s", entry.Id, entry.Class))
+	}
+	expect := "201 HEALTHY, 202 ORPHANED, 203 CALLBACK_FAILED, 204 UNKNOWN_HOST, 205 DISABLED, 206 ORPHANED, 207 ORPHANED"
+	if strings.Join(classes, ", ") != expect {
+		t.Errorf("AuditWebhooks, Expecting\n%s\nGot\n%s", expect, strings.Join(classes, ", "))
+	}
+	if audit.Counts[WebhookOrphaned] != 3 || audit.Counts[WebhookHealthy] != 1 || len(audit.Deleted) != 0 {
+		t.Errorf("AuditWebhooks wrong counts %v or deleted %v", audit.Counts, audit.Deleted)
+	}
+	if audit.Entries[3].Reason != "callback host legacy-server.internal not allowed" {
+		t.Error("AuditWebhooks wrong reason", audit.Entries[3].Reason)
+	}
+	if len(requests) != 1 {
+		t.Error("AuditWebhooks without DeleteOrphaned expected only the list request, got", requests)
+	}
+
+	// no allowlist, hosts not checked
+	audit, _ = AuditWebhooks(WebhookAuditOptions{})
+	if audit.Entries[3].Class != WebhookHealthy {
+		t.Error("AuditWebhooks without AllowedHosts expected 204 HEALTHY, got", audit.Entries[3].Class)
+	}
+}
+
+func Test_AuditWebhooksDelete(t *testing.T) {
+	var requests []string
+	auditServer(t, &requests)
+
+	confirmed := make([]int64, 0)
+	audit, err := AuditWebhooks(WebhookAuditOptions{DeleteOrphaned: true, Confirm: func(hook WebHook) bool {
+		confirmed = append(confirmed, hook.Id)
+		return true
+	}})
+	if err != nil || audit.DeleteErr != nil {
+		t.Fatal("AuditWebhooks Failed", err, audit.DeleteErr)
+	}
+	expect := "GET /webhooks, GET /webhooks/202, DELETE /webhooks/202, GET /webhooks/206, GET /webhooks/207"
+	if strings.Join(requests, ", ") != expect {
+		t.Errorf("AuditWebhooks delete requests, Expecting\n%s\nGot\n%s", expect, strings.Join(requests, ", "))
+	}
+	if fmt.Sprint(audit.Deleted) != "[202 207]" || fmt.Sprint(confirmed) != "[202]" {
+		t.Error("AuditWebhooks expected 202 deleted and 207 already deleted, got", audit.Deleted, confirmed)
+	}
+	if entry := audit.Entries[5]; entry.Deleted || entry.DeleteErr == nil || !strings.Contains(entry.DeleteErr.Error(), "status changed to ENABLED") {
+		t.Error("AuditWebhooks expected 206 kept, status changed, got", entry.Deleted, entry.DeleteErr)
+	}
+
+	// confirm declines
+	requests = nil
+	audit, _ = AuditWebhooks(WebhookAuditOptions{DeleteOrphaned: true, Confirm: func(hook WebHook) bool { return false }})
+	for _, request := range requests {
+		if strings.HasPrefix(request, "DELETE") {
+			t.Error("AuditWebhooks Confirm false expected no delete, got", request)
+		}
+	}
+
+	// too many
+	requests = nil
+	audit, _ = AuditWebhooks(WebhookAuditOptions{DeleteOrphaned: true, MaxDeletes: 2})
+	if !errors.Is(audit.DeleteErr, ErrTooManyDeletes) || len(requests) != 1 || len(audit.Deleted) != 0 {
+		t.Error("AuditWebhooks MaxDeletes expected ErrTooManyDeletes and no deletes, got", audit.DeleteErr, requests)
+	}
+}
@@ -0,0 +1,328 @@
+// webhookevents.go contains types for decoding webhook callbacks and funcs for resolving callback events to cell values.
+// ParseWebhookCallback decodes both callback payload versions (see WebHook Versions) into WebhookCallback.
+// Callbacks only contain ids, see ResolveWebhookEvents to get column titles and new values.
+// EventDebouncer combines bursts of callbacks (1 callback per save) before they are processed.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookCallback is the body of a webhook callback request (POST to the webhook CallbackUrl).
+// When a webhook is enabled, the api sends a verification request containing Challenge, which must be echoed
+// in the response (header Smartsheet-Hook-Response). Callbacks contain Events.
+// Use ParseWebhookCallback to decode a callback of any payload version.
+type WebhookCallback struct {
+	Nonce         string                 `json:"nonce"`
+	Timestamp     time.Time              `json:"timestamp"`
+	WebhookId     int64                  `json:"webhookId"`
+	Scope         string                 `json:"scope"`
+	ScopeObjectId int64                  `json:"scopeObjectId"`
+	Events        []WebhookCallbackEvent `json:"events"`
+	Challenge     string                 `json:"challenge"`
+	Version       int                    `json:"-"` // payload version, set by ParseWebhookCallback
+}
+
+// WebhookCallbackEvent is 1 change in a webhook callback. Id is the object's id (ex. row id for row events).
+// RowId and ColumnId are set for cell events.
+type WebhookCallbackEvent struct {
+	ObjectType string    `json:"objectType"` // ex. "sheet", "row", "cell", "column"
+	EventType  string    `json:"eventType"`  // ex. "created", "updated", "deleted"
+	Id         int64     `json:"id"`
+	RowId      int64     `json:"rowId"`
+	ColumnId   int64     `json:"columnId"`
+	UserId     int64     `json:"userId"`
+	Timestamp  time.Time `json:"timestamp"`
+
+	Detail json.RawMessage `json:"-"` // additional event detail of version 2 callbacks, nil for version 1
+}
+
+// ErrWebhookVersion is returned by ParseWebhookCallback for a callback payload version this package cannot read.
+var ErrWebhookVersion = errors.New("Unsupported Webhook Callback Version")
+
+// webhookCallbackV2 is a version 2 callback. The format is assumed, it is not documented by the api and no real
+// version 2 payload has been captured (testdata/webhook_callback_v2.json is hand-written): each event names its
+// WebHookEvent ("objectType.eventType") in Type and may carry event detail, other attributes are the same as version 1.
+type webhookCallbackV2 struct {
+	Nonce         string    `json:"nonce"`
+	Timestamp     time.Time `json:"timestamp"`
+	WebhookId     int64     `json:"webhookId"`
+	Scope         string    `json:"scope"`
+	ScopeObjectId int64     `json:"scopeObjectId"`
+	Challenge     string    `json:"challenge"`
+	Events        []struct {
+		Type      WebHookEvent    `json:"type"`
+		ObjectId  int64           `json:"objectId"`
+		RowId     int64           `json:"rowId"`
+		ColumnId  int64           `json:"columnId"`
+		UserId    int64           `json:"userId"`
+		Timestamp time.Time       `json:"timestamp"`
+		Detail    json.RawMessage `json:"detail"`
+	} `json:"events"`
+}
+
+// ParseWebhookCallback decodes a callback request body of either payload version into the same WebhookCallback.
+// The version 2 format is assumed (see webhookCallbackV2), check the parsed events before relying on them.
+// The version is the callback's declared version, if not declared it is version 2 when events name their type in
+// "type" rather than "objectType", otherwise version 1. cb.Version is set to the version read.
+func ParseWebhookCallback(body []byte) (WebhookCallback, error) {
+	var cb WebhookCallback
+	var probe struct {
+		Version int                          `json:"version"`
+		Events  []map[string]json.RawMessage `json:"events"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		log.Println("ERROR ParseWebhookCallback", err)
+		return cb, err
+	}
+	version := probe.Version
+	if version == 0 {
+		version = WebHookVersion1
+		if len(probe.Events) > 0 {
+			_, hasObjectType := probe.Events[0]["objectType"]
+			_, hasType := probe.Events[0]["type"]
+			if hasType && !hasObjectType {
+				version = WebHookVersion2
+			}
+		}
+	}
+
+	switch version {
+	case WebHookVersion1:
+		if err := json.Unmarshal(body, &cb); err != nil {
+			log.Println("ERROR ParseWebhookCallback", err)
+			return cb, err
+		}
+	case WebHookVersion2:
+		var v2 webhookCallbackV2
+		if err := json.Unmarshal(body, &v2); err != nil {
+			log.Println("ERROR ParseWebhookCallback", err)
+			return cb, err
+		}
+		cb = WebhookCallback{Nonce: v2.Nonce, Timestamp: v2.Timestamp, WebhookId: v2.WebhookId, Scope: v2.Scope,
+			ScopeObjectId: v2.ScopeObjectId, Challenge: v2.Challenge, Events: make([]WebhookCallbackEvent, len(v2.Events))}
+		for i, event := range v2.Events {
+			objectType, eventType := string(event.Type), ""
+			if dot := strings.Index(objectType, "."); dot >= 0 {
+				objectType, eventType = objectType[:dot], objectType[dot+1:]
+			}
+			cb.Events[i] = WebhookCallbackEvent{ObjectType: objectType, EventType: eventType, Id: event.ObjectId,
+				RowId: event.RowId, ColumnId: event.ColumnId, UserId: event.UserId, Timestamp: event.Timestamp, Detail: event.Detail}
+		}
+	default:
+		log.Println("ERROR ParseWebhookCallback version", version)
+		return cb, fmt.Errorf("%w - version %d, this package reads up to %d", ErrWebhookVersion, version, WebHookVersion2)
+	}
+	cb.Version = version
+	return cb, nil
+}
+
+// RowChange is a row or cell event resolved by ResolveWebhookEvents.
+type RowChange struct {
+	RowId       int64
+	ColumnTitle string      // empty for row events, or if column not in SheetInfo columns
+	NewValue    interface{} // current cell value, nil for row events
+	EventType   string      // "objectType.eventType", ex. "cell.updated", "row.created" (see WebHookEvent constants)
+	Timestamp   time.Time
+	Deleted     bool // row deleted (row.deleted event) or no longer in sheet
+}
+
+// ResolveWebhookEvents returns a RowChange for each row and cell event in cb, in event order.
+// Referenced rows are requested using 1 GetSheet call. Column titles are from sheet.ColumnsById, SheetId must be set.
+// Values are the current values, when a cell changes more than once all changes contain the last value.
+// Other events (ex. sheet, column, comment) are ignored.
+func ResolveWebhookEvents(sheet *SheetInfo, cb WebhookCallback) ([]RowChange, error) {
+	trace("ResolveWebhookEvents")
+	rowIds := make([]int64, 0, len(cb.Events))
+	requested := make(map[int64]bool)
+	for _, event := range cb.Events {
+		rowId := event.RowId
+		switch {
+		case event.ObjectType == "row" && event.EventType != "deleted":
+			rowId = event.Id
+		case event.ObjectType != "cell":
+			continue
+		}
+		if !requested[rowId] {
+			requested[rowId] = true
+			rowIds = append(rowIds, rowId)
+		}
+	}
+	rows := make(map[int64]Row, len(rowIds))
+	if len(rowIds) > 0 {
+		current, err := GetSheet(sheet.SheetId, &GetSheetOptions{RowIds: rowIds})
+		if err != nil {
+			log.Println("ERROR ResolveWebhookEvents GetSheet failed", sheet.SheetName, err)
+			return nil, err
+		}
+		for _, row := range current.Rows {
+			rows[row.Id] = row
+		}
+	}
+	changes := make([]RowChange, 0, len(cb.Events))
+	for _, event := range cb.Events {
+		change := RowChange{EventType: fmt.Sprintf("%s.%s", event.ObjectType, event.EventType), Timestamp: event.Timestamp}
+		switch event.ObjectType {
+		case "row":
+			change.RowId = event.Id
+		case "cell":
+			change.RowId = event.RowId
+			change.ColumnTitle = sheet.ColumnsById[event.ColumnId].Title
+		default:
+			continue
+		}
+		row, found := rows[change.RowId]
+		if !found {
+			change.Deleted = true
+			changes = append(changes, change)
+			continue
+		}
+		if event.ObjectType == "cell" {
+			for _, cell := range row.Cells {
+				if cell.ColumnId == event.ColumnId {
+					change.NewValue = cell.Value
+				}
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// ErrDebouncerClosed is returned by EventDebouncer.Add after Close.
+var ErrDebouncerClosed = errors.New("EventDebouncer Closed")
+
+// DebouncedEvent is the last of Count events coalesced by EventDebouncer.
+type DebouncedEvent struct {
+	SheetId int64 // WebhookCallback.ScopeObjectId
+	WebhookCallbackEvent
+	Count int
+}
+
+// debounceKey identifies events that are coalesced, same sheet, object, row, column and event type.
+type debounceKey struct {
+	sheetId               int64
+	objectType, eventType string
+	id, rowId, columnId   int64
+}
+
+// EventDebouncer collects webhook callback events and calls onFlush with the coalesced events
+// once no events have been added for window, or maxDelay after the 1st collected event (if maxDelay > 0).
+// Events for the same sheet, object (row, cell, etc.) and event type are coalesced, the last event is kept.
+// Events are passed to onFlush in the order first added. Safe for use by multiple goroutines,
+// onFlush calls do not overlap. Call Close on shutdown to flush collected events.
+type EventDebouncer struct {
+	window, maxDelay time.Duration
+	onFlush          func([]DebouncedEvent)
+	clock            clock // replaced in tests
+
+	flushMu    sync.Mutex // held while onFlush runs, keeps flushes in order
+	mu         sync.Mutex // protects fields below
+	pending    map[debounceKey]DebouncedEvent
+	order      []debounceKey
+	first      time.Time // time 1st pending event added
+	timer      stopper
+	generation uint64 // incremented when timer replaced, stale timers do not flush
+	closed     bool
+}
+
+// NewEventDebouncer returns an EventDebouncer calling onFlush, see EventDebouncer.
+func NewEventDebouncer(window, maxDelay time.Duration, onFlush func([]DebouncedEvent)) *EventDebouncer {
+	return &EventDebouncer{
+		window:   window,
+		maxDelay: maxDelay,
+		onFlush:  onFlush,
+		clock:    realClock{},
+		pending:  make(map[debounceKey]DebouncedEvent),
+	}
+}
+
+// Add collects the events of a callback and restarts the window.
+func (d *EventDebouncer) Add(cb WebhookCallback) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return ErrDebouncerClosed
+	}
+	if len(cb.Events) == 0 {
+		return nil
+	}
+	now := d.clock.Now()
+	if len(d.order) == 0 {
+		d.first = now
+	}
+	for _, event := range cb.Events {
+		key := debounceKey{cb.ScopeObjectId, event.ObjectType, event.EventType, event.Id, event.RowId, event.ColumnId}
+		merged, found := d.pending[key]
+		if !found {
+			d.order = append(d.order, key)
+		}
+		d.pending[key] = DebouncedEvent{SheetId: cb.ScopeObjectId, WebhookCallbackEvent: event, Count: merged.Count + 1}
+	}
+	deadline := now.Add(d.window)
+	if maxDeadline := d.first.Add(d.maxDelay); d.maxDelay > 0 && maxDeadline.Before(deadline) {
+		deadline = maxDeadline
+	}
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.generation++
+	generation := d.generation
+	d.timer = d.clock.AfterFunc(deadline.Sub(now), func() { d.flush(generation) })
+	return nil
+}
+
+// Close flushes collected events, later calls to Add return ErrDebouncerClosed.
+func (d *EventDebouncer) Close() {
+	d.mu.Lock()
+	d.closed = true
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	generation := d.generation
+	d.mu.Unlock()
+	d.flush(generation)
+}
+
+// flush passes pending events to onFlush, unless generation is not current (timer was replaced).
+func (d *EventDebouncer) flush(generation uint64) {
+	d.flushMu.Lock()
+	defer d.flushMu.Unlock()
+	d.mu.Lock()
+	if generation != d.generation || len(d.order) == 0 {
+		d.mu.Unlock()
+		return
+	}
+	events := make([]DebouncedEvent, len(d.order))
+	for i, key := range d.order {
+		events[i] = d.pending[key]
+	}
+	d.pending = make(map[debounceKey]DebouncedEvent)
+	d.order = nil
+	d.timer = nil
+	d.mu.Unlock()
+	d.onFlush(events)
+}
+
+// clock allows tests to control time used by EventDebouncer.
+type clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) stopper
+}
+
+type stopper interface {
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) AfterFunc(d time.Duration, f func()) stopper { return time.AfterFunc(d, f) }
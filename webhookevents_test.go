@@ -0,0 +1,270 @@
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// callbackJSON is a webhook callback: sheet update, cells updated in rows 11 and 12,
+// row 13 created, row 14 deleted and a cell event for row 15 (deleted before the sheet is requested).
+const callbackJSON = `{"nonce":"4b2ed20d-6f00-4b0c-8fac-082182aa9aac","timestamp":"2024-05-02T10:00:05Z","webhookId":4444,
+	"scope":"sheet","scopeObjectId":1849449510135684,"events":[
+	{"objectType":"sheet","eventType":"updated","id":1849449510135684,"userId":77,"timestamp":"2024-05-02T10:00:00Z"},
+	{"objectType":"row","eventType":"updated","id":11,"userId":77,"timestamp":"2024-05-02T10:00:00Z"},
+	{"objectType":"cell","eventType":"updated","rowId":11,"columnId":108,"userId":77,"timestamp":"2024-05-02T10:00:00Z"},
+	{"objectType":"cell","eventType":"updated","rowId":12,"columnId":105,"userId":77,"timestamp":"2024-05-02T10:00:01Z"},
+	{"objectType":"row","eventType":"created","id":13,"userId":77,"timestamp":"2024-05-02T10:00:02Z"},
+	{"objectType":"row","eventType":"deleted","id":14,"userId":77,"timestamp":"2024-05-02T10:00:03Z"},
+	{"objectType":"cell","eventType":"updated","rowId":15,"columnId":101,"userId":77,"timestamp":"2024-05-02T10:00:04Z"}]}`
+
+func Test_ResolveWebhookEvents(t *testing.T) {
+	var rowIds string
+	requests := 0
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		rowIds = r.URL.Query().Get("rowIds")
+		w.Write([]byte(`{"id":1849449510135684,"rows":[
+			{"id":11,"cells":[{"columnId":101,"value":"1 Main"},{"columnId":108,"value":"Green"}]},
+			{"id":12,"cells":[{"columnId":105,"value":74.5}]},
+			{"id":13,"cells":[{"columnId":101,"value":"3 Oak"}]}]}`))
+	})
+	var cb WebhookCallback
+	if err := json.Unmarshal([]byte(callbackJSON), &cb); err != nil {
+		t.Fatal("Unmarshal callback Failed", err)
+	}
+	changes, err := ResolveWebhookEvents(testSheet(), cb)
+	if err != nil {
+		t.Fatal("ResolveWebhookEvents Failed", err)
+	}
+	if requests != 1 || rowIds != "11,12,13,15" {
+		t.Errorf("ResolveWebhookEvents expected 1 request for rows 11,12,13,15, got %d requests, rowIds %s", requests, rowIds)
+	}
+	at := func(sec int) time.Time { return time.Date(2024, 5, 2, 10, 0, sec, 0, time.UTC) }
+	expect := []RowChange{
+		{RowId: 11, EventType: "row.updated", Timestamp: at(0)},
+		{RowId: 11, ColumnTitle: "Status", NewValue: "Green", EventType: "cell.updated", Timestamp: at(0)},
+		{RowId: 12, ColumnTitle: "Amt", NewValue: 74.5, EventType: "cell.updated", Timestamp: at(1)},
+		{RowId: 13, EventType: "row.created", Timestamp: at(2)},
+		{RowId: 14, EventType: "row.deleted", Timestamp: at(3), Deleted: true},
+		{RowId: 15, ColumnTitle: "Address", EventType: "cell.updated", Timestamp: at(4), Deleted: true},
+	}
+	if len(changes) != len(expect) {
+		t.Fatalf("ResolveWebhookEvents expected %d changes, got %+v", len(expect), changes)
+	}
+	for i, change := range changes {
+		if change.RowId != expect[i].RowId || change.ColumnTitle != expect[i].ColumnTitle || change.NewValue != expect[i].NewValue ||
+			change.EventType != expect[i].EventType || !change.Timestamp.Equal(expect[i].Timestamp) || change.Deleted != expect[i].Deleted {
+			t.Errorf("ResolveWebhookEvents change %d, Expecting %+v, Got %+v", i, expect[i], change)
+		}
+	}
+
+	requests = 0
+	cb.Events = cb.Events[5:6] // only row.deleted, no rows to request
+	if changes, err = ResolveWebhookEvents(testSheet(), cb); err != nil || len(changes) != 1 || requests != 0 {
+		t.Error("ResolveWebhookEvents expected no request for deleted row event", changes, err, requests)
+	}
+}
+
+// fakeClock runs timer funcs synchronously when Advance passes their time.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+func (t *fakeTimer) Stop() bool {
+	stopped := t.stopped
+	t.stopped = true
+	return !stopped
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) stopper {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	timer := &fakeTimer{at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	due := make([]*fakeTimer, 0)
+	for _, timer := range c.timers {
+		if !timer.stopped && !timer.at.After(c.now) {
+			timer.stopped = true
+			due = append(due, timer)
+		}
+	}
+	c.mu.Unlock()
+	for _, timer := range due {
+		timer.f()
+	}
+}
+
+func cellCallback(rowId, columnId int64, sec int) WebhookCallback {
+	at := time.Date(2024, 5, 2, 10, 0, sec, 0, time.UTC)
+	return WebhookCallback{ScopeObjectId: 1849449510135684, Events: []WebhookCallbackEvent{
+		{ObjectType: "sheet", EventType: "updated", Id: 1849449510135684, Timestamp: at},
+		{ObjectType: "cell", EventType: "updated", RowId: rowId, ColumnId: columnId, Timestamp: at},
+	}}
+}
+
+// Test_ParseWebhookCallback reads hand-written callbacks, version 1 in the documented format, version 2 in the assumed format.
+func Test_ParseWebhookCallback(t *testing.T) {
+	normalized := make([][]WebhookCallbackEvent, 0, 2)
+	for version, file := range map[int]string{1: "testdata/webhook_callback_v1.json", 2: "testdata/webhook_callback_v2.json"} {
+		body, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cb, err := ParseWebhookCallback(body)
+		if err != nil {
+			t.Fatal("ParseWebhookCallback Failed", file, err)
+		}
+		if cb.Version != version || cb.WebhookId != 4444 || cb.ScopeObjectId != 1849449510135684 || len(cb.Events) != 4 {
+			t.Errorf("ParseWebhookCallback %s, wrong callback %+v", file, cb)
+			continue
+		}
+		if version == 2 && compactJSON(cb.Events[2].Detail) != `{"previousValue":"Open","value":"Done"}` {
+			t.Error("ParseWebhookCallback expected version 2 event detail, got", string(cb.Events[2].Detail))
+		}
+		for i := range cb.Events {
+			cb.Events[i].Detail = nil
+		}
+		normalized = append(normalized, cb.Events)
+	}
+	if len(normalized) == 2 && fmt.Sprint(normalized[0]) != fmt.Sprint(normalized[1]) {
+		t.Errorf("ParseWebhookCallback versions differ\n%v\n%v", normalized[0], normalized[1])
+	}
+	if len(normalized) > 0 {
+		event := normalized[0][2]
+		if event.ObjectType != "cell" || event.EventType != "updated" || event.RowId != 11 || event.ColumnId != 108 {
+			t.Errorf("ParseWebhookCallback wrong cell event %+v", event)
+		}
+	}
+
+	// undeclared version 2, detected by event type
+	cb, err := ParseWebhookCallback([]byte(`{"webhookId":4444,"events":[{"type":"row.updated","objectId":11}]}`))
+	if err != nil || cb.Version != WebHookVersion2 || cb.Events[0].ObjectType != "row" || cb.Events[0].EventType != "updated" || cb.Events[0].Id != 11 {
+		t.Errorf("ParseWebhookCallback expected version 2 detected, got %+v %v", cb, err)
+	}
+	cb, err = ParseWebhookCallback([]byte(`{"nonce":"n2","webhookId":5555,"challenge":"d78dd1d3"}`))
+	if err != nil || cb.Version != WebHookVersion1 || cb.Challenge != "d78dd1d3" {
+		t.Errorf("ParseWebhookCallback verification request, got %+v %v", cb, err)
+	}
+	if _, err = ParseWebhookCallback([]byte(`{"version":3,"events":[]}`)); !errors.Is(err, ErrWebhookVersion) {
+		t.Error("ParseWebhookCallback expected ErrWebhookVersion, got", err)
+	}
+	if _, err = ParseWebhookCallback([]byte(`{"events":`)); err == nil {
+		t.Error("ParseWebhookCallback expected error for invalid json")
+	}
+}
+
+func Test_EventDebouncer(t *testing.T) {
+	var flushes [][]DebouncedEvent
+	clock := &fakeClock{now: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)}
+	debouncer := NewEventDebouncer(time.Second, 5*time.Second, func(events []DebouncedEvent) {
+		flushes = append(flushes, events)
+	})
+	debouncer.clock = clock
+
+	// burst of 10 callbacks for 2 cells, 300ms apart, flushed 1 window after the last
+	for i := 0; i < 10; i++ {
+		debouncer.Add(cellCallback(11, int64(101+i%2), i))
+		clock.Advance(300 * time.Millisecond)
+	}
+	if len(flushes) != 0 {
+		t.Fatal("EventDebouncer flushed during window", flushes)
+	}
+	clock.Advance(700 * time.Millisecond)
+	if len(flushes) != 1 || len(flushes[0]) != 3 {
+		t.Fatalf("EventDebouncer expected 1 flush of 3 events, got %+v", flushes)
+	}
+	sheetEvent, cell1, cell2 := flushes[0][0], flushes[0][1], flushes[0][2]
+	if sheetEvent.ObjectType != "sheet" || sheetEvent.Count != 10 || sheetEvent.Timestamp.Second() != 9 {
+		t.Errorf("EventDebouncer wrong sheet event %+v", sheetEvent)
+	}
+	if cell1.ColumnId != 101 || cell1.Count != 5 || cell1.Timestamp.Second() != 8 || cell2.ColumnId != 102 || cell2.Count != 5 {
+		t.Errorf("EventDebouncer wrong cell events %+v %+v", cell1, cell2)
+	}
+
+	// continuous events every 500ms are flushed after maxDelay
+	flushes = nil
+	for i := 0; i < 11; i++ {
+		debouncer.Add(cellCallback(int64(20+i), 101, i))
+		clock.Advance(500 * time.Millisecond)
+	}
+	if len(flushes) != 1 || len(flushes[0]) != 11 { // 1 sheet event + rows 20-29
+		t.Fatalf("EventDebouncer expected maxDelay flush of 11 events, got %d flushes", len(flushes))
+	}
+	if len(debouncer.order) != 2 {
+		t.Error("EventDebouncer expected sheet and row 30 events pending after maxDelay flush, got", len(debouncer.order))
+	}
+
+	// close flushes pending events and rejects new ones
+	debouncer.Close()
+	if len(flushes) != 2 || len(flushes[1]) != 2 || flushes[1][1].RowId != 30 {
+		t.Errorf("EventDebouncer Close expected flush of pending events, got %+v", flushes)
+	}
+	if err := debouncer.Add(cellCallback(40, 101, 0)); !errors.Is(err, ErrDebouncerClosed) {
+		t.Error("EventDebouncer expected ErrDebouncerClosed, got", err)
+	}
+	clock.Advance(time.Minute)
+	if len(flushes) != 2 {
+		t.Error("EventDebouncer flushed after Close", len(flushes))
+	}
+}
+
+// Test_EventDebouncerConcurrent feeds events from several goroutines using the real clock (run with -race).
+func Test_EventDebouncerConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	counts := make(map[int64]int) // rowId: coalesced event count
+	debouncer := NewEventDebouncer(5*time.Millisecond, 20*time.Millisecond, func(events []DebouncedEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, event := range events {
+			if event.ObjectType == "cell" {
+				counts[event.RowId] += event.Count
+			}
+		}
+	})
+	var wg sync.WaitGroup
+	for feeder := 0; feeder < 4; feeder++ {
+		wg.Add(1)
+		go func(feeder int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				debouncer.Add(cellCallback(int64(feeder), 101, 0))
+				time.Sleep(100 * time.Microsecond)
+			}
+		}(feeder)
+	}
+	wg.Wait()
+	debouncer.Close()
+	mu.Lock()
+	defer mu.Unlock()
+	for feeder := int64(0); feeder < 4; feeder++ {
+		if counts[feeder] != 50 {
+			t.Errorf("EventDebouncer expected 50 events for row %d, got %d", feeder, counts[feeder])
+		}
+	}
+}
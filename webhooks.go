@@ -6,78 +6,377 @@ package smartsheet
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"strings"
 )
 
+// WebHook Scopes
+const (
+	SheetScope     = "sheet"
+	WorkspaceScope = "workspace" // not available on all plans, api returns an error if not supported
+)
+
+// WebHook Versions, the payload version of the callbacks sent to a webhook (WebHookSpec.Version).
+// ParseWebhookCallback reads both versions into the same WebhookCallback.
+const (
+	WebHookVersion1 = 1
+	WebHookVersion2 = 2 // callbacks include event detail, payload format assumed, see webhookCallbackV2
+)
+
+// WebHookVersion is used when WebHookSpec.Version is 0.
+const WebHookVersion = WebHookVersion1
+
+// WebHookEvent is an event type a webhook is called for, "objectType.eventType".
+type WebHookEvent string
+
+// WebHook Events
+const (
+	EventAll               WebHookEvent = "*.*"
+	EventSheetUpdated      WebHookEvent = "sheet.updated"
+	EventRowCreated        WebHookEvent = "row.created"
+	EventRowUpdated        WebHookEvent = "row.updated"
+	EventRowDeleted        WebHookEvent = "row.deleted"
+	EventCellUpdated       WebHookEvent = "cell.updated"
+	EventColumnCreated     WebHookEvent = "column.created"
+	EventColumnUpdated     WebHookEvent = "column.updated"
+	EventColumnDeleted     WebHookEvent = "column.deleted"
+	EventCommentCreated    WebHookEvent = "comment.created"
+	EventCommentUpdated    WebHookEvent = "comment.updated"
+	EventCommentDeleted    WebHookEvent = "comment.deleted"
+	EventDiscussionCreated WebHookEvent = "discussion.created"
+	EventDiscussionDeleted WebHookEvent = "discussion.deleted"
+	EventAttachmentCreated WebHookEvent = "attachment.created"
+	EventAttachmentUpdated WebHookEvent = "attachment.updated"
+	EventAttachmentDeleted WebHookEvent = "attachment.deleted"
+)
+
+var webHookEvents = map[WebHookEvent]bool{
+	EventAll: true, EventSheetUpdated: true, EventRowCreated: true, EventRowUpdated: true, EventRowDeleted: true,
+	EventCellUpdated: true, EventColumnCreated: true, EventColumnUpdated: true, EventColumnDeleted: true,
+	EventCommentCreated: true, EventCommentUpdated: true, EventCommentDeleted: true,
+	EventDiscussionCreated: true, EventDiscussionDeleted: true,
+	EventAttachmentCreated: true, EventAttachmentUpdated: true, EventAttachmentDeleted: true,
+}
+
+// WebHookSpec is used by CreateWebHookWith.
+type WebHookSpec struct {
+	Name          string
+	CallbackUrl   string
+	Scope         string         // use WebHook Scope constants, default SheetScope
+	ScopeObjectId int64          // sheet or workspace id
+	Events        []WebHookEvent // default EventAll
+	Version       int            // use WebHook Version constants, default WebHookVersion
+	ColumnIds     []int64        // only call webhook when these columns change, sheet scope only
+}
+
+// Validate returns an error describing all invalid spec values, defaults are applied first.
+func (spec *WebHookSpec) Validate() error {
+	spec.setDefaults()
+	problems := make([]string, 0, 3)
+	if spec.Name == "" {
+		problems = append(problems, "Name required")
+	}
+	if !strings.HasPrefix(spec.CallbackUrl, "https://") {
+		problems = append(problems, "CallbackUrl must use https")
+	}
+	if spec.Scope != SheetScope && spec.Scope != WorkspaceScope {
+		problems = append(problems, "unknown Scope "+spec.Scope)
+	}
+	if spec.ScopeObjectId == 0 {
+		problems = append(problems, "ScopeObjectId required")
+	}
+	for _, event := range spec.Events {
+		if !webHookEvents[event] {
+			problems = append(problems, "unknown Event "+string(event))
+		}
+		if event == EventAll && len(spec.Events) > 1 {
+			problems = append(problems, "EventAll cannot be combined with other events")
+		}
+	}
+	if spec.Version < WebHookVersion1 || spec.Version > WebHookVersion2 {
+		problems = append(problems, fmt.Sprintf("unsupported Version %d", spec.Version))
+	}
+	if len(spec.ColumnIds) > 0 && spec.Scope != SheetScope {
+		problems = append(problems, "ColumnIds only allowed with sheet scope")
+	}
+	if len(problems) > 0 {
+		return errors.New("Invalid WebHookSpec - " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (spec *WebHookSpec) setDefaults() {
+	if spec.Scope == "" {
+		spec.Scope = SheetScope
+	}
+	if len(spec.Events) == 0 {
+		spec.Events = []WebHookEvent{EventAll}
+	}
+	if spec.Version == 0 {
+		spec.Version = WebHookVersion
+	}
+}
+
 type webHookRequest struct {
-	Name          string   `json:"name"`
-	CallbackUrl   string   `json:"callbackUrl"`
-	Scope         string   `json:"scope"`
-	ScopeObjectId int64    `json:"scopeObjectId"`
-	Events        []string `json:"events"`
-	Version       int      `json:"version"`
-	SubScope      struct {
-		ColumnIds []int64 `json:"columnIds,omitempty"`
+	Name          string         `json:"name"`
+	CallbackUrl   string         `json:"callbackUrl"`
+	Scope         string         `json:"scope"`
+	ScopeObjectId int64          `json:"scopeObjectId"`
+	Events        []WebHookEvent `json:"events"`
+	Version       int            `json:"version"`
+	SubScope      *struct {
+		ColumnIds []int64 `json:"columnIds"`
 	} `json:"subscope,omitempty"`
 }
 
 // Create WebHook
+// Scope is sheet, called for all events. Optional columnNames limit calls to changes in these columns.
 func CreateWebHook(sheet *SheetInfo, name string, columnNames ...string) (int64, error) {
-
-	hookReq := webHookRequest{
+	spec := WebHookSpec{
 		Name:          name,
 		CallbackUrl:   "https://cheepcode.com/smartsheet/" + name,
-		Scope:         "sheet",
 		ScopeObjectId: sheet.SheetId,
-		Events:        []string{"*.*"},
-		Version:       1,
 	}
 	// optionally, specify columns that trigger webhook call
-	if len(columnNames) > 0 {
-		hookReq.SubScope.ColumnIds = make([]int64, len(columnNames))
-		for i, colName := range columnNames {
-			col, found := sheet.ColumnsByName[colName]
-			if !found {
-				log.Panicln("CreateWebHook bad colName", colName)
-			}
-			hookReq.SubScope.ColumnIds[i] = col.Id
+	for _, colName := range columnNames {
+		col, found := sheet.ColumnsByName[colName]
+		if !found {
+			log.Println("ERROR CreateWebHook bad colName", colName)
+			return 0, fmt.Errorf("%w - %s", ErrInvalidColumnName, colName)
 		}
+		spec.ColumnIds = append(spec.ColumnIds, col.Id)
 	}
-	reqBytes, _ := json.Marshal(hookReq)
-	reqBody := bytes.NewReader(reqBytes)
+	hook, err := CreateWebHookWith(spec)
+	if err != nil {
+		return 0, err
+	}
+	return hook.Id, nil
+}
 
-	fmt.Println(string(reqBytes))
+// WebHook is the api webhook object, returned by CreateWebHookWith and GetWebHook.
+// SharedSecret is used to validate callback signatures, store it by Id (see SecretStore in webhookserver.go).
+// ApiClientVersion is the callback payload version the api sends, which may be newer than the requested Version
+// once the api upgrades the webhook, see CallbackVersion.
+type WebHook struct {
+	Id               int64          `json:"id"`
+	Name             string         `json:"name"`
+	CallbackUrl      string         `json:"callbackUrl"`
+	Scope            string         `json:"scope"`
+	ScopeObjectId    int64          `json:"scopeObjectId"`
+	Events           []WebHookEvent `json:"events"`
+	Version          int            `json:"version"`
+	ApiClientVersion int            `json:"apiClientVersion"` // 0 if not returned by the api
+	Enabled          bool           `json:"enabled"`
+	Status           string         `json:"status"` // ex. "NEW_NOT_VERIFIED", "ENABLED"
+	SharedSecret     string         `json:"sharedSecret"`
+	SubScope         struct {
+		ColumnIds []int64 `json:"columnIds"`
+	} `json:"subscope"`
+}
 
-	url := basePath + "/webhooks"
-	req, _ := http.NewRequest("POST", url, reqBody)
+// CreateWebHookWith creates a webhook using spec, see WebHookSpec.Validate.
+// The webhook must be enabled (see EnableWebHook) before it is called.
+// If the api rejects the webhook, the error includes the scope and wraps the *ApiError (use errors.As for ErrorCode).
+func CreateWebHookWith(spec WebHookSpec) (*WebHook, error) {
+	trace("CreateWebHookWith")
+	if err := spec.Validate(); err != nil {
+		log.Println("ERROR CreateWebHook", err)
+		return nil, err
+	}
+	hookReq := webHookRequest{
+		Name:          spec.Name,
+		CallbackUrl:   spec.CallbackUrl,
+		Scope:         spec.Scope,
+		ScopeObjectId: spec.ScopeObjectId,
+		Events:        spec.Events,
+		Version:       spec.Version,
+	}
+	if len(spec.ColumnIds) > 0 {
+		hookReq.SubScope = &struct {
+			ColumnIds []int64 `json:"columnIds"`
+		}{spec.ColumnIds}
+	}
+	req := Post("/webhooks", hookReq, nil)
 	req.Header.Set("Content-Type", "application/json")
 
 	httpResp, err := DoRequest(req)
 	if err != nil {
-		fmt.Println("xxx CreateWebHook request failed", err)
+		var apiErr *ApiError
+		if errors.As(err, &apiErr) {
+			err = fmt.Errorf("CreateWebHook %s scope %d rejected, ErrorCode %d %s: %w", spec.Scope, spec.ScopeObjectId, apiErr.ErrorCode, apiErr.Message, err)
+		}
+		log.Println("ERROR CreateWebHook", err)
+		return nil, err
 	}
 	defer httpResp.Body.Close()
 
 	responseJSON, _ := ioutil.ReadAll(httpResp.Body)
-	fmt.Println(string(responseJSON))
+	debugLn(string(responseJSON))
 
 	var webHooksResponse struct {
-		Message    string `json:"message"`
-		ResultCode int    `json:"resultCode"`
-		Result     struct {
-			Id int64 `json:"id"`
-		} `json:"result"`
+		Message    string  `json:"message"`
+		ResultCode int     `json:"resultCode"`
+		Result     WebHook `json:"result"`
 	}
 	err = json.Unmarshal(responseJSON, &webHooksResponse)
 	if err != nil {
-		log.Panicln("xxx CreateWebHook Unmarshal Response failed", err)
+		log.Println("ERROR CreateWebHook Unmarshal Response failed", err)
+		return nil, err
+	}
+	return &webHooksResponse.Result, nil
+}
+
+// CallbackVersion returns the payload version of the callbacks sent to the webhook, ApiClientVersion if returned
+// by the api, otherwise Version.
+func (hook *WebHook) CallbackVersion() int {
+	if hook.ApiClientVersion > 0 {
+		return hook.ApiClientVersion
+	}
+	return hook.Version
+}
+
+// ListWebHooks returns all webhooks owned by the user, all pages of the api response are requested.
+func ListWebHooks() ([]WebHook, error) {
+	trace("ListWebHooks")
+	hooks := make([]WebHook, 0, 10)
+	err := getAllPages("/webhooks", nil, func(data json.RawMessage) error {
+		var pageHooks []WebHook
+		err := json.Unmarshal(data, &pageHooks)
+		hooks = append(hooks, pageHooks...)
+		return err
+	})
+	if err != nil {
+		log.Println("ERROR ListWebHooks", err)
+		return nil, err
+	}
+	return hooks, nil
+}
+
+// EnsureWebHook makes the user's webhooks match spec, so it can be called at every deployment of a callback service
+// without leaving stale webhooks behind. Webhooks are matched by Name and ScopeObjectId:
+//   - none found, a webhook is created
+//   - found with a different CallbackUrl or Events, it is updated
+//   - found with different ColumnIds, it is replaced (the api cannot update the subscope)
+//
+// The webhook is enabled if not already, and other webhooks matching Name and ScopeObjectId are deleted (an enabled
+// match is kept in preference). The final webhook is returned, a created or replaced webhook has a new SharedSecret.
+func EnsureWebHook(spec WebHookSpec) (*WebHook, error) {
+	trace("EnsureWebHook")
+	if err := spec.Validate(); err != nil {
+		log.Println("ERROR EnsureWebHook", err)
+		return nil, err
+	}
+	hooks, err := ListWebHooks()
+	if err != nil {
+		return nil, err
+	}
+	var hook *WebHook
+	duplicates := make([]int64, 0)
+	for i := range hooks {
+		if hooks[i].Name != spec.Name || hooks[i].ScopeObjectId != spec.ScopeObjectId {
+			continue
+		}
+		switch {
+		case !sameIds(hooks[i].SubScope.ColumnIds, spec.ColumnIds):
+			duplicates = append(duplicates, hooks[i].Id)
+		case hook == nil:
+			hook = &hooks[i]
+		case hooks[i].Enabled && !hook.Enabled:
+			duplicates = append(duplicates, hook.Id)
+			hook = &hooks[i]
+		default:
+			duplicates = append(duplicates, hooks[i].Id)
+		}
+	}
+
+	if hook == nil {
+		if hook, err = CreateWebHookWith(spec); err != nil {
+			return nil, err
+		}
+	}
+	if !hook.Enabled || hook.CallbackUrl != spec.CallbackUrl || !sameEvents(hook.Events, spec.Events) {
+		update := map[string]interface{}{
+			"callbackUrl": spec.CallbackUrl,
+			"events":      spec.Events,
+			"version":     spec.Version,
+			"enabled":     true,
+		}
+		if hook, err = updateWebHook(hook.Id, update); err != nil {
+			log.Println("ERROR EnsureWebHook Update Failed", spec.Name, err)
+			return nil, err
+		}
+	}
+	for _, webHookId := range duplicates {
+		if err = deleteObject(fmt.Sprintf("/webhooks/%d", webHookId)); err != nil {
+			log.Println("ERROR EnsureWebHook Delete Duplicate Failed", webHookId, err)
+			return hook, err
+		}
+	}
+	return hook, nil
+}
+
+// updateWebHook sends the changed webhook attributes and returns the updated webhook.
+func updateWebHook(webHookId int64, update map[string]interface{}) (*WebHook, error) {
+	req := Put(fmt.Sprintf("/webhooks/%d", webHookId), update, nil)
+	req.Header.Set("Content-Type", "application/json")
+	httpResp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	responseJSON, _ := ioutil.ReadAll(httpResp.Body)
+	debugLn(string(responseJSON))
+
+	var webHooksResponse struct {
+		Message    string  `json:"message"`
+		ResultCode int     `json:"resultCode"`
+		Result     WebHook `json:"result"`
+	}
+	if err = json.Unmarshal(responseJSON, &webHooksResponse); err != nil {
+		return nil, err
+	}
+	return &webHooksResponse.Result, nil
+}
+
+// sameEvents returns true if a and b contain the same events, in any order.
+func sameEvents(a, b []WebHookEvent) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[WebHookEvent]int)
+	for _, event := range a {
+		counts[event]++
+	}
+	for _, event := range b {
+		if counts[event] == 0 {
+			return false
+		}
+		counts[event]--
 	}
-	fmt.Printf("%+v\n\n", webHooksResponse)
+	return true
+}
 
-	return webHooksResponse.Result.Id, err
+// sameIds returns true if a and b contain the same ids, in any order.
+func sameIds(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[int64]int)
+	for _, id := range a {
+		counts[id]++
+	}
+	for _, id := range b {
+		if counts[id] == 0 {
+			return false
+		}
+		counts[id]--
+	}
+	return true
 }
 
 func EnableWebHook(webHookId int64) error {
@@ -107,23 +406,25 @@ func EnableWebHook(webHookId int64) error {
 	return err
 }
 
-func GetWebHook(webHookId int64) error {
-
-	url := fmt.Sprintf(basePath+"/webhooks/%d", webHookId)
-	fmt.Println("url", url)
-
-	req, _ := http.NewRequest("GET", url, nil)
-
-	httpResp, err := DoRequest(req)
+// GetWebHook returns the webhook, including its ApiClientVersion (the callback payload version the api sends).
+func GetWebHook(webHookId int64) (*WebHook, error) {
+	trace("GetWebHook")
+	httpResp, err := DoRequest(Get(fmt.Sprintf("/webhooks/%d", webHookId), nil))
 	if err != nil {
-		fmt.Println("xxx GetWebHook failed", err)
+		log.Println("ERROR GetWebHook", webHookId, err)
+		return nil, err
 	}
 	defer httpResp.Body.Close()
 
 	responseJSON, _ := ioutil.ReadAll(httpResp.Body)
-	fmt.Println("-- Response\n", string(responseJSON))
+	debugLn(string(responseJSON))
 
-	return err
+	hook := new(WebHook)
+	if err = json.Unmarshal(responseJSON, hook); err != nil {
+		log.Println("ERROR GetWebHook Unmarshal Response failed", err)
+		return nil, err
+	}
+	return hook, nil
 }
 
 func DeleteWebHook(webHookId int64) error {
@@ -0,0 +1,206 @@
+package smartsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func Test_CreateWebHookWith(t *testing.T) {
+	var reqJSON string
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/webhooks" || r.Method != "POST" {
+			t.Error("CreateWebHookWith wrong request", r.Method, r.URL.Path)
+		}
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		reqJSON = compactJSON(reqBytes)
+		if strings.Contains(reqJSON, `"scope":"workspace"`) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorCode":1032,"message":"The attribute(s) webhook.scope contains an invalid value."}`))
+			return
+		}
+		w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":4444,"status":"NEW_NOT_VERIFIED","sharedSecret":"216ejjzfsss2y9jm8yzqvbd0hf"}}`))
+	})
+
+	sheet := testSheet()
+	id, err := CreateWebHook(sheet, "orders", "Status", "Amt")
+	if err != nil || id != 4444 {
+		t.Fatal("CreateWebHook Failed", id, err)
+	}
+	expect := `{"name":"orders","callbackUrl":"https://cheepcode.com/smartsheet/orders","scope":"sheet","scopeObjectId":1849449510135684,` +
+		`"events":["*.*"],"version":1,"subscope":{"columnIds":[108,105]}}`
+	if reqJSON != expect {
+		t.Errorf("CreateWebHook request, Expecting\n%s\nGot\n%s", expect, reqJSON)
+	}
+
+	spec := WebHookSpec{Name: "rows", CallbackUrl: "https://test.com/hook", ScopeObjectId: 1849449510135684,
+		Events: []WebHookEvent{EventRowCreated, EventCellUpdated}}
+	hook, err := CreateWebHookWith(spec)
+	if err != nil {
+		t.Fatal("CreateWebHookWith Failed", err)
+	}
+	if hook.Id != 4444 || hook.SharedSecret != "216ejjzfsss2y9jm8yzqvbd0hf" || hook.Status != "NEW_NOT_VERIFIED" {
+		t.Errorf("CreateWebHookWith wrong result %+v", hook)
+	}
+	expect = `{"name":"rows","callbackUrl":"https://test.com/hook","scope":"sheet","scopeObjectId":1849449510135684,` +
+		`"events":["row.created","cell.updated"],"version":1}`
+	if reqJSON != expect {
+		t.Errorf("CreateWebHookWith request, Expecting\n%s\nGot\n%s", expect, reqJSON)
+	}
+
+	spec = WebHookSpec{Name: "ops", CallbackUrl: "https://test.com/hook", Scope: WorkspaceScope, ScopeObjectId: 55}
+	_, err = CreateWebHookWith(spec)
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != 1032 || !strings.Contains(err.Error(), "workspace scope 55 rejected, ErrorCode 1032") {
+		t.Error("CreateWebHookWith expected scope ApiError, got", err)
+	}
+	expect = `{"name":"ops","callbackUrl":"https://test.com/hook","scope":"workspace","scopeObjectId":55,"events":["*.*"],"version":1}`
+	if reqJSON != expect {
+		t.Errorf("CreateWebHookWith workspace request, Expecting\n%s\nGot\n%s", expect, reqJSON)
+	}
+
+	reqJSON = ""
+	invalid := []WebHookSpec{
+		{Name: "x", CallbackUrl: "http://test.com", ScopeObjectId: 1},
+		{Name: "x", CallbackUrl: "https://test.com", Scope: "report", ScopeObjectId: 1},
+		{Name: "x", CallbackUrl: "https://test.com", ScopeObjectId: 1, Events: []WebHookEvent{"row.moved"}},
+		{Name: "x", CallbackUrl: "https://test.com", ScopeObjectId: 1, Events: []WebHookEvent{EventAll, EventRowCreated}},
+		{Name: "x", CallbackUrl: "https://test.com", ScopeObjectId: 1, Version: 3},
+		{Name: "x", CallbackUrl: "https://test.com", Scope: WorkspaceScope, ScopeObjectId: 55, ColumnIds: []int64{101}},
+	}
+	for _, spec := range invalid {
+		if _, err = CreateWebHookWith(spec); err == nil {
+			t.Errorf("CreateWebHookWith expected error for %+v", spec)
+		}
+	}
+	if reqJSON != "" {
+		t.Error("CreateWebHookWith expected no request for invalid specs")
+	}
+	if _, err = CreateWebHook(sheet, "bad", "Nope"); !errors.Is(err, ErrInvalidColumnName) {
+		t.Error("CreateWebHook expected ErrInvalidColumnName, got", err)
+	}
+}
+
+func Test_EnsureWebHook(t *testing.T) {
+	var mu sync.Mutex
+	var hooks []WebHook
+	nextId := int64(100)
+	requests := make([]string, 0)
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		reqBytes, _ := ioutil.ReadAll(r.Body)
+		id, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/webhooks/"), 10, 64)
+		switch {
+		case r.Method == "GET":
+			data, _ := json.Marshal(hooks)
+			fmt.Fprintf(w, `{"pageNumber":1,"totalPages":1,"data":%s}`, data)
+		case r.Method == "POST":
+			var hook WebHook
+			json.Unmarshal(reqBytes, &hook)
+			nextId++
+			hook.Id, hook.Status, hook.SharedSecret = nextId, "NEW_NOT_VERIFIED", "secret"
+			hooks = append(hooks, hook)
+			result, _ := json.Marshal(hook)
+			fmt.Fprintf(w, `{"message":"SUCCESS","resultCode":0,"result":%s}`, result)
+		case r.Method == "PUT":
+			for i := range hooks {
+				if hooks[i].Id == id {
+					json.Unmarshal(reqBytes, &hooks[i])
+					hooks[i].Status = "ENABLED"
+					result, _ := json.Marshal(hooks[i])
+					fmt.Fprintf(w, `{"message":"SUCCESS","resultCode":0,"result":%s}`, result)
+				}
+			}
+		case r.Method == "DELETE":
+			for i := range hooks {
+				if hooks[i].Id == id {
+					hooks = append(hooks[:i], hooks[i+1:]...)
+					break
+				}
+			}
+			w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+		}
+	})
+	spec := WebHookSpec{Name: "orders", CallbackUrl: "https://test.com/v1", ScopeObjectId: 55, Events: []WebHookEvent{EventRowCreated}}
+
+	// create new
+	hook, err := EnsureWebHook(spec)
+	if err != nil {
+		t.Fatal("EnsureWebHook Failed", err)
+	}
+	expect := "GET /webhooks POST /webhooks PUT /webhooks/101"
+	if hook.Id != 101 || !hook.Enabled || len(hooks) != 1 || strings.Join(requests, " ") != expect {
+		t.Errorf("EnsureWebHook create, Expecting %s, Got %v %+v", expect, requests, hook)
+	}
+
+	// unchanged, list only
+	requests = requests[:0]
+	if hook, err = EnsureWebHook(spec); err != nil || hook.Id != 101 || strings.Join(requests, " ") != "GET /webhooks" {
+		t.Error("EnsureWebHook unchanged expected list only, got", requests, err)
+	}
+
+	// update existing callback url and events in place
+	requests = requests[:0]
+	spec.CallbackUrl, spec.Events = "https://test.com/v2", []WebHookEvent{EventCellUpdated, EventRowCreated}
+	hook, err = EnsureWebHook(spec)
+	if err != nil || hook.Id != 101 || hook.CallbackUrl != "https://test.com/v2" || len(hook.Events) != 2 ||
+		strings.Join(requests, " ") != "GET /webhooks PUT /webhooks/101" {
+		t.Errorf("EnsureWebHook update, got %v %+v %v", requests, hook, err)
+	}
+
+	// prune duplicates, enabled hook kept, other scope and names untouched
+	mu.Lock()
+	hooks = append(hooks,
+		WebHook{Id: 90, Name: "orders", ScopeObjectId: 55, CallbackUrl: "https://test.com/v0", Events: spec.Events},
+		WebHook{Id: 91, Name: "orders", ScopeObjectId: 66, CallbackUrl: "https://test.com/v2", Events: spec.Events},
+		WebHook{Id: 92, Name: "audit", ScopeObjectId: 55, CallbackUrl: "https://test.com/v2", Events: spec.Events},
+	)
+	hooks[0], hooks[1] = hooks[1], hooks[0] // disabled duplicate listed first
+	mu.Unlock()
+	requests = requests[:0]
+	hook, err = EnsureWebHook(spec)
+	if err != nil || hook.Id != 101 || strings.Join(requests, " ") != "GET /webhooks DELETE /webhooks/90" || len(hooks) != 3 {
+		t.Errorf("EnsureWebHook prune, got %v %+v %v", requests, hooks, err)
+	}
+
+	// different columns, replaced
+	requests = requests[:0]
+	spec.ColumnIds = []int64{105}
+	hook, err = EnsureWebHook(spec)
+	expect = "GET /webhooks POST /webhooks PUT /webhooks/102 DELETE /webhooks/101"
+	if err != nil || hook.Id != 102 || strings.Join(requests, " ") != expect || len(hooks) != 3 {
+		t.Errorf("EnsureWebHook subscope, Expecting %s, Got %v %v", expect, requests, err)
+	}
+
+	if _, err = EnsureWebHook(WebHookSpec{Name: "x", CallbackUrl: "http://test.com", ScopeObjectId: 1}); err == nil {
+		t.Error("EnsureWebHook expected invalid spec error")
+	}
+}
+
+func Test_GetWebHook(t *testing.T) {
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/webhooks/4444" || r.Method != "GET" {
+			t.Error("GetWebHook wrong request", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"id":4444,"name":"orders","scope":"sheet","scopeObjectId":1849449510135684,"events":["*.*"],
+			"version":1,"apiClientVersion":2,"enabled":true,"status":"ENABLED"}`))
+	})
+	hook, err := GetWebHook(4444)
+	if err != nil {
+		t.Fatal("GetWebHook Failed", err)
+	}
+	if hook.Id != 4444 || hook.Version != WebHookVersion1 || hook.ApiClientVersion != WebHookVersion2 || hook.CallbackVersion() != WebHookVersion2 {
+		t.Errorf("GetWebHook wrong result %+v", hook)
+	}
+	if version := (&WebHook{Version: WebHookVersion1}).CallbackVersion(); version != WebHookVersion1 {
+		t.Error("CallbackVersion expected Version when ApiClientVersion not returned, got", version)
+	}
+}
@@ -0,0 +1,142 @@
+// webhookserver.go contains WebhookServer, an http.Handler receiving webhook callbacks,
+// and SecretStore, used to find the shared secret of each webhook to validate callback signatures.
+
+package smartsheet
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sync"
+)
+
+// SignatureHeader contains the HMAC-SHA256 (hex) of the callback body, using the webhook's SharedSecret.
+const SignatureHeader = "Smartsheet-Hmac-SHA256"
+
+// ErrSecretNotFound is returned by SecretStore.GetSecret when the webhook id is unknown.
+var ErrSecretNotFound = errors.New("WebHook Secret Not Found")
+
+// SecretStore returns the shared secret of a webhook (WebHook.SharedSecret).
+// Use MemorySecretStore, or SecretStoreFunc to look up secrets in a database.
+type SecretStore interface {
+	GetSecret(webhookId int64) (string, error)
+}
+
+// SecretStoreFunc allows a func to be used as a SecretStore.
+type SecretStoreFunc func(webhookId int64) (string, error)
+
+// GetSecret calls f.
+func (f SecretStoreFunc) GetSecret(webhookId int64) (string, error) {
+	return f(webhookId)
+}
+
+// MemorySecretStore is a SecretStore safe for use by multiple goroutines.
+type MemorySecretStore struct {
+	mu      sync.RWMutex
+	secrets map[int64]string
+}
+
+// NewMemorySecretStore returns an empty MemorySecretStore.
+func NewMemorySecretStore() *MemorySecretStore {
+	return &MemorySecretStore{secrets: make(map[int64]string)}
+}
+
+// Add stores the shared secret of a webhook returned by CreateWebHookWith.
+func (store *MemorySecretStore) Add(hook *WebHook) {
+	store.SetSecret(hook.Id, hook.SharedSecret)
+}
+
+// SetSecret stores the shared secret of webhookId, replacing any previous secret.
+func (store *MemorySecretStore) SetSecret(webhookId int64, secret string) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.secrets[webhookId] = secret
+}
+
+// GetSecret returns the shared secret of webhookId, error wraps ErrSecretNotFound if not stored.
+func (store *MemorySecretStore) GetSecret(webhookId int64) (string, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	secret, found := store.secrets[webhookId]
+	if !found {
+		return "", fmt.Errorf("%w - %d", ErrSecretNotFound, webhookId)
+	}
+	return secret, nil
+}
+
+// ValidateSignature returns true if signature (SignatureHeader value) is the HMAC-SHA256 of body using secret.
+func ValidateSignature(secret string, body []byte, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+	received, err := hex.DecodeString(signature)
+	return err == nil && hmac.Equal(expected, received)
+}
+
+// WebhookServer handles webhook callback requests for any number of webhooks.
+// Each request's signature is validated using the secret of its webhook id, invalid requests get status 403.
+// Verification requests (Challenge set) are answered, other callbacks are parsed (see ParseWebhookCallback) and passed
+// to OnCallback. If Nonces is set, a callback whose nonce was already received (redelivered by the api) is answered
+// but not passed to OnCallback, and counted in Stats (DuplicateCallbacks).
+type WebhookServer struct {
+	Secrets    SecretStore
+	OnCallback func(WebhookCallback) // called before the response is sent, should not block (ex. use EventDebouncer.Add)
+	Nonces     NonceStore            // ex. NewNonceCache(0, 0), nil to pass every callback to OnCallback
+}
+
+func (server *WebhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	cb, err := ParseWebhookCallback(body)
+	if err != nil {
+		log.Println("ERROR WebhookServer Parse Callback failed", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	secret, err := server.Secrets.GetSecret(cb.WebhookId)
+	if err != nil {
+		log.Println("ERROR WebhookServer", err)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if !ValidateSignature(secret, body, r.Header.Get(SignatureHeader)) {
+		log.Println("ERROR WebhookServer invalid signature, webhookId", cb.WebhookId)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if cb.Challenge != "" {
+		w.Header().Set("Smartsheet-Hook-Response", cb.Challenge)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"smartsheetHookResponse": cb.Challenge})
+		return
+	}
+	if server.Nonces != nil && cb.Nonce != "" {
+		seen, err := server.Nonces.Seen(cb.Nonce)
+		if err != nil { // callback is processed, a duplicate is preferred to a lost callback
+			log.Println("ERROR WebhookServer NonceStore", err)
+		}
+		if seen {
+			log.Println("WebhookServer duplicate callback dropped, webhookId", cb.WebhookId, "nonce", cb.Nonce)
+			countDuplicateCallback()
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+	if server.OnCallback != nil {
+		server.OnCallback(cb)
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,137 @@
+package smartsheet
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func Test_WebhookServer(t *testing.T) {
+	store := NewMemorySecretStore()
+	store.Add(&WebHook{Id: 4444, SharedSecret: "216ejjzfsss2y9jm8yzqvbd0hf"})
+	store.SetSecret(5555, "xkq77d0jtb1f4b9k3s2p")
+
+	var received []int64 // webhook ids passed to OnCallback
+	server := httptest.NewServer(&WebhookServer{
+		Secrets:    store,
+		OnCallback: func(cb WebhookCallback) { received = append(received, cb.WebhookId) },
+	})
+	defer server.Close()
+
+	post := func(body []byte, signature string) *http.Response {
+		req, _ := http.NewRequest("POST", server.URL, bytes.NewReader(body))
+		req.Header.Set(SignatureHeader, signature)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal("WebhookServer request failed", err)
+		}
+		return resp
+	}
+	callback := func(webhookId int64) []byte {
+		return []byte(fmt.Sprintf(`{"nonce":"n1","webhookId":%d,"scope":"sheet","scopeObjectId":1849449510135684,
+			"events":[{"objectType":"row","eventType":"created","id":11}]}`, webhookId))
+	}
+	tests := []struct {
+		webhookId int64
+		secret    string
+		status    int
+	}{
+		{4444, "216ejjzfsss2y9jm8yzqvbd0hf", http.StatusOK},
+		{5555, "xkq77d0jtb1f4b9k3s2p", http.StatusOK},
+		{4444, "xkq77d0jtb1f4b9k3s2p", http.StatusForbidden}, // other webhook's secret
+		{5555, "216ejjzfsss2y9jm8yzqvbd0hf", http.StatusForbidden},
+		{6666, "216ejjzfsss2y9jm8yzqvbd0hf", http.StatusForbidden}, // unknown webhook
+	}
+	for _, test := range tests {
+		body := callback(test.webhookId)
+		resp := post(body, sign(test.secret, body))
+		resp.Body.Close()
+		if resp.StatusCode != test.status {
+			t.Errorf("WebhookServer webhook %d, Expecting status %d, Got %d", test.webhookId, test.status, resp.StatusCode)
+		}
+	}
+	if fmt.Sprint(received) != "[4444 5555]" {
+		t.Error("WebhookServer expected callbacks for 4444 and 5555 only, got", received)
+	}
+
+	challenge := []byte(`{"nonce":"n2","webhookId":5555,"challenge":"d78dd1d3-01b0-4dc7-9b27-4bd1f6d4e7ae"}`)
+	resp := post(challenge, sign("xkq77d0jtb1f4b9k3s2p", challenge))
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.Header.Get("Smartsheet-Hook-Response") != "d78dd1d3-01b0-4dc7-9b27-4bd1f6d4e7ae" ||
+		compactJSON(respBody) != `{"smartsheetHookResponse":"d78dd1d3-01b0-4dc7-9b27-4bd1f6d4e7ae"}` {
+		t.Error("WebhookServer wrong verification response", resp.Header, string(respBody))
+	}
+	if len(received) != 2 {
+		t.Error("WebhookServer expected verification request not passed to OnCallback")
+	}
+}
+
+func Test_WebhookServerNonces(t *testing.T) {
+	store := NewMemorySecretStore()
+	store.SetSecret(4444, "216ejjzfsss2y9jm8yzqvbd0hf")
+	dispatched := 0
+	server := httptest.NewServer(&WebhookServer{
+		Secrets:    store,
+		OnCallback: func(cb WebhookCallback) { dispatched++ },
+		Nonces:     NewNonceCache(0, 0),
+	})
+	defer server.Close()
+
+	ResetStats()
+	body := []byte(`{"nonce":"4b2ed20d","webhookId":4444,"scope":"sheet","scopeObjectId":1849449510135684,
+		"events":[{"objectType":"row","eventType":"created","id":11}]}`)
+	for i := 0; i < 2; i++ { // redelivered
+		req, _ := http.NewRequest("POST", server.URL, bytes.NewReader(body))
+		req.Header.Set(SignatureHeader, sign("216ejjzfsss2y9jm8yzqvbd0hf", body))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal("WebhookServer request failed", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Error("WebhookServer expected status 200 for callback and duplicate, got", resp.StatusCode)
+		}
+	}
+	if dispatched != 1 || Stats().DuplicateCallbacks != 1 {
+		t.Error("WebhookServer expected 1 dispatch and 1 duplicate dropped, got", dispatched, Stats().DuplicateCallbacks)
+	}
+}
+
+func Test_SecretStoreFunc(t *testing.T) {
+	lookups := 0
+	var store SecretStore = SecretStoreFunc(func(webhookId int64) (string, error) {
+		lookups++
+		if webhookId == 4444 {
+			return "216ejjzfsss2y9jm8yzqvbd0hf", nil
+		}
+		return "", ErrSecretNotFound
+	})
+	body := []byte(`{"webhookId":4444}`)
+	secret, err := store.GetSecret(4444)
+	if err != nil || !ValidateSignature(secret, body, sign("216ejjzfsss2y9jm8yzqvbd0hf", body)) {
+		t.Error("SecretStoreFunc expected valid signature", err)
+	}
+	if ValidateSignature(secret, body, "not-hex") || ValidateSignature(secret, body, sign("other", body)) {
+		t.Error("ValidateSignature expected invalid signatures to fail")
+	}
+	if _, err = NewMemorySecretStore().GetSecret(4444); !errors.Is(err, ErrSecretNotFound) {
+		t.Error("MemorySecretStore expected ErrSecretNotFound, got", err)
+	}
+	if lookups != 1 {
+		t.Error("SecretStoreFunc expected 1 lookup, got", lookups)
+	}
+}
@@ -0,0 +1,190 @@
+// workspacereport.go contains WorkspaceReport, an inventory of the sheets in a workspace (row and column counts,
+// last modified time, owner, webhooks and attachments), and ListSheetsInWorkspace.
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultReportConcurrency is used when ReportOptions.Concurrency is 0.
+const DefaultReportConcurrency = 4
+
+// ListSheetsInWorkspace returns every sheet in a workspace, including sheets in folders (recursively).
+// Path is the workspace and folder names followed by the sheet name, as Home.AllSheets.
+func ListSheetsInWorkspace(workspaceId int64) ([]HomeSheet, error) {
+	trace("ListSheetsInWorkspace")
+	endPoint := fmt.Sprintf("/workspaces/%d", workspaceId)
+	req := Get(endPoint, newQuery().flag("loadAll", true).parms())
+	resp, err := DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respJSON, _ := ioutil.ReadAll(resp.Body)
+	debugLn(string(respJSON))
+
+	var workspace HomeWorkspace
+	if err = json.Unmarshal(respJSON, &workspace); err != nil {
+		log.Println("ERROR ListSheetsInWorkspace Unmarshal Response Failed", err)
+		return nil, err
+	}
+	return appendHomeSheets(nil, workspace.Name+homePathSep, workspace.Sheets, workspace.Folders), nil
+}
+
+// WorkspaceInventory is returned by WorkspaceReport.
+type WorkspaceInventory struct {
+	WorkspaceId int64
+	Options     ReportOptions
+	Sheets      []SheetInventory // in ListSheetsInWorkspace order
+}
+
+// SheetInventory is 1 sheet of a WorkspaceInventory. If a request for the sheet failed, Err is set and the values
+// of the failed request are 0.
+type SheetInventory struct {
+	SheetId      int64
+	Path         string // workspace and folder names followed by sheet name
+	RowCount     int
+	ColumnCount  int
+	ModifiedAt   time.Time
+	Owner        string // owner email
+	Webhooks     int    // webhooks with the sheet as scope, set if ReportOptions.Webhooks
+	Attachments  int    // sheet, row and comment attachments, set if ReportOptions.Attachments
+	AttachmentKb int64  // total size of file attachments, set if ReportOptions.Attachments
+	Err          error
+}
+
+// Failed returns the number of sheets with an error.
+func (report *WorkspaceInventory) Failed() int {
+	count := 0
+	for _, sheet := range report.Sheets {
+		if sheet.Err != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// WorkspaceReport lists the sheets in a workspace and requests each sheet without rows (row count, columns,
+// modified time and owner), using up to opts.Concurrency goroutines. Webhooks and attachments are added if set in opts.
+// A failed sheet is recorded in its SheetInventory.Err and does not stop the report, an error is only returned if the
+// workspace (or webhook) list cannot be requested.
+func WorkspaceReport(workspaceId int64, opts ReportOptions) (*WorkspaceInventory, error) {
+	trace("WorkspaceReport")
+	sheets, err := ListSheetsInWorkspace(workspaceId)
+	if err != nil {
+		log.Println("ERROR WorkspaceReport", workspaceId, err)
+		return nil, err
+	}
+	webhooks := make(map[int64]int)
+	if opts.Webhooks {
+		hooks, err := ListWebHooks()
+		if err != nil {
+			log.Println("ERROR WorkspaceReport", workspaceId, err)
+			return nil, err
+		}
+		for _, hook := range hooks {
+			if hook.Scope == SheetScope {
+				webhooks[hook.ScopeObjectId]++
+			}
+		}
+	}
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = DefaultReportConcurrency
+	}
+
+	report := &WorkspaceInventory{WorkspaceId: workspaceId, Options: opts, Sheets: make([]SheetInventory, len(sheets))}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				entry := &report.Sheets[i]
+				entry.SheetId, entry.Path = sheets[i].Id, sheets[i].Path
+				entry.Webhooks = webhooks[entry.SheetId]
+				entry.Err = entry.load(opts)
+			}
+		}()
+	}
+	for i := range sheets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if failed := report.Failed(); failed > 0 {
+		log.Println("ERROR WorkspaceReport", failed, "of", len(sheets), "sheets failed")
+	}
+	return report, nil
+}
+
+// load requests the sheet without rows, and its attachments if set in opts.
+func (entry *SheetInventory) load(opts ReportOptions) error {
+	sheet, err := GetSheet(entry.SheetId, &GetSheetOptions{RowIds: []int64{0}, IncludeOwnerInfo: true})
+	if err != nil {
+		return err
+	}
+	entry.RowCount, entry.ColumnCount, entry.Owner = sheet.TotalRowCount, len(sheet.Columns), sheet.Owner
+	entry.ModifiedAt, _ = time.Parse(time.RFC3339, sheet.ModifiedAt)
+	if !opts.Attachments {
+		return nil
+	}
+	attachments, err := ListSheetAttachments(entry.SheetId)
+	if err != nil {
+		return err
+	}
+	entry.Attachments = len(attachments)
+	for _, attachment := range attachments {
+		entry.AttachmentKb += attachment.SizeInKb
+	}
+	return nil
+}
+
+// WriteCSV writes the report to w, 1 line per sheet after a header line. The Webhooks and Attachments columns
+// are only written if set in the report Options. ModifiedAt is in RFC3339 format, Error is empty for sheets not failed.
+func (report *WorkspaceInventory) WriteCSV(w io.Writer, opts ExportOptions) error {
+	trace("WorkspaceInventory.WriteCSV")
+	csvWriter, err := opts.newWriter(w)
+	if err != nil {
+		log.Println("ERROR - WorkspaceInventory.WriteCSV", err)
+		return err
+	}
+	header := []string{"SheetId", "Path", "Rows", "Columns", "ModifiedAt", "Owner"}
+	if report.Options.Webhooks {
+		header = append(header, "Webhooks")
+	}
+	if report.Options.Attachments {
+		header = append(header, "Attachments", "AttachmentKb")
+	}
+	csvWriter.Write(append(header, "Error"))
+	for _, sheet := range report.Sheets {
+		modifiedAt, errText := "", ""
+		if !sheet.ModifiedAt.IsZero() {
+			modifiedAt = sheet.ModifiedAt.Format(time.RFC3339)
+		}
+		if sheet.Err != nil {
+			errText = sheet.Err.Error()
+		}
+		record := []string{strconv.FormatInt(sheet.SheetId, 10), sheet.Path, strconv.Itoa(sheet.RowCount),
+			strconv.Itoa(sheet.ColumnCount), modifiedAt, sheet.Owner}
+		if report.Options.Webhooks {
+			record = append(record, strconv.Itoa(sheet.Webhooks))
+		}
+		if report.Options.Attachments {
+			record = append(record, strconv.Itoa(sheet.Attachments), strconv.FormatInt(sheet.AttachmentKb, 10))
+		}
+		csvWriter.Write(append(record, errText))
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
@@ -0,0 +1,68 @@
+package smartsheet
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_WorkspaceReport(t *testing.T) {
+	stubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/9":
+			if r.URL.Query().Get("loadAll") != "true" {
+				t.Error("ListSheetsInWorkspace expected loadAll, got", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"id":9,"name":"Ops","sheets":[{"id":1,"name":"Orders"}],
+				"folders":[{"id":5,"name":"Archive","sheets":[{"id":2,"name":"Orders 2023"},{"id":3,"name":"Deleted"}]}]}`))
+		case "/webhooks":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"id":71,"scope":"sheet","scopeObjectId":1},
+				{"id":72,"scope":"sheet","scopeObjectId":1},{"id":73,"scope":"workspace","scopeObjectId":2}]}`))
+		case "/sheets/1", "/sheets/2":
+			if r.URL.Query().Get("rowIds") != "0" {
+				t.Error("WorkspaceReport expected sheet without rows, got", r.URL.RawQuery)
+			}
+			id := strings.TrimPrefix(r.URL.Path, "/sheets/")
+			fmt.Fprintf(w, `{"id":%s,"totalRowCount":%s0,"modifiedAt":"2024-03-02T09:15:30Z","owner":"ops@example.com",
+				"columns":[{"id":101,"title":"Name"},{"id":102,"title":"Status"}]}`, id, id)
+		case "/sheets/1/attachments":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[{"id":81,"sizeInKb":120},{"id":82,"attachmentType":"LINK"}]}`))
+		case "/sheets/2/attachments":
+			w.Write([]byte(`{"pageNumber":1,"totalPages":1,"data":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorCode":1006,"message":"Not Found"}`))
+		}
+	})
+
+	report, err := WorkspaceReport(9, ReportOptions{Concurrency: 2, Webhooks: true, Attachments: true})
+	if err != nil {
+		t.Fatal("WorkspaceReport Failed", err)
+	}
+	if len(report.Sheets) != 3 || report.Failed() != 1 {
+		t.Fatalf("WorkspaceReport expected 3 sheets, 1 failed, got %+v", report.Sheets)
+	}
+	var csvText strings.Builder
+	if err = report.WriteCSV(&csvText, ExportOptions{}); err != nil {
+		t.Fatal("WorkspaceInventory.WriteCSV Failed", err)
+	}
+	expect := "SheetId,Path,Rows,Columns,ModifiedAt,Owner,Webhooks,Attachments,AttachmentKb,Error\n" +
+		"1,Ops / Orders,10,2,2024-03-02T09:15:30Z,ops@example.com,2,2,120,\n" +
+		"2,Ops / Archive / Orders 2023,20,2,2024-03-02T09:15:30Z,ops@example.com,0,0,0,\n" +
+		"3,Ops / Archive / Deleted,0,0,,,0,0,0,\"Smartsheet Http API Request Failed - StatusCode 404, ErrorCode 1006, Not Found\"\n"
+	if csvText.String() != expect {
+		t.Errorf("WorkspaceInventory.WriteCSV, Expecting:\n%s\nGot:\n%s", expect, csvText.String())
+	}
+
+	report, _ = WorkspaceReport(9, ReportOptions{})
+	csvText.Reset()
+	report.WriteCSV(&csvText, ExportOptions{})
+	if !strings.HasPrefix(csvText.String(), "SheetId,Path,Rows,Columns,ModifiedAt,Owner,Error\n1,Ops / Orders,10,2,") {
+		t.Error("WorkspaceInventory.WriteCSV expected no webhook or attachment columns, got", csvText.String())
+	}
+
+	if _, err = WorkspaceReport(8, ReportOptions{}); err == nil {
+		t.Error("WorkspaceReport expected error for workspace not found")
+	}
+}